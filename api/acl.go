@@ -890,6 +890,41 @@ type ACLAuthMethodConfig struct {
 	// Duration in seconds of leeway when validating all claims to account for
 	// clock skew.
 	ClockSkewLeeway time.Duration
+	// A list of LDAP server URLs, using the ldap:// or ldaps:// scheme, which
+	// are tried in order
+	LDAPURLs []string
+	// Issue a StartTLS command after connecting to ldap:// URLs
+	LDAPStartTLS bool
+	// Skip the verification of the LDAP server certificates
+	LDAPInsecureTLS bool
+	// PEM encoded CA certs for use by the TLS client used to talk with the
+	// LDAP servers
+	LDAPCACerts []string
+	// PEM encoded client certificate and key used for mutual TLS with the
+	// LDAP servers
+	LDAPClientTLSCert string
+	LDAPClientTLSKey  string
+	// The DN and password used to search for the user entry and the groups of
+	// the user. When unset, the DN of the user entry is built from
+	// LDAPUserAttr and LDAPUserDN instead of being searched for.
+	LDAPBindDN       string
+	LDAPBindPassword string
+	// The base DN under which to search for users
+	LDAPUserDN string
+	// The attribute of the user entries matched against the username,
+	// defaults to "cn"
+	LDAPUserAttr string
+	// An optional go template used to build the user search filter when
+	// LDAPBindDN is set, such as "({{.UserAttr}}={{.Username}})"
+	LDAPUserFilter string
+	// The base DN under which to search for groups
+	LDAPGroupDN string
+	// The attribute of the group entries used as the group names, defaults to
+	// "cn"
+	LDAPGroupAttr string
+	// An optional go template used to build the group membership search
+	// filter, such as "(member={{.UserDN}})"
+	LDAPGroupFilter string
 	// Mappings of claims (key) that will be copied to a metadata field
 	// (value).
 	ClaimMappings     map[string]string
@@ -1128,6 +1163,10 @@ const (
 	// ACLAuthMethodTypeJWT the ACLAuthMethod.Type and represents an auth-method
 	// which uses the JWT type.
 	ACLAuthMethodTypeJWT = "JWT"
+
+	// ACLAuthMethodTypeLDAP the ACLAuthMethod.Type and represents an
+	// auth-method which authenticates users against an LDAP directory.
+	ACLAuthMethodTypeLDAP = "LDAP"
 )
 
 // ACLBindingRule contains a direct relation to an ACLAuthMethod and represents
@@ -1266,8 +1305,13 @@ type ACLLoginRequest struct {
 	// AuthMethodName is the name of the auth method being used to login. This
 	// is a required parameter.
 	AuthMethodName string
-	// LoginToken is the token used to login. This is a required parameter.
+	// LoginToken is the token used to login. This is a required parameter,
+	// unless logging in with a username and password.
 	LoginToken string
+	// Username and Password are the credentials used to login to auth methods
+	// which authenticate users directly, such as LDAP.
+	Username string
+	Password string
 }
 
 // ACLIdentity is used to query the ACL identity endpoints.
@@ -0,0 +1,146 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/url"
+)
+
+// EventConsumers is used to access the durable event consumers endpoints.
+type EventConsumers struct {
+	client *Client
+}
+
+// EventConsumers returns a handle on the durable event consumers endpoints.
+func (c *Client) EventConsumers() *EventConsumers {
+	return &EventConsumers{client: c}
+}
+
+// List is used to list all durable event consumers.
+func (e *EventConsumers) List(q *QueryOptions) ([]*EventConsumer, *QueryMeta, error) {
+	var resp []*EventConsumer
+	qm, err := e.client.query("/v1/event/consumers", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// Info is used to fetch details of a specific durable event consumer.
+func (e *EventConsumers) Info(name string, q *QueryOptions) (*EventConsumer, *QueryMeta, error) {
+	if name == "" {
+		return nil, nil, errors.New("missing event consumer name")
+	}
+
+	var resp EventConsumer
+	qm, err := e.client.query("/v1/event/consumer/"+url.PathEscape(name), &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// Register is used to create or update a durable event consumer. Updating a
+// consumer keeps its acknowledged index.
+func (e *EventConsumers) Register(consumer *EventConsumer, w *WriteOptions) (*WriteMeta, error) {
+	if consumer == nil {
+		return nil, errors.New("missing event consumer")
+	}
+	if consumer.Name == "" {
+		return nil, errors.New("missing event consumer name")
+	}
+
+	wm, err := e.client.put("/v1/event/consumers", consumer, nil, w)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// Delete is used to delete a durable event consumer.
+func (e *EventConsumers) Delete(name string, w *WriteOptions) (*WriteMeta, error) {
+	if name == "" {
+		return nil, errors.New("missing event consumer name")
+	}
+
+	wm, err := e.client.delete("/v1/event/consumer/"+url.PathEscape(name), nil, nil, w)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// Ack is used to acknowledge the events received by a durable event consumer
+// up to and including the index. Streaming the consumer resumes after the
+// last acknowledged index.
+func (e *EventConsumers) Ack(name string, index uint64, w *WriteOptions) (*WriteMeta, error) {
+	if name == "" {
+		return nil, errors.New("missing event consumer name")
+	}
+
+	req := &EventConsumerAckRequest{Index: index}
+	wm, err := e.client.put("/v1/event/consumer/"+url.PathEscape(name)+"/ack", req, nil, w)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// Stream establishes a subscription to Nomad's event stream for the durable
+// event consumer and streams results back to the returned channel. Events are
+// streamed from after the last index acknowledged by the consumer, using its
+// namespace, topics and filter.
+//
+// Events stop being emitted once the Events.Err field is non-nil.
+func (e *EventConsumers) Stream(ctx context.Context, name string, q *QueryOptions) (<-chan *Events, error) {
+	if name == "" {
+		return nil, errors.New("missing event consumer name")
+	}
+
+	r, err := e.client.newRequest("GET", "/v1/event/stream")
+	if err != nil {
+		return nil, err
+	}
+	q = q.WithContext(ctx)
+	if q.Params == nil {
+		q.Params = map[string]string{}
+	}
+	q.Params["consumer"] = name
+	r.setQueryOptions(q)
+
+	return e.client.streamEvents(ctx, r)
+}
+
+// EventConsumer is a named durable consumer of the event stream. The index of
+// the events it acknowledged is stored by the servers, so streaming can resume
+// without missing events after disconnects or leader changes.
+type EventConsumer struct {
+	// Name is the unique name of the consumer.
+	Name string
+
+	// Namespace is the namespace of the events streamed to the consumer.
+	Namespace string
+
+	// Topics are the topics and filter keys of the events streamed to the
+	// consumer. Defaults to all topics.
+	Topics map[Topic][]string
+
+	// Filter is an optional boolean expression filtering the events streamed
+	// to the consumer.
+	Filter string
+
+	// AckIndex is the index of the last events acknowledged by the consumer.
+	AckIndex uint64
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// EventConsumerAckRequest is the body of a request acknowledging events for
+// a durable event consumer.
+type EventConsumerAckRequest struct {
+	Index uint64
+}
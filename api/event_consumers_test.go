@@ -0,0 +1,71 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/api/internal/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestEventConsumers(t *testing.T) {
+	testutil.Parallel(t)
+
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+	consumers := c.EventConsumers()
+
+	t.Run("register", func(t *testing.T) {
+		consumer := &EventConsumer{
+			Name:   "billing",
+			Topics: map[Topic][]string{TopicJob: {"*"}},
+			Filter: `Type == "JobRegistered"`,
+		}
+		wm, err := consumers.Register(consumer, nil)
+		must.NoError(t, err)
+		assertWriteMeta(t, wm)
+
+		got, _, err := consumers.Info(consumer.Name, nil)
+		must.NoError(t, err)
+		must.Eq(t, "default", got.Namespace)
+		must.Eq(t, consumer.Topics, got.Topics)
+		must.Eq(t, consumer.Filter, got.Filter)
+		must.Eq(t, got.CreateIndex, got.AckIndex)
+
+		list, _, err := consumers.List(nil)
+		must.NoError(t, err)
+		must.Len(t, 1, list)
+		must.Eq(t, consumer.Name, list[0].Name)
+	})
+
+	t.Run("ack", func(t *testing.T) {
+		got, _, err := consumers.Info("billing", nil)
+		must.NoError(t, err)
+
+		wm, err := consumers.Ack("billing", got.ModifyIndex, nil)
+		must.NoError(t, err)
+		assertWriteMeta(t, wm)
+
+		got, _, err = consumers.Info("billing", nil)
+		must.NoError(t, err)
+		must.Eq(t, got.ModifyIndex, got.AckIndex)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		_, err := consumers.Delete("billing", nil)
+		must.NoError(t, err)
+
+		_, _, err = consumers.Info("billing", nil)
+		must.ErrorContains(t, err, "not found")
+	})
+
+	t.Run("missing name", func(t *testing.T) {
+		_, err := consumers.Register(&EventConsumer{}, nil)
+		must.ErrorContains(t, err, "missing event consumer name")
+
+		_, err = consumers.Ack("", 1, nil)
+		must.ErrorContains(t, err, "missing event consumer name")
+	})
+}
@@ -204,7 +204,13 @@ func (e *EventStream) Stream(ctx context.Context, topics map[Topic][]string, ind
 		}
 	}
 
-	_, resp, err := requireOK(e.client.doRequest(r)) //nolint:bodyclose
+	return e.client.streamEvents(ctx, r)
+}
+
+// streamEvents performs the event stream request and decodes the events into
+// the returned channel.
+func (c *Client) streamEvents(ctx context.Context, r *request) (<-chan *Events, error) {
+	_, resp, err := requireOK(c.doRequest(r)) //nolint:bodyclose
 
 	if err != nil {
 		return nil, err
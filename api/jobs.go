@@ -621,15 +621,137 @@ type periodicForceResponse struct {
 
 // UpdateStrategy defines a task groups update strategy.
 type UpdateStrategy struct {
-	Stagger          *time.Duration `mapstructure:"stagger" hcl:"stagger,optional"`
-	MaxParallel      *int           `mapstructure:"max_parallel" hcl:"max_parallel,optional"`
-	HealthCheck      *string        `mapstructure:"health_check" hcl:"health_check,optional"`
-	MinHealthyTime   *time.Duration `mapstructure:"min_healthy_time" hcl:"min_healthy_time,optional"`
-	HealthyDeadline  *time.Duration `mapstructure:"healthy_deadline" hcl:"healthy_deadline,optional"`
-	ProgressDeadline *time.Duration `mapstructure:"progress_deadline" hcl:"progress_deadline,optional"`
-	Canary           *int           `mapstructure:"canary" hcl:"canary,optional"`
-	AutoRevert       *bool          `mapstructure:"auto_revert" hcl:"auto_revert,optional"`
-	AutoPromote      *bool          `mapstructure:"auto_promote" hcl:"auto_promote,optional"`
+	Stagger          *time.Duration  `mapstructure:"stagger" hcl:"stagger,optional"`
+	MaxParallel      *int            `mapstructure:"max_parallel" hcl:"max_parallel,optional"`
+	HealthCheck      *string         `mapstructure:"health_check" hcl:"health_check,optional"`
+	MinHealthyTime   *time.Duration  `mapstructure:"min_healthy_time" hcl:"min_healthy_time,optional"`
+	HealthyDeadline  *time.Duration  `mapstructure:"healthy_deadline" hcl:"healthy_deadline,optional"`
+	ProgressDeadline *time.Duration  `mapstructure:"progress_deadline" hcl:"progress_deadline,optional"`
+	Canary           *int            `mapstructure:"canary" hcl:"canary,optional"`
+	AutoRevert       *bool           `mapstructure:"auto_revert" hcl:"auto_revert,optional"`
+	AutoPromote      *bool           `mapstructure:"auto_promote" hcl:"auto_promote,optional"`
+	Windows          []*UpdateWindow `mapstructure:"window" hcl:"window,block"`
+	Analysis         *UpdateAnalysis `mapstructure:"analysis" hcl:"analysis,block"`
+}
+
+// UpdateAnalysis is a metric analysis run against the canaries of a
+// deployment. Query is evaluated against the Prometheus-compatible HTTP API
+// of the Provider configured on the servers every Interval, and the deployment is promoted once Count
+// results are within the Min and Max thresholds, or failed once more than
+// FailureLimit results are not.
+type UpdateAnalysis struct {
+	Provider     *string        `mapstructure:"provider" hcl:"provider,optional"`
+	Query        *string        `mapstructure:"query" hcl:"query,optional"`
+	Interval     *time.Duration `mapstructure:"interval" hcl:"interval,optional"`
+	Count        *int           `mapstructure:"count" hcl:"count,optional"`
+	FailureLimit *int           `mapstructure:"failure_limit" hcl:"failure_limit,optional"`
+	Min          *float64       `mapstructure:"min" hcl:"min,optional"`
+	Max          *float64       `mapstructure:"max" hcl:"max,optional"`
+}
+
+func (a *UpdateAnalysis) Copy() *UpdateAnalysis {
+	if a == nil {
+		return nil
+	}
+
+	copy := new(UpdateAnalysis)
+
+	if a.Provider != nil {
+		copy.Provider = pointerOf(*a.Provider)
+	}
+
+	if a.Query != nil {
+		copy.Query = pointerOf(*a.Query)
+	}
+
+	if a.Interval != nil {
+		copy.Interval = pointerOf(*a.Interval)
+	}
+
+	if a.Count != nil {
+		copy.Count = pointerOf(*a.Count)
+	}
+
+	if a.FailureLimit != nil {
+		copy.FailureLimit = pointerOf(*a.FailureLimit)
+	}
+
+	if a.Min != nil {
+		copy.Min = pointerOf(*a.Min)
+	}
+
+	if a.Max != nil {
+		copy.Max = pointerOf(*a.Max)
+	}
+
+	return copy
+}
+
+func (a *UpdateAnalysis) Canonicalize() {
+	if a.Provider == nil {
+		a.Provider = pointerOf("")
+	}
+
+	if a.Query == nil {
+		a.Query = pointerOf("")
+	}
+
+	if a.Interval == nil {
+		a.Interval = pointerOf(1 * time.Minute)
+	}
+
+	if a.Count == nil {
+		a.Count = pointerOf(3)
+	}
+
+	if a.FailureLimit == nil {
+		a.FailureLimit = pointerOf(0)
+	}
+}
+
+// UpdateWindow is a recurring window of time during which a deployment may
+// progress. The window opens at each time matching Cron and stays open for
+// Duration.
+type UpdateWindow struct {
+	Cron     *string        `mapstructure:"cron" hcl:"cron,optional"`
+	Duration *time.Duration `mapstructure:"duration" hcl:"duration,optional"`
+	TimeZone *string        `mapstructure:"time_zone" hcl:"time_zone,optional"`
+}
+
+func (w *UpdateWindow) Copy() *UpdateWindow {
+	if w == nil {
+		return nil
+	}
+
+	copy := new(UpdateWindow)
+
+	if w.Cron != nil {
+		copy.Cron = pointerOf(*w.Cron)
+	}
+
+	if w.Duration != nil {
+		copy.Duration = pointerOf(*w.Duration)
+	}
+
+	if w.TimeZone != nil {
+		copy.TimeZone = pointerOf(*w.TimeZone)
+	}
+
+	return copy
+}
+
+func (w *UpdateWindow) Canonicalize() {
+	if w.Cron == nil {
+		w.Cron = pointerOf("")
+	}
+
+	if w.Duration == nil {
+		w.Duration = pointerOf(time.Duration(0))
+	}
+
+	if w.TimeZone == nil {
+		w.TimeZone = pointerOf("UTC")
+	}
 }
 
 // DefaultUpdateStrategy provides a baseline that can be used to upgrade
@@ -691,6 +813,15 @@ func (u *UpdateStrategy) Copy() *UpdateStrategy {
 		copy.AutoPromote = pointerOf(*u.AutoPromote)
 	}
 
+	if u.Windows != nil {
+		copy.Windows = make([]*UpdateWindow, len(u.Windows))
+		for i, w := range u.Windows {
+			copy.Windows[i] = w.Copy()
+		}
+	}
+
+	copy.Analysis = u.Analysis.Copy()
+
 	return copy
 }
 
@@ -734,6 +865,17 @@ func (u *UpdateStrategy) Merge(o *UpdateStrategy) {
 	if o.AutoPromote != nil {
 		u.AutoPromote = pointerOf(*o.AutoPromote)
 	}
+
+	if o.Windows != nil {
+		u.Windows = make([]*UpdateWindow, len(o.Windows))
+		for i, w := range o.Windows {
+			u.Windows[i] = w.Copy()
+		}
+	}
+
+	if o.Analysis != nil {
+		u.Analysis = o.Analysis.Copy()
+	}
 }
 
 func (u *UpdateStrategy) Canonicalize() {
@@ -774,6 +916,14 @@ func (u *UpdateStrategy) Canonicalize() {
 	if u.AutoPromote == nil {
 		u.AutoPromote = d.AutoPromote
 	}
+
+	for _, w := range u.Windows {
+		w.Canonicalize()
+	}
+
+	if u.Analysis != nil {
+		u.Analysis.Canonicalize()
+	}
 }
 
 // Empty returns whether the UpdateStrategy is empty or has user defined values.
@@ -818,6 +968,10 @@ func (u *UpdateStrategy) Empty() bool {
 		return false
 	}
 
+	if len(u.Windows) != 0 || u.Analysis != nil {
+		return false
+	}
+
 	return true
 }
 
@@ -902,6 +1056,19 @@ type PeriodicConfig struct {
 	SpecType        *string
 	ProhibitOverlap *bool   `mapstructure:"prohibit_overlap" hcl:"prohibit_overlap,optional"`
 	TimeZone        *string `mapstructure:"time_zone" hcl:"time_zone,optional"`
+
+	// ConcurrencyPolicy controls launches while a previous launch is still
+	// running. One of "allow", "forbid" or "replace".
+	ConcurrencyPolicy *string `mapstructure:"concurrency_policy" hcl:"concurrency_policy,optional"`
+
+	// MissedRuns controls the launches missed while the job was disabled or
+	// the cluster had no leader. One of "skip", "run-once" or "run-all".
+	MissedRuns       *string        `mapstructure:"missed_runs" hcl:"missed_runs,optional"`
+	MaxMissedRuns    *int           `mapstructure:"max_missed_runs" hcl:"max_missed_runs,optional"`
+	StartingDeadline *time.Duration `mapstructure:"starting_deadline" hcl:"starting_deadline,optional"`
+
+	// ChildHistoryLimit is the number of dead child jobs to keep.
+	ChildHistoryLimit *int `mapstructure:"child_history_limit" hcl:"child_history_limit,optional"`
 }
 
 func (p *PeriodicConfig) Canonicalize() {
@@ -1124,6 +1291,7 @@ type Job struct {
 	ParameterizedJob *ParameterizedJobConfig `hcl:"parameterized,block"`
 	Reschedule       *ReschedulePolicy       `hcl:"reschedule,block"`
 	Migrate          *MigrateStrategy        `hcl:"migrate,block"`
+	DisruptionBudget *DisruptionBudget       `hcl:"disruption_budget,block"`
 	Meta             map[string]string       `hcl:"meta,block"`
 	UI               *JobUIConfig            `hcl:"ui,block"`
 
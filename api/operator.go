@@ -192,6 +192,11 @@ type SchedulerConfiguration struct {
 	// spread and/or affinity.
 	NodeLimitForFeasibilityChecks uint
 
+	// NamespaceWeights sets the relative share of evaluations each namespace
+	// has dequeued from the evaluation broker when several namespaces have
+	// evaluations ready. Namespaces without a weight have a weight of 1.
+	NamespaceWeights map[string]int
+
 	// CreateIndex/ModifyIndex store the create/modify indexes of this configuration.
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -266,6 +271,120 @@ func (op *Operator) SchedulerCASConfiguration(conf *SchedulerConfiguration, q *W
 	return &out, wm, nil
 }
 
+// SchedulerSimulateRequest describes the hypothetical changes to the cluster
+// state that the schedulers are run against.
+type SchedulerSimulateRequest struct {
+	// Jobs are registered in the simulated state and evaluated in order.
+	Jobs []*Job
+
+	// RemoveNodes are the IDs, or ID prefixes, of nodes to remove.
+	RemoveNodes []string
+
+	// NodePools maps the IDs, or ID prefixes, of nodes to the node pool they
+	// are moved to.
+	NodePools map[string]string
+
+	// SchedulerConfig replaces the scheduler configuration, if set.
+	SchedulerConfig *SchedulerConfiguration
+}
+
+// SchedulerSimulateResponse is the result of a scheduler simulation.
+type SchedulerSimulateResponse struct {
+	Jobs        []*SimulatedJobResult
+	Utilization *SimulatedUtilization
+
+	QueryMeta
+}
+
+// SimulatedJobResult is the outcome of evaluating a single job during a
+// scheduler simulation.
+type SimulatedJobResult struct {
+	Namespace      string
+	JobID          string
+	Placements     []*SimulatedPlacement
+	Stopped        int
+	Preempted      []string
+	FailedTGAllocs map[string]*AllocationMetric
+}
+
+// SimulatedPlacement is an allocation placed during a scheduler simulation.
+type SimulatedPlacement struct {
+	AllocID   string
+	Name      string
+	TaskGroup string
+	NodeID    string
+	NodeName  string
+	NodePool  string
+}
+
+// SimulatedUtilization is the resource utilization of the ready nodes of the
+// simulated cluster.
+type SimulatedUtilization struct {
+	Nodes             int
+	CPUCapacity       int64
+	CPUAllocated      int64
+	MemoryCapacityMB  int64
+	MemoryAllocatedMB int64
+}
+
+// SchedulerSimulate runs the schedulers against a snapshot of the cluster state
+// after applying the changes of the request. The cluster state is never
+// modified.
+func (op *Operator) SchedulerSimulate(req *SchedulerSimulateRequest, q *WriteOptions) (*SchedulerSimulateResponse, *WriteMeta, error) {
+	var out SchedulerSimulateResponse
+	wm, err := op.c.put("/v1/operator/scheduler/simulate", req, &out, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &out, wm, nil
+}
+
+// SchedulerRebalanceRequest is used to move healthy service allocations
+// between nodes to improve the packing or spread of the cluster.
+type SchedulerRebalanceRequest struct {
+	// NodePool limits the rebalance to the nodes of the node pool.
+	NodePool string
+
+	// MaxMoves is the maximum number of allocations moved.
+	MaxMoves int
+
+	// DryRun computes the moves without migrating any allocations.
+	DryRun bool
+}
+
+// SchedulerRebalanceResponse is the result of a rebalance request.
+type SchedulerRebalanceResponse struct {
+	Moves   []*RebalanceMove
+	EvalIDs []string
+
+	WriteMeta
+}
+
+// RebalanceMove is a proposed move of an allocation to a better placed node.
+// The replacement allocation is placed by the scheduler, so it is not
+// guaranteed to land on the target node.
+type RebalanceMove struct {
+	AllocID      string
+	AllocName    string
+	Namespace    string
+	JobID        string
+	TaskGroup    string
+	NodePool     string
+	SourceNodeID string
+	TargetNodeID string
+}
+
+// SchedulerRebalance computes allocation moves which improve the packing or
+// spread of the cluster and, unless it is a dry run, migrates the allocations.
+func (op *Operator) SchedulerRebalance(req *SchedulerRebalanceRequest, q *WriteOptions) (*SchedulerRebalanceResponse, *WriteMeta, error) {
+	var out SchedulerRebalanceResponse
+	wm, err := op.c.put("/v1/operator/scheduler/rebalance", req, &out, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &out, wm, nil
+}
+
 // Snapshot is used to capture a snapshot state of a running cluster.
 // The returned reader that must be consumed fully
 func (op *Operator) Snapshot(q *QueryOptions) (io.ReadCloser, error) {
@@ -161,6 +161,15 @@ type CNIConfig struct {
 	Args map[string]string `hcl:"args,optional"`
 }
 
+// NetworkUpstream configures a local proxy in the network namespace of an
+// allocation to a service registered with the Nomad service provider.
+type NetworkUpstream struct {
+	DestinationName      string `mapstructure:"destination_name" hcl:"destination_name,optional"`
+	DestinationNamespace string `mapstructure:"destination_namespace" hcl:"destination_namespace,optional"`
+	LocalBindAddress     string `mapstructure:"local_bind_address" hcl:"local_bind_address,optional"`
+	LocalBindPort        int    `mapstructure:"local_bind_port" hcl:"local_bind_port,optional"`
+}
+
 // NetworkResource is used to describe required network
 // resources of a given task.
 type NetworkResource struct {
@@ -179,6 +188,8 @@ type NetworkResource struct {
 	// then.
 	MBits *int       `hcl:"mbits,optional"`
 	CNI   *CNIConfig `hcl:"cni,block"`
+
+	Upstreams []*NetworkUpstream `hcl:"upstream,block"`
 }
 
 // Megabits should not be used.
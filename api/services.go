@@ -59,6 +59,10 @@ type ServiceRegistration struct {
 	// is determined by a combination of factors on the client.
 	Port int
 
+	// CheckStatus is the aggregated status of the Nomad checks of this service
+	// registration. It is empty if the service has no checks.
+	CheckStatus string
+
 	CreateIndex uint64
 	ModifyIndex uint64
 }
@@ -226,6 +230,8 @@ type ServiceCheck struct {
 	FailuresBeforeWarning  int                 `mapstructure:"failures_before_warning" hcl:"failures_before_warning,optional"`
 	Body                   string              `hcl:"body,optional"`
 	OnUpdate               string              `mapstructure:"on_update" hcl:"on_update,optional"`
+	OnStart                bool                `mapstructure:"on_start" hcl:"on_start,optional"`
+	StartupTimeout         time.Duration       `mapstructure:"startup_timeout" hcl:"startup_timeout,optional"`
 }
 
 // Service represents a Nomad job-submitters view of a Consul or Nomad service.
@@ -314,10 +320,16 @@ func (s *Service) Canonicalize(t *Task, tg *TaskGroup, job *Job) {
 	s.Weights.Canonicalize()
 
 	// Canonicalize CheckRestart on Checks and merge Service.CheckRestart
-	// into each check.
+	// into each check. Startup checks are gated by their startup timeout
+	// rather than check_restart, so the service check_restart isn't merged
+	// into them.
 	for i, check := range s.Checks {
-		s.Checks[i].CheckRestart = s.CheckRestart.Merge(check.CheckRestart)
-		s.Checks[i].CheckRestart.Canonicalize()
+		if check.OnStart {
+			s.Checks[i].CheckRestart.Canonicalize()
+		} else {
+			s.Checks[i].CheckRestart = s.CheckRestart.Merge(check.CheckRestart)
+			s.Checks[i].CheckRestart.Canonicalize()
+		}
 
 		if s.Checks[i].SuccessBeforePassing < 0 {
 			s.Checks[i].SuccessBeforePassing = 0
@@ -74,9 +74,17 @@ type TaskResourceUsage struct {
 type AllocResourceUsage struct {
 	ResourceUsage *ResourceUsage
 	Tasks         map[string]*TaskResourceUsage
+	DiskStats     *AllocDiskStats
 	Timestamp     int64
 }
 
+// AllocDiskStats holds the disk usage of an allocation dir in bytes.
+type AllocDiskStats struct {
+	Used      uint64
+	Size      uint64
+	Timestamp int64
+}
+
 // AllocCheckStatus contains the current status of a nomad service discovery check.
 type AllocCheckStatus struct {
 	ID         string
@@ -101,6 +109,8 @@ type RestartPolicy struct {
 	Interval        *time.Duration `hcl:"interval,optional"`
 	Attempts        *int           `hcl:"attempts,optional"`
 	Delay           *time.Duration `hcl:"delay,optional"`
+	DelayFunction   *string        `mapstructure:"delay_function" hcl:"delay_function,optional"`
+	MaxDelay        *time.Duration `mapstructure:"max_delay" hcl:"max_delay,optional"`
 	Mode            *string        `hcl:"mode,optional"`
 	RenderTemplates *bool          `mapstructure:"render_templates" hcl:"render_templates,optional"`
 }
@@ -115,6 +125,12 @@ func (r *RestartPolicy) Merge(rp *RestartPolicy) {
 	if rp.Delay != nil {
 		r.Delay = rp.Delay
 	}
+	if rp.DelayFunction != nil {
+		r.DelayFunction = rp.DelayFunction
+	}
+	if rp.MaxDelay != nil {
+		r.MaxDelay = rp.MaxDelay
+	}
 	if rp.Mode != nil {
 		r.Mode = rp.Mode
 	}
@@ -327,6 +343,7 @@ type Spread struct {
 	Attribute    string          `hcl:"attribute,optional"`
 	Weight       *int8           `hcl:"weight,optional"`
 	SpreadTarget []*SpreadTarget `hcl:"target,block"`
+	MaxSkew      int             `hcl:"max_skew,optional"`
 }
 
 // SpreadTarget is used to serialize target allocation spread percentages
@@ -361,6 +378,13 @@ type EphemeralDisk struct {
 	Sticky  *bool `hcl:"sticky,optional"`
 	Migrate *bool `hcl:"migrate,optional"`
 	SizeMB  *int  `mapstructure:"size" hcl:"size,optional"`
+
+	// PreCopy starts copying the data of a remote previous allocation while
+	// it is still running. Requires Migrate.
+	PreCopy *bool `hcl:"precopy,optional"`
+
+	// Enforcement is one of "none", "soft" or "hard".
+	Enforcement *string `hcl:"enforcement,optional"`
 }
 
 func DefaultEphemeralDisk() *EphemeralDisk {
@@ -444,6 +468,35 @@ func (m *MigrateStrategy) Copy() *MigrateStrategy {
 	return nm
 }
 
+// DisruptionBudget limits the number of healthy allocations of a task group
+// which may be voluntarily disrupted at once by drains, preemption, or
+// operator initiated stops.
+type DisruptionBudget struct {
+	MaxUnavailable *int `mapstructure:"max_unavailable" hcl:"max_unavailable,optional"`
+	MinAvailable   *int `mapstructure:"min_available" hcl:"min_available,optional"`
+}
+
+func (d *DisruptionBudget) Canonicalize() {
+	if d == nil {
+		return
+	}
+	if d.MaxUnavailable == nil {
+		d.MaxUnavailable = pointerOf(0)
+	}
+	if d.MinAvailable == nil {
+		d.MinAvailable = pointerOf(0)
+	}
+}
+
+func (d *DisruptionBudget) Copy() *DisruptionBudget {
+	if d == nil {
+		return nil
+	}
+	nd := new(DisruptionBudget)
+	*nd = *d
+	return nd
+}
+
 // VolumeRequest is a representation of a storage volume that a TaskGroup wishes to use.
 type VolumeRequest struct {
 	Name           string           `hcl:"name,label"`
@@ -492,6 +545,7 @@ func (vm *VolumeMount) Canonicalize() {
 type TaskGroup struct {
 	Name             *string                   `hcl:"name,label"`
 	Count            *int                      `hcl:"count,optional"`
+	Gang             *string                   `hcl:"gang,optional"`
 	Constraints      []*Constraint             `hcl:"constraint,block"`
 	Affinities       []*Affinity               `hcl:"affinity,block"`
 	Tasks            []*Task                   `hcl:"task,block"`
@@ -503,6 +557,7 @@ type TaskGroup struct {
 	EphemeralDisk    *EphemeralDisk            `hcl:"ephemeral_disk,block"`
 	Update           *UpdateStrategy           `hcl:"update,block"`
 	Migrate          *MigrateStrategy          `hcl:"migrate,block"`
+	DisruptionBudget *DisruptionBudget         `hcl:"disruption_budget,block"`
 	Networks         []*NetworkResource        `hcl:"network,block"`
 	Meta             map[string]string         `hcl:"meta,block"`
 	Services         []*Service                `hcl:"service,block"`
@@ -602,6 +657,12 @@ func (g *TaskGroup) Canonicalize(job *Job) {
 		g.Migrate.Canonicalize()
 	}
 
+	// Inherit the disruption budget from the job
+	if g.DisruptionBudget == nil && job.DisruptionBudget != nil {
+		g.DisruptionBudget = job.DisruptionBudget.Copy()
+	}
+	g.DisruptionBudget.Canonicalize()
+
 	var defaultRestartPolicy *RestartPolicy
 	switch *job.Type {
 	case "service", "system":
@@ -716,6 +777,27 @@ type LogConfig struct {
 	Enabled *bool `mapstructure:"enabled" hcl:"enabled,optional"`
 
 	Disabled *bool `mapstructure:"disabled" hcl:"disabled,optional"`
+
+	Sinks []*LogSink `hcl:"sink,block"`
+
+	RotationInterval *time.Duration `mapstructure:"rotation_interval" hcl:"rotation_interval,optional"`
+	Compression      *string        `mapstructure:"compression" hcl:"compression,optional"`
+}
+
+// LogSink configures a destination that task logs are forwarded to, in
+// addition to the log files in the alloc dir.
+type LogSink struct {
+	// Type is one of "syslog", "journald" or "otlp".
+	Type       string            `hcl:",label"`
+	Address    string            `hcl:"address,optional"`
+	Headers    map[string]string `hcl:"headers,optional"`
+	BufferSize *int              `mapstructure:"buffer_size" hcl:"buffer_size,optional"`
+}
+
+func (s *LogSink) Canonicalize() {
+	if s.BufferSize == nil {
+		s.BufferSize = pointerOf(1024)
+	}
 }
 
 func DefaultLogConfig() *LogConfig {
@@ -736,6 +818,15 @@ func (l *LogConfig) Canonicalize() {
 	if l.Disabled == nil {
 		l.Disabled = pointerOf(false)
 	}
+	if l.RotationInterval == nil {
+		l.RotationInterval = pointerOf(time.Duration(0))
+	}
+	if l.Compression == nil {
+		l.Compression = pointerOf("none")
+	}
+	for _, sink := range l.Sinks {
+		sink.Canonicalize()
+	}
 }
 
 // DispatchPayloadConfig configures how a task gets its input from a job dispatch
@@ -1052,11 +1143,15 @@ func (v *Vault) Canonicalize() {
 }
 
 type Secret struct {
-	Name     string            `hcl:"name,label"`
-	Provider string            `hcl:"provider,optional"`
-	Path     string            `hcl:"path,optional"`
-	Config   map[string]any    `hcl:"config,block"`
-	Env      map[string]string `hcl:"env,block"`
+	Name            string            `hcl:"name,label"`
+	Provider        string            `hcl:"provider,optional"`
+	Path            string            `hcl:"path,optional"`
+	Config          map[string]any    `hcl:"config,block"`
+	Env             map[string]string `hcl:"env,block"`
+	RefreshInterval time.Duration     `mapstructure:"refresh_interval" hcl:"refresh_interval,optional"`
+	ChangeMode      string            `mapstructure:"change_mode" hcl:"change_mode,optional"`
+	ChangeSignal    string            `mapstructure:"change_signal" hcl:"change_signal,optional"`
+	ChangeScript    *ChangeScript     `mapstructure:"change_script" hcl:"change_script,block"`
 }
 
 func (s *Secret) Canonicalize() {
@@ -1067,6 +1162,18 @@ func (s *Secret) Canonicalize() {
 	if len(s.Env) == 0 {
 		s.Env = nil
 	}
+
+	// An empty change mode is treated as restart by the client
+	if s.ChangeSignal == "" {
+		if s.ChangeMode == "signal" {
+			s.ChangeSignal = "SIGHUP"
+		}
+	} else {
+		s.ChangeSignal = strings.ToUpper(s.ChangeSignal)
+	}
+	if s.ChangeScript != nil {
+		s.ChangeScript.Canonicalize()
+	}
 }
 
 // NewTask creates and initializes a new Task.
@@ -1129,13 +1236,14 @@ func (t *Task) SetLifecycle(l *TaskLifecycle) *Task {
 // TaskState tracks the current state of a task and events that caused state
 // transitions.
 type TaskState struct {
-	State       string
-	Failed      bool
-	Restarts    uint64
-	LastRestart time.Time
-	StartedAt   time.Time
-	FinishedAt  time.Time
-	Events      []*TaskEvent
+	State        string
+	Failed       bool
+	Restarts     uint64
+	LastRestart  time.Time
+	StartedAt    time.Time
+	FinishedAt   time.Time
+	CrashLooping bool
+	Events       []*TaskEvent
 }
 
 const (
@@ -1151,6 +1259,8 @@ const (
 	TaskKilled                 = "Killed"
 	TaskRestarting             = "Restarting"
 	TaskNotRestarting          = "Not Restarting"
+	TaskCrashLooping           = "Crash Looping"
+	TaskMemoryPressureEvicted  = "Evicted Memory Pressure"
 	TaskDownloadingArtifacts   = "Downloading Artifacts"
 	TaskArtifactDownloadFailed = "Failed Artifact Download"
 	TaskSiblingFailed          = "Sibling Task Failed"
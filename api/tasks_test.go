@@ -921,6 +921,57 @@ func Test_NewDefaultReschedulePolicy(t *testing.T) {
 	}
 }
 
+func TestTaskGroup_Canonicalize_DisruptionBudget(t *testing.T) {
+	testutil.Parallel(t)
+
+	t.Run("inherit from job", func(t *testing.T) {
+		job := &Job{
+			ID:               pointerOf("job"),
+			DisruptionBudget: &DisruptionBudget{MaxUnavailable: pointerOf(1)},
+		}
+		job.Canonicalize()
+
+		tg := &TaskGroup{
+			Name: pointerOf("group"),
+		}
+		tg.Canonicalize(job)
+
+		must.Eq(t, &DisruptionBudget{
+			MaxUnavailable: pointerOf(1),
+			MinAvailable:   pointerOf(0),
+		}, tg.DisruptionBudget)
+		must.Nil(t, job.DisruptionBudget.MinAvailable)
+	})
+
+	t.Run("override job budget in group", func(t *testing.T) {
+		job := &Job{
+			ID:               pointerOf("job"),
+			DisruptionBudget: &DisruptionBudget{MaxUnavailable: pointerOf(1)},
+		}
+		job.Canonicalize()
+
+		tg := &TaskGroup{
+			Name:             pointerOf("group"),
+			DisruptionBudget: &DisruptionBudget{MinAvailable: pointerOf(3)},
+		}
+		tg.Canonicalize(job)
+
+		must.Eq(t, &DisruptionBudget{
+			MaxUnavailable: pointerOf(0),
+			MinAvailable:   pointerOf(3),
+		}, tg.DisruptionBudget)
+	})
+
+	t.Run("unset", func(t *testing.T) {
+		job := &Job{ID: pointerOf("job")}
+		job.Canonicalize()
+
+		tg := &TaskGroup{Name: pointerOf("group")}
+		tg.Canonicalize(job)
+		must.Nil(t, tg.DisruptionBudget)
+	})
+}
+
 func TestTaskGroup_Canonicalize_Consul(t *testing.T) {
 	testutil.Parallel(t)
 
@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/fs"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -151,6 +152,8 @@ type AllocDirFS interface {
 	Stat(path string) (*cstructs.AllocFileInfo, error)
 	ReadAt(path string, offset int64) (io.ReadCloser, error)
 	Snapshot(w io.Writer) error
+	SnapshotManifest() ([]*cstructs.AllocSnapshotEntry, error)
+	SnapshotEntry(path string) (*cstructs.AllocSnapshotEntry, error)
 	BlockUntilExists(ctx context.Context, path string) (chan error, error)
 	ChangeEvents(ctx context.Context, path string, curOffset int64) (*watch.FileChanges, error)
 }
@@ -197,11 +200,7 @@ func (a *AllocDir) Snapshot(w io.Writer) error {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 
-	allocDataDir := filepath.Join(a.SharedDir, SharedDataDir)
-	rootPaths := []string{allocDataDir}
-	for _, taskdir := range a.TaskDirs {
-		rootPaths = append(rootPaths, taskdir.LocalDir)
-	}
+	rootPaths := a.snapshotRootPaths()
 
 	tw := tar.NewWriter(w)
 	defer tw.Close()
@@ -272,6 +271,122 @@ func (a *AllocDir) Snapshot(w io.Writer) error {
 	return nil
 }
 
+// SnapshotManifest returns the entries of the files and directories that a
+// Snapshot of the alloc dir would contain, without their contents. The
+// entries are ordered so that directories come before their contents.
+func (a *AllocDir) SnapshotManifest() ([]*cstructs.AllocSnapshotEntry, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	var entries []*cstructs.AllocSnapshotEntry
+	walkFn := func(path string, fileInfo os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		entry, err := a.snapshotEntry(path, fileInfo)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+		return nil
+	}
+
+	for _, path := range a.snapshotRootPaths() {
+		if err := filepath.Walk(path, walkFn); err != nil {
+			return nil, fmt.Errorf("failed to snapshot %s: %w", path, err)
+		}
+	}
+	return entries, nil
+}
+
+// SnapshotEntry returns the entry of the file, directory or symlink at the
+// path relative to the alloc dir that SnapshotManifest would return, without
+// walking the rest of the snapshot. An error wrapping fs.ErrNotExist is
+// returned if the snapshot wouldn't contain the path.
+func (a *AllocDir) SnapshotEntry(path string) (*cstructs.AllocSnapshotEntry, error) {
+	notInSnapshot := fmt.Errorf("%q is not in the snapshot: %w", path, fs.ErrNotExist)
+	if !filepath.IsLocal(path) {
+		return nil, notInSnapshot
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	p := filepath.Join(a.AllocDir, path)
+	for _, root := range a.snapshotRootPaths() {
+		relPath, err := filepath.Rel(root, p)
+		if err != nil || !filepath.IsLocal(relPath) {
+			continue
+		}
+
+		// Snapshots don't follow symlinks, so every parent of the path
+		// below the root must be a directory
+		dir := root
+		for _, elem := range strings.Split(filepath.Dir(relPath), string(filepath.Separator)) {
+			if elem == "." {
+				continue
+			}
+			dir = filepath.Join(dir, elem)
+			fileInfo, err := os.Lstat(dir)
+			if err != nil {
+				return nil, err
+			}
+			if !fileInfo.IsDir() {
+				return nil, notInSnapshot
+			}
+		}
+
+		fileInfo, err := os.Lstat(p)
+		if err != nil {
+			return nil, err
+		}
+		return a.snapshotEntry(p, fileInfo)
+	}
+	return nil, notInSnapshot
+}
+
+// snapshotEntry returns the snapshot entry of the file at path.
+func (a *AllocDir) snapshotEntry(path string, fileInfo os.FileInfo) (*cstructs.AllocSnapshotEntry, error) {
+	relPath, err := filepath.Rel(a.AllocDir, path)
+	if err != nil {
+		return nil, err
+	}
+	link := ""
+	if fileInfo.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Readlink(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading symlink: %v", err)
+		}
+		link = target
+	}
+	hdr, err := tar.FileInfoHeader(fileInfo, link)
+	if err != nil {
+		return nil, fmt.Errorf("error creating file header: %w", err)
+	}
+
+	return &cstructs.AllocSnapshotEntry{
+		Path:     relPath,
+		Typeflag: hdr.Typeflag,
+		Mode:     hdr.Mode,
+		Uid:      hdr.Uid,
+		Gid:      hdr.Gid,
+		Size:     hdr.Size,
+		ModTime:  hdr.ModTime,
+		Linkname: hdr.Linkname,
+	}, nil
+}
+
+// snapshotRootPaths returns the directories included in a snapshot of the
+// alloc dir. Caller must hold the read lock.
+func (a *AllocDir) snapshotRootPaths() []string {
+	rootPaths := []string{filepath.Join(a.SharedDir, SharedDataDir)}
+	for _, taskdir := range a.TaskDirs {
+		rootPaths = append(rootPaths, taskdir.LocalDir)
+	}
+	return rootPaths
+}
+
 // Move other alloc directory's shared path and local dir to this alloc dir.
 func (a *AllocDir) Move(other Interface, tasks []*structs.Task) error {
 	a.mu.RLock()
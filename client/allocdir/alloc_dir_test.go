@@ -206,6 +206,43 @@ func TestAllocDir_Snapshot(t *testing.T) {
 	must.SliceLen(t, 2, links)
 }
 
+func TestAllocDir_SnapshotEntry(t *testing.T) {
+	ci.Parallel(t)
+
+	tmp := t.TempDir()
+
+	d := NewAllocDir(testlog.HCLogger(t), tmp, tmp, "test")
+	defer d.Destroy()
+	must.NoError(t, d.Build())
+
+	td1 := d.NewTaskDir(t1)
+	must.NoError(t, td1.Build(fsisolation.None, nil, "nobody"))
+
+	must.NoError(t, os.WriteFile(filepath.Join(td1.LocalDir, "foo"), []byte("foo"), 0o666))
+	must.NoError(t, os.WriteFile(filepath.Join(td1.Dir, "bar"), []byte("bar"), 0o666))
+	must.NoError(t, os.Symlink(td1.Dir, filepath.Join(td1.LocalDir, "link")))
+
+	// The entry matches the one in the manifest
+	entries, err := d.SnapshotManifest()
+	must.NoError(t, err)
+	entry, err := d.SnapshotEntry(filepath.Join(t1.Name, "local", "foo"))
+	must.NoError(t, err)
+	must.SliceContains(t, entries, entry)
+
+	// Paths outside of the snapshot roots or behind symlinks aren't in the
+	// snapshot
+	for _, path := range []string{
+		filepath.Join(t1.Name, "bar"),
+		filepath.Join(t1.Name, "local", "link", "bar"),
+		filepath.Join(t1.Name, "local", "..", "bar"),
+		filepath.Join("..", "bar"),
+		filepath.Join(t1.Name, "local", "missing"),
+	} {
+		_, err := d.SnapshotEntry(path)
+		must.ErrorIs(t, err, fs.ErrNotExist, must.Sprint(path))
+	}
+}
+
 func TestAllocDir_Move(t *testing.T) {
 	ci.Parallel(t)
 
@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	log "github.com/hashicorp/go-hclog"
 	metrics "github.com/hashicorp/go-metrics/compat"
 	multierror "github.com/hashicorp/go-multierror"
@@ -160,6 +161,11 @@ type allocRunner struct {
 	// tasks are the set of task runners
 	tasks map[string]*taskrunner.TaskRunner
 
+	// diskStats is the latest disk usage of the alloc dir measured by the
+	// disk limit hook. Must acquire diskStatsLock to access.
+	diskStats     *cstructs.AllocDiskStats
+	diskStatsLock sync.RWMutex
+
 	// deviceStatsReporter is used to lookup resource usage for alloc devices
 	deviceStatsReporter cinterfaces.DeviceStatsReporter
 
@@ -749,6 +755,10 @@ func (ar *allocRunner) killTasks() map[string]*structs.TaskState {
 
 		if ar.maxRunDurationExceeded() {
 			event.SetDisplayMessage(structs.AllocTimeoutReasonMaxRunDuration)
+		} else if ar.diskLimitExceeded() {
+			event.SetDisplayMessage(structs.AllocFailedReasonDiskLimit)
+		} else if ar.memoryPressureEvicted() {
+			event.SetDisplayMessage(structs.AllocFailedReasonMemoryPressure)
 		}
 		return event
 	}
@@ -870,6 +880,12 @@ func (ar *allocRunner) clientAlloc(taskStates map[string]*structs.TaskState) *st
 	if ar.state.MaxRunDurationExceeded {
 		a.ClientStatus = structs.AllocClientStatusComplete
 		a.ClientDescription = structs.AllocTimeoutReasonMaxRunDuration
+	} else if ar.state.DiskLimitExceeded {
+		a.ClientStatus = structs.AllocClientStatusFailed
+		a.ClientDescription = structs.AllocFailedReasonDiskLimit
+	} else if ar.state.MemoryPressureEvicted {
+		a.ClientStatus = structs.AllocClientStatusFailed
+		a.ClientDescription = structs.AllocFailedReasonMemoryPressure
 	} else if ar.state.ClientStatus != "" {
 		// The client status is being forced
 		a.ClientStatus, a.ClientDescription = ar.state.ClientStatus, ar.state.ClientDescription
@@ -1133,6 +1149,86 @@ func (ar *allocRunner) maxRunDurationExceeded() bool {
 	return ar.state.MaxRunDurationExceeded
 }
 
+// enforceDiskLimit is called by the disk limit hook when the alloc dir grows
+// larger than its ephemeral_disk size. It emits a task event for every task
+// that's still running, and fails the allocation if hard is set.
+func (ar *allocRunner) enforceDiskLimit(used, size uint64, hard bool) {
+	if ar.isShuttingDown() {
+		return
+	}
+
+	msg := fmt.Sprintf("Allocation disk usage of %s exceeds ephemeral_disk size of %s",
+		humanize.IBytes(used), humanize.IBytes(size))
+	for _, tr := range ar.tasks {
+		if tr.TaskState().FinishedAt.IsZero() {
+			tr.EmitEvent(structs.NewTaskEvent(structs.TaskDiskExceeded).
+				SetDiskLimit(int64(size)).
+				SetMessage(msg))
+		}
+	}
+
+	if !hard {
+		return
+	}
+
+	ar.stateLock.Lock()
+	ar.state.DiskLimitExceeded = true
+	ar.state.ClientStatus = structs.AllocClientStatusFailed
+	ar.state.ClientDescription = structs.AllocFailedReasonDiskLimit
+	ar.stateLock.Unlock()
+
+	ar.logger.Debug("allocation exceeded ephemeral_disk size, killing tasks", "used", used, "size", size)
+	ar.killTasks()
+}
+
+func (ar *allocRunner) diskLimitExceeded() bool {
+	ar.stateLock.Lock()
+	defer ar.stateLock.Unlock()
+	return ar.state.DiskLimitExceeded
+}
+
+// EvictMemoryPressure is called by the client when the node is running out of
+// memory and this allocation was chosen to be evicted. It emits a task event
+// for every task that's still running and fails the allocation, so that the
+// servers reschedule it on another node.
+func (ar *allocRunner) EvictMemoryPressure(usage, reserved uint64) {
+	if ar.isShuttingDown() || ar.memoryPressureEvicted() {
+		return
+	}
+
+	msg := fmt.Sprintf("Allocation evicted due to node memory pressure: memory usage of %s exceeds reserved memory of %s",
+		humanize.IBytes(usage), humanize.IBytes(reserved))
+	for _, tr := range ar.tasks {
+		if tr.TaskState().FinishedAt.IsZero() {
+			tr.EmitEvent(structs.NewTaskEvent(structs.TaskMemoryPressureEvicted).
+				SetMessage(msg))
+		}
+	}
+
+	ar.stateLock.Lock()
+	ar.state.MemoryPressureEvicted = true
+	ar.state.ClientStatus = structs.AllocClientStatusFailed
+	ar.state.ClientDescription = structs.AllocFailedReasonMemoryPressure
+	ar.stateLock.Unlock()
+
+	ar.logger.Debug("allocation evicted due to node memory pressure, killing tasks", "usage", usage, "reserved", reserved)
+	ar.killTasks()
+}
+
+func (ar *allocRunner) memoryPressureEvicted() bool {
+	ar.stateLock.Lock()
+	defer ar.stateLock.Unlock()
+	return ar.state.MemoryPressureEvicted
+}
+
+// setDiskStats is called by the disk limit hook with the latest disk usage of
+// the alloc dir.
+func (ar *allocRunner) setDiskStats(stats *cstructs.AllocDiskStats) {
+	ar.diskStatsLock.Lock()
+	defer ar.diskStatsLock.Unlock()
+	ar.diskStats = stats
+}
+
 func (ar *allocRunner) destroyImpl() {
 	// Stop any running tasks and persist states in case the client is
 	// shutdown before Destroy finishes.
@@ -1358,6 +1454,13 @@ func (ar *allocRunner) LatestAllocStats(taskFilter string) (*cstructs.AllocResou
 		},
 	}
 
+	ar.diskStatsLock.RLock()
+	if ar.diskStats != nil {
+		stats := *ar.diskStats
+		astat.DiskStats = &stats
+	}
+	ar.diskStatsLock.RUnlock()
+
 	for name, tr := range ar.tasks {
 		if taskFilter != "" && taskFilter != name {
 			// Getting stats for a particular task and its not this one!
@@ -1398,6 +1501,43 @@ func (ar *allocRunner) Restart(ctx context.Context, event *structs.TaskEvent, fa
 	return ar.restartTasks(ctx, event, failure, false)
 }
 
+// Kill satisfies the WorkloadRestarter interface and kills all tasks that
+// haven't finished yet with the given event.
+func (ar *allocRunner) Kill(ctx context.Context, event *structs.TaskEvent) error {
+	waitCh := make(chan struct{})
+	var err *multierror.Error
+	var errMutex sync.Mutex
+
+	go func() {
+		var wg sync.WaitGroup
+		defer close(waitCh)
+		for tn, tr := range ar.tasks {
+			if !tr.TaskState().FinishedAt.IsZero() {
+				continue
+			}
+			wg.Add(1)
+			go func(taskName string, taskRunner *taskrunner.TaskRunner) {
+				defer wg.Done()
+
+				e := taskRunner.Kill(ctx, event.Copy())
+				if e != nil && e != te.ErrTaskNotRunning {
+					errMutex.Lock()
+					defer errMutex.Unlock()
+					err = multierror.Append(err, fmt.Errorf("failed to kill task %s: %v", taskName, e))
+				}
+			}(tn, tr)
+		}
+		wg.Wait()
+	}()
+
+	select {
+	case <-waitCh:
+	case <-ctx.Done():
+	}
+
+	return err.ErrorOrNil()
+}
+
 // RestartTask restarts the provided task.
 func (ar *allocRunner) RestartTask(taskName string, event *structs.TaskEvent) error {
 	tr, ok := ar.tasks[taskName]
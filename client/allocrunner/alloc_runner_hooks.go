@@ -108,6 +108,10 @@ func (ar *allocRunner) initRunnerHooks(config *clientconfig.Config) error {
 	// directory path exists for other hooks.
 	alloc := ar.Alloc()
 
+	// the upstream proxies listen in the network namespace created by the
+	// network hook
+	nh := newNetworkHook(hookLogger, ns, alloc, nm, nc, ar)
+
 	ar.runnerHooks = []interfaces.RunnerHook{
 		newIdentityHook(hookLogger, ar.widmgr),
 		newAllocDirHook(hookLogger, ar.allocDir),
@@ -124,9 +128,12 @@ func (ar *allocRunner) initRunnerHooks(config *clientconfig.Config) error {
 		}),
 		newUpstreamAllocsHook(hookLogger, ar.prevAllocWatcher),
 		newDiskMigrationHook(hookLogger, ar.prevAllocMigrator, ar.allocDir),
+		// the disk limit hook may tag the alloc dir with a project quota, into
+		// which the data of a previous alloc can't be moved anymore
+		newDiskLimitHook(hookLogger, alloc, ar.allocDir.AllocDirPath(), ar),
 		newCPUPartsHook(hookLogger, ar.partitions, alloc),
 		newAllocHealthWatcherHook(hookLogger, alloc, hs, ar.Listener(), ar.consulServicesHandler, ar.checkStore),
-		newNetworkHook(hookLogger, ns, alloc, nm, nc, ar),
+		nh,
 		newGroupServiceHook(groupServiceHookConfig{
 			alloc:             alloc,
 			providerNamespace: alloc.ServiceProviderNamespace(),
@@ -141,6 +148,7 @@ func (ar *allocRunner) initRunnerHooks(config *clientconfig.Config) error {
 			config.GetConsulConfigs(ar.logger), config.Node.Attributes),
 		newConsulHTTPSocketHook(hookLogger, alloc, ar.allocDir,
 			config.GetConsulConfigs(ar.logger)),
+		newUpstreamProxyHook(hookLogger, alloc, ar.rpcClient, ar.widmgr, config.Region, nh),
 		newCSIHook(alloc, hookLogger, ar.csiManager, ar.rpcClient, ar, ar.hookResources, ar.clientConfig.Node.SecretID),
 		newChecksHook(hookLogger, alloc, ar.checkStore, ar),
 	}
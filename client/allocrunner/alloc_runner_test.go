@@ -1902,6 +1902,68 @@ func TestAllocRunner_MoveAllocDir(t *testing.T) {
 
 }
 
+// TestAllocRunner_MoveAllocDir_DiskEnforced asserts that the ephemeral disk
+// content of a previous alloc is moved before the disk usage of an alloc
+// enforcing its ephemeral disk size is measured, as the alloc dir can't be
+// moved into once it's tagged with a project quota.
+func TestAllocRunner_MoveAllocDir_DiskEnforced(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	conf, cleanup := testAllocRunnerConfig(t, alloc)
+	defer cleanup()
+	ar, err := NewAllocRunner(conf)
+	must.NoError(t, err)
+
+	ar.Run()
+	defer destroy(ar)
+
+	WaitForClientState(t, ar, structs.AllocClientStatusComplete)
+
+	dataFile := filepath.Join(ar.GetAllocDir().ShareDirPath(), "data", "data_file")
+	must.NoError(t, os.WriteFile(dataFile, []byte("hello world"), 0o644))
+
+	alloc2 := mock.BatchAlloc()
+	alloc2.PreviousAllocation = alloc.ID
+	alloc2.Job.TaskGroups[0].EphemeralDisk.Sticky = true
+	alloc2.Job.TaskGroups[0].EphemeralDisk.Enforcement = structs.EphemeralDiskEnforcementSoft
+
+	conf2, cleanup := testAllocRunnerConfig(t, alloc2)
+	conf2.PrevAllocWatcher, conf2.PrevAllocMigrator = allocwatcher.NewAllocWatcher(allocwatcher.Config{
+		Alloc:          alloc2,
+		PreviousRunner: ar,
+		Logger:         conf2.Logger,
+	})
+	defer cleanup()
+	ar2, err := NewAllocRunner(conf2)
+	must.NoError(t, err)
+
+	// The disk usage is only measured once the data is migrated
+	hookIndex := map[string]int{}
+	for i, hook := range ar2.(*allocRunner).runnerHooks {
+		hookIndex[hook.Name()] = i
+	}
+	must.Greater(t, hookIndex["migrate_disk"], hookIndex["disk_limit"])
+
+	ar2.Run()
+	defer destroy(ar2)
+
+	WaitForClientState(t, ar2, structs.AllocClientStatusComplete)
+
+	dataFile = filepath.Join(ar2.GetAllocDir().ShareDirPath(), "data", "data_file")
+	must.FileExists(t, dataFile)
+
+	if diskUsageSupported {
+		must.Wait(t, wait.InitialSuccess(wait.BoolFunc(func() bool {
+			stats, err := ar2.StatsReporter().LatestAllocStats("")
+			return err == nil && stats.DiskStats != nil && stats.DiskStats.Used > 0
+		}),
+			wait.Timeout(5*time.Second),
+			wait.Gap(10*time.Millisecond),
+		))
+	}
+}
+
 // TestAllocRuner_HandlesArtifactFailure ensures that if one task in a task group is
 // retrying fetching an artifact, other tasks in the group should be able
 // to proceed.
@@ -2200,6 +2262,68 @@ func TestAllocRunner_TaskFailed_KillTG(t *testing.T) {
 	})
 }
 
+// TestAllocRunner_Kill asserts killing the allocation with a failing event, as
+// the check watcher does for group services, fails its tasks.
+func TestAllocRunner_Kill(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Driver = "mock_driver"
+	task.KillTimeout = 10 * time.Millisecond
+	task.Config = map[string]interface{}{
+		"run_for": "10s",
+	}
+	alloc.Job.TaskGroups[0].RestartPolicy.Attempts = 0
+
+	conf, cleanup := testAllocRunnerConfig(t, alloc)
+	defer cleanup()
+
+	ar, err := NewAllocRunner(conf)
+	must.NoError(t, err)
+
+	go ar.Run()
+	defer destroy(ar)
+
+	upd := conf.StateUpdater.(*MockStateUpdater)
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.ErrorFunc(func() error {
+			last := upd.Last()
+			if last == nil {
+				return fmt.Errorf("no updates")
+			}
+			if s := last.TaskStates[task.Name].State; s != structs.TaskStateRunning {
+				return fmt.Errorf("expected task to be running not %s", s)
+			}
+			return nil
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	event := structs.NewTaskEvent(structs.TaskKilling).
+		SetKillReason("startup check did not pass").
+		SetFailsTask()
+	must.NoError(t, ar.(*allocRunner).Kill(t.Context(), event))
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.ErrorFunc(func() error {
+			last := upd.Last()
+			if last.ClientStatus != structs.AllocClientStatusFailed {
+				return fmt.Errorf("expected alloc to be failed not %s", last.ClientStatus)
+			}
+			state := last.TaskStates[task.Name]
+			if state.State != structs.TaskStateDead || !state.Failed {
+				return fmt.Errorf("expected task to be dead and failed not %s (failed=%t)", state.State, state.Failed)
+			}
+			return nil
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+}
+
 // Test that alloc becoming terminal should destroy the alloc runner
 func TestAllocRunner_TerminalUpdate_Destroy(t *testing.T) {
 	ci.Parallel(t)
@@ -37,10 +37,52 @@ type observer struct {
 	qc      *checks.QueryContext
 	check   *structs.ServiceCheck
 	allocID string
+
+	// gate is the startup gate of the workload of the check, if any
+	gate *startupGate
+}
+
+// startupGate blocks the checks of a workload until its startup checks have
+// each passed once.
+type startupGate struct {
+	lock    sync.Mutex
+	pending map[structs.CheckID]struct{}
+	passed  chan struct{}
+}
+
+func newStartupGate() *startupGate {
+	return &startupGate{
+		pending: make(map[structs.CheckID]struct{}),
+		passed:  make(chan struct{}),
+	}
+}
+
+// pass marks the startup check as passed, and opens the gate once all startup
+// checks have passed.
+func (g *startupGate) pass(id structs.CheckID) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if _, exists := g.pending[id]; !exists {
+		return
+	}
+	delete(g.pending, id)
+	if len(g.pending) == 0 {
+		close(g.passed)
+	}
 }
 
 // start checking our check on its interval
 func (o *observer) start() {
+	// checks gated by startup checks only start once those have passed
+	if o.gate != nil && !o.check.OnStart {
+		select {
+		case <-o.ctx.Done():
+			return
+		case <-o.gate.passed:
+		}
+	}
+
 	// compromise between immediate (too early) and waiting full interval (slow)
 	firstWait := o.check.Interval / 2
 
@@ -62,6 +104,11 @@ func (o *observer) start() {
 			// and put the results into the store (already logged)
 			_ = o.checkStore.Set(o.allocID, result)
 
+			// open the startup gate of the workload once this passes
+			if o.gate != nil && o.check.OnStart && result.Status == structs.CheckSuccess {
+				o.gate.pass(o.qc.ID)
+			}
+
 			// setup timer for next interval
 			timer.Reset(o.check.Interval)
 		}
@@ -90,6 +137,12 @@ type checksHook struct {
 	stop      func()
 	observers observers
 	alloc     *structs.Allocation
+
+	// gates maps the name of each workload (the task name, or empty for the
+	// group) with startup checks to its startup gate. Gates are not reset
+	// when tasks restart, as the startup checks are then enforced by the
+	// check watcher.
+	gates map[string]*startupGate
 }
 
 func newChecksHook(
@@ -135,6 +188,7 @@ func (h *checksHook) initialize(alloc *structs.Allocation) {
 
 	// fresh set of observers
 	h.observers = make(observers)
+	h.gates = make(map[string]*startupGate)
 
 	// set the initial alloc
 	h.alloc = alloc
@@ -152,6 +206,27 @@ func (h *checksHook) observe(alloc *structs.Allocation, services []*structs.Serv
 		networks = alloc.AllocatedResources.Shared.Networks
 	}
 
+	// create the startup gates of workloads with startup checks; gates of
+	// workloads already observed are kept as is
+	created := make(map[string]*startupGate)
+	for _, service := range services {
+		for _, check := range service.Checks {
+			if !check.OnStart {
+				continue
+			}
+			gate, exists := h.gates[service.TaskName]
+			if exists && created[service.TaskName] != gate {
+				continue
+			}
+			if !exists {
+				gate = newStartupGate()
+				created[service.TaskName] = gate
+				h.gates[service.TaskName] = gate
+			}
+			gate.pending[structs.NomadCheckID(alloc.ID, alloc.TaskGroup, check)] = struct{}{}
+		}
+	}
+
 	for _, service := range services {
 		for _, check := range service.Checks {
 
@@ -176,6 +251,7 @@ func (h *checksHook) observe(alloc *structs.Allocation, services []*structs.Serv
 				checkStore: h.shim,
 				checker:    h.checker,
 				allocID:    h.allocID,
+				gate:       h.gates[service.TaskName],
 				qc: &checks.QueryContext{
 					ID:               id,
 					CustomAddress:    service.Address,
@@ -212,6 +212,101 @@ func TestCheckHook_Checks_ResultsSet(t *testing.T) {
 	}
 }
 
+func TestCheckHook_Checks_Startup(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+
+	// create an http server with various responses
+	ts := httptest.NewServer(checkHandler)
+	defer ts.Close()
+
+	// get the address and port for http server
+	tokens := strings.Split(ts.URL, ":")
+	addr, port := strings.TrimPrefix(tokens[1], "//"), tokens[2]
+
+	network := mock.NewNetworkStatus(addr)
+
+	countResults := func(checkStore checkstore.Shim, allocID string) (passing, failing, pending int) {
+		for _, result := range checkStore.List(allocID) {
+			switch result.Status {
+			case structs.CheckSuccess:
+				passing++
+			case structs.CheckFailure:
+				failing++
+			case structs.CheckPending:
+				pending++
+			}
+		}
+		return
+	}
+
+	t.Run("gated", func(t *testing.T) {
+		checkStore := makeCheckStore(logger)
+
+		// the failing check is a startup check, so the other checks never run
+		alloc := allocWithNomadChecks(addr, port, true)
+		alloc.Job.LookupTaskGroup(alloc.TaskGroup).Services[0].Checks[1].OnStart = true
+
+		env := taskenv.NewBuilder(mock.Node(), alloc, nil, alloc.Job.Region).Build()
+		h := newChecksHook(logger, alloc, checkStore, network)
+		must.NoError(t, h.Prerun(env))
+		defer h.PreKill()
+
+		testutil.WaitForResultUntil(
+			2*time.Second,
+			func() (bool, error) {
+				if _, failing, _ := countResults(checkStore, alloc.ID); failing != 1 {
+					return false, fmt.Errorf("expected 1 failing, got %d", failing)
+				}
+				return true, nil
+			},
+			func(err error) {
+				t.Fatal(err)
+			},
+		)
+
+		// wait a few intervals of the gated checks
+		time.Sleep(time.Second)
+
+		passing, failing, pending := countResults(checkStore, alloc.ID)
+		must.Eq(t, 0, passing)
+		must.Eq(t, 1, failing)
+		must.Eq(t, 2, pending)
+	})
+
+	t.Run("passed", func(t *testing.T) {
+		checkStore := makeCheckStore(logger)
+
+		// the passing check is a startup check, so the other checks run once
+		// it passed
+		alloc := allocWithNomadChecks(addr, port, true)
+		alloc.Job.LookupTaskGroup(alloc.TaskGroup).Services[0].Checks[0].OnStart = true
+
+		env := taskenv.NewBuilder(mock.Node(), alloc, nil, alloc.Job.Region).Build()
+		h := newChecksHook(logger, alloc, checkStore, network)
+		must.NoError(t, h.Prerun(env))
+		defer h.PreKill()
+
+		testutil.WaitForResultUntil(
+			3*time.Second,
+			func() (bool, error) {
+				passing, failing, pending := countResults(checkStore, alloc.ID)
+				if passing != 1 || failing != 2 || pending != 0 {
+					return false, fmt.Errorf(
+						"expected 1 passing, 2 failing, 0 pending, got %d passing, %d failing, %d pending",
+						passing, failing, pending,
+					)
+				}
+				return true, nil
+			},
+			func(err error) {
+				t.Fatal(err)
+			},
+		)
+	})
+}
+
 func TestCheckHook_Checks_UpdateSet(t *testing.T) {
 	ci.Parallel(t)
 
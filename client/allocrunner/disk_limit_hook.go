@@ -0,0 +1,289 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocrunner
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/client/taskenv"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// diskLimitInterval is how often the disk usage of the alloc dir is
+	// measured.
+	diskLimitInterval = 15 * time.Second
+
+	// diskLimitMaxInterval is the longest interval between measurements of
+	// alloc dirs that have to be walked to measure their disk usage. The
+	// interval grows with the time a walk takes, so that large alloc dirs
+	// aren't walked constantly.
+	diskLimitMaxInterval = 5 * time.Minute
+
+	// diskLimitWalkFactor is the factor of the time a walk of the alloc dir
+	// took that is waited before the next walk.
+	diskLimitWalkFactor = 20
+)
+
+var (
+	_ interfaces.RunnerPrerunHook  = (*diskLimitHook)(nil)
+	_ interfaces.RunnerPostrunHook = (*diskLimitHook)(nil)
+	_ interfaces.RunnerUpdateHook  = (*diskLimitHook)(nil)
+	_ interfaces.ShutdownHook      = (*diskLimitHook)(nil)
+)
+
+// diskLimitEnforcer is implemented by the alloc runner to receive the disk
+// usage measured by the disk limit hook.
+type diskLimitEnforcer interface {
+	// setDiskStats records the latest disk usage of the alloc dir.
+	setDiskStats(*cstructs.AllocDiskStats)
+
+	// enforceDiskLimit is called when the alloc dir grows larger than the
+	// ephemeral_disk size. If hard is set the allocation should be failed.
+	enforceDiskLimit(used, size uint64, hard bool)
+}
+
+// diskUsage measures the disk usage of an alloc dir.
+type diskUsage interface {
+	// usage returns the number of bytes allocated on disk for the alloc dir.
+	usage() (uint64, error)
+
+	// cheap returns whether measuring the usage is cheap, or requires walking
+	// the alloc dir.
+	cheap() bool
+
+	// close releases the resources used to measure the usage.
+	close()
+}
+
+// diskLimitHook periodically measures the disk usage of the alloc dir and
+// enforces the ephemeral_disk size of allocations that set an enforcement
+// mode. The disk usage is only measured while enforcement is enabled. Disk
+// usage can only be measured on Linux, so the hook does nothing on other
+// platforms.
+type diskLimitHook struct {
+	mu sync.Mutex
+
+	alloc    *structs.Allocation
+	allocDir string
+	interval time.Duration
+	enforcer diskLimitEnforcer
+	logger   hclog.Logger
+
+	// usage measures the disk usage of the alloc dir. It's created when the
+	// measurements start for the first time.
+	usage diskUsage
+
+	// running is set between Prerun and Postrun, while the disk usage may
+	// be measured.
+	running bool
+
+	// exceeded is set once the usage exceeds the disk size, so the limit is
+	// only enforced once until the usage drops below the size again.
+	exceeded bool
+
+	cancel context.CancelFunc
+	doneCh chan struct{}
+}
+
+func newDiskLimitHook(
+	logger hclog.Logger,
+	alloc *structs.Allocation,
+	allocDir string,
+	enforcer diskLimitEnforcer,
+) *diskLimitHook {
+	return &diskLimitHook{
+		alloc:    alloc,
+		allocDir: allocDir,
+		interval: diskLimitInterval,
+		enforcer: enforcer,
+		logger:   logger.Named("disk_limit"),
+	}
+}
+
+func (h *diskLimitHook) Name() string {
+	return "disk_limit"
+}
+
+func (h *diskLimitHook) Prerun(*taskenv.TaskEnv) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.running = true
+	if h.enforcedLocked() {
+		h.startLocked()
+	}
+	return nil
+}
+
+func (h *diskLimitHook) Update(req *interfaces.RunnerUpdateRequest) error {
+	h.mu.Lock()
+	h.alloc = req.Alloc
+	if !h.running || h.enforcedLocked() {
+		if h.running {
+			h.startLocked()
+		}
+		h.mu.Unlock()
+		return nil
+	}
+	h.mu.Unlock()
+
+	// Enforcement was disabled, so there is no need to measure the usage
+	h.stop()
+	h.enforcer.setDiskStats(nil)
+	return nil
+}
+
+func (h *diskLimitHook) Postrun() error {
+	h.mu.Lock()
+	h.running = false
+	h.mu.Unlock()
+
+	h.stop()
+	h.closeUsage()
+	return nil
+}
+
+func (h *diskLimitHook) Shutdown() {
+	h.mu.Lock()
+	h.running = false
+	h.mu.Unlock()
+
+	h.stop()
+	h.closeUsage()
+}
+
+// closeUsage releases the disk usage measurement, if any. Must be called once
+// the measurements are stopped for good.
+func (h *diskLimitHook) closeUsage() {
+	if h.usage != nil {
+		h.usage.close()
+		h.usage = nil
+	}
+}
+
+// enforcedLocked returns whether the disk size of the allocation is enforced.
+// Must be called with the lock held.
+func (h *diskLimitHook) enforcedLocked() bool {
+	if !diskUsageSupported {
+		return false
+	}
+	tg := h.alloc.Job.LookupTaskGroup(h.alloc.TaskGroup)
+	if tg == nil {
+		return false
+	}
+	enforced, _ := tg.EphemeralDisk.Enforced()
+	return enforced
+}
+
+// startLocked starts measuring the disk usage, unless it's already being
+// measured. Must be called with the lock held.
+func (h *diskLimitHook) startLocked() {
+	if h.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	h.cancel = cancel
+	h.doneCh = make(chan struct{})
+	go h.run(ctx, h.doneCh)
+}
+
+// stop stops measuring the disk usage and waits for the measurement in
+// progress, if any, to finish.
+func (h *diskLimitHook) stop() {
+	h.mu.Lock()
+	cancel, doneCh := h.cancel, h.doneCh
+	h.cancel, h.doneCh = nil, nil
+	h.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-doneCh
+}
+
+func (h *diskLimitHook) run(ctx context.Context, doneCh chan struct{}) {
+	defer close(doneCh)
+
+	// Assigning a project quota to the alloc dir walks it, so it's done
+	// here rather than blocking Prerun.
+	if h.usage == nil {
+		h.usage = newDiskUsage(h.logger, h.alloc.ID, h.allocDir)
+	}
+
+	timer, stop := helper.NewSafeTimer(0)
+	defer stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		start := time.Now()
+		h.measure()
+		timer.Reset(h.nextInterval(time.Since(start)))
+	}
+}
+
+// nextInterval returns the time to wait before the next measurement, given
+// the time the last one took. Measurements that walk the alloc dir are spaced
+// out further the longer the walk takes.
+func (h *diskLimitHook) nextInterval(took time.Duration) time.Duration {
+	if h.usage.cheap() {
+		return h.interval
+	}
+	return max(h.interval, min(took*diskLimitWalkFactor, diskLimitMaxInterval))
+}
+
+// measure records the disk usage of the alloc dir and enforces the disk size
+// if it has been exceeded.
+func (h *diskLimitHook) measure() {
+	used, err := h.usage.usage()
+	if err != nil {
+		h.logger.Warn("failed to measure alloc dir disk usage", "error", err)
+		return
+	}
+
+	h.mu.Lock()
+	alloc := h.alloc
+	h.mu.Unlock()
+
+	var size uint64
+	if alloc.AllocatedResources != nil {
+		size = uint64(alloc.AllocatedResources.Shared.DiskMB) * 1024 * 1024
+	}
+	h.enforcer.setDiskStats(&cstructs.AllocDiskStats{
+		Used:      used,
+		Size:      size,
+		Timestamp: time.Now().UnixNano(),
+	})
+
+	var enforced, hard bool
+	if tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup); tg != nil {
+		enforced, hard = tg.EphemeralDisk.Enforced()
+	}
+	if !enforced || size == 0 || used <= size {
+		h.exceeded = false
+		return
+	}
+	if h.exceeded {
+		return
+	}
+	h.exceeded = true
+
+	// Enforcing a hard limit kills the tasks, which must not block the hook
+	// from being stopped.
+	h.logger.Debug("alloc dir exceeded ephemeral_disk size", "used", used, "size", size, "hard", hard)
+	go h.enforcer.enforceDiskLimit(used, size, hard)
+}
@@ -0,0 +1,246 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package allocrunner
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/client/taskenv"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+type mockDiskLimitEnforcer struct {
+	lock     sync.Mutex
+	stats    *cstructs.AllocDiskStats
+	enforced []bool
+}
+
+func (m *mockDiskLimitEnforcer) setDiskStats(stats *cstructs.AllocDiskStats) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.stats = stats
+}
+
+func (m *mockDiskLimitEnforcer) enforceDiskLimit(_, _ uint64, hard bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.enforced = append(m.enforced, hard)
+}
+
+func (m *mockDiskLimitEnforcer) get() (*cstructs.AllocDiskStats, []bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.stats, append([]bool(nil), m.enforced...)
+}
+
+// writeTestFile writes a file of the given size with data blocks allocated.
+func writeTestFile(t *testing.T, path string, size int) {
+	t.Helper()
+	must.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	must.NoError(t, os.WriteFile(path, make([]byte, size), 0o644))
+}
+
+func TestAllocDirUsage(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "alloc", "data", "a"), 1<<20)
+	writeTestFile(t, filepath.Join(dir, "web", "local", "b"), 1<<20)
+
+	used, err := allocDirUsage(dir)
+	must.NoError(t, err)
+	must.Between(t, 2<<20, used, 3<<20)
+
+	// Hard links share their blocks so the usage doesn't grow
+	must.NoError(t, os.Link(
+		filepath.Join(dir, "web", "local", "b"),
+		filepath.Join(dir, "web", "local", "c")))
+	linked, err := allocDirUsage(dir)
+	must.NoError(t, err)
+	must.Between(t, used-8192, linked, used+8192)
+}
+
+func TestDiskLimitHook(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name        string
+		enforcement string
+		exp         []bool
+	}{
+		{name: "soft", enforcement: structs.EphemeralDiskEnforcementSoft, exp: []bool{false}},
+		{name: "hard", enforcement: structs.EphemeralDiskEnforcementHard, exp: []bool{true}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeTestFile(t, filepath.Join(dir, "alloc", "data", "a"), 2<<20)
+
+			alloc := mock.Alloc()
+			alloc.AllocatedResources.Shared.DiskMB = 1
+			alloc.Job.TaskGroups[0].EphemeralDisk.Enforcement = tc.enforcement
+
+			enforcer := &mockDiskLimitEnforcer{}
+			hook := newDiskLimitHook(log.NewNullLogger(), alloc, dir, enforcer)
+			hook.interval = 10 * time.Millisecond
+			must.NoError(t, hook.Prerun((*taskenv.TaskEnv)(nil)))
+			t.Cleanup(hook.Shutdown)
+
+			must.Wait(t, wait.InitialSuccess(wait.BoolFunc(func() bool {
+				stats, enforced := enforcer.get()
+				return stats != nil && len(enforced) == len(tc.exp)
+			}),
+				wait.Timeout(5*time.Second),
+				wait.Gap(10*time.Millisecond),
+			))
+
+			// The limit is only enforced once while it's exceeded
+			time.Sleep(50 * time.Millisecond)
+			stats, enforced := enforcer.get()
+			must.Eq(t, tc.exp, enforced)
+			must.Eq(t, 1<<20, stats.Size)
+			must.Greater(t, 2<<20-1, stats.Used)
+
+			// Raising the size stops enforcing it
+			alloc = alloc.Copy()
+			alloc.AllocatedResources.Shared.DiskMB = 10
+			must.NoError(t, hook.Update(&interfaces.RunnerUpdateRequest{Alloc: alloc}))
+			must.Wait(t, wait.InitialSuccess(wait.BoolFunc(func() bool {
+				stats, _ := enforcer.get()
+				return stats.Size == 10<<20
+			}),
+				wait.Timeout(5*time.Second),
+				wait.Gap(10*time.Millisecond),
+			))
+
+			must.NoError(t, hook.Postrun())
+		})
+	}
+}
+
+func TestDiskLimitHook_NotEnforced(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "alloc", "data", "a"), 2<<20)
+
+	alloc := mock.Alloc()
+	alloc.AllocatedResources.Shared.DiskMB = 1
+	alloc.Job.TaskGroups[0].EphemeralDisk.Enforcement = structs.EphemeralDiskEnforcementNone
+
+	enforcer := &mockDiskLimitEnforcer{}
+	hook := newDiskLimitHook(log.NewNullLogger(), alloc, dir, enforcer)
+	hook.interval = 10 * time.Millisecond
+	must.NoError(t, hook.Prerun((*taskenv.TaskEnv)(nil)))
+	t.Cleanup(hook.Shutdown)
+
+	// The alloc dir isn't measured while the disk size isn't enforced
+	time.Sleep(50 * time.Millisecond)
+	stats, enforced := enforcer.get()
+	must.Nil(t, stats)
+	must.SliceEmpty(t, enforced)
+
+	// Enabling enforcement starts measuring the alloc dir
+	alloc = alloc.Copy()
+	alloc.Job.TaskGroups[0].EphemeralDisk.Enforcement = structs.EphemeralDiskEnforcementSoft
+	must.NoError(t, hook.Update(&interfaces.RunnerUpdateRequest{Alloc: alloc}))
+	must.Wait(t, wait.InitialSuccess(wait.BoolFunc(func() bool {
+		stats, enforced := enforcer.get()
+		return stats != nil && len(enforced) == 1
+	}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	// Disabling it again stops measuring it
+	alloc = alloc.Copy()
+	alloc.Job.TaskGroups[0].EphemeralDisk.Enforcement = ""
+	must.NoError(t, hook.Update(&interfaces.RunnerUpdateRequest{Alloc: alloc}))
+	stats, _ = enforcer.get()
+	must.Nil(t, stats)
+	hook.mu.Lock()
+	must.Nil(t, hook.cancel)
+	hook.mu.Unlock()
+}
+
+func TestDiskLimitHook_NextInterval(t *testing.T) {
+	ci.Parallel(t)
+
+	hook := newDiskLimitHook(log.NewNullLogger(), mock.Alloc(), t.TempDir(), &mockDiskLimitEnforcer{})
+
+	// Walks of the alloc dir are spaced out with the time they take
+	hook.usage = &walkDiskUsage{root: hook.allocDir}
+	must.Eq(t, diskLimitInterval, hook.nextInterval(10*time.Millisecond))
+	must.Eq(t, 2*time.Minute, hook.nextInterval(6*time.Second))
+	must.Eq(t, diskLimitMaxInterval, hook.nextInterval(time.Hour))
+
+	// Project quotas are cheap to query
+	hook.usage = &projectQuotaUsage{root: hook.allocDir}
+	must.Eq(t, diskLimitInterval, hook.nextInterval(time.Hour))
+}
+
+func TestAssignProjectID(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	allocID := uuid.Generate()
+	if _, err := assignProjectID(allocID, dir); err != nil {
+		t.Skipf("project quotas unavailable: %v", err)
+	}
+	t.Cleanup(func() {
+		projectIDs.Lock()
+		defer projectIDs.Unlock()
+		for id, owner := range projectIDs.assigned {
+			if owner == allocID || owner == "other" {
+				delete(projectIDs.assigned, id)
+			}
+		}
+	})
+
+	// The ID assigned to an alloc is stable
+	q, err := newProjectQuotaUsage(allocID, dir)
+	must.NoError(t, err)
+	first := q.projectID
+	q.close()
+
+	// An ID assigned to another alloc is skipped
+	projectIDs.Lock()
+	projectIDs.assigned[first] = "other"
+	projectIDs.Unlock()
+
+	other := t.TempDir()
+	second, err := assignProjectID(allocID, other)
+	must.NoError(t, err)
+	must.NotEq(t, first, second)
+
+	// An ID used by files on the filesystem is skipped
+	projectIDs.Lock()
+	delete(projectIDs.assigned, first)
+	delete(projectIDs.assigned, second)
+	projectIDs.Unlock()
+
+	third, err := assignProjectID(allocID, other)
+	must.NoError(t, err)
+	must.NotEq(t, first, third)
+
+	// The alloc dir tagged with the ID keeps it
+	fourth, err := assignProjectID(allocID, dir)
+	must.NoError(t, err)
+	must.Eq(t, first, fourth)
+}
@@ -0,0 +1,370 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package allocrunner
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"github.com/hashicorp/go-hclog"
+	"golang.org/x/sys/unix"
+)
+
+// diskUsageSupported is true if the disk usage of alloc dirs can be measured
+// and enforced.
+const diskUsageSupported = true
+
+const (
+	// fsXflagProjinherit is FS_XFLAG_PROJINHERIT, which makes files and
+	// directories created in a directory inherit its project ID.
+	fsXflagProjinherit = 0x200
+
+	// qGetQuotaProject is QCMD(Q_GETQUOTA, PRJQUOTA).
+	qGetQuotaProject = 0x800007<<8 | 2
+
+	// projectIDAttempts is the number of project IDs derived from an alloc
+	// ID that are tried before giving up on project quotas for its alloc dir.
+	projectIDAttempts = 16
+)
+
+// projectIDs are the project IDs assigned to alloc dirs by this client, so
+// that allocs started at the same time can't be assigned the same one before
+// their alloc dirs are tagged with it.
+var projectIDs = struct {
+	sync.Mutex
+	assigned map[uint32]string
+}{assigned: make(map[uint32]string)}
+
+var (
+	// fsIocFsgetxattr and fsIocFssetxattr are the FS_IOC_FSGETXATTR and
+	// FS_IOC_FSSETXATTR ioctls.
+	fsIocFsgetxattr = ioc(iocRead, 'X', 31, unsafe.Sizeof(fsxattr{}))
+	fsIocFssetxattr = ioc(iocWrite, 'X', 32, unsafe.Sizeof(fsxattr{}))
+)
+
+// fsxattr is struct fsxattr from linux/fs.h.
+type fsxattr struct {
+	xflags     uint32
+	extsize    uint32
+	nextents   uint32
+	projid     uint32
+	cowextsize uint32
+	pad        [8]byte
+}
+
+// ifDqblk is struct if_dqblk from linux/quota.h.
+type ifDqblk struct {
+	bhardlimit uint64
+	bsoftlimit uint64
+	curspace   uint64
+	ihardlimit uint64
+	isoftlimit uint64
+	curinodes  uint64
+	btime      uint64
+	itime      uint64
+	valid      uint32
+	_          uint32
+}
+
+const (
+	iocWrite = iota
+	iocRead
+)
+
+// ioc encodes an ioctl request number, which differs between architectures.
+func ioc(dir int, typ, nr, size uintptr) uintptr {
+	read, write, dirShift := uintptr(2), uintptr(1), 30
+	switch runtime.GOARCH {
+	case "mips", "mipsle", "mips64", "mips64le", "ppc64", "ppc64le", "sparc64":
+		read, write, dirShift = 2, 4, 29
+	}
+	d := write
+	if dir == iocRead {
+		d = read
+	}
+	return d<<dirShift | size<<16 | typ<<8 | nr
+}
+
+// newDiskUsage returns the disk usage of the alloc dir measured with a project
+// quota if the filesystem of the alloc dir supports them, such as XFS or ext4
+// mounted with project quotas enabled. Otherwise the usage is measured by
+// walking the alloc dir.
+//
+// Files can't be hard linked into a directory of another project, so files
+// that would otherwise be hard linked into the alloc dir, like the contents
+// of a chroot, are copied and count against its usage.
+func newDiskUsage(logger hclog.Logger, allocID, root string) diskUsage {
+	q, err := newProjectQuotaUsage(allocID, root)
+	if err != nil {
+		logger.Debug("project quotas unavailable, measuring disk usage by walking the alloc dir", "error", err)
+		return &walkDiskUsage{root: root}
+	}
+	return q
+}
+
+// walkDiskUsage measures the disk usage of an alloc dir by walking it.
+type walkDiskUsage struct {
+	root string
+}
+
+func (w *walkDiskUsage) usage() (uint64, error) { return allocDirUsage(w.root) }
+func (*walkDiskUsage) cheap() bool              { return false }
+func (*walkDiskUsage) close()                   {}
+
+// projectQuotaUsage measures the disk usage of an alloc dir with the project
+// quota of a project ID assigned to it.
+type projectQuotaUsage struct {
+	root      string
+	allocID   string
+	projectID uint32
+}
+
+// newProjectQuotaUsage assigns a project ID derived from the alloc ID to the
+// alloc dir and its contents, and returns an error if project quotas aren't
+// supported and enabled on its filesystem.
+func newProjectQuotaUsage(allocID, root string) (*projectQuotaUsage, error) {
+	projectID, err := assignProjectID(allocID, root)
+	if err != nil {
+		return nil, err
+	}
+	q := &projectQuotaUsage{root: root, allocID: allocID, projectID: projectID}
+
+	if err := q.tag(); err != nil {
+		q.close()
+		return nil, fmt.Errorf("failed to assign project to alloc dir: %w", err)
+	}
+	return q, nil
+}
+
+// assignProjectID returns an unused project ID for the alloc dir, derived
+// from the alloc ID. An alloc dir already tagged with one of the IDs derived
+// from its alloc ID, such as after the client restarted, keeps it. Otherwise
+// IDs assigned to other alloc dirs, or used by any file on the filesystem,
+// are skipped so that allocs can't share a quota project. An error is
+// returned if project quotas aren't supported and enabled.
+func assignProjectID(allocID, root string) (uint32, error) {
+	current, err := getProject(root)
+	if err != nil {
+		return 0, err
+	}
+
+	projectIDs.Lock()
+	defer projectIDs.Unlock()
+
+	for i := range projectIDAttempts {
+		h := fnv.New32a()
+		h.Write([]byte(allocID))
+		if i > 0 {
+			fmt.Fprintf(h, "-%d", i)
+		}
+		projectID := h.Sum32() | 1
+
+		if owner, ok := projectIDs.assigned[projectID]; ok && owner != allocID {
+			continue
+		}
+		if projectID != current {
+			dq, err := getProjectQuota(root, projectID)
+			if err != nil {
+				return 0, err
+			}
+			if dq.curspace != 0 || dq.curinodes != 0 {
+				continue
+			}
+		}
+
+		projectIDs.assigned[projectID] = allocID
+		return projectID, nil
+	}
+	return 0, fmt.Errorf("no unused project ID found in %d attempts", projectIDAttempts)
+}
+
+// tag assigns the project ID to the alloc dir and its contents.
+func (q *projectQuotaUsage) tag() error {
+	root := q.root
+	var rootStat unix.Stat_t
+	if err := unix.Lstat(root, &rootStat); err != nil {
+		return err
+	}
+
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+
+		var st unix.Stat_t
+		if err := unix.Lstat(path, &st); err != nil {
+			if errors.Is(err, unix.ENOENT) {
+				return nil
+			}
+			return err
+		}
+		if st.Dev != rootStat.Dev {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		// Hard linked files are shared with files outside of the alloc
+		// dir, and other than directories and regular files can't be
+		// opened to set their project.
+		switch {
+		case d.IsDir():
+		case d.Type().IsRegular() && st.Nlink == 1:
+		default:
+			return nil
+		}
+		return setProject(path, q.projectID, d.IsDir())
+	})
+}
+
+func (q *projectQuotaUsage) usage() (uint64, error) {
+	dq, err := getProjectQuota(q.root, q.projectID)
+	if err != nil {
+		return 0, err
+	}
+	return dq.curspace, nil
+}
+
+func (*projectQuotaUsage) cheap() bool { return true }
+
+// close releases the project ID so that it can be assigned again once the
+// alloc dir is removed.
+func (q *projectQuotaUsage) close() {
+	projectIDs.Lock()
+	defer projectIDs.Unlock()
+	if projectIDs.assigned[q.projectID] == q.allocID {
+		delete(projectIDs.assigned, q.projectID)
+	}
+}
+
+// getProjectQuota returns the quota of the project ID on the filesystem of
+// path. A project without usage may have no quota on some filesystems, like
+// XFS, in which case an empty quota is returned.
+func getProjectQuota(path string, projectID uint32) (*ifDqblk, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var dq ifDqblk
+	_, _, errno := unix.Syscall6(unix.SYS_QUOTACTL_FD, f.Fd(), qGetQuotaProject,
+		uintptr(projectID), uintptr(unsafe.Pointer(&dq)), 0, 0)
+	switch errno {
+	case 0:
+	case unix.ENOENT:
+		return &ifDqblk{}, nil
+	default:
+		return nil, fmt.Errorf("failed to get project quota: %w", errno)
+	}
+	return &dq, nil
+}
+
+// getProject returns the project ID of the file.
+func getProject(path string) (uint32, error) {
+	f, err := os.OpenFile(path, unix.O_RDONLY|unix.O_NOFOLLOW|unix.O_NONBLOCK, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	var attr fsxattr
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, f.Fd(), fsIocFsgetxattr, uintptr(unsafe.Pointer(&attr))); errno != 0 {
+		return 0, errno
+	}
+	return attr.projid, nil
+}
+
+// setProject sets the project ID of the file, and makes directories pass it
+// on to the files created in them.
+func setProject(path string, projectID uint32, dir bool) error {
+	f, err := os.OpenFile(path, unix.O_RDONLY|unix.O_NOFOLLOW|unix.O_NONBLOCK, 0)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	var attr fsxattr
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, f.Fd(), fsIocFsgetxattr, uintptr(unsafe.Pointer(&attr))); errno != 0 {
+		return errno
+	}
+	attr.projid = projectID
+	if dir {
+		attr.xflags |= fsXflagProjinherit
+	}
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, f.Fd(), fsIocFssetxattr, uintptr(unsafe.Pointer(&attr))); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// allocDirUsage returns the number of bytes allocated on disk for the files
+// in the alloc dir. Other filesystems mounted into the alloc dir, like the
+// secrets dir, aren't counted. Directories bind mounted more than once, like
+// the shared alloc dir, are only counted once, and hard linked files, like
+// the contents of a chroot, are shared between their links.
+func allocDirUsage(root string) (uint64, error) {
+	var rootStat unix.Stat_t
+	if err := unix.Lstat(root, &rootStat); err != nil {
+		return 0, err
+	}
+
+	var used uint64
+	seenDirs := make(map[uint64]struct{})
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Files may be removed by the tasks while walking
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+
+		var st unix.Stat_t
+		if err := unix.Lstat(path, &st); err != nil {
+			if errors.Is(err, unix.ENOENT) {
+				return nil
+			}
+			return err
+		}
+
+		if st.Dev != rootStat.Dev {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			if _, ok := seenDirs[st.Ino]; ok {
+				return fs.SkipDir
+			}
+			seenDirs[st.Ino] = struct{}{}
+		}
+
+		blocks := uint64(st.Blocks) * 512
+		if !d.IsDir() && st.Nlink > 1 {
+			blocks /= uint64(st.Nlink)
+		}
+		used += blocks
+		return nil
+	})
+	return used, err
+}
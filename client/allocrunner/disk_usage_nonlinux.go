@@ -0,0 +1,29 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+
+package allocrunner
+
+import (
+	"errors"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// diskUsageSupported is true if the disk usage of alloc dirs can be measured
+// and enforced.
+const diskUsageSupported = false
+
+// newDiskUsage is only supported on Linux.
+func newDiskUsage(hclog.Logger, string, string) diskUsage {
+	return unsupportedDiskUsage{}
+}
+
+type unsupportedDiskUsage struct{}
+
+func (unsupportedDiskUsage) usage() (uint64, error) {
+	return 0, errors.New("alloc dir disk usage is only supported on Linux")
+}
+func (unsupportedDiskUsage) cheap() bool { return false }
+func (unsupportedDiskUsage) close()      {}
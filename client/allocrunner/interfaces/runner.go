@@ -39,6 +39,7 @@ type AllocRunner interface {
 	AcknowledgeState(*state.State)
 	GetUpdatePriority(*structs.Allocation) cstructs.AllocUpdatePriority
 	SetClientStatus(string)
+	EvictMemoryPressure(usage, reserved uint64)
 
 	Signal(taskName, signal string) error
 	RestartTask(taskName string, taskEvent *structs.TaskEvent) error
@@ -110,6 +110,12 @@ func (h *networkHook) Name() string {
 	return "network"
 }
 
+// NetworkIsolation returns the network namespace created for the allocation,
+// or nil if the allocation has none. It is only set once the hook has run.
+func (h *networkHook) NetworkIsolation() *drivers.NetworkIsolationSpec {
+	return h.spec
+}
+
 func (h *networkHook) Prerun(allocEnv *taskenv.TaskEnv) error {
 	tg := h.alloc.Job.LookupTaskGroup(h.alloc.TaskGroup)
 	if len(tg.Networks) == 0 || tg.Networks[0].Mode == "host" || tg.Networks[0].Mode == "" {
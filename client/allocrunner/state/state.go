@@ -24,6 +24,16 @@ type State struct {
 	// regardless of task exit status.
 	MaxRunDurationExceeded bool
 
+	// DiskLimitExceeded indicates the allocation exceeded its ephemeral_disk
+	// size with hard enforcement and should be reported as failed regardless
+	// of task exit status.
+	DiskLimitExceeded bool
+
+	// MemoryPressureEvicted indicates the allocation was evicted by the client
+	// because the node ran out of memory, and should be reported as failed
+	// regardless of task exit status.
+	MemoryPressureEvicted bool
+
 	// DeploymentStatus captures the status of the deployment
 	DeploymentStatus *structs.AllocDeploymentStatus
 
@@ -68,6 +78,8 @@ func (s *State) Copy() *State {
 		ClientStatus:           s.ClientStatus,
 		ClientDescription:      s.ClientDescription,
 		MaxRunDurationExceeded: s.MaxRunDurationExceeded,
+		DiskLimitExceeded:      s.DiskLimitExceeded,
+		MemoryPressureEvicted:  s.MemoryPressureEvicted,
 		DeploymentStatus:       s.DeploymentStatus.Copy(),
 		TaskStates:             taskStates,
 		NetworkStatus:          s.NetworkStatus.Copy(),
@@ -10,9 +10,11 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"time"
 
 	hclog "github.com/hashicorp/go-hclog"
+	metrics "github.com/hashicorp/go-metrics/compat"
 	plugin "github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
 	"github.com/hashicorp/nomad/client/logmon"
@@ -38,6 +40,9 @@ type logmonHook struct {
 	logmon             logmon.LogMon
 	logmonPluginClient *plugin.Client
 
+	// stopSinkStats stops emitting the metrics of the log sinks
+	stopSinkStats context.CancelFunc
+
 	config *logmonHookConfig
 
 	logger hclog.Logger
@@ -192,23 +197,84 @@ func (h *logmonHook) prestartOneLoop(ctx context.Context, req *interfaces.TaskPr
 		}
 	}
 
-	err := h.logmon.Start(&logmon.LogConfig{
-		LogDir:        h.config.logDir,
-		StdoutLogFile: fmt.Sprintf("%s.stdout", req.Task.Name),
-		StderrLogFile: fmt.Sprintf("%s.stderr", req.Task.Name),
-		StdoutFifo:    h.config.stdoutFifo,
-		StderrFifo:    h.config.stderrFifo,
-		MaxFiles:      req.Task.LogConfig.MaxFiles,
-		MaxFileSizeMB: req.Task.LogConfig.MaxFileSizeMB,
-	})
+	cfg := &logmon.LogConfig{
+		LogDir:           h.config.logDir,
+		StdoutLogFile:    fmt.Sprintf("%s.stdout", req.Task.Name),
+		StderrLogFile:    fmt.Sprintf("%s.stderr", req.Task.Name),
+		StdoutFifo:       h.config.stdoutFifo,
+		StderrFifo:       h.config.stderrFifo,
+		MaxFiles:         req.Task.LogConfig.MaxFiles,
+		MaxFileSizeMB:    req.Task.LogConfig.MaxFileSizeMB,
+		RotationInterval: req.Task.LogConfig.RotationInterval,
+		Compression:      req.Task.LogConfig.Compression,
+		Metadata:         logmon.LogMetadata{TaskName: req.Task.Name},
+	}
+	if alloc := h.runner.Alloc(); alloc != nil {
+		cfg.Metadata.AllocID = alloc.ID
+		cfg.Metadata.JobID = alloc.JobID
+		cfg.Metadata.Namespace = alloc.Namespace
+		cfg.Metadata.GroupName = alloc.TaskGroup
+	}
+	for _, sink := range req.Task.LogConfig.Sinks {
+		cfg.Sinks = append(cfg.Sinks, &logmon.LogSinkConfig{
+			Type:       sink.Type,
+			Address:    sink.Address,
+			Headers:    sink.Headers,
+			BufferSize: sink.BufferSize,
+		})
+	}
+
+	err := h.logmon.Start(cfg)
 	if err != nil {
 		h.logger.Error("failed to start logmon", "error", err)
 		return err
 	}
 
+	if len(cfg.Sinks) > 0 && h.runner.clientConfig.PublishAllocationMetrics {
+		if h.stopSinkStats != nil {
+			h.stopSinkStats()
+		}
+		var statsCtx context.Context
+		statsCtx, h.stopSinkStats = context.WithCancel(context.Background())
+		go h.emitSinkStats(statsCtx, h.logmon)
+	}
+
 	return nil
 }
 
+// emitSinkStats periodically emits the metrics of the task's log sinks until
+// the context is canceled.
+func (h *logmonHook) emitSinkStats(ctx context.Context, lm logmon.LogMon) {
+	ticker := time.NewTicker(h.runner.clientConfig.StatsCollectionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		stats, err := lm.Stats()
+		if status.Code(err) == codes.Unimplemented {
+			// logmon was started by an older client
+			return
+		} else if err != nil {
+			h.logger.Debug("failed to get log sink stats", "error", err)
+			continue
+		}
+
+		for _, st := range stats {
+			labels := append(slices.Clone(h.runner.baseLabels),
+				metrics.Label{Name: "sink_type", Value: st.Type})
+			metrics.SetGaugeWithLabels([]string{"client", "logmon", "sink", "sent"}, float32(st.Sent), labels)
+			metrics.SetGaugeWithLabels([]string{"client", "logmon", "sink", "dropped"}, float32(st.Dropped), labels)
+			metrics.SetGaugeWithLabels([]string{"client", "logmon", "sink", "failed"}, float32(st.Failed), labels)
+			metrics.SetGaugeWithLabels([]string{"client", "logmon", "sink", "buffered"}, float32(st.Buffered), labels)
+		}
+	}
+}
+
 func (h *logmonHook) Stop(_ context.Context, req *interfaces.TaskStopRequest, _ *interfaces.TaskStopResponse) error {
 	if h.isLoggingDisabled() {
 		return nil
@@ -222,6 +288,9 @@ func (h *logmonHook) Stop(_ context.Context, req *interfaces.TaskStopRequest, _
 		}
 	}
 
+	if h.stopSinkStats != nil {
+		h.stopSinkStats()
+	}
 	if h.logmon != nil {
 		h.logmon.Stop()
 	}
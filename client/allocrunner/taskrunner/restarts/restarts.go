@@ -21,6 +21,7 @@ const (
 	ReasonUnrecoverableError = "Error was unrecoverable"
 	ReasonWithinPolicy       = "Restart within policy"
 	ReasonDelay              = "Exceeded allowed attempts, applying a delay"
+	ReasonMaxDelay           = "Restart delay reached its maximum"
 )
 
 func NewRestartTracker(policy *structs.RestartPolicy, jobType string, tlc *structs.TaskLifecycleConfig) *RestartTracker {
@@ -64,6 +65,7 @@ type RestartTracker struct {
 	onSuccess        bool      // Whether to restart on successful exit code.
 	startTime        time.Time // When the interval began
 	reason           string    // The reason for the last state
+	crashLooping     bool      // Whether the last state was a crash loop
 	policy           *structs.RestartPolicy
 	rand             *rand.Rand
 	lock             sync.Mutex
@@ -133,6 +135,16 @@ func (r *RestartTracker) GetReason() string {
 	return r.reason
 }
 
+// CrashLooping returns whether the task was found to be crash looping by the
+// last call to GetState. A task is crash looping if it has exhausted its
+// restart attempts in delay mode or if its restart delay has grown to the
+// policy's max_delay.
+func (r *RestartTracker) CrashLooping() bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.crashLooping
+}
+
 // GetCount returns the current restart count
 func (r *RestartTracker) GetCount() int {
 	r.lock.Lock()
@@ -163,6 +175,8 @@ func (r *RestartTracker) GetState() (string, time.Duration) {
 		r.killed = false
 	}()
 
+	r.crashLooping = false
+
 	// Hot path if task was killed
 	if r.killed {
 		r.reason = ""
@@ -231,12 +245,18 @@ func (r *RestartTracker) GetState() (string, time.Duration) {
 			return structs.TaskNotRestarting, 0
 		} else {
 			r.reason = ReasonDelay
+			r.crashLooping = true
 			return structs.TaskRestarting, r.getDelay()
 		}
 	}
 
 	r.reason = ReasonWithinPolicy
-	return structs.TaskRestarting, r.jitter()
+	delay, maxed := r.backoff()
+	if maxed && r.count > 1 {
+		r.reason = ReasonMaxDelay
+		r.crashLooping = true
+	}
+	return structs.TaskRestarting, r.jitter(delay)
 }
 
 // getDelay returns the delay time to enter the next interval.
@@ -246,10 +266,35 @@ func (r *RestartTracker) getDelay() time.Duration {
 	return end.Sub(now)
 }
 
+// backoff returns the delay before the current restart attempt according to
+// the policy's delay function, capped at the policy's max delay, and whether
+// the delay has reached the cap.
+func (r *RestartTracker) backoff() (time.Duration, bool) {
+	delay := r.policy.Delay
+	switch r.policy.DelayFunction {
+	case "exponential":
+		for i := 1; i < r.count && delay < r.policy.MaxDelay; i++ {
+			delay *= 2
+		}
+	case "fibonacci":
+		prev := time.Duration(0)
+		for i := 1; i < r.count && delay < r.policy.MaxDelay; i++ {
+			prev, delay = delay, prev+delay
+		}
+	default:
+		return delay, false
+	}
+
+	if delay >= r.policy.MaxDelay {
+		return r.policy.MaxDelay, true
+	}
+	return delay, false
+}
+
 // jitter returns the delay time plus a jitter.
-func (r *RestartTracker) jitter() time.Duration {
-	// Get the delay and ensure it is valid.
-	d := r.policy.Delay.Nanoseconds()
+func (r *RestartTracker) jitter(delay time.Duration) time.Duration {
+	// Ensure the delay is valid.
+	d := delay.Nanoseconds()
 	if d == 0 {
 		d = 1
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/require"
 )
 
@@ -82,6 +83,89 @@ func TestClient_RestartTracker_ModeFail(t *testing.T) {
 	}
 }
 
+func TestClient_RestartTracker_DelayFunction(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name     string
+		function string
+		expected []time.Duration
+	}{
+		{
+			name:     "constant",
+			function: "constant",
+			expected: []time.Duration{
+				1 * time.Second, 1 * time.Second, 1 * time.Second,
+				1 * time.Second, 1 * time.Second, 1 * time.Second,
+			},
+		},
+		{
+			name:     "exponential",
+			function: "exponential",
+			expected: []time.Duration{
+				1 * time.Second, 2 * time.Second, 4 * time.Second,
+				8 * time.Second, 10 * time.Second, 10 * time.Second,
+			},
+		},
+		{
+			name:     "fibonacci",
+			function: "fibonacci",
+			expected: []time.Duration{
+				1 * time.Second, 1 * time.Second, 2 * time.Second,
+				3 * time.Second, 5 * time.Second, 8 * time.Second,
+				10 * time.Second,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := testPolicy(true, structs.RestartPolicyModeDelay)
+			p.Attempts = 10
+			p.DelayFunction = tc.function
+			p.MaxDelay = 10 * time.Second
+			rt := NewRestartTracker(p, structs.JobTypeService, nil)
+
+			for i, expected := range tc.expected {
+				state, when := rt.SetExitResult(testExitResult(127)).GetState()
+				must.Eq(t, structs.TaskRestarting, state)
+				must.GreaterEq(t, expected, when, must.Sprintf("attempt %d", i+1))
+				must.LessEq(t, expected+time.Duration(float64(expected)*jitter), when,
+					must.Sprintf("attempt %d", i+1))
+
+				// Only a delay that has grown to the max delay marks the task
+				// as crash looping
+				maxed := tc.function != "constant" && expected == p.MaxDelay
+				must.Eq(t, maxed, rt.CrashLooping(), must.Sprintf("attempt %d", i+1))
+				if maxed {
+					must.Eq(t, ReasonMaxDelay, rt.GetReason())
+				}
+			}
+		})
+	}
+}
+
+func TestClient_RestartTracker_CrashLooping(t *testing.T) {
+	ci.Parallel(t)
+	p := testPolicy(true, structs.RestartPolicyModeDelay)
+	rt := NewRestartTracker(p, structs.JobTypeService, nil)
+	for i := 0; i < p.Attempts; i++ {
+		state, _ := rt.SetExitResult(testExitResult(127)).GetState()
+		must.Eq(t, structs.TaskRestarting, state)
+		must.False(t, rt.CrashLooping())
+	}
+
+	// Exceeding the attempts in delay mode is a crash loop
+	state, _ := rt.SetExitResult(testExitResult(127)).GetState()
+	must.Eq(t, structs.TaskRestarting, state)
+	must.True(t, rt.CrashLooping())
+
+	// A restart that isn't due to a failure clears the crash loop
+	state, _ = rt.SetRestartTriggered(false).GetState()
+	must.Eq(t, structs.TaskRestarting, state)
+	must.False(t, rt.CrashLooping())
+}
+
 func TestClient_RestartTracker_NoRestartOnSuccess(t *testing.T) {
 	ci.Parallel(t)
 	p := testPolicy(false, structs.RestartPolicyModeDelay)
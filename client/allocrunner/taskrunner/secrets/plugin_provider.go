@@ -6,6 +6,7 @@ package secrets
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/nomad/client/commonplugins"
 )
@@ -26,6 +27,10 @@ type ExternalPluginProvider struct {
 
 	// env is the set of environment variables passed into plugin
 	env map[string]string
+
+	// ttl is how long the result of the last Fetch is valid for, or zero if
+	// the plugin didn't return a TTL
+	ttl time.Duration
 }
 
 type Response struct {
@@ -63,5 +68,16 @@ func (p *ExternalPluginProvider) Fetch(ctx context.Context) (map[string]string,
 		formatted[fmt.Sprintf("secret.%s.%s", p.secretName, k)] = v
 	}
 
+	p.ttl = 0
+	if resp.TTL > 0 {
+		p.ttl = time.Duration(resp.TTL) * time.Second
+	}
+
 	return formatted, nil
 }
+
+// TTL returns how long the result of the last Fetch is valid for, or zero if
+// the plugin didn't return a TTL.
+func (p *ExternalPluginProvider) TTL() time.Duration {
+	return p.ttl
+}
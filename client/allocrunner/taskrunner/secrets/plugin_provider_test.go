@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/client/commonplugins"
 	"github.com/shoenig/test/must"
@@ -82,5 +83,22 @@ func TestExternalPluginProvider_Fetch(t *testing.T) {
 			"secret.test.testkey": "testvalue",
 		}
 		must.Eq(t, exp, result)
+		must.Eq(t, 0, testProvider.TTL())
+	})
+
+	t.Run("returns ttl", func(t *testing.T) {
+		mockSecretPlugin := new(MockSecretPlugin)
+		mockSecretPlugin.On("Fetch", mock.Anything, mock.Anything, mock.Anything).Return(&commonplugins.SecretResponse{
+			Result: map[string]string{
+				"testkey": "testvalue",
+			},
+			TTL: 30,
+		}, nil)
+
+		testProvider := NewExternalPluginProvider(mockSecretPlugin, "test-provider", "test", "test", nil)
+
+		_, err := testProvider.Fetch(t.Context())
+		must.NoError(t, err)
+		must.Eq(t, 30*time.Second, testProvider.TTL())
 	})
 }
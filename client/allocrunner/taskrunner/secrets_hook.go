@@ -6,20 +6,31 @@ package taskrunner
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/consul-template/renderer"
+	cts "github.com/hashicorp/consul-template/signals"
 	"github.com/hashicorp/go-envparse"
 	log "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	te "github.com/hashicorp/nomad/client/allocrunner/taskrunner/errors"
 	ti "github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
 	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/secrets"
 	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/template"
 	"github.com/hashicorp/nomad/client/commonplugins"
 	"github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/client/taskenv"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -78,6 +89,30 @@ type secretsHook struct {
 
 	// secrets to be fetched and populated for interpolation
 	secrets []*structs.Secret
+
+	// fetchLock guards the fields used to fetch secrets after the task has
+	// started
+	fetchLock sync.Mutex
+
+	// fetchConfig is the template manager config used to fetch the Nomad
+	// and Vault secrets, set in Prestart
+	fetchConfig *template.TaskTemplateManagerConfig
+
+	// secretsDir is the task's secrets dir, where the refreshed secrets are
+	// rendered
+	secretsDir string
+
+	// refreshed are the secrets fetched in Prestart that are refreshed
+	// once the task has started
+	refreshed []*secretProvider
+
+	// restoreChanged are the refreshed secrets of a restored task that
+	// changed while the client wasn't running. Their change mode is applied
+	// once refreshing starts.
+	restoreChanged []*structs.Secret
+
+	// stopRefresh stops refreshing the secrets, if any are refreshed
+	stopRefresh context.CancelFunc
 }
 
 func newSecretsHook(conf *secretsHookConfig, secrets []*structs.Secret) *secretsHook {
@@ -98,27 +133,22 @@ func (h *secretsHook) Name() string {
 }
 
 func (h *secretsHook) Prestart(ctx context.Context, req *interfaces.TaskPrestartRequest, resp *interfaces.TaskPrestartResponse) error {
-	tmplProvider, pluginProvider, err := h.buildSecretProviders(req.TaskDir.SecretsDir)
+	// Refreshed secrets are fetched again when the task restarts, and
+	// refreshing them resumes once it has started
+	h.stop()
+
+	providers, err := h.buildSecretProviders(req.TaskDir.SecretsDir)
 	if err != nil {
 		return err
 	}
 
-	templates := []*structs.Template{}
-	for _, p := range tmplProvider {
-		templates = append(templates, p.BuildTemplate())
-	}
-
 	vaultCluster := req.Task.GetVaultClusterName()
 	vaultConfig := h.clientConfig.GetVaultConfigs(h.logger)[vaultCluster]
 
-	mu := &sync.Mutex{}
-	contents := []byte{}
-	unblock := make(chan struct{})
-	tm, err := template.NewTaskTemplateManager(&template.TaskTemplateManagerConfig{
-		UnblockCh:            unblock,
+	h.fetchLock.Lock()
+	h.fetchConfig = &template.TaskTemplateManagerConfig{
 		Lifecycle:            h.lifecycle,
 		Events:               h.events,
-		Templates:            templates,
 		ClientConfig:         h.clientConfig,
 		VaultToken:           req.VaultToken,
 		VaultConfig:          vaultConfig,
@@ -130,25 +160,193 @@ func (h *secretsHook) Prestart(ctx context.Context, req *interfaces.TaskPrestart
 		NomadToken:           req.NomadToken,
 		TaskID:               req.Alloc.ID + "-" + req.Task.Name,
 		Logger:               h.logger,
+	}
+	h.fetchLock.Unlock()
 
-		// This RenderFunc is used to keep any secret data from being written to disk.
-		RenderFunc: func(ri *renderer.RenderInput) (*renderer.RenderResult, error) {
-			// This RenderFunc is called by a single goroutine synchronously, but we
-			// lock the append in the event this behavior changes without us knowing.
-			mu.Lock()
-			defer mu.Unlock()
-			contents = append(contents, ri.Contents...)
-			return &renderer.RenderResult{
-				DidRender:   true,
-				WouldRender: true,
-				Contents:    ri.Contents,
-			}, nil
-		},
-	})
-	if err != nil {
+	if err := h.fetchSecrets(ctx, providers); err != nil {
+		// The task was killed while the secrets were being fetched
+		if ctx.Err() != nil {
+			return nil
+		}
 		return err
 	}
 
+	// A restored task was started with the secrets that were rendered
+	// before the client restarted, so compare against those to find the
+	// secrets that changed in the meantime
+	restored := h.lifecycle.IsRunning()
+	refreshed := []*secretProvider{}
+	changed := []*structs.Secret{}
+	for _, p := range providers {
+		if p.refreshInterval() == 0 {
+			continue
+		}
+		refreshed = append(refreshed, p)
+
+		path := secretFilePath(req.TaskDir.SecretsDir, p.secret)
+		if restored {
+			if prev, err := os.ReadFile(path); err == nil && !bytes.Equal(prev, p.render()) {
+				changed = append(changed, p.secret)
+			}
+		}
+		if err := writeSecretFile(path, p.render()); err != nil {
+			return fmt.Errorf("failed to render secret %q: %w", p.secret.Name, err)
+		}
+	}
+
+	h.fetchLock.Lock()
+	h.secretsDir = req.TaskDir.SecretsDir
+	h.refreshed = refreshed
+	h.restoreChanged = changed
+	h.fetchLock.Unlock()
+
+	// Refreshed secrets must be fetched again when the task is restored, so
+	// the hook is only done if none are refreshed
+	resp.Done = len(refreshed) == 0
+	return nil
+}
+
+// Poststart starts refreshing the secrets once the task has started or has
+// been restored.
+func (h *secretsHook) Poststart(_ context.Context, _ *interfaces.TaskPoststartRequest, _ *interfaces.TaskPoststartResponse) error {
+	h.fetchLock.Lock()
+	defer h.fetchLock.Unlock()
+
+	if h.stopRefresh != nil || len(h.refreshed) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	for _, p := range h.refreshed {
+		p.nextRefresh = now.Add(p.refreshInterval())
+	}
+
+	var ctx context.Context
+	ctx, h.stopRefresh = context.WithCancel(context.Background())
+	go h.refresh(ctx, h.secretsDir, h.refreshed, h.restoreChanged)
+	h.restoreChanged = nil
+	return nil
+}
+
+// Update is used to pick up new Vault and Nomad tokens for refreshing
+// secrets.
+func (h *secretsHook) Update(_ context.Context, req *interfaces.TaskUpdateRequest, _ *interfaces.TaskUpdateResponse) error {
+	h.fetchLock.Lock()
+	defer h.fetchLock.Unlock()
+
+	if h.fetchConfig != nil {
+		h.fetchConfig.VaultToken = req.VaultToken
+		h.fetchConfig.NomadToken = req.NomadToken
+	}
+	return nil
+}
+
+// Stop stops refreshing the secrets.
+func (h *secretsHook) Stop(_ context.Context, _ *interfaces.TaskStopRequest, _ *interfaces.TaskStopResponse) error {
+	h.stop()
+	return nil
+}
+
+func (h *secretsHook) stop() {
+	h.fetchLock.Lock()
+	defer h.fetchLock.Unlock()
+
+	if h.stopRefresh != nil {
+		h.stopRefresh()
+		h.stopRefresh = nil
+	}
+}
+
+// secretProvider fetches a single secret block, either by rendering a
+// template or by executing a plugin.
+type secretProvider struct {
+	secret *structs.Secret
+	tmpl   TemplateProvider
+	plugin PluginProvider
+
+	// values are the last fetched values of the secret
+	values map[string]string
+
+	// nextRefresh is when the secret is next refreshed
+	nextRefresh time.Time
+}
+
+// fetchSecrets fetches the given secrets and sets them in the task's
+// environment. Template secrets are fetched first, so the env of plugin
+// secrets can reference them.
+func (h *secretsHook) fetchSecrets(ctx context.Context, providers []*secretProvider) error {
+	templates := []*structs.Template{}
+	for _, p := range providers {
+		if p.tmpl != nil {
+			templates = append(templates, p.tmpl.BuildTemplate())
+		}
+	}
+
+	if len(templates) > 0 {
+		m, err := h.renderTemplates(ctx, templates)
+		if err != nil {
+			return err
+		}
+		h.envBuilder.SetSecrets(m)
+		for _, p := range providers {
+			if p.tmpl != nil {
+				p.values = secretValues(p.secret.Name, m)
+			}
+		}
+	}
+
+	taskEnv := h.envBuilder.Build()
+
+	for _, p := range providers {
+		if p.plugin == nil {
+			continue
+		}
+		if ep, ok := p.plugin.(*secrets.ExternalPluginProvider); ok {
+			ep.InterpolateEnv(taskEnv.ReplaceEnv)
+		}
+		vars, err := p.plugin.Fetch(ctx)
+		if err != nil {
+			return err
+		}
+		h.envBuilder.SetSecrets(vars)
+		p.values = vars
+	}
+
+	return nil
+}
+
+// renderTemplates renders the templates of the Nomad and Vault secrets in
+// memory and returns the secrets they contain.
+func (h *secretsHook) renderTemplates(ctx context.Context, templates []*structs.Template) (map[string]string, error) {
+	h.fetchLock.Lock()
+	tmConfig := *h.fetchConfig
+	h.fetchLock.Unlock()
+
+	mu := &sync.Mutex{}
+	contents := []byte{}
+	unblock := make(chan struct{})
+	tmConfig.UnblockCh = unblock
+	tmConfig.Templates = templates
+
+	// This RenderFunc is used to keep any secret data from being written to disk.
+	tmConfig.RenderFunc = func(ri *renderer.RenderInput) (*renderer.RenderResult, error) {
+		// This RenderFunc is called by a single goroutine synchronously, but we
+		// lock the append in the event this behavior changes without us knowing.
+		mu.Lock()
+		defer mu.Unlock()
+		contents = append(contents, ri.Contents...)
+		return &renderer.RenderResult{
+			DidRender:   true,
+			WouldRender: true,
+			Contents:    ri.Contents,
+		}, nil
+	}
+
+	tm, err := template.NewTaskTemplateManager(&tmConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	go tm.Run()
 
 	// Safeguard against the template manager continuing to run.
@@ -156,38 +354,237 @@ func (h *secretsHook) Prestart(ctx context.Context, req *interfaces.TaskPrestart
 
 	select {
 	case <-ctx.Done():
-		return nil
+		return nil, ctx.Err()
 	case <-unblock:
 	}
 
-	// Set secrets from templates
-	m, err := envparse.Parse(bytes.NewBuffer(contents))
+	mu.Lock()
+	defer mu.Unlock()
+	return envparse.Parse(bytes.NewBuffer(contents))
+}
+
+// secretValues returns the values of the named secret in m.
+func secretValues(name string, m map[string]string) map[string]string {
+	prefix := fmt.Sprintf("secret.%s.", name)
+	values := make(map[string]string)
+	for k, v := range m {
+		if strings.HasPrefix(k, prefix) {
+			values[k] = v
+		}
+	}
+	return values
+}
+
+// refreshInterval returns how long until the secret is next refreshed, or
+// zero if it isn't refreshed. A plugin can shorten the secret's refresh
+// interval by returning a TTL, or have a secret without a refresh interval
+// refreshed before its TTL expires.
+func (p *secretProvider) refreshInterval() time.Duration {
+	interval := p.secret.RefreshInterval
+	if ep, ok := p.plugin.(*secrets.ExternalPluginProvider); ok {
+		if ttl := ep.TTL(); ttl > 0 && (interval == 0 || ttl < interval) {
+			interval = ttl
+		}
+	}
+	return interval
+}
+
+// render returns the values of the secret in the env file format, keyed by
+// their names without the secret's prefix.
+func (p *secretProvider) render() []byte {
+	prefix := fmt.Sprintf("secret.%s.", p.secret.Name)
+	var buf bytes.Buffer
+	for _, k := range slices.Sorted(maps.Keys(p.values)) {
+		// JSON strings are valid double quoted env file values
+		v, _ := json.Marshal(p.values[k])
+		fmt.Fprintf(&buf, "%s=%s\n", strings.TrimPrefix(k, prefix), v)
+	}
+	return buf.Bytes()
+}
+
+// secretFilePath returns the path of the file in the task's secrets dir that
+// the refreshed secret is rendered to, so that tasks that aren't restarted
+// when it changes can read its new values.
+func secretFilePath(secretsDir string, secret *structs.Secret) string {
+	return filepath.Join(secretsDir, fmt.Sprintf("secret.%s.env", secret.Name))
+}
+
+// writeSecretFile atomically replaces the file at path with contents.
+func writeSecretFile(path string, contents []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
 	if err != nil {
 		return err
 	}
-	h.envBuilder.SetSecrets(m)
+	defer os.Remove(tmp.Name())
 
-	taskEnv := h.envBuilder.Build()
+	if _, err := tmp.Write(contents); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
 
-	for _, p := range pluginProvider {
-		if ep, ok := p.(*secrets.ExternalPluginProvider); ok {
-			ep.InterpolateEnv(taskEnv.ReplaceEnv)
+// refresh fetches the secrets again as their refresh intervals elapse and
+// applies their change mode when they have changed. The change mode of the
+// secrets in changed is applied before the first refresh.
+func (h *secretsHook) refresh(ctx context.Context, secretsDir string, providers []*secretProvider, changed []*structs.Secret) {
+	timer, stop := helper.NewStoppedTimer()
+	defer stop()
+
+	if len(changed) > 0 {
+		h.handleChange(changed)
+	}
+
+	for len(providers) > 0 {
+		next := providers[0].nextRefresh
+		for _, p := range providers[1:] {
+			if p.nextRefresh.Before(next) {
+				next = p.nextRefresh
+			}
+		}
+		timer.Reset(time.Until(next))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
 		}
-		vars, err := p.Fetch(ctx)
+
+		now := time.Now()
+		due := []*secretProvider{}
+		previous := map[*secretProvider]map[string]string{}
+		for _, p := range providers {
+			if !p.nextRefresh.After(now) {
+				due = append(due, p)
+				previous[p] = p.values
+			}
+		}
+
+		err := h.fetchSecrets(ctx, due)
+
+		// Secrets that were only refreshed because of their TTL aren't
+		// refreshed anymore if the plugin stops returning one
+		now = time.Now()
+		for _, p := range due {
+			p.nextRefresh = now.Add(p.refreshInterval())
+		}
+		providers = slices.DeleteFunc(providers, func(p *secretProvider) bool {
+			return p.refreshInterval() == 0
+		})
+
 		if err != nil {
-			return err
+			if ctx.Err() != nil {
+				return
+			}
+			h.logger.Warn("failed to refresh secrets", "error", err)
+			h.events.EmitEvent(structs.NewTaskEvent(structs.TaskHookMessage).
+				SetDisplayMessage(fmt.Sprintf("Failed to refresh secrets: %v", err)))
+			continue
+		}
+
+		changed := []*structs.Secret{}
+		for _, p := range due {
+			if maps.Equal(previous[p], p.values) {
+				continue
+			}
+			changed = append(changed, p.secret)
+
+			if err := writeSecretFile(secretFilePath(secretsDir, p.secret), p.render()); err != nil {
+				h.logger.Warn("failed to render refreshed secret", "secret", p.secret.Name, "error", err)
+				h.events.EmitEvent(structs.NewTaskEvent(structs.TaskHookMessage).
+					SetDisplayMessage(fmt.Sprintf("Failed to render refreshed secret %q: %v", p.secret.Name, err)))
+			}
+		}
+		if len(changed) > 0 {
+			h.handleChange(changed)
 		}
-		h.envBuilder.SetSecrets(vars)
 	}
+}
 
-	resp.Done = true
-	return nil
+// handleChange applies the change mode of the secrets that have changed. A
+// restart takes precedence over signals and scripts, since the restarted task
+// picks up the new secrets anyway.
+func (h *secretsHook) handleChange(changed []*structs.Secret) {
+	restart := false
+	signals := map[string]struct{}{}
+	scripts := []*structs.ChangeScript{}
+	for _, s := range changed {
+		switch s.ChangeMode {
+		case structs.SecretChangeModeNoop:
+		case structs.SecretChangeModeSignal:
+			signals[s.ChangeSignal] = struct{}{}
+		case structs.SecretChangeModeScript:
+			scripts = append(scripts, s.ChangeScript)
+		default:
+			restart = true
+		}
+	}
+
+	if restart {
+		_ = h.lifecycle.Restart(context.Background(),
+			structs.NewTaskEvent(structs.TaskRestartSignal).
+				SetDisplayMessage("Secret with change_mode restart changed"), false)
+		return
+	}
+
+	for signal := range signals {
+		event := structs.NewTaskEvent(structs.TaskSignaling).SetDisplayMessage("Secret changed")
+		if sig, err := cts.Parse(signal); err == nil {
+			event.SetTaskSignal(sig)
+		}
+		if err := h.lifecycle.Signal(event, signal); err != nil && !errors.Is(err, te.ErrTaskNotRunning) {
+			h.logger.Error("failed to send signal", "signal", signal, "error", err)
+			_ = h.lifecycle.Kill(context.Background(),
+				structs.NewTaskEvent(structs.TaskKilling).
+					SetFailsTask().
+					SetDisplayMessage(fmt.Sprintf("Secret failed to send signal %v: %v", signal, err)))
+			return
+		}
+	}
+
+	for _, script := range scripts {
+		h.runScript(script)
+	}
+}
+
+// runScript runs a change_mode script in the task.
+func (h *secretsHook) runScript(script *structs.ChangeScript) {
+	_, exitCode, err := h.lifecycle.Exec(script.Timeout, script.Command, script.Args)
+	if err == nil && exitCode == 0 {
+		h.events.EmitEvent(structs.NewTaskEvent(structs.TaskHookMessage).
+			SetDisplayMessage(fmt.Sprintf(
+				"Secret successfully ran script %v with arguments: %v. Exit code: 0",
+				script.Command, script.Args)))
+		return
+	}
+
+	msg := fmt.Sprintf("Secret ran script %v with arguments %v on change but it exited with code: %v",
+		script.Command, script.Args, exitCode)
+	if err != nil {
+		msg = fmt.Sprintf("Secret failed to run script %v with arguments %v on change: %v. Exit code: %v",
+			script.Command, script.Args, err, exitCode)
+	}
+	h.events.EmitEvent(structs.NewTaskEvent(structs.TaskHookFailed).SetDisplayMessage(msg))
+
+	if script.FailOnError {
+		_ = h.lifecycle.Kill(context.Background(),
+			structs.NewTaskEvent(structs.TaskKilling).
+				SetFailsTask().
+				SetDisplayMessage("Secret script failed, task is being killed"))
+	}
 }
 
-func (h *secretsHook) buildSecretProviders(secretDir string) ([]TemplateProvider, []PluginProvider, error) {
+func (h *secretsHook) buildSecretProviders(secretDir string) ([]*secretProvider, error) {
 	// Any configuration errors will be found when calling the secret providers constructor,
 	// so use a multierror to collect all errors and return them to the user at the same time.
-	tmplProvider, pluginProvider, mErr := []TemplateProvider{}, []PluginProvider{}, new(multierror.Error)
+	providers, mErr := []*secretProvider{}, new(multierror.Error)
 
 	for idx, s := range h.secrets {
 		if s == nil {
@@ -200,13 +597,13 @@ func (h *secretsHook) buildSecretProviders(secretDir string) ([]TemplateProvider
 			if p, err := secrets.NewNomadProvider(s, secretDir, tmplFile, h.nomadNamespace); err != nil {
 				multierror.Append(mErr, err)
 			} else {
-				tmplProvider = append(tmplProvider, p)
+				providers = append(providers, &secretProvider{secret: s, tmpl: p})
 			}
 		case secrets.SecretProviderVault:
 			if p, err := secrets.NewVaultProvider(s, secretDir, tmplFile); err != nil {
 				multierror.Append(mErr, err)
 			} else {
-				tmplProvider = append(tmplProvider, p)
+				providers = append(providers, &secretProvider{secret: s, tmpl: p})
 			}
 		default:
 			plug, err := commonplugins.NewExternalSecretsPlugin(h.clientConfig.CommonPluginDir, s.Provider)
@@ -216,11 +613,14 @@ func (h *secretsHook) buildSecretProviders(secretDir string) ([]TemplateProvider
 			}
 			// Add/overwrite the nomad namespace and jobID envVars
 			s.Env = h.setupPluginEnv(s.Env)
-			pluginProvider = append(pluginProvider, secrets.NewExternalPluginProvider(plug, s.Provider, s.Name, s.Path, s.Env))
+			providers = append(providers, &secretProvider{
+				secret: s,
+				plugin: secrets.NewExternalPluginProvider(plug, s.Provider, s.Name, s.Path, s.Env),
+			})
 		}
 	}
 
-	return tmplProvider, pluginProvider, mErr.ErrorOrNil()
+	return providers, mErr.ErrorOrNil()
 }
 
 func (h *secretsHook) setupPluginEnv(env map[string]string) map[string]string {
@@ -26,6 +26,14 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 	structsc "github.com/hashicorp/nomad/nomad/structs/config"
 	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+var (
+	_ interfaces.TaskPrestartHook  = (*secretsHook)(nil)
+	_ interfaces.TaskPoststartHook = (*secretsHook)(nil)
+	_ interfaces.TaskUpdateHook    = (*secretsHook)(nil)
+	_ interfaces.TaskStopHook      = (*secretsHook)(nil)
 )
 
 func TestSecretsHook_Prestart_Nomad(t *testing.T) {
@@ -506,3 +514,149 @@ fi`
 		must.Eq(t, "my-secret-token", secrets["secret.my_plugin.received_token"])
 	})
 }
+
+func TestSecretsHook_Refresh_Plugin(t *testing.T) {
+	// The plugin returns a new value every time it's executed
+	counterPlugin := `#!/bin/bash
+count=$(cat %[1]s 2>/dev/null || echo 0)
+count=$((count+1))
+echo $count > %[1]s
+cat <<EOF
+{
+  "result": {
+    "count": "$count"
+  },
+  "ttl": %[2]d
+}
+EOF`
+
+	newHook := func(t *testing.T, secret *structs.Secret, ttl int, taskDir string, restored bool) (*secretsHook, *taskenv.Builder, *trtesting.MockTaskHooks) {
+		clientConfig := config.DefaultConfig()
+		clientConfig.CommonPluginDir = t.TempDir()
+
+		pluginDir := filepath.Join(clientConfig.CommonPluginDir, "secrets")
+		must.NoError(t, os.MkdirAll(pluginDir, 0755))
+
+		counterFile := filepath.Join(t.TempDir(), "count")
+		testPlugin := fmt.Sprintf(counterPlugin, counterFile, ttl)
+		must.NoError(t, os.WriteFile(filepath.Join(pluginDir, "counter"), []byte(testPlugin), 0755))
+
+		alloc := mock.MinAlloc()
+		task := alloc.Job.TaskGroups[0].Tasks[0]
+
+		taskEnv := taskenv.NewBuilder(mock.Node(), alloc, task, clientConfig.Region)
+		lifecycle := trtesting.NewMockTaskHooks()
+		lifecycle.HasHandle = restored
+		conf := &secretsHookConfig{
+			logger:         testlog.HCLogger(t),
+			lifecycle:      lifecycle,
+			events:         &trtesting.MockEmitter{},
+			clientConfig:   clientConfig,
+			envBuilder:     taskEnv,
+			nomadNamespace: "default",
+			jobId:          "test-job",
+		}
+		secretHook := newSecretsHook(conf, []*structs.Secret{secret})
+
+		req := &interfaces.TaskPrestartRequest{
+			Alloc:   alloc,
+			Task:    task,
+			TaskDir: &allocdir.TaskDir{Dir: taskDir, SecretsDir: taskDir},
+		}
+		resp := &interfaces.TaskPrestartResponse{}
+		must.NoError(t, secretHook.Prestart(t.Context(), req, resp))
+		t.Cleanup(func() {
+			must.NoError(t, secretHook.Stop(context.Background(), nil, nil))
+		})
+
+		// Refreshed secrets are fetched again when the task is restored
+		must.False(t, resp.Done)
+		must.Eq(t, "1", taskEnv.Build().TaskSecrets["secret.counter.count"])
+
+		must.NoError(t, secretHook.Poststart(t.Context(), nil, nil))
+		return secretHook, taskEnv, lifecycle
+	}
+
+	t.Run("signals task on change", func(t *testing.T) {
+		taskDir := t.TempDir()
+		_, taskEnv, lifecycle := newHook(t, &structs.Secret{
+			Name:            "counter",
+			Provider:        "counter",
+			Path:            "/counter",
+			RefreshInterval: 50 * time.Millisecond,
+			ChangeMode:      structs.SecretChangeModeSignal,
+			ChangeSignal:    "SIGHUP",
+		}, 0, taskDir, false)
+
+		select {
+		case <-lifecycle.SignalCh:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for signal")
+		}
+		must.Eq(t, []string{"SIGHUP"}, lifecycle.Signals()[:1])
+		must.NotEq(t, "1", taskEnv.Build().TaskSecrets["secret.counter.count"])
+
+		// The signaled task can read the new value from the secrets dir
+		b, err := os.ReadFile(filepath.Join(taskDir, "secret.counter.env"))
+		must.NoError(t, err)
+		must.NotEq(t, "count=\"1\"\n", string(b))
+	})
+
+	t.Run("refreshes before plugin ttl expires", func(t *testing.T) {
+		_, taskEnv, _ := newHook(t, &structs.Secret{
+			Name:       "counter",
+			Provider:   "counter",
+			Path:       "/counter",
+			ChangeMode: structs.SecretChangeModeNoop,
+		}, 1, t.TempDir(), false)
+
+		must.Wait(t, wait.InitialSuccess(
+			wait.BoolFunc(func() bool {
+				return taskEnv.Build().TaskSecrets["secret.counter.count"] != "1"
+			}),
+			wait.Timeout(5*time.Second),
+			wait.Gap(50*time.Millisecond),
+		))
+	})
+
+	t.Run("restored task restarts on change", func(t *testing.T) {
+		// The secret was rendered with a different value before the client
+		// restarted
+		taskDir := t.TempDir()
+		must.NoError(t, os.WriteFile(filepath.Join(taskDir, "secret.counter.env"), []byte("count=\"0\"\n"), 0644))
+
+		_, _, lifecycle := newHook(t, &structs.Secret{
+			Name:            "counter",
+			Provider:        "counter",
+			Path:            "/counter",
+			RefreshInterval: time.Hour,
+			ChangeMode:      structs.SecretChangeModeRestart,
+		}, 0, taskDir, true)
+
+		select {
+		case <-lifecycle.RestartCh:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for restart")
+		}
+	})
+
+	t.Run("noop updates secret", func(t *testing.T) {
+		_, taskEnv, lifecycle := newHook(t, &structs.Secret{
+			Name:            "counter",
+			Provider:        "counter",
+			Path:            "/counter",
+			RefreshInterval: 50 * time.Millisecond,
+			ChangeMode:      structs.SecretChangeModeNoop,
+		}, 0, t.TempDir(), false)
+
+		must.Wait(t, wait.InitialSuccess(
+			wait.BoolFunc(func() bool {
+				return taskEnv.Build().TaskSecrets["secret.counter.count"] != "1"
+			}),
+			wait.Timeout(5*time.Second),
+			wait.Gap(50*time.Millisecond),
+		))
+		must.Zero(t, lifecycle.Restarts())
+		must.Len(t, 0, lifecycle.Signals())
+	})
+}
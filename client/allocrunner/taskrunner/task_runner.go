@@ -620,6 +620,11 @@ func (tr *TaskRunner) Run() {
 	timer, stop := helper.NewStoppedTimer()
 	defer stop()
 
+	// stableTimer fires once a crash looping task has stayed up for a full
+	// restart interval
+	stableTimer, stopStable := helper.NewStoppedTimer()
+	defer stopStable()
+
 MAIN:
 	for !tr.shouldShutdown() {
 		if dead {
@@ -678,6 +683,10 @@ MAIN:
 			tr.logger.Error("poststart failed", "error", err)
 		}
 
+		if tr.isCrashLooping() {
+			stableTimer.Reset(tr.restartTracker.GetPolicy().Interval)
+		}
+
 		// Grab the result proxy and wait for task to exit
 	WAIT:
 		{
@@ -698,6 +707,9 @@ MAIN:
 				case <-tr.shutdownCtx.Done():
 					// TaskRunner was told to exit immediately
 					return
+				case <-stableTimer.C:
+					tr.clearCrashLooping()
+					goto WAIT
 				case result = <-resultCh:
 				}
 
@@ -710,6 +722,7 @@ MAIN:
 
 		// Clear the handle
 		tr.clearDriverHandle()
+		stableTimer.Stop()
 
 		// Store the wait result on the restart tracker
 		tr.restartTracker.SetExitResult(result)
@@ -899,6 +912,10 @@ func (tr *TaskRunner) shouldRestart() (bool, time.Duration) {
 		return false, 0
 	case structs.TaskRestarting:
 		tr.logger.Info("restarting task", "reason", reason, "delay", when)
+		if tr.restartTracker.CrashLooping() && !tr.isCrashLooping() {
+			tr.logger.Warn("task is crash looping", "reason", reason)
+			tr.AppendEvent(structs.NewTaskEvent(structs.TaskCrashLooping).SetRestartReason(reason))
+		}
 		tr.UpdateState(structs.TaskStatePending, structs.NewTaskEvent(structs.TaskRestarting).SetRestartDelay(when).SetRestartReason(reason))
 		return true, when
 	default:
@@ -1373,6 +1390,9 @@ func (tr *TaskRunner) updateStateImpl(state string) error {
 			taskState.FinishedAt = time.Now().UTC()
 		}
 
+		// A dead task is no longer restarted, so it can't be crash looping
+		taskState.CrashLooping = false
+
 		// Emitting metrics to indicate task complete and failures
 		if taskState.Failed {
 			metrics.IncrCounterWithLabels([]string{"client", "allocs", "failed"}, 1, tr.baseLabels)
@@ -1442,6 +1462,11 @@ func (tr *TaskRunner) appendEvent(event *structs.TaskEvent) error {
 		tr.state.LastRestart = time.Unix(0, event.Time)
 	}
 
+	if event.Type == structs.TaskCrashLooping {
+		metrics.IncrCounterWithLabels([]string{"client", "allocs", "crash_looping"}, 1, tr.baseLabels)
+		tr.state.CrashLooping = true
+	}
+
 	tr.logger.Info("Task event", "type", event.Type, "msg", event.DisplayMessage, "failed", event.FailsTask)
 
 	// Append event to slice
@@ -1450,6 +1475,30 @@ func (tr *TaskRunner) appendEvent(event *structs.TaskEvent) error {
 	return nil
 }
 
+// isCrashLooping returns whether the task is currently marked as crash
+// looping.
+func (tr *TaskRunner) isCrashLooping() bool {
+	tr.stateLock.RLock()
+	defer tr.stateLock.RUnlock()
+	return tr.state.CrashLooping
+}
+
+// clearCrashLooping clears the crash looping mark of a task that has
+// recovered and notifies the alloc runner.
+func (tr *TaskRunner) clearCrashLooping() {
+	tr.stateLock.Lock()
+	defer tr.stateLock.Unlock()
+
+	tr.logger.Info("task recovered from crash loop")
+	tr.state.CrashLooping = false
+
+	if err := tr.stateDB.PutTaskState(tr.allocID, tr.taskName, tr.state); err != nil {
+		tr.logger.Warn("error persisting task state", "error", err)
+	}
+
+	tr.stateUpdater.TaskStateUpdated()
+}
+
 // WaitCh is closed when TaskRunner.Run exits.
 func (tr *TaskRunner) WaitCh() <-chan struct{} {
 	return tr.waitCh
@@ -30,6 +30,7 @@ import (
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
 	te "github.com/hashicorp/nomad/client/allocrunner/taskrunner/errors"
 	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/getter"
+	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/restarts"
 	"github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/client/devicemanager"
 	"github.com/hashicorp/nomad/client/lib/cgroupslib"
@@ -1313,6 +1314,53 @@ func TestTaskRunner_RestartTask(t *testing.T) {
 	require.True(t, found, "restarting task event not found", pretty.Sprint(events))
 }
 
+// TestTaskRunner_CrashLooping asserts that a task whose restart delay grows to
+// the policy's max delay is marked as crash looping and emits a Crash Looping
+// event.
+func TestTaskRunner_CrashLooping(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	tg := alloc.Job.TaskGroups[0]
+	tg.RestartPolicy.Attempts = 100
+	tg.RestartPolicy.Interval = 1 * time.Minute
+	tg.RestartPolicy.Delay = 10 * time.Millisecond
+	tg.RestartPolicy.DelayFunction = "exponential"
+	tg.RestartPolicy.MaxDelay = 40 * time.Millisecond
+	tg.RestartPolicy.Mode = structs.RestartPolicyModeFail
+
+	task := tg.Tasks[0]
+	task.Driver = "mock_driver"
+	task.Config = map[string]interface{}{
+		"run_for":   "10ms",
+		"exit_code": 1,
+	}
+
+	tr, _, cleanup := runTestTaskRunner(t, alloc, task.Name)
+	defer cleanup()
+
+	testutil.WaitForResult(func() (bool, error) {
+		ts := tr.TaskState()
+		if !ts.CrashLooping {
+			return false, fmt.Errorf("expected task to be crash looping\nevents: %s",
+				pretty.Sprint(ts.Events))
+		}
+		return true, nil
+	}, func(err error) {
+		must.NoError(t, err)
+	})
+
+	// The event is only emitted when the task starts crash looping
+	found := 0
+	for _, e := range tr.TaskState().Events {
+		if e.Type == structs.TaskCrashLooping {
+			found++
+			must.StrContains(t, e.DisplayMessage, restarts.ReasonMaxDelay)
+		}
+	}
+	must.Eq(t, 1, found)
+}
+
 // TestTaskRunner_CheckWatcher_Restart asserts that when enabled an unhealthy
 // Consul check will cause a task to restart following restart policy rules.
 func TestTaskRunner_CheckWatcher_Restart(t *testing.T) {
@@ -0,0 +1,478 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocrunner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/client/taskenv"
+	"github.com/hashicorp/nomad/client/widmgr"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+const (
+	upstreamProxyHookName = "upstream_proxy"
+
+	// upstreamDialTimeout is the maximum amount of time spent connecting to
+	// an instance of an upstream service before failing over to the next one.
+	upstreamDialTimeout = 5 * time.Second
+
+	// upstreamRetryMin and upstreamRetryMax bound the random amount of time
+	// waited before retrying to watch the instances of an upstream service
+	// after an error.
+	upstreamRetryMin = time.Second
+	upstreamRetryMax = 5 * time.Second
+)
+
+// networkIsolationGetter returns the network namespace of the allocation, or
+// nil if the allocation has none.
+type networkIsolationGetter interface {
+	NetworkIsolation() *drivers.NetworkIsolationSpec
+}
+
+// upstreamLookupFn returns the registrations of the service of an upstream,
+// blocking until the registrations change after the given index.
+type upstreamLookupFn func(index uint64) ([]*structs.ServiceRegistration, uint64, error)
+
+// upstreamProxyHook runs a local TCP proxy in the network namespace of the
+// allocation for every upstream of its network block. Each proxy watches the
+// registrations of its destination service in the Nomad service provider and
+// load balances connections in a round-robin fashion across the instances
+// with passing checks.
+//
+// Noop for allocations without upstreams.
+type upstreamProxyHook struct {
+	logger           hclog.Logger
+	rpc              config.RPCer
+	widmgr           widmgr.IdentityManager
+	region           string
+	networkIsolation networkIsolationGetter
+
+	// listen creates the listener of a proxy in the network namespace
+	listen func(spec *drivers.NetworkIsolationSpec, addr string) (net.Listener, error)
+
+	// mu synchronizes alloc and proxies which may be mutated and read
+	// concurrently via Prerun, Update and Postrun.
+	mu      sync.Mutex
+	alloc   *structs.Allocation
+	proxies map[string]*upstreamProxy // bind address -> proxy
+}
+
+func newUpstreamProxyHook(
+	logger hclog.Logger,
+	alloc *structs.Allocation,
+	rpc config.RPCer,
+	widmgr widmgr.IdentityManager,
+	region string,
+	networkIsolation networkIsolationGetter,
+) *upstreamProxyHook {
+	return &upstreamProxyHook{
+		logger:           logger.Named(upstreamProxyHookName),
+		rpc:              rpc,
+		widmgr:           widmgr,
+		region:           region,
+		networkIsolation: networkIsolation,
+		listen:           listenUpstream,
+		alloc:            alloc,
+		proxies:          map[string]*upstreamProxy{},
+	}
+}
+
+// statically assert that the hook meets the expected interfaces
+var (
+	_ interfaces.RunnerPrerunHook  = (*upstreamProxyHook)(nil)
+	_ interfaces.RunnerUpdateHook  = (*upstreamProxyHook)(nil)
+	_ interfaces.RunnerPostrunHook = (*upstreamProxyHook)(nil)
+)
+
+func (*upstreamProxyHook) Name() string {
+	return upstreamProxyHookName
+}
+
+func (h *upstreamProxyHook) Prerun(_ *taskenv.TaskEnv) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.reconcile()
+}
+
+// Update starts the proxies of added or changed upstreams and stops the
+// proxies of removed ones.
+func (h *upstreamProxyHook) Update(req *interfaces.RunnerUpdateRequest) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.alloc = req.Alloc
+	return h.reconcile()
+}
+
+func (h *upstreamProxyHook) Postrun() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for addr, p := range h.proxies {
+		if err := p.stop(); err != nil {
+			// Only log failures to stop proxies. Worst case scenario is a
+			// small goroutine leak.
+			h.logger.Warn("error stopping upstream proxy", "error", err, "bind_addr", addr)
+		}
+		delete(h.proxies, addr)
+	}
+	return nil
+}
+
+// upstreams returns the upstreams of the allocation. Requires the mutex to be
+// held.
+func (h *upstreamProxyHook) upstreams() structs.NetworkUpstreams {
+	tg := h.alloc.Job.LookupTaskGroup(h.alloc.TaskGroup)
+	if tg == nil || len(tg.Networks) == 0 {
+		return nil
+	}
+	return tg.Networks[0].Upstreams
+}
+
+// reconcile runs a proxy for every upstream of the allocation. Requires the
+// mutex to be held.
+func (h *upstreamProxyHook) reconcile() error {
+	upstreams := h.upstreams()
+
+	desired := make(map[string]*structs.NetworkUpstream, len(upstreams))
+	for _, u := range upstreams {
+		desired[u.BindAddr()] = u
+	}
+
+	for addr, p := range h.proxies {
+		if u, ok := desired[addr]; ok && u.Equal(p.upstream) {
+			continue
+		}
+		if err := p.stop(); err != nil {
+			h.logger.Warn("error stopping upstream proxy", "error", err, "bind_addr", addr)
+		}
+		delete(h.proxies, addr)
+	}
+
+	if len(upstreams) == 0 {
+		return nil
+	}
+
+	spec := h.networkIsolation.NetworkIsolation()
+	if spec == nil {
+		return errors.New("upstreams require the allocation to have a network namespace")
+	}
+
+	var mErr *multierror.Error
+	for addr, u := range desired {
+		if _, ok := h.proxies[addr]; ok {
+			continue
+		}
+
+		l, err := h.listen(spec, addr)
+		if err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("failed to listen for upstream %q on %s: %w", u.DestinationName, addr, err))
+			continue
+		}
+
+		p := newUpstreamProxy(h.logger, u, h.lookupFn(u))
+		p.run(l)
+		h.proxies[addr] = p
+	}
+	return mErr.ErrorOrNil()
+}
+
+// lookupFn returns the function looking up the registrations of the
+// destination service of the upstream. Requires the mutex to be held.
+func (h *upstreamProxyHook) lookupFn(u *structs.NetworkUpstream) upstreamLookupFn {
+	namespace := u.DestinationNamespace
+	if namespace == "" {
+		namespace = h.alloc.Namespace
+	}
+
+	// Registrations are read with the default identity of the first task of
+	// the group, as workload identities are allowed to read the services of
+	// their own namespace. Reading the services of another namespace requires
+	// an ACL policy granting it to be attached to the job or group, since a
+	// policy attached to a task only applies to that task's identity.
+	var taskName string
+	if tg := h.alloc.Job.LookupTaskGroup(h.alloc.TaskGroup); tg != nil && len(tg.Tasks) > 0 {
+		taskName = tg.Tasks[0].Name
+	}
+
+	return func(index uint64) ([]*structs.ServiceRegistration, uint64, error) {
+		signed, err := h.widmgr.Get(structs.WIHandle{
+			WorkloadIdentifier: taskName,
+			IdentityName:       structs.WorkloadIdentityDefaultName,
+		})
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to retrieve signed workload identity: %w", err)
+		}
+
+		args := structs.ServiceRegistrationByNameRequest{
+			ServiceName: u.DestinationName,
+			QueryOptions: structs.QueryOptions{
+				Region:        h.region,
+				Namespace:     namespace,
+				MinQueryIndex: index,
+				AllowStale:    true,
+				AuthToken:     signed.JWT,
+			},
+		}
+		var reply structs.ServiceRegistrationByNameResponse
+		if err := h.rpc.RPC(structs.ServiceRegistrationGetServiceRPCMethod, &args, &reply); err != nil {
+			if structs.IsErrPermissionDenied(err) && namespace != h.alloc.Namespace {
+				return nil, 0, fmt.Errorf("workload identity of task %q can't read services in namespace %q, "+
+					"an ACL policy granting it must be attached to the job or group: %w", taskName, namespace, err)
+			}
+			return nil, 0, err
+		}
+		return reply.Services, reply.Index, nil
+	}
+}
+
+// upstreamProxy accepts connections on a local listener and proxies them to
+// the healthy instances of the destination service of an upstream.
+type upstreamProxy struct {
+	logger   hclog.Logger
+	upstream *structs.NetworkUpstream
+	lookup   upstreamLookupFn
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	doneCh chan struct{}
+
+	// instances are the addresses of the healthy instances, and next is the
+	// index of the instance the next connection is proxied to. Both are
+	// synchronized by lock.
+	instances []string
+	next      int
+	lock      sync.Mutex
+}
+
+func newUpstreamProxy(logger hclog.Logger, upstream *structs.NetworkUpstream, lookup upstreamLookupFn) *upstreamProxy {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &upstreamProxy{
+		logger:   logger.With("destination", upstream.DestinationName, "bind_addr", upstream.BindAddr()),
+		upstream: upstream.Copy(),
+		lookup:   lookup,
+		ctx:      ctx,
+		cancel:   cancel,
+		doneCh:   make(chan struct{}),
+	}
+}
+
+// run watches the instances of the upstream and proxies the connections
+// accepted on the listener until the proxy is stopped. Blocking queries can't
+// be cancelled, so the watch isn't waited for when stopping and exits once its
+// current query returns.
+func (p *upstreamProxy) run(l net.Listener) {
+	go p.watch()
+	go func() {
+		p.serve(l)
+		close(p.doneCh)
+	}()
+}
+
+// stop the proxy and blocks until the proxy has stopped. Returns an error if
+// the proxy does not exit in a timely fashion.
+func (p *upstreamProxy) stop() error {
+	p.cancel()
+
+	select {
+	case <-p.doneCh:
+		return nil
+	case <-time.After(socketProxyStopWaitTime):
+		return errSocketProxyTimeout
+	}
+}
+
+// watch keeps the healthy instances of the upstream up to date.
+func (p *upstreamProxy) watch() {
+	var index uint64
+	for {
+		regs, newIndex, err := p.lookup(index)
+		if p.ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			p.logger.Warn("failed to watch upstream service instances", "error", err)
+			select {
+			case <-p.ctx.Done():
+				return
+			case <-time.After(helper.RandomStagger(upstreamRetryMax-upstreamRetryMin) + upstreamRetryMin):
+			}
+			continue
+		}
+
+		// Reset the index if it went backwards, e.g. after a snapshot restore.
+		if newIndex < index {
+			newIndex = 0
+		}
+		index = newIndex
+
+		p.setInstances(regs)
+	}
+}
+
+// setInstances sets the healthy instances of the upstream from the given
+// registrations.
+func (p *upstreamProxy) setInstances(regs []*structs.ServiceRegistration) {
+	instances := make([]string, 0, len(regs))
+	for _, reg := range regs {
+		if !reg.Healthy() {
+			continue
+		}
+		instances = append(instances, net.JoinHostPort(reg.Address, strconv.Itoa(reg.Port)))
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if len(instances) != len(p.instances) {
+		p.logger.Debug("upstream instances changed", "healthy", len(instances), "total", len(regs))
+	}
+	p.instances = instances
+}
+
+// candidates returns the healthy instances in the order connections should be
+// attempted, starting with the next instance in round-robin order.
+func (p *upstreamProxy) candidates() []string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	n := len(p.instances)
+	if n == 0 {
+		return nil
+	}
+
+	start := p.next % n
+	p.next = start + 1
+
+	candidates := make([]string, 0, n)
+	candidates = append(candidates, p.instances[start:]...)
+	return append(candidates, p.instances[:start]...)
+}
+
+// serve accepts connections until the proxy is stopped.
+func (p *upstreamProxy) serve(l net.Listener) {
+	// Wait for all connections to be done before exiting to prevent
+	// goroutine leaks.
+	wg := sync.WaitGroup{}
+	defer func() {
+		_ = l.Close()
+		wg.Wait()
+	}()
+
+	// Close Accept() when the proxy is stopped
+	go func() {
+		<-p.ctx.Done()
+		_ = l.Close()
+	}()
+
+	for p.ctx.Err() == nil {
+		conn, err := l.Accept()
+		if err != nil {
+			if p.ctx.Err() != nil {
+				// Accept errors during shutdown are to be expected
+				return
+			}
+			p.logger.Error("error in upstream proxy; shutting down proxy", "error", err)
+			return
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.proxyConn(conn)
+		}()
+	}
+}
+
+// proxyConn proxies the connection to the first instance of the upstream that
+// accepts a connection, trying the healthy instances in round-robin order.
+func (p *upstreamProxy) proxyConn(conn net.Conn) {
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(p.ctx)
+	defer cancel()
+
+	candidates := p.candidates()
+	if len(candidates) == 0 {
+		p.logger.Warn("no healthy upstream instances", "src_remote", conn.RemoteAddr())
+		return
+	}
+
+	var dest net.Conn
+	for _, addr := range candidates {
+		dialer := &net.Dialer{Timeout: upstreamDialTimeout}
+		var err error
+		dest, err = dialer.DialContext(ctx, "tcp", addr)
+		if err == nil {
+			break
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		p.logger.Debug("failed to connect to upstream instance", "error", err, "dest", addr)
+	}
+	if dest == nil {
+		p.logger.Warn("failed to connect to any upstream instance", "instances", len(candidates))
+		return
+	}
+	defer dest.Close()
+
+	// Wait for goroutines to exit before exiting to prevent leaking.
+	wg := sync.WaitGroup{}
+	defer wg.Wait()
+
+	// Each side closing its connection is passed on by closing the write
+	// side of the other, so the other direction keeps working until its
+	// side closes too, like for a client that half-closes after sending its
+	// request.
+	copyConn := func(dst, src net.Conn) {
+		defer wg.Done()
+		n, err := io.Copy(dst, src)
+		if err != nil {
+			if ctx.Err() == nil {
+				p.logger.Trace("error proxying upstream connection", "error", err,
+					"dest", dest.RemoteAddr(), "src_remote", conn.RemoteAddr(), "bytes", n)
+			}
+			cancel()
+			return
+		}
+		if cw, ok := dst.(interface{ CloseWrite() error }); !ok || cw.CloseWrite() != nil {
+			cancel()
+		}
+	}
+
+	wg.Add(2)
+	go copyConn(dest, conn)
+	go copyConn(conn, dest)
+
+	doneCh := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(doneCh)
+	}()
+
+	// When the proxy is stopped, either copy fails, or both sides of the
+	// connection are done, close both connections to unblock the copies.
+	select {
+	case <-ctx.Done():
+	case <-doneCh:
+	}
+	_ = conn.Close()
+	_ = dest.Close()
+}
@@ -0,0 +1,26 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package allocrunner
+
+import (
+	"net"
+
+	"github.com/hashicorp/nomad/client/lib/nsutil"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+// listenUpstream listens on the given address in the network namespace. The
+// socket stays in the network namespace it was created in, so connections are
+// accepted from the namespace while the proxy runs in the client.
+func listenUpstream(spec *drivers.NetworkIsolationSpec, addr string) (net.Listener, error) {
+	var l net.Listener
+	err := nsutil.WithNetNSPath(spec.Path, func(nsutil.NetNS) error {
+		var err error
+		l, err = net.Listen("tcp", addr)
+		return err
+	})
+	return l, err
+}
@@ -0,0 +1,20 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+// +build !linux
+
+package allocrunner
+
+import (
+	"errors"
+	"net"
+
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+// listenUpstream is not supported as network namespaces are only supported on
+// Linux.
+func listenUpstream(_ *drivers.NetworkIsolationSpec, _ string) (net.Listener, error) {
+	return nil, errors.New("upstreams are only supported on Linux")
+}
@@ -0,0 +1,296 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocrunner
+
+import (
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/widmgr"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+// TestUpstreamProxyHook_RoundRobin asserts the upstream proxy balances
+// connections across the healthy instances of the upstream service, and stops
+// when the upstream is removed.
+func TestUpstreamProxyHook_RoundRobin(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	alloc.Job.TaskGroups[0].Networks = structs.Networks{{
+		Mode:      "bridge",
+		Upstreams: structs.NetworkUpstreams{{DestinationName: "db", LocalBindPort: 5432}},
+	}}
+
+	// the failing instance must never be used, and the unreachable one is
+	// failed over
+	unreachable, err := net.Listen("tcp", "127.0.0.1:0")
+	must.NoError(t, err)
+	must.NoError(t, unreachable.Close())
+
+	rpc := &mockUpstreamRPC{}
+	rpc.setServices(
+		upstreamRegistration(t, startUpstreamBackend(t, "a"), structs.CheckSuccess),
+		upstreamRegistration(t, startUpstreamBackend(t, "b"), ""),
+		upstreamRegistration(t, startUpstreamBackend(t, "c"), structs.CheckFailure),
+		upstreamRegistration(t, unreachable.Addr().String(), structs.CheckSuccess),
+	)
+
+	wid := widmgr.NewMockIdentityManager()
+	wid.(*widmgr.MockIdentityManager).SetIdentity(structs.WIHandle{
+		WorkloadIdentifier: "web",
+		IdentityName:       structs.WorkloadIdentityDefaultName,
+	}, &structs.SignedWorkloadIdentity{JWT: "web-token"})
+
+	h := newUpstreamProxyHook(testlog.HCLogger(t), alloc, rpc, wid, "global",
+		&mockNetworkIsolation{spec: &drivers.NetworkIsolationSpec{Path: "/var/run/netns/test"}})
+
+	var listenAddr string
+	h.listen = func(spec *drivers.NetworkIsolationSpec, addr string) (net.Listener, error) {
+		must.Eq(t, "/var/run/netns/test", spec.Path)
+		must.Eq(t, "127.0.0.1:5432", addr)
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		if err == nil {
+			listenAddr = l.Addr().String()
+		}
+		return l, err
+	}
+
+	must.NoError(t, h.Prerun(nil))
+	t.Cleanup(func() { _ = h.Postrun() })
+
+	must.Wait(t, wait.InitialSuccess(wait.BoolFunc(func() bool {
+		return readUpstream(listenAddr) != ""
+	}), wait.Timeout(5*time.Second), wait.Gap(10*time.Millisecond)))
+
+	seen := map[string]int{}
+	for range 6 {
+		seen[readUpstream(listenAddr)]++
+	}
+	must.MapLen(t, 2, seen)
+	must.MapContainsKeys(t, seen, []string{"a", "b"})
+
+	rpc.lock.Lock()
+	must.Eq(t, "web-token", rpc.authToken)
+	must.Eq(t, alloc.Namespace, rpc.namespace)
+	rpc.lock.Unlock()
+
+	// instances are removed once their checks fail
+	rpc.setServices(
+		upstreamRegistration(t, startUpstreamBackend(t, "a"), structs.CheckFailure),
+		upstreamRegistration(t, startUpstreamBackend(t, "b"), structs.CheckSuccess),
+	)
+	must.Wait(t, wait.InitialSuccess(wait.BoolFunc(func() bool {
+		return readUpstream(listenAddr) == "b" && readUpstream(listenAddr) == "b"
+	}), wait.Timeout(5*time.Second), wait.Gap(10*time.Millisecond)))
+
+	// removing the upstream stops its proxy
+	updated := alloc.Copy()
+	updated.Job.TaskGroups[0].Networks[0].Upstreams = nil
+	must.NoError(t, h.Update(&interfaces.RunnerUpdateRequest{Alloc: updated}))
+	must.MapEmpty(t, h.proxies)
+
+	_, err = net.Dial("tcp", listenAddr)
+	must.Error(t, err)
+}
+
+// TestUpstreamProxyHook_NoNetworkNamespace asserts upstreams fail to start
+// without a network namespace.
+func TestUpstreamProxyHook_NoNetworkNamespace(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	h := newUpstreamProxyHook(testlog.HCLogger(t), alloc, &mockUpstreamRPC{},
+		widmgr.NewMockIdentityManager(), "global", &mockNetworkIsolation{})
+
+	// allocations without upstreams are a noop
+	must.NoError(t, h.Prerun(nil))
+
+	alloc = alloc.Copy()
+	alloc.Job.TaskGroups[0].Networks = structs.Networks{{
+		Mode:      "bridge",
+		Upstreams: structs.NetworkUpstreams{{DestinationName: "db", LocalBindPort: 5432}},
+	}}
+	err := h.Update(&interfaces.RunnerUpdateRequest{Alloc: alloc})
+	must.ErrorContains(t, err, "upstreams require the allocation to have a network namespace")
+}
+
+// TestUpstreamProxyHook_HalfClose asserts a client closing its write side still
+// receives the full response from the upstream.
+func TestUpstreamProxyHook_HalfClose(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	alloc.Job.TaskGroups[0].Networks = structs.Networks{{
+		Mode:      "bridge",
+		Upstreams: structs.NetworkUpstreams{{DestinationName: "db", LocalBindPort: 5432}},
+	}}
+
+	rpc := &mockUpstreamRPC{}
+	rpc.setServices(upstreamRegistration(t, startEchoBackend(t), structs.CheckSuccess))
+
+	wid := widmgr.NewMockIdentityManager()
+	wid.(*widmgr.MockIdentityManager).SetIdentity(structs.WIHandle{
+		WorkloadIdentifier: "web",
+		IdentityName:       structs.WorkloadIdentityDefaultName,
+	}, &structs.SignedWorkloadIdentity{JWT: "web-token"})
+
+	h := newUpstreamProxyHook(testlog.HCLogger(t), alloc, rpc, wid, "global",
+		&mockNetworkIsolation{spec: &drivers.NetworkIsolationSpec{Path: "/var/run/netns/test"}})
+
+	var listenAddr string
+	h.listen = func(_ *drivers.NetworkIsolationSpec, _ string) (net.Listener, error) {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		if err == nil {
+			listenAddr = l.Addr().String()
+		}
+		return l, err
+	}
+
+	must.NoError(t, h.Prerun(nil))
+	t.Cleanup(func() { _ = h.Postrun() })
+
+	echo := func() string {
+		conn, err := net.Dial("tcp", listenAddr)
+		if err != nil {
+			return ""
+		}
+		defer conn.Close()
+		_ = conn.SetDeadline(time.Now().Add(time.Second))
+		if _, err := conn.Write([]byte("ping")); err != nil {
+			return ""
+		}
+		if err := conn.(*net.TCPConn).CloseWrite(); err != nil {
+			return ""
+		}
+		b, _ := io.ReadAll(conn)
+		return string(b)
+	}
+
+	must.Wait(t, wait.InitialSuccess(wait.BoolFunc(func() bool {
+		return echo() == "ping"
+	}), wait.Timeout(5*time.Second), wait.Gap(10*time.Millisecond)))
+}
+
+type mockNetworkIsolation struct {
+	spec *drivers.NetworkIsolationSpec
+}
+
+func (m *mockNetworkIsolation) NetworkIsolation() *drivers.NetworkIsolationSpec {
+	return m.spec
+}
+
+// mockUpstreamRPC serves service registrations to upstream proxies, emulating
+// blocking queries with a short timeout.
+type mockUpstreamRPC struct {
+	lock      sync.Mutex
+	services  []*structs.ServiceRegistration
+	index     uint64
+	authToken string
+	namespace string
+}
+
+func (m *mockUpstreamRPC) setServices(services ...*structs.ServiceRegistration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.services = services
+	m.index++
+}
+
+func (m *mockUpstreamRPC) RPC(method string, args, reply interface{}) error {
+	req := args.(*structs.ServiceRegistrationByNameRequest)
+	resp := reply.(*structs.ServiceRegistrationByNameResponse)
+
+	deadline := time.Now().Add(100 * time.Millisecond)
+	for {
+		m.lock.Lock()
+		m.authToken = req.AuthToken
+		m.namespace = req.Namespace
+		if m.index > req.MinQueryIndex || time.Now().After(deadline) {
+			resp.Services = m.services
+			resp.Index = m.index
+			m.lock.Unlock()
+			return nil
+		}
+		m.lock.Unlock()
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// startUpstreamBackend starts a server writing its name to every connection.
+func startUpstreamBackend(t *testing.T, name string) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	must.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			_, _ = conn.Write([]byte(name))
+			_ = conn.Close()
+		}
+	}()
+	return l.Addr().String()
+}
+
+// startEchoBackend starts a server that reads each connection until EOF
+// before echoing what it read back.
+func startEchoBackend(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	must.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			b, _ := io.ReadAll(conn)
+			_, _ = conn.Write(b)
+			_ = conn.Close()
+		}
+	}()
+	return l.Addr().String()
+}
+
+func upstreamRegistration(t *testing.T, addr string, status structs.CheckStatus) *structs.ServiceRegistration {
+	host, port, err := net.SplitHostPort(addr)
+	must.NoError(t, err)
+	p, err := strconv.Atoi(port)
+	must.NoError(t, err)
+	return &structs.ServiceRegistration{
+		ServiceName: "db",
+		Address:     host,
+		Port:        p,
+		CheckStatus: status,
+	}
+}
+
+// readUpstream returns the name of the backend the connection was proxied to,
+// or an empty string if the connection wasn't proxied.
+func readUpstream(addr string) string {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return ""
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(time.Second))
+	b, _ := io.ReadAll(conn)
+	return string(b)
+}
@@ -0,0 +1,405 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocwatcher
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-version"
+	nomadapi "github.com/hashicorp/nomad/api"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/escapingfs"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// syncStateFile is the file in the staging alloc dir which records the
+	// remote version of each file copied so far.
+	syncStateFile = ".nomad-migrate-state.json"
+
+	// syncMaxAttempts is the number of times a sync of the alloc dir is
+	// attempted before migration fails. Each attempt resumes where the
+	// previous one stopped.
+	syncMaxAttempts = 5
+
+	// syncCheckpointSize is the number of bytes left to copy of a file above
+	// which the sync state is persisted before copying it, so that the copy
+	// can be resumed after the client restarts.
+	syncCheckpointSize = 4 * 1024 * 1024
+)
+
+// syncRetryIntv is the minimum interval between sync attempts. We pick a value
+// between this and 2x this.
+var syncRetryIntv = 5 * time.Second
+
+// minIncrementalMigrateVersion is the minimum Nomad version of the previous
+// alloc's node that serves snapshot manifests. The alloc dir of allocs on
+// older nodes is migrated as a single snapshot.
+var minIncrementalMigrateVersion = version.Must(version.NewVersion("2.0.5"))
+
+// supportsIncrementalMigration returns whether the alloc dir of allocs on the
+// node can be migrated incrementally.
+func supportsIncrementalMigration(node *structs.Node) bool {
+	v, err := version.NewVersion(node.Attributes["nomad.version"])
+	if err != nil {
+		return false
+	}
+	return v.Core().GreaterThanOrEqual(minIncrementalMigrateVersion)
+}
+
+// syncFile is the remote version of a file, completely or partially copied,
+// in the staging alloc dir.
+type syncFile struct {
+	Size    int64
+	ModTime time.Time
+}
+
+func (f *syncFile) equal(o *syncFile) bool {
+	return f.Size == o.Size && f.ModTime.Equal(o.ModTime)
+}
+
+// syncState records which remote version of each file has been copied into
+// the staging alloc dir, so that repeated or interrupted syncs only copy files
+// which changed and resume partially copied ones.
+type syncState struct {
+	Files map[string]*syncFile
+}
+
+// allocDirSyncer incrementally copies the alloc dir of an alloc on a remote
+// node into a local staging alloc dir.
+type allocDirSyncer struct {
+	// prevAllocID is the ID of the alloc being copied
+	prevAllocID string
+
+	// client is an API client of the remote node
+	client *nomadapi.Client
+
+	// migrateToken allows access to the remote alloc dir
+	migrateToken string
+
+	// dest is the path of the staging alloc dir
+	dest string
+
+	// roots are the paths relative to dest that are synced
+	roots []string
+
+	// state is loaded from dest on the first sync
+	state *syncState
+
+	logger hclog.Logger
+}
+
+// syncWithRetries syncs the alloc dir, resuming the sync after errors until
+// it succeeds, the context is done, or syncMaxAttempts is reached.
+func (s *allocDirSyncer) syncWithRetries(ctx context.Context) error {
+	for attempt := 1; ; attempt++ {
+		err := s.sync(ctx)
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+		if attempt == syncMaxAttempts {
+			return fmt.Errorf("error syncing previous alloc %q after %d attempts: %w",
+				s.prevAllocID, attempt, err)
+		}
+
+		retry := syncRetryIntv + helper.RandomStagger(syncRetryIntv)
+		s.logger.Warn("error syncing previous alloc dir; resuming",
+			"error", err, "attempt", attempt, "wait", retry)
+
+		timer, stop := helper.NewSafeTimer(retry)
+		select {
+		case <-timer.C:
+			stop()
+		case <-ctx.Done():
+			stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// sync copies the files of the remote alloc dir that changed since the last
+// sync and removes the ones that no longer exist.
+func (s *allocDirSyncer) sync(ctx context.Context) error {
+	if s.state == nil {
+		state, err := s.loadState()
+		if err != nil {
+			return err
+		}
+		s.state = state
+	}
+
+	entries, err := s.manifest(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Persist the progress of this sync even if it fails
+	defer func() {
+		if err := s.saveState(); err != nil {
+			s.logger.Warn("error saving migration state", "error", err)
+		}
+	}()
+
+	// Cache effective uid as we only run Chown if we're root
+	euid := syscall.Geteuid()
+
+	var copied int64
+	seen := make(map[string]struct{}, len(entries))
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if escapes, err := escapingfs.PathEscapesAllocDir(s.dest, "", entry.Path); err != nil {
+			return fmt.Errorf("error evaluating object: %w", err)
+		} else if escapes {
+			return fmt.Errorf("snapshot contains object that escapes alloc dir")
+		}
+		seen[entry.Path] = struct{}{}
+
+		path := filepath.Join(s.dest, entry.Path)
+		switch entry.Typeflag {
+		case tar.TypeDir:
+			err = s.syncDir(path, entry)
+		case tar.TypeSymlink:
+			err = s.syncSymlink(path, entry)
+		case tar.TypeReg:
+			var n int64
+			n, err = s.syncFile(ctx, path, entry)
+			copied += n
+		default:
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if euid == 0 {
+			if err := os.Lchown(path, entry.Uid, entry.Gid); err != nil {
+				return fmt.Errorf("error chowning %q: %w", path, err)
+			}
+		}
+	}
+
+	if err := s.prune(seen); err != nil {
+		return err
+	}
+
+	s.logger.Debug("synced previous alloc dir", "entries", len(entries), "bytes_copied", copied)
+	return nil
+}
+
+// manifest returns the entries of the remote alloc dir snapshot.
+func (s *allocDirSyncer) manifest(ctx context.Context) ([]*cstructs.AllocSnapshotEntry, error) {
+	var entries []*cstructs.AllocSnapshotEntry
+	qo := &nomadapi.QueryOptions{
+		AuthToken: s.migrateToken,
+		Params:    map[string]string{"manifest": "true"},
+	}
+	url := fmt.Sprintf("/v1/client/allocation/%v/snapshot", s.prevAllocID)
+	if _, err := s.client.Raw().Query(url, &entries, qo.WithContext(ctx)); err != nil {
+		return nil, fmt.Errorf("error getting snapshot manifest from previous alloc %q: %w", s.prevAllocID, err)
+	}
+	return entries, nil
+}
+
+func (s *allocDirSyncer) syncDir(path string, entry *cstructs.AllocSnapshotEntry) error {
+	if fi, err := os.Lstat(path); err == nil && !fi.IsDir() {
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("error removing existing file: %w", err)
+		}
+	}
+	if err := os.MkdirAll(path, os.FileMode(entry.Mode)); err != nil {
+		return fmt.Errorf("error creating directory: %w", err)
+	}
+	return os.Chmod(path, os.FileMode(entry.Mode))
+}
+
+func (s *allocDirSyncer) syncSymlink(path string, entry *cstructs.AllocSnapshotEntry) error {
+	if escapes, err := escapingfs.PathEscapesAllocDir(s.dest, "", entry.Linkname); err != nil {
+		return fmt.Errorf("error evaluating symlink: %w", err)
+	} else if escapes {
+		return fmt.Errorf("snapshot contains symlink that escapes alloc dir")
+	}
+
+	if target, err := os.Readlink(path); err == nil && target == entry.Linkname {
+		return nil
+	}
+	if err := os.RemoveAll(path); err != nil {
+		return fmt.Errorf("error removing existing file: %w", err)
+	}
+	if err := os.Symlink(entry.Linkname, path); err != nil {
+		return fmt.Errorf("error creating symlink: %w", err)
+	}
+	return nil
+}
+
+// syncFile copies the remote file if it changed since it was last copied,
+// resuming a partial copy of the same version of the file. It returns the
+// number of bytes copied.
+func (s *allocDirSyncer) syncFile(ctx context.Context, path string, entry *cstructs.AllocSnapshotEntry) (int64, error) {
+	remote := &syncFile{Size: entry.Size, ModTime: entry.ModTime}
+
+	var offset int64
+	if fi, err := os.Lstat(path); err == nil {
+		if !fi.Mode().IsRegular() {
+			if err := os.RemoveAll(path); err != nil {
+				return 0, fmt.Errorf("error removing existing file: %w", err)
+			}
+		} else if local := s.state.Files[entry.Path]; local != nil && local.equal(remote) && fi.Size() <= remote.Size {
+			if fi.Size() == remote.Size {
+				// Already up to date
+				return 0, os.Chmod(path, os.FileMode(entry.Mode))
+			}
+			offset = fi.Size()
+		}
+	}
+
+	// Record the version being copied before copying it so a partial copy
+	// can be resumed
+	s.state.Files[entry.Path] = remote
+	if remote.Size-offset >= syncCheckpointSize {
+		if err := s.saveState(); err != nil {
+			return 0, err
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return 0, fmt.Errorf("error creating file: %w", err)
+	}
+	defer f.Close()
+
+	if err := f.Truncate(offset); err != nil {
+		return 0, fmt.Errorf("error truncating file %q: %w", path, err)
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("error seeking file %q: %w", path, err)
+	}
+
+	var n int64
+	if offset < remote.Size {
+		qo := &nomadapi.QueryOptions{
+			AuthToken: s.migrateToken,
+			Params: map[string]string{
+				"path":   entry.Path,
+				"offset": strconv.FormatInt(offset, 10),
+			},
+		}
+		url := fmt.Sprintf("/v1/client/allocation/%v/snapshot", s.prevAllocID)
+		body, err := s.client.Raw().Response(url, qo.WithContext(ctx))
+		if err != nil {
+			return 0, fmt.Errorf("error getting file %q from previous alloc %q: %w", entry.Path, s.prevAllocID, err)
+		}
+		defer body.Close()
+
+		// Only copy up to the size in the manifest, as the file may still be
+		// written to while the previous alloc is running
+		n, err = io.CopyN(f, body, remote.Size-offset)
+		if errors.Is(err, io.EOF) {
+			return n, fmt.Errorf("file %q changed while being copied", entry.Path)
+		} else if err != nil {
+			return n, fmt.Errorf("error copying file %q: %w", entry.Path, err)
+		}
+	}
+
+	if err := f.Chmod(os.FileMode(entry.Mode)); err != nil {
+		return n, fmt.Errorf("error chmoding file %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return n, fmt.Errorf("error writing to file %q: %w", path, err)
+	}
+	return n, os.Chtimes(path, entry.ModTime, entry.ModTime)
+}
+
+// prune removes the files in the synced roots of the staging alloc dir that
+// are not part of the remote snapshot anymore.
+func (s *allocDirSyncer) prune(seen map[string]struct{}) error {
+	for _, root := range s.roots {
+		err := filepath.WalkDir(filepath.Join(s.dest, root), func(path string, d fs.DirEntry, err error) error {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			} else if err != nil {
+				return err
+			}
+
+			relPath, err := filepath.Rel(s.dest, path)
+			if err != nil {
+				return err
+			}
+			if _, ok := seen[relPath]; ok {
+				return nil
+			}
+
+			if err := os.RemoveAll(path); err != nil {
+				return fmt.Errorf("error removing %q: %w", path, err)
+			}
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for path := range s.state.Files {
+		if _, ok := seen[path]; !ok {
+			delete(s.state.Files, path)
+		}
+	}
+	return nil
+}
+
+func (s *allocDirSyncer) loadState() (*syncState, error) {
+	state := &syncState{Files: map[string]*syncFile{}}
+
+	buf, err := os.ReadFile(filepath.Join(s.dest, syncStateFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading migration state: %w", err)
+	}
+
+	if err := json.Unmarshal(buf, state); err != nil {
+		// Start over rather than failing the migration
+		s.logger.Warn("ignoring invalid migration state", "error", err)
+		return &syncState{Files: map[string]*syncFile{}}, nil
+	}
+	if state.Files == nil {
+		state.Files = map[string]*syncFile{}
+	}
+	return state, nil
+}
+
+func (s *allocDirSyncer) saveState() error {
+	buf, err := json.Marshal(s.state)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(s.dest, syncStateFile)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, buf, 0600); err != nil {
+		return fmt.Errorf("error writing migration state: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("error writing migration state: %w", err)
+	}
+	return nil
+}
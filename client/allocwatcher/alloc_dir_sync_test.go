@@ -0,0 +1,177 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocwatcher
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	nomadapi "github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers/fsisolation"
+	"github.com/shoenig/test/must"
+)
+
+// testSnapshotServer serves the snapshot manifest and files of an alloc dir
+// like the HTTP API of a remote client, and records the file requests it
+// received.
+type testSnapshotServer struct {
+	allocDir *allocdir.AllocDir
+
+	lock     sync.Mutex
+	requests []string
+}
+
+func (s *testSnapshotServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if _, ok := query["manifest"]; ok {
+		entries, err := s.allocDir.SnapshotManifest()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("X-Nomad-Index", "1")
+		w.Header().Set("X-Nomad-LastContact", "0")
+		json.NewEncoder(w).Encode(entries)
+		return
+	}
+
+	path := query.Get("path")
+	offset, _ := strconv.ParseInt(query.Get("offset"), 10, 64)
+
+	s.lock.Lock()
+	s.requests = append(s.requests, path+"@"+strconv.FormatInt(offset, 10))
+	s.lock.Unlock()
+
+	f, err := s.allocDir.ReadAt(path, offset)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	defer f.Close()
+	io.Copy(w, f)
+}
+
+func (s *testSnapshotServer) takeRequests() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	requests := s.requests
+	s.requests = nil
+	return requests
+}
+
+func TestAllocDirSyncer_Sync(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+	alloc := mock.Alloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+
+	// Build the remote alloc dir
+	remote := allocdir.NewAllocDir(logger, t.TempDir(), t.TempDir(), alloc.ID)
+	must.NoError(t, remote.Build())
+	t.Cleanup(func() { remote.Destroy() })
+	remoteTaskDir := remote.NewTaskDir(task)
+	must.NoError(t, remoteTaskDir.Build(fsisolation.None, nil, "nobody"))
+
+	dataDir := filepath.Join(remote.SharedDir, allocdir.SharedDataDir)
+	must.NoError(t, os.WriteFile(filepath.Join(dataDir, "unchanged"), []byte("same"), 0644))
+	must.NoError(t, os.WriteFile(filepath.Join(dataDir, "changed"), []byte("before"), 0644))
+	must.NoError(t, os.WriteFile(filepath.Join(dataDir, "deleted"), []byte("gone"), 0644))
+	must.NoError(t, os.WriteFile(filepath.Join(dataDir, "big"), []byte(strings.Repeat("x", 1000)), 0644))
+	must.NoError(t, os.Mkdir(filepath.Join(remoteTaskDir.LocalDir, "sub"), 0755))
+	must.NoError(t, os.WriteFile(filepath.Join(remoteTaskDir.LocalDir, "sub", "file"), []byte("local"), 0600))
+	must.NoError(t, os.Symlink("file", filepath.Join(remoteTaskDir.LocalDir, "sub", "link")))
+
+	srv := &testSnapshotServer{allocDir: remote}
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+
+	apiConfig := nomadapi.DefaultConfig()
+	apiConfig.Address = ts.URL
+	client, err := nomadapi.NewClient(apiConfig)
+	must.NoError(t, err)
+
+	dest := t.TempDir()
+	newSyncer := func() *allocDirSyncer {
+		return &allocDirSyncer{
+			prevAllocID: alloc.ID,
+			client:      client,
+			dest:        dest,
+			roots: []string{
+				filepath.Join(allocdir.SharedAllocName, allocdir.SharedDataDir),
+				filepath.Join(task.Name, allocdir.TaskLocal),
+			},
+			logger: logger,
+		}
+	}
+
+	readDest := func(path ...string) string {
+		t.Helper()
+		buf, err := os.ReadFile(filepath.Join(append([]string{dest}, path...)...))
+		must.NoError(t, err)
+		return string(buf)
+	}
+
+	// The first sync copies everything
+	must.NoError(t, newSyncer().sync(context.Background()))
+	must.Len(t, 5, srv.takeRequests())
+	must.Eq(t, "before", readDest("alloc", "data", "changed"))
+	must.Eq(t, "local", readDest(task.Name, "local", "sub", "file"))
+	link, err := os.Readlink(filepath.Join(dest, task.Name, "local", "sub", "link"))
+	must.NoError(t, err)
+	must.Eq(t, "file", link)
+
+	fi, err := os.Stat(filepath.Join(dest, task.Name, "local", "sub", "file"))
+	must.NoError(t, err)
+	must.Eq(t, os.FileMode(0600), fi.Mode().Perm())
+
+	// A later sync only copies the files that changed and removes deleted ones
+	must.NoError(t, os.WriteFile(filepath.Join(dataDir, "changed"), []byte("after!"), 0644))
+	must.NoError(t, os.Remove(filepath.Join(dataDir, "deleted")))
+	must.NoError(t, newSyncer().sync(context.Background()))
+	must.Eq(t, []string{"alloc/data/changed@0"}, srv.takeRequests())
+	must.Eq(t, "after!", readDest("alloc", "data", "changed"))
+	must.FileNotExists(t, filepath.Join(dest, "alloc", "data", "deleted"))
+
+	// An interrupted copy resumes from where it stopped
+	must.NoError(t, os.Truncate(filepath.Join(dest, "alloc", "data", "big"), 400))
+	must.NoError(t, newSyncer().sync(context.Background()))
+	must.Eq(t, []string{"alloc/data/big@400"}, srv.takeRequests())
+	must.Eq(t, strings.Repeat("x", 1000), readDest("alloc", "data", "big"))
+}
+
+func TestAllocDirSyncer_SupportsIncrementalMigration(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		version  string
+		expected bool
+	}{
+		{"", false},
+		{"1.10.0", false},
+		{"2.0.4", false},
+		{"2.0.5-dev", true},
+		{"2.0.5", true},
+		{"2.1.0", true},
+	}
+
+	for _, tc := range cases {
+		node := &structs.Node{Attributes: map[string]string{"nomad.version": tc.version}}
+		must.Eq(t, tc.expected, supportsIncrementalMigration(node), must.Sprintf("version %q", tc.version))
+	}
+}
@@ -81,6 +81,7 @@ func newMigratorForAlloc(c Config, tg *structs.TaskGroup, watchedAllocID string,
 	tasks := tg.Tasks
 	migrate := tg.EphemeralDisk != nil && tg.EphemeralDisk.Migrate
 	sticky := tg.EphemeralDisk != nil && (tg.EphemeralDisk.Sticky || migrate)
+	precopy := migrate && tg.EphemeralDisk.PreCopy
 
 	if m != nil {
 		// Local Allocation because there's an alloc runner
@@ -102,6 +103,7 @@ func newMigratorForAlloc(c Config, tg *structs.TaskGroup, watchedAllocID string,
 		tasks:        tasks,
 		config:       c.Config,
 		migrate:      migrate,
+		precopy:      precopy,
 		rpc:          c.RPC,
 		migrateToken: c.MigrateToken,
 		logger:       logger,
@@ -301,6 +303,14 @@ type remotePrevAlloc struct {
 	// migrate is true if data should be moved between nodes
 	migrate bool
 
+	// precopy is true if data should be copied while the previous alloc is
+	// still running
+	precopy bool
+
+	// precopied is true once a pre-copy of the previous alloc was started.
+	// It is only accessed by Wait.
+	precopied bool
+
 	// rpc provides an RPC method for watching for updates to the previous
 	// alloc and determining what node it was on.
 	rpc RPCer
@@ -371,6 +381,15 @@ func (p *remotePrevAlloc) Wait(ctx context.Context) error {
 		}
 	}
 
+	// Stop any pre-copy once the previous alloc has terminated; the final
+	// sync in Migrate resumes where it stopped
+	precopyCtx, precopyCancel := context.WithCancel(ctx)
+	var precopyWg sync.WaitGroup
+	defer func() {
+		precopyCancel()
+		precopyWg.Wait()
+	}()
+
 	for !done() {
 		resp := structs.SingleAllocResponse{}
 		err := p.rpc.RPC("Alloc.GetAlloc", &req, &resp)
@@ -414,6 +433,15 @@ func (p *remotePrevAlloc) Wait(ctx context.Context) error {
 			return nil
 		}
 
+		if p.precopy && !p.precopied && resp.Alloc.ClientStatus == structs.AllocClientStatusRunning {
+			p.precopied = true
+			precopyWg.Add(1)
+			go func(nodeID string) {
+				defer precopyWg.Done()
+				p.preCopy(precopyCtx, nodeID)
+			}(resp.Alloc.NodeID)
+		}
+
 		// Update the query index and requery.
 		if resp.Index > req.MinQueryIndex {
 			req.AllowStale = true
@@ -449,12 +477,12 @@ func (p *remotePrevAlloc) Migrate(ctx context.Context, dest allocdir.Interface)
 		return nil
 	}
 
-	addr, err := p.getNodeAddr(ctx, p.nodeID)
+	node, err := p.getNode(ctx, p.nodeID)
 	if err != nil {
 		return err
 	}
 
-	prevAllocDir, err := p.migrateAllocDir(ctx, addr)
+	prevAllocDir, err := p.migrateAllocDir(ctx, node)
 	if err != nil {
 		return err
 	}
@@ -472,8 +500,40 @@ func (p *remotePrevAlloc) Migrate(ctx context.Context, dest allocdir.Interface)
 	return nil
 }
 
-// getNodeAddr gets the node from the server with the given Node ID
-func (p *remotePrevAlloc) getNodeAddr(ctx context.Context, nodeID string) (string, error) {
+// preCopy copies the alloc dir of the previous alloc while it is still
+// running. Errors are only logged as Migrate copies anything pre-copy missed.
+func (p *remotePrevAlloc) preCopy(ctx context.Context, nodeID string) {
+	node, err := p.getNode(ctx, nodeID)
+	if err != nil {
+		if ctx.Err() == nil {
+			p.logger.Warn("unable to pre-copy previous alloc", "error", err)
+		}
+		return
+	}
+	if !supportsIncrementalMigration(node) {
+		p.logger.Debug("node of previous alloc does not support pre-copy", "node_id", nodeID)
+		return
+	}
+
+	prevAllocDir, syncer, err := p.newAllocDirSyncer(node)
+	if err != nil {
+		p.logger.Warn("unable to pre-copy previous alloc", "error", err)
+		return
+	}
+
+	p.logger.Info("pre-copying data from running previous alloc")
+	if err := syncer.syncWithRetries(ctx); err != nil {
+		if ctx.Err() == nil {
+			p.logger.Warn("error pre-copying previous alloc", "error", err,
+				"previous_alloc_dir", prevAllocDir.AllocDir)
+		}
+		return
+	}
+	p.logger.Debug("pre-copied previous alloc")
+}
+
+// getNode gets the node from the server with the given Node ID
+func (p *remotePrevAlloc) getNode(ctx context.Context, nodeID string) (*structs.Node, error) {
 	req := structs.NodeSpecificRequest{
 		NodeID: nodeID,
 		QueryOptions: structs.QueryOptions{
@@ -493,42 +553,102 @@ func (p *remotePrevAlloc) getNodeAddr(ctx context.Context, nodeID string) (strin
 			case <-time.After(retry):
 				continue
 			case <-ctx.Done():
-				return "", ctx.Err()
+				return nil, ctx.Err()
 			}
 		}
 		break
 	}
 
 	if resp.Node == nil {
-		return "", fmt.Errorf("node %q not found", nodeID)
+		return nil, fmt.Errorf("node %q not found", nodeID)
 	}
+	return resp.Node, nil
+}
 
+// nodeClient returns an API client of the node's HTTP API.
+func (p *remotePrevAlloc) nodeClient(node *structs.Node) (*nomadapi.Client, error) {
 	scheme := "http://"
-	if resp.Node.TLSEnabled {
+	if node.TLSEnabled {
 		scheme = "https://"
 	}
-	return scheme + resp.Node.HTTPAddr, nil
-}
-
-// migrate a remote alloc dir to local node. Caller is responsible for calling
-// Destroy on the returned allocdir if no error occurs.
-func (p *remotePrevAlloc) migrateAllocDir(ctx context.Context, nodeAddr string) (*allocdir.AllocDir, error) {
-	// Create the previous alloc dir
-	prevAllocDir := allocdir.NewAllocDir(p.logger, p.config.AllocDir, p.config.AllocMountsDir, p.prevAllocID)
-	if err := prevAllocDir.Build(); err != nil {
-		return nil, fmt.Errorf("error building alloc dir for previous alloc %q: %w", p.prevAllocID, err)
-	}
 
-	// Create an API client
 	apiConfig := nomadapi.DefaultConfig()
-	apiConfig.Address = nodeAddr
+	apiConfig.Address = scheme + node.HTTPAddr
 	apiConfig.TLSConfig = &nomadapi.TLSConfig{
 		CACert:        p.config.TLSConfig.CAFile,
 		ClientCert:    p.config.TLSConfig.CertFile,
 		ClientKey:     p.config.TLSConfig.KeyFile,
 		TLSServerName: fmt.Sprintf("client.%s.nomad", p.config.Region),
 	}
-	apiClient, err := nomadapi.NewClient(apiConfig)
+	return nomadapi.NewClient(apiConfig)
+}
+
+// buildPrevAllocDir builds the local alloc dir that the previous alloc's data
+// is copied into before being moved to the new alloc dir.
+func (p *remotePrevAlloc) buildPrevAllocDir() (*allocdir.AllocDir, error) {
+	prevAllocDir := allocdir.NewAllocDir(p.logger, p.config.AllocDir, p.config.AllocMountsDir, p.prevAllocID)
+	if err := prevAllocDir.Build(); err != nil {
+		return nil, fmt.Errorf("error building alloc dir for previous alloc %q: %w", p.prevAllocID, err)
+	}
+	return prevAllocDir, nil
+}
+
+// newAllocDirSyncer returns a syncer of the previous alloc dir on the node
+// into the local previous alloc dir.
+func (p *remotePrevAlloc) newAllocDirSyncer(node *structs.Node) (*allocdir.AllocDir, *allocDirSyncer, error) {
+	prevAllocDir, err := p.buildPrevAllocDir()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	apiClient, err := p.nodeClient(node)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	roots := []string{filepath.Join(allocdir.SharedAllocName, allocdir.SharedDataDir)}
+	for _, task := range p.tasks {
+		roots = append(roots, filepath.Join(task.Name, allocdir.TaskLocal))
+	}
+
+	syncer := &allocDirSyncer{
+		prevAllocID:  p.prevAllocID,
+		client:       apiClient,
+		migrateToken: p.migrateToken,
+		dest:         prevAllocDir.AllocDir,
+		roots:        roots,
+		logger:       p.logger,
+	}
+	return prevAllocDir, syncer, nil
+}
+
+// migrate a remote alloc dir to local node. Caller is responsible for calling
+// Destroy on the returned allocdir if no error occurs.
+//
+// The alloc dir of allocs on nodes that support it is synced incrementally,
+// resuming after errors and only copying the files that changed since a
+// pre-copy. Otherwise it is streamed as a single snapshot.
+func (p *remotePrevAlloc) migrateAllocDir(ctx context.Context, node *structs.Node) (*allocdir.AllocDir, error) {
+	if supportsIncrementalMigration(node) {
+		prevAllocDir, syncer, err := p.newAllocDirSyncer(node)
+		if err != nil {
+			return nil, err
+		}
+		if err := syncer.syncWithRetries(ctx); err != nil {
+			prevAllocDir.Destroy()
+			return nil, err
+		}
+		return prevAllocDir, nil
+	}
+
+	// Create the previous alloc dir
+	prevAllocDir, err := p.buildPrevAllocDir()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create an API client
+	apiClient, err := p.nodeClient(node)
 	if err != nil {
 		return nil, err
 	}
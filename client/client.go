@@ -572,6 +572,12 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 	// lifetime when out of touch with the server
 	go c.heartbeatStop.watch()
 
+	// Evict allocations in order of priority when the node runs out of memory
+	// because of memory oversubscription
+	if conf := cfg.MemoryPressure; conf != nil && conf.Enabled {
+		c.shutdownGroup.Go(newMemoryPressureWatcher(c.getAllocRunners, conf, logger, c.shutdownCh).watch)
+	}
+
 	// Add the stats collector
 	statsCollector := hoststats.NewHostStatsCollector(c.logger, c.topology, c.GetConfig().AllocDir, c.devicemanager.AllStats)
 	c.hostStatsCollector = statsCollector
@@ -3063,6 +3069,7 @@ func (c *Client) setupNomadServiceRegistrationHandler() {
 		CheckWatcher: serviceregistration.NewCheckWatcher(
 			c.logger, nsd.NewStatusGetter(c.checkStore),
 		),
+		CheckStatusGetter: nsd.NewStatusGetter(c.checkStore),
 	}
 	c.nomadService = nsd.NewServiceRegistrationHandler(c.logger, &cfg)
 }
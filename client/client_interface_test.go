@@ -132,6 +132,10 @@ func (ar *emptyAllocRunner) SetClientStatus(status string) {
 	ar.alloc.ClientStatus = status
 }
 
+func (ar *emptyAllocRunner) EvictMemoryPressure(usage, reserved uint64) {
+	ar.SetClientStatus(structs.AllocClientStatusFailed)
+}
+
 func (ar *emptyAllocRunner) Signal(taskName, signal string) error { return nil }
 func (ar *emptyAllocRunner) RestartTask(taskName string, taskEvent *structs.TaskEvent) error {
 	return nil
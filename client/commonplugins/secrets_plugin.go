@@ -35,6 +35,11 @@ type SecretsPlugin interface {
 type SecretResponse struct {
 	Result map[string]string `json:"result"`
 	Error  *string           `json:"error"`
+
+	// TTL is the number of seconds the result is valid for. If it's shorter
+	// than the secret's refresh interval, the secret is refreshed before the
+	// result expires.
+	TTL int `json:"ttl,omitempty"`
 }
 
 type externalSecretsPlugin struct {
@@ -391,6 +391,9 @@ type Config struct {
 	// Drain configuration from the agent's config file.
 	Drain *DrainConfig
 
+	// MemoryPressure configuration from the agent's config file.
+	MemoryPressure *MemoryPressureConfig
+
 	// Uesrs configuration from the agent's config file.
 	Users *UsersConfig
 
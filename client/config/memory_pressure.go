@@ -0,0 +1,73 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs/config"
+)
+
+const (
+	// DefaultMemoryPressureThreshold is the default percentage of time that
+	// all tasks were stalled waiting on memory above which an allocation is
+	// evicted.
+	DefaultMemoryPressureThreshold = 10.0
+
+	// DefaultMemoryPressureWindow is the default window the stall time is
+	// averaged over.
+	DefaultMemoryPressureWindow = 10 * time.Second
+)
+
+// MemoryPressureConfig describes how the client evicts allocations when the
+// node runs out of memory because of memory oversubscription.
+type MemoryPressureConfig struct {
+	// Enabled enables evicting allocations under memory pressure.
+	Enabled bool
+
+	// Threshold is the percentage of time that all tasks were stalled
+	// waiting on memory above which an allocation is evicted.
+	Threshold float64
+
+	// Window is the window the stall time is averaged over, one of 10s, 60s
+	// or 5m.
+	Window time.Duration
+}
+
+// MemoryPressureConfigFromAgent creates the internal read-only copy of the
+// client agent's MemoryPressureConfig. Evicting allocations under memory
+// pressure is disabled by default.
+func MemoryPressureConfigFromAgent(c *config.MemoryPressureConfig) (*MemoryPressureConfig, error) {
+	m := &MemoryPressureConfig{
+		Threshold: DefaultMemoryPressureThreshold,
+		Window:    DefaultMemoryPressureWindow,
+	}
+	if c == nil {
+		return m, nil
+	}
+
+	if c.Enabled != nil {
+		m.Enabled = *c.Enabled
+	}
+	if c.Threshold != nil {
+		if *c.Threshold <= 0 || *c.Threshold > 100 {
+			return nil, fmt.Errorf("threshold must be a percentage above 0 and up to 100; got %v", *c.Threshold)
+		}
+		m.Threshold = *c.Threshold
+	}
+	if c.Window != nil {
+		window, err := time.ParseDuration(*c.Window)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing window: %w", err)
+		}
+		switch window {
+		case 10 * time.Second, time.Minute, 5 * time.Minute:
+		default:
+			return nil, fmt.Errorf("window must be one of 10s, 60s or 5m; got %v", window)
+		}
+		m.Window = window
+	}
+	return m, nil
+}
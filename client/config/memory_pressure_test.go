@@ -0,0 +1,43 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/shoenig/test/must"
+)
+
+func TestMemoryPressureConfigFromAgent(t *testing.T) {
+	ci.Parallel(t)
+
+	// Disabled by default
+	m, err := MemoryPressureConfigFromAgent(nil)
+	must.NoError(t, err)
+	must.Eq(t, &MemoryPressureConfig{
+		Threshold: DefaultMemoryPressureThreshold,
+		Window:    DefaultMemoryPressureWindow,
+	}, m)
+
+	m, err = MemoryPressureConfigFromAgent(&config.MemoryPressureConfig{
+		Enabled:   new(true),
+		Threshold: new(25.0),
+		Window:    new("60s"),
+	})
+	must.NoError(t, err)
+	must.Eq(t, &MemoryPressureConfig{
+		Enabled:   true,
+		Threshold: 25,
+		Window:    time.Minute,
+	}, m)
+
+	_, err = MemoryPressureConfigFromAgent(&config.MemoryPressureConfig{Threshold: new(0.0)})
+	must.ErrorContains(t, err, "threshold must be a percentage")
+
+	_, err = MemoryPressureConfigFromAgent(&config.MemoryPressureConfig{Window: new("30s")})
+	must.ErrorContains(t, err, "window must be one of")
+}
@@ -13,7 +13,6 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
-	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -25,6 +24,7 @@ import (
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/client/allocdir"
 	sframer "github.com/hashicorp/nomad/client/lib/streamframer"
+	"github.com/hashicorp/nomad/client/logmon/logging"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
@@ -591,11 +591,24 @@ func (f *FileSystem) logsImpl(ctx context.Context, follow, plain bool, offset in
 			maxIndex = idx
 		}
 
+		// Offsets are counted in decompressed bytes, so the size of
+		// compressed log files is needed to find where to start reading
+		if offset != 0 {
+			if err := decompressedSizes(fs, logPath, entries, task, logType); err != nil {
+				return err
+			}
+		}
+
 		logEntry, idx, openOffset, err := findClosest(entries, nextIdx, offset, task, logType)
 		if err != nil {
 			return err
 		}
 
+		// Compressed log files have already been rotated, so they're read to
+		// the end without waiting for the next log file.
+		_, compression, _ := logging.ParseLogFileName(logEntry.Name, fmt.Sprintf("%s.%s", task, logType))
+		compressed := compression != logging.CompressionNone
+
 		var eofCancelCh chan error
 		cancelAfterFirstEof := false
 		exitAfter := false
@@ -606,12 +619,16 @@ func (f *FileSystem) logsImpl(ctx context.Context, follow, plain bool, offset in
 			// At the end
 			cancelAfterFirstEof = true
 			exitAfter = true
-		} else {
+		} else if !compressed {
 			eofCancelCh = blockUntilNextLog(ctx, fs, logPath, task, logType, idx+1)
 		}
 
 		p := filepath.Join(logPath, logEntry.Name)
-		err = f.streamFile(ctx, openOffset, p, 0, fs, framer, eofCancelCh, cancelAfterFirstEof)
+		if compressed {
+			err = f.streamCompressedFile(ctx, openOffset, p, compression, fs, framer)
+		} else {
+			err = f.streamFile(ctx, openOffset, p, 0, fs, framer, eofCancelCh, cancelAfterFirstEof)
+		}
 
 		// Check if the context is cancelled
 		select {
@@ -801,6 +818,90 @@ OUTER:
 	}
 }
 
+// streamCompressedFile streams the decompressed content of a rotated log file
+// that was compressed by logmon, starting at offset bytes into the
+// decompressed content. Compressed files are never written to again, so the
+// stream ends at EOF.
+func (f *FileSystem) streamCompressedFile(ctx context.Context, offset int64, path, compression string,
+	fs allocdir.AllocDirFS, framer *sframer.StreamFramer) error {
+
+	file, err := fs.ReadAt(path, 0)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader, err := logging.NewDecompressReader(file, compression)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	// Skip to the offset in the decompressed content
+	if offset > 0 {
+		n, err := io.CopyN(io.Discard, reader, offset)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		offset = n
+	}
+
+	data := make([]byte, streamFrameSize)
+	for {
+		select {
+		case <-framer.ExitCh():
+			return nil
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		n, readErr := reader.Read(data)
+		offset += int64(n)
+		if readErr != nil && readErr != io.EOF {
+			return readErr
+		}
+
+		if n != 0 {
+			if err := framer.Send(path, "", data[:n], offset); err != nil {
+				return parseFramerErr(err)
+			}
+		}
+
+		if readErr == io.EOF {
+			return nil
+		}
+	}
+}
+
+// decompressedSizes replaces the size of the compressed log files of the task
+// in entries with the size of their decompressed contents.
+func decompressedSizes(fs allocdir.AllocDirFS, logPath string, entries []*cstructs.AllocFileInfo,
+	task, logType string) error {
+
+	for _, entry := range entries {
+		if entry.IsDir {
+			continue
+		}
+		_, compression, ok := logging.ParseLogFileName(entry.Name, fmt.Sprintf("%s.%s", task, logType))
+		if !ok || compression == logging.CompressionNone {
+			continue
+		}
+
+		p := filepath.Join(logPath, entry.Name)
+		size, err := logging.DecompressedSize(func(offset int64) (io.ReadCloser, error) {
+			return fs.ReadAt(p, offset)
+		}, entry.Size, compression)
+		if err != nil {
+			return fmt.Errorf("failed to read size of %q: %v", entry.Name, err)
+		}
+		entry.Size = size
+	}
+	return nil
+}
+
 // blockUntilNextLog returns a channel that will have data sent when the next
 // log index or anything greater is created.
 func blockUntilNextLog(ctx context.Context, fs allocdir.AllocDirFS, logPath, task, logType string, nextIndex int64) chan error {
@@ -877,6 +978,7 @@ func (a indexTupleArray) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 // error is returned.
 func logIndexes(entries []*cstructs.AllocFileInfo, task, logType string) (indexTupleArray, error) {
 	var indexes []indexTuple
+	positions := make(map[int]int)
 	prefix := fmt.Sprintf("%s.%s.", task, logType)
 	for _, entry := range entries {
 		if entry.IsDir {
@@ -889,12 +991,23 @@ func logIndexes(entries []*cstructs.AllocFileInfo, task, logType string) (indexT
 			continue
 		}
 
-		// Convert to an int
-		idx, err := strconv.Atoi(idxStr)
-		if err != nil {
-			return nil, fmt.Errorf("failed to convert %q to a log index: %v", idxStr, err)
+		// Convert to an int, ignoring the extension of compressed files
+		idx, compression, ok := logging.ParseLogFileName(entry.Name, strings.TrimSuffix(prefix, "."))
+		if !ok {
+			return nil, fmt.Errorf("failed to convert %q to a log index", idxStr)
+		}
+
+		// A log file is listed twice while it's being compressed, between
+		// its compressed copy being renamed into place and it being
+		// removed. The compressed copy is complete, so it's preferred.
+		if i, ok := positions[idx]; ok {
+			if compression != logging.CompressionNone {
+				indexes[i].entry = entry
+			}
+			continue
 		}
 
+		positions[idx] = len(indexes)
 		indexes = append(indexes, indexTuple{idx: int64(idx), entry: entry})
 	}
 
@@ -921,7 +1034,8 @@ func (e notFoundErr) Code() int {
 // findClosest takes a list of entries, the desired log index and desired log
 // offset (which can be negative, treated as offset from end), task name and log
 // type and returns the log entry, the log index, the offset to read from and a
-// potential error.
+// potential error. The sizes of compressed log entries must be the sizes of
+// their decompressed contents, as set by decompressedSizes.
 func findClosest(entries []*cstructs.AllocFileInfo, desiredIdx, desiredOffset int64,
 	task, logType string) (*cstructs.AllocFileInfo, int64, int64, error) {
 
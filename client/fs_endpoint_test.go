@@ -4,6 +4,8 @@
 package client
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"fmt"
 	"io"
@@ -1741,6 +1743,71 @@ func TestFS_findClosest(t *testing.T) {
 	}
 }
 
+func TestFS_findClosest_Compressed(t *testing.T) {
+	ci.Parallel(t)
+
+	entries := []*cstructs.AllocFileInfo{
+		{Name: "foo.stdout.0.gz", Size: 10},
+		{Name: "foo.stdout.1.zst", Size: 10},
+		{Name: "foo.stdout.2", Size: 100},
+	}
+
+	entry, idx, offset, err := findClosest(entries, 1, 0, "foo", "stdout")
+	must.NoError(t, err)
+	must.Eq(t, "foo.stdout.1.zst", entry.Name)
+	must.Eq(t, 1, idx)
+	must.Eq(t, 0, offset)
+
+	entry, idx, _, err = findClosest(entries, math.MaxInt64, 0, "foo", "stdout")
+	must.NoError(t, err)
+	must.Eq(t, "foo.stdout.2", entry.Name)
+	must.Eq(t, 2, idx)
+
+	// A log file with an unknown extension isn't a valid log index
+	_, _, _, err = findClosest(append(entries, &cstructs.AllocFileInfo{Name: "foo.stdout.3.bz2"}),
+		0, 0, "foo", "stdout")
+	must.Error(t, err)
+
+	// A log file listed while it's being compressed is read from its
+	// compressed copy
+	entry, idx, _, err = findClosest(append(entries, &cstructs.AllocFileInfo{Name: "foo.stdout.1", Size: 50}),
+		1, 0, "foo", "stdout")
+	must.NoError(t, err)
+	must.Eq(t, "foo.stdout.1.zst", entry.Name)
+	must.Eq(t, 1, idx)
+}
+
+func TestFS_decompressedSizes(t *testing.T) {
+	ci.Parallel(t)
+
+	ad := tempAllocDir(t)
+	defer ad.Destroy()
+
+	logPath := filepath.Join(allocdir.SharedAllocName, allocdir.LogDirName)
+	logDir := filepath.Join(ad.AllocDir, logPath)
+	must.NoError(t, os.MkdirAll(logDir, 0755))
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	_, err := gw.Write(bytes.Repeat([]byte("a"), 1000))
+	must.NoError(t, err)
+	must.NoError(t, gw.Close())
+	must.NoError(t, os.WriteFile(filepath.Join(logDir, "foo.stdout.0.gz"), buf.Bytes(), 0644))
+	must.NoError(t, os.WriteFile(filepath.Join(logDir, "foo.stdout.1"), []byte("bbbb"), 0644))
+
+	entries, err := ad.List(logPath)
+	must.NoError(t, err)
+	must.NoError(t, decompressedSizes(ad, logPath, entries, "foo", "stdout"))
+
+	// The tail of the logs reaches back into the decompressed contents of
+	// the compressed log file
+	entry, idx, offset, err := findClosest(entries, math.MaxInt64, -10, "foo", "stdout")
+	must.NoError(t, err)
+	must.Eq(t, "foo.stdout.0.gz", entry.Name)
+	must.Eq(t, 0, idx)
+	must.Eq(t, 994, offset)
+}
+
 func TestFS_streamFile_NoFile(t *testing.T) {
 	ci.Parallel(t)
 
@@ -0,0 +1,121 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package cgroupslib
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrPressureUnsupported is returned when memory pressure can't be read,
+// because the node isn't using cgroups v2.
+var ErrPressureUnsupported = errors.New("memory pressure requires cgroups v2")
+
+// PressureStats is one line of a pressure stall information (PSI) file. The
+// averages are the percentage of time over the last 10, 60 and 300 seconds
+// that tasks were stalled, and Total is the absolute stall time in
+// microseconds.
+type PressureStats struct {
+	Avg10  float64
+	Avg60  float64
+	Avg300 float64
+	Total  uint64
+}
+
+// Pressure is the content of a PSI file such as memory.pressure. Some is the
+// share of time at least one task was stalled, and Full is the share of time
+// all tasks were stalled at once.
+type Pressure struct {
+	Some PressureStats
+	Full PressureStats
+}
+
+// MemoryEvents is the content of a cgroups v2 memory.events file.
+type MemoryEvents struct {
+	Low     uint64
+	High    uint64
+	Max     uint64
+	OOM     uint64
+	OOMKill uint64
+}
+
+// ParsePressure parses the content of a PSI file.
+func ParsePressure(content string) (*Pressure, error) {
+	p := new(Pressure)
+	for _, line := range strings.Split(strings.TrimSpace(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		var stats *PressureStats
+		switch fields[0] {
+		case "some":
+			stats = &p.Some
+		case "full":
+			stats = &p.Full
+		default:
+			return nil, fmt.Errorf("invalid pressure line %q", line)
+		}
+
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid pressure field %q", field)
+			}
+
+			var err error
+			switch key {
+			case "avg10":
+				stats.Avg10, err = strconv.ParseFloat(value, 64)
+			case "avg60":
+				stats.Avg60, err = strconv.ParseFloat(value, 64)
+			case "avg300":
+				stats.Avg300, err = strconv.ParseFloat(value, 64)
+			case "total":
+				stats.Total, err = strconv.ParseUint(value, 10, 64)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid pressure field %q: %w", field, err)
+			}
+		}
+	}
+	return p, nil
+}
+
+// ParseMemoryEvents parses the content of a memory.events file. Unknown
+// events are ignored.
+func ParseMemoryEvents(content string) (*MemoryEvents, error) {
+	e := new(MemoryEvents)
+	for _, line := range strings.Split(strings.TrimSpace(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid memory event %q", line)
+		}
+
+		count, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid memory event %q: %w", line, err)
+		}
+
+		switch fields[0] {
+		case "low":
+			e.Low = count
+		case "high":
+			e.High = count
+		case "max":
+			e.Max = count
+		case "oom":
+			e.OOM = count
+		case "oom_kill":
+			e.OOMKill = count
+		}
+	}
+	return e, nil
+}
@@ -0,0 +1,16 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+
+package cgroupslib
+
+// ReadMemoryPressure is not supported on non-Linux systems
+func ReadMemoryPressure() (*Pressure, error) {
+	return nil, ErrPressureUnsupported
+}
+
+// ReadMemoryEvents is not supported on non-Linux systems
+func ReadMemoryEvents() (*MemoryEvents, error) {
+	return nil, ErrPressureUnsupported
+}
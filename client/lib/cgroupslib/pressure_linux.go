@@ -0,0 +1,31 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package cgroupslib
+
+// ReadMemoryPressure reads the memory pressure of the Nomad parent cgroup,
+// which covers the tasks of every allocation on the node.
+func ReadMemoryPressure() (*Pressure, error) {
+	if GetMode() != CG2 {
+		return nil, ErrPressureUnsupported
+	}
+	content, err := ReadNomadCG2("memory.pressure")
+	if err != nil {
+		return nil, err
+	}
+	return ParsePressure(content)
+}
+
+// ReadMemoryEvents reads the memory events of the Nomad parent cgroup.
+func ReadMemoryEvents() (*MemoryEvents, error) {
+	if GetMode() != CG2 {
+		return nil, ErrPressureUnsupported
+	}
+	content, err := ReadNomadCG2("memory.events")
+	if err != nil {
+		return nil, err
+	}
+	return ParseMemoryEvents(content)
+}
@@ -0,0 +1,44 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package cgroupslib
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestParsePressure(t *testing.T) {
+	content := `some avg10=12.50 avg60=4.25 avg300=1.00 total=123456
+full avg10=8.75 avg60=2.00 avg300=0.50 total=65432
+`
+	p, err := ParsePressure(content)
+	must.NoError(t, err)
+	must.Eq(t, &Pressure{
+		Some: PressureStats{Avg10: 12.5, Avg60: 4.25, Avg300: 1, Total: 123456},
+		Full: PressureStats{Avg10: 8.75, Avg60: 2, Avg300: 0.5, Total: 65432},
+	}, p)
+
+	_, err = ParsePressure("partial avg10=1.00")
+	must.ErrorContains(t, err, "invalid pressure line")
+
+	_, err = ParsePressure("some avg10=abc")
+	must.ErrorContains(t, err, "invalid pressure field")
+}
+
+func TestParseMemoryEvents(t *testing.T) {
+	content := `low 1
+high 2
+max 3
+oom 4
+oom_kill 5
+oom_group_kill 6
+`
+	e, err := ParseMemoryEvents(content)
+	must.NoError(t, err)
+	must.Eq(t, &MemoryEvents{Low: 1, High: 2, Max: 3, OOM: 4, OOMKill: 5}, e)
+
+	_, err = ParseMemoryEvents("oom_kill many")
+	must.ErrorContains(t, err, "invalid memory event")
+}
@@ -22,13 +22,28 @@ const logmonRPCTimeout = 1 * time.Minute
 
 func (c *logmonClient) Start(cfg *LogConfig) error {
 	req := &proto.StartRequest{
-		LogDir:         cfg.LogDir,
-		StdoutFileName: cfg.StdoutLogFile,
-		StderrFileName: cfg.StderrLogFile,
-		MaxFiles:       uint32(cfg.MaxFiles),
-		MaxFileSizeMb:  uint32(cfg.MaxFileSizeMB),
-		StdoutFifo:     cfg.StdoutFifo,
-		StderrFifo:     cfg.StderrFifo,
+		LogDir:             cfg.LogDir,
+		StdoutFileName:     cfg.StdoutLogFile,
+		StderrFileName:     cfg.StderrLogFile,
+		MaxFiles:           uint32(cfg.MaxFiles),
+		MaxFileSizeMb:      uint32(cfg.MaxFileSizeMB),
+		RotationIntervalNs: int64(cfg.RotationInterval),
+		Compression:        cfg.Compression,
+		StdoutFifo:         cfg.StdoutFifo,
+		StderrFifo:         cfg.StderrFifo,
+		AllocId:            cfg.Metadata.AllocID,
+		JobId:              cfg.Metadata.JobID,
+		Namespace:          cfg.Metadata.Namespace,
+		GroupName:          cfg.Metadata.GroupName,
+		TaskName:           cfg.Metadata.TaskName,
+	}
+	for _, sink := range cfg.Sinks {
+		req.Sinks = append(req.Sinks, &proto.LogSink{
+			Type:       sink.Type,
+			Address:    sink.Address,
+			Headers:    sink.Headers,
+			BufferSize: uint32(sink.BufferSize),
+		})
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), logmonRPCTimeout)
 	defer cancel()
@@ -45,3 +60,27 @@ func (c *logmonClient) Stop() error {
 	_, err := c.client.Stop(ctx, req)
 	return grpcutils.HandleGrpcErr(err, c.doneCtx)
 }
+
+func (c *logmonClient) Stats() ([]*SinkStats, error) {
+	req := &proto.StatsRequest{}
+	ctx, cancel := context.WithTimeout(context.Background(), logmonRPCTimeout)
+	defer cancel()
+
+	resp, err := c.client.Stats(ctx, req)
+	if err != nil {
+		return nil, grpcutils.HandleGrpcErr(err, c.doneCtx)
+	}
+
+	stats := make([]*SinkStats, 0, len(resp.Sinks))
+	for _, st := range resp.Sinks {
+		stats = append(stats, &SinkStats{
+			Type:     st.Type,
+			Address:  st.Address,
+			Sent:     st.Sent,
+			Dropped:  st.Dropped,
+			Failed:   st.Failed,
+			Buffered: int(st.Buffered),
+		})
+	}
+	return stats, nil
+}
@@ -0,0 +1,253 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package logging
+
+import (
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// CompressionNone leaves rotated log files uncompressed
+	CompressionNone = "none"
+
+	// CompressionGzip compresses rotated log files with gzip
+	CompressionGzip = "gzip"
+
+	// CompressionZstd compresses rotated log files with zstd
+	CompressionZstd = "zstd"
+)
+
+// gzipSizeSubfieldID identifies the subfield of the extra field of gzip
+// headers that holds the decompressed size of the log file, as a little endian
+// uint64. The size in the gzip trailer only holds the size modulo 2^32.
+var gzipSizeSubfieldID = [2]byte{'N', 'S'}
+
+// compressionExts maps each compression algorithm to the extension appended
+// to the name of the log files it compressed.
+var compressionExts = map[string]string{
+	CompressionGzip: ".gz",
+	CompressionZstd: ".zst",
+}
+
+// ParseLogFileName parses the name of a log file written by a FileRotator
+// with the given base file name. It returns the index of the file and the
+// compression algorithm of the file, or false if the name is not one of the
+// rotator's files.
+func ParseLogFileName(name, baseFileName string) (int, string, bool) {
+	idxStr, found := strings.CutPrefix(name, baseFileName+".")
+	if !found {
+		return 0, "", false
+	}
+
+	compression := CompressionNone
+	for c, ext := range compressionExts {
+		if s, found := strings.CutSuffix(idxStr, ext); found {
+			idxStr = s
+			compression = c
+			break
+		}
+	}
+
+	idx, err := strconv.Atoi(idxStr)
+	if err != nil {
+		return 0, "", false
+	}
+	return idx, compression, true
+}
+
+// NewDecompressReader returns a reader of the decompressed contents of r,
+// which was compressed with the given algorithm.
+func NewDecompressReader(r io.Reader, compression string) (io.ReadCloser, error) {
+	switch compression {
+	case "", CompressionNone:
+		return io.NopCloser(r), nil
+	case CompressionGzip:
+		return gzip.NewReader(r)
+	case CompressionZstd:
+		dec, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return dec.IOReadCloser(), nil
+	default:
+		return nil, fmt.Errorf("unknown log compression %q", compression)
+	}
+}
+
+// DecompressedSize returns the size of the decompressed contents of a log file
+// compressed with the given algorithm, where size is its compressed size and
+// open opens it at the given offset. The size is read from the extra field of
+// the header of gzip files and from the frame header of zstd files, where it
+// was recorded when the file was compressed. Files without a recorded size are
+// decompressed to count their size.
+func DecompressedSize(open func(offset int64) (io.ReadCloser, error), size int64, compression string) (int64, error) {
+	switch compression {
+	case "", CompressionNone:
+		return size, nil
+	case CompressionGzip:
+		r, err := open(0)
+		if err != nil {
+			return 0, err
+		}
+		defer r.Close()
+
+		// The gzip reader only reads the header until it's read from
+		dec, err := gzip.NewReader(r)
+		if err != nil {
+			return 0, err
+		}
+		defer dec.Close()
+		if size, ok := parseGzipSizeExtra(dec.Header.Extra); ok {
+			return size, nil
+		}
+		return io.Copy(io.Discard, dec)
+	case CompressionZstd:
+		r, err := open(0)
+		if err != nil {
+			return 0, err
+		}
+		defer r.Close()
+
+		buf := make([]byte, zstd.HeaderMaxSize)
+		n, err := io.ReadFull(r, buf)
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return 0, err
+		}
+		var h zstd.Header
+		if err := h.Decode(buf[:n]); err == nil && h.HasFCS {
+			return int64(h.FrameContentSize), nil
+		}
+
+		// Count the decompressed contents of files compressed without
+		// their content size
+		r.Close()
+		if r, err = open(0); err != nil {
+			return 0, err
+		}
+		dec, err := NewDecompressReader(r, compression)
+		if err != nil {
+			return 0, err
+		}
+		defer dec.Close()
+		return io.Copy(io.Discard, dec)
+	default:
+		return 0, fmt.Errorf("unknown log compression %q", compression)
+	}
+}
+
+// compressFile compresses the file at path with the given algorithm and
+// removes it once its compressed copy has been written. The compressed copy
+// is written and synced to a hidden temporary file first and only renamed
+// into place once it's complete, so readers of the log dir never see a
+// partially compressed file.
+func compressFile(path, compression string) error {
+	ext, ok := compressionExts[compression]
+	if !ok {
+		return fmt.Errorf("unknown log compression %q", compression)
+	}
+
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+
+	dir, name := filepath.Split(path)
+	tmpPath := filepath.Join(dir, "."+name+ext+".tmp")
+	dst, err := os.OpenFile(tmpPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	if err := compressTo(dst, src, info.Size(), compression); err != nil {
+		dst.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := dst.Sync(); err != nil {
+		dst.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path+ext); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return os.Remove(path)
+}
+
+// compressTo writes the size bytes of src to dst compressed with the given
+// algorithm. The size is recorded in the header of gzip files and the frame
+// header of zstd files so their decompressed size can be read without
+// decompressing them.
+func compressTo(dst io.Writer, src io.Reader, size int64, compression string) error {
+	var enc io.WriteCloser
+	switch compression {
+	case CompressionGzip:
+		genc := gzip.NewWriter(dst)
+		genc.Extra = gzipSizeExtra(size)
+		enc = genc
+	case CompressionZstd:
+		zenc, err := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			return err
+		}
+		zenc.ResetContentSize(dst, size)
+		enc = zenc
+	default:
+		return fmt.Errorf("unknown log compression %q", compression)
+	}
+
+	if _, err := io.Copy(enc, io.LimitReader(src, size)); err != nil {
+		enc.Close()
+		return err
+	}
+	return enc.Close()
+}
+
+// gzipSizeExtra returns the extra field of a gzip header with a subfield that
+// holds the given decompressed size.
+func gzipSizeExtra(size int64) []byte {
+	extra := make([]byte, 12)
+	copy(extra, gzipSizeSubfieldID[:])
+	binary.LittleEndian.PutUint16(extra[2:], 8)
+	binary.LittleEndian.PutUint64(extra[4:], uint64(size))
+	return extra
+}
+
+// parseGzipSizeExtra returns the decompressed size held by the extra field of
+// a gzip header, or false if it doesn't have the size subfield.
+func parseGzipSizeExtra(extra []byte) (int64, bool) {
+	for len(extra) >= 4 {
+		id := [2]byte{extra[0], extra[1]}
+		n := int(binary.LittleEndian.Uint16(extra[2:]))
+		if len(extra) < 4+n {
+			return 0, false
+		}
+		if id == gzipSizeSubfieldID && n == 8 {
+			return int64(binary.LittleEndian.Uint64(extra[4:])), true
+		}
+		extra = extra[4+n:]
+	}
+	return 0, false
+}
@@ -10,8 +10,6 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
-	"strconv"
-	"strings"
 	"sync"
 	"time"
 
@@ -40,6 +38,17 @@ type FileRotator struct {
 	MaxFiles int   // MaxFiles is the maximum number of rotated files allowed in a path
 	FileSize int64 // FileSize is the size a rotated file is allowed to grow
 
+	// RotationInterval is how long a file is written to before it's rotated,
+	// even if it hasn't reached FileSize. Files are only rotated on writes,
+	// so an idle file isn't rotated until it's written to again. Zero
+	// disables time based rotation.
+	RotationInterval time.Duration
+
+	// Compression is the algorithm rotated files are compressed with, one of
+	// the Compression constants. Files are compressed in the background once
+	// they have been rotated.
+	Compression string
+
 	path         string // path is the path on the file system where the rotated set of files are opened
 	baseFileName string // baseFileName is the base file name of the rotated files
 	logFileIdx   int    // logFileIdx is the current index of the rotated files
@@ -48,8 +57,9 @@ type FileRotator struct {
 	closed           bool
 	fileLock         sync.Mutex
 
-	currentFile *os.File // currentFile is the file that is currently getting written
-	currentWr   int64    // currentWr is the number of bytes written to the current file
+	currentFile *os.File  // currentFile is the file that is currently getting written
+	currentWr   int64     // currentWr is the number of bytes written to the current file
+	currentOpen time.Time // currentOpen is when the current file was opened
 	bufw        *bufio.Writer
 	bufLock     sync.Mutex
 
@@ -57,6 +67,7 @@ type FileRotator struct {
 	logger      hclog.Logger
 	purgeCh     chan struct{}
 	doneCh      chan struct{}
+	compressWg  sync.WaitGroup
 }
 
 // NewFileRotator returns a new file rotator
@@ -93,7 +104,7 @@ func (f *FileRotator) Write(p []byte) (n int, err error) {
 	for n < len(p) {
 		// Check if we still have space in the current file, otherwise close and
 		// open the next file
-		if forceRotate || f.currentWr >= f.FileSize {
+		if forceRotate || f.currentWr >= f.FileSize || f.intervalElapsed() {
 			forceRotate = false
 			f.flushBuffer()
 			f.currentFile.Close()
@@ -159,9 +170,17 @@ func (f *FileRotator) Write(p []byte) (n int, err error) {
 	return
 }
 
+// intervalElapsed returns true if the current file has been written to for
+// longer than the rotation interval.
+func (f *FileRotator) intervalElapsed() bool {
+	return f.RotationInterval > 0 && f.currentWr > 0 &&
+		time.Since(f.currentOpen) >= f.RotationInterval
+}
+
 // nextFile opens the next file and purges older files if the number of rotated
 // files is larger than the maximum files configured by the user
 func (f *FileRotator) nextFile() error {
+	prevFileIdx := f.logFileIdx
 	nextFileIdx := f.logFileIdx
 	for {
 		nextFileIdx += 1
@@ -171,12 +190,18 @@ func (f *FileRotator) nextFile() error {
 				continue
 			}
 		}
+		if f.compressedFileExists(nextFileIdx) {
+			continue
+		}
 		f.logFileIdx = nextFileIdx
 		if err := f.createFile(); err != nil {
 			return err
 		}
 		break
 	}
+
+	f.compressRotated(prevFileIdx)
+
 	// Purge old files if we have more files than MaxFiles
 	f.fileLock.Lock()
 	defer f.fileLock.Unlock()
@@ -196,22 +221,27 @@ func (f *FileRotator) lastFile() error {
 		return err
 	}
 
-	prefix := fmt.Sprintf("%s.", f.baseFileName)
+	lastCompressed := false
 	for _, fi := range finfos {
 		if fi.IsDir() {
 			continue
 		}
-		if strings.HasPrefix(fi.Name(), prefix) {
-			fileIdx := strings.TrimPrefix(fi.Name(), prefix)
-			n, err := strconv.Atoi(fileIdx)
-			if err != nil {
-				continue
-			}
-			if n > f.logFileIdx {
-				f.logFileIdx = n
-			}
+		n, compression, ok := ParseLogFileName(fi.Name(), f.baseFileName)
+		if !ok {
+			continue
+		}
+		if n > f.logFileIdx {
+			f.logFileIdx = n
+			lastCompressed = compression != CompressionNone
+		} else if n == f.logFileIdx && compression != CompressionNone {
+			lastCompressed = true
 		}
 	}
+
+	// A compressed file has already been rotated, so don't append to it
+	if lastCompressed {
+		f.logFileIdx++
+	}
 	if err := f.createFile(); err != nil {
 		return err
 	}
@@ -232,6 +262,7 @@ func (f *FileRotator) createFile() error {
 		return err
 	}
 	f.currentWr = fi.Size()
+	f.currentOpen = time.Now()
 	f.createOrResetBuffer()
 	return nil
 }
@@ -267,9 +298,41 @@ func (f *FileRotator) Close() error {
 		f.currentFile.Close()
 	}
 
+	// Wait for the rotated files to be compressed
+	f.compressWg.Wait()
+
 	return nil
 }
 
+// compressedFileExists returns true if a compressed file with the given index
+// exists in the path.
+func (f *FileRotator) compressedFileExists(idx int) bool {
+	for _, ext := range compressionExts {
+		logFileName := filepath.Join(f.path, fmt.Sprintf("%s.%d%s", f.baseFileName, idx, ext))
+		if _, err := os.Stat(logFileName); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// compressRotated compresses the rotated file with the given index in the
+// background if compression is enabled.
+func (f *FileRotator) compressRotated(idx int) {
+	if f.Compression == "" || f.Compression == CompressionNone {
+		return
+	}
+
+	logFileName := filepath.Join(f.path, fmt.Sprintf("%s.%d", f.baseFileName, idx))
+	f.compressWg.Add(1)
+	go func() {
+		defer f.compressWg.Done()
+		if err := compressFile(logFileName, f.Compression); err != nil && !os.IsNotExist(err) {
+			f.logger.Error("error compressing file", "filename", logFileName, "error", err)
+		}
+	}()
+}
+
 // purgeOldFiles removes older files and keeps only the last N files rotated for
 // a file
 func (f *FileRotator) purgeOldFiles() {
@@ -277,22 +340,23 @@ func (f *FileRotator) purgeOldFiles() {
 		select {
 		case <-f.purgeCh:
 			var fIndexes []int
+			fNames := make(map[int][]string)
 			files, err := os.ReadDir(f.path)
 			if err != nil {
 				f.logger.Error("error getting directory listing", "error", err)
 				return
 			}
-			// Inserting all the rotated files in a slice
+			// Inserting all the rotated files in a slice. A file may exist
+			// both compressed and uncompressed while it's being compressed.
 			for _, fi := range files {
-				if strings.HasPrefix(fi.Name(), f.baseFileName) {
-					fileIdx := strings.TrimPrefix(fi.Name(), fmt.Sprintf("%s.", f.baseFileName))
-					n, err := strconv.Atoi(fileIdx)
-					if err != nil {
-						f.logger.Error("error extracting file index", "error", err)
-						continue
-					}
+				n, _, ok := ParseLogFileName(fi.Name(), f.baseFileName)
+				if !ok {
+					continue
+				}
+				if _, seen := fNames[n]; !seen {
 					fIndexes = append(fIndexes, n)
 				}
+				fNames[n] = append(fNames[n], fi.Name())
 			}
 
 			// Not continuing to delete files if the number of files is not more
@@ -306,10 +370,12 @@ func (f *FileRotator) purgeOldFiles() {
 			sort.Ints(fIndexes)
 			toDelete := fIndexes[0 : len(fIndexes)-f.MaxFiles]
 			for _, fIndex := range toDelete {
-				fname := filepath.Join(f.path, fmt.Sprintf("%s.%d", f.baseFileName, fIndex))
-				err := os.RemoveAll(fname)
-				if err != nil {
-					f.logger.Error("error removing file", "filename", fname, "error", err)
+				for _, name := range fNames[fIndex] {
+					fname := filepath.Join(f.path, name)
+					err := os.RemoveAll(fname)
+					if err != nil {
+						f.logger.Error("error removing file", "filename", fname, "error", err)
+					}
 				}
 			}
 
@@ -4,11 +4,15 @@
 package logging
 
 import (
+	"bytes"
+	"compress/gzip"
 	"fmt"
+	"io"
 	"math/rand"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/testutil"
@@ -275,6 +279,159 @@ func TestFileRotator_PurgeOldFiles(t *testing.T) {
 	})
 }
 
+func TestFileRotator_RotateOnInterval(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	path := t.TempDir()
+
+	fr, err := NewFileRotator(path, baseFileName, 10, 1024, testlog.HCLogger(t))
+	must.NoError(t, err)
+	defer fr.Close()
+	fr.RotationInterval = 50 * time.Millisecond
+
+	_, err = fr.Write([]byte("abc\n"))
+	must.NoError(t, err)
+
+	// The file isn't rotated until it's written to after the interval
+	time.Sleep(100 * time.Millisecond)
+	_, err = os.Stat(filepath.Join(path, "redis.stdout.1"))
+	must.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = fr.Write([]byte("def\n"))
+	must.NoError(t, err)
+
+	testutil.WaitForResult(func() (bool, error) {
+		for fname, expected := range map[string]string{
+			"redis.stdout.0": "abc\n",
+			"redis.stdout.1": "def\n",
+		} {
+			b, err := os.ReadFile(filepath.Join(path, fname))
+			if err != nil {
+				return false, fmt.Errorf("failed to read file %v: %w", fname, err)
+			}
+			if string(b) != expected {
+				return false, fmt.Errorf("expected %q in %v, got: %q", expected, fname, b)
+			}
+		}
+		return true, nil
+	}, func(err error) {
+		must.NoError(t, err)
+	})
+}
+
+func TestFileRotator_CompressRotated(t *testing.T) {
+	for _, tc := range []struct {
+		compression string
+		ext         string
+	}{
+		{compression: CompressionGzip, ext: ".gz"},
+		{compression: CompressionZstd, ext: ".zst"},
+	} {
+		t.Run(tc.compression, func(t *testing.T) {
+			defer goleak.VerifyNone(t)
+
+			path := t.TempDir()
+
+			fr, err := NewFileRotator(path, baseFileName, 10, 5, testlog.HCLogger(t))
+			must.NoError(t, err)
+			fr.Compression = tc.compression
+
+			str := "abcdefgh"
+			nw, err := fr.Write([]byte(str))
+			must.NoError(t, err)
+			must.Eq(t, len(str), nw)
+
+			// Close waits for the rotated file to be compressed
+			must.NoError(t, fr.Close())
+
+			_, err = os.Stat(filepath.Join(path, "redis.stdout.0"))
+			must.ErrorIs(t, err, os.ErrNotExist)
+
+			name := "redis.stdout.0" + tc.ext
+			idx, compression, ok := ParseLogFileName(name, baseFileName)
+			must.True(t, ok)
+			must.Eq(t, 0, idx)
+			must.Eq(t, tc.compression, compression)
+
+			f, err := os.Open(filepath.Join(path, name))
+			must.NoError(t, err)
+			defer f.Close()
+			r, err := NewDecompressReader(f, compression)
+			must.NoError(t, err)
+			defer r.Close()
+			b, err := io.ReadAll(r)
+			must.NoError(t, err)
+			must.Eq(t, "abcde", string(b))
+
+			info, err := f.Stat()
+			must.NoError(t, err)
+			size, err := DecompressedSize(func(offset int64) (io.ReadCloser, error) {
+				f, err := os.Open(filepath.Join(path, name))
+				if err != nil {
+					return nil, err
+				}
+				_, err = f.Seek(offset, io.SeekStart)
+				return f, err
+			}, info.Size(), compression)
+			must.NoError(t, err)
+			must.Eq(t, 5, size)
+
+			// Only the compressed file is left in the log dir
+			entries, err := os.ReadDir(path)
+			must.NoError(t, err)
+			for _, e := range entries {
+				must.StrNotHasPrefix(t, ".", e.Name())
+			}
+
+			// A restarted rotator doesn't append to the compressed file
+			fr, err = NewFileRotator(path, baseFileName, 10, 5, testlog.HCLogger(t))
+			must.NoError(t, err)
+			defer fr.Close()
+			must.Eq(t, filepath.Join(path, "redis.stdout.1"), fr.currentFile.Name())
+		})
+	}
+}
+
+func TestDecompressedSize_Gzip(t *testing.T) {
+	open := func(b []byte) func(int64) (io.ReadCloser, error) {
+		return func(offset int64) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(b[offset:])), nil
+		}
+	}
+
+	// The size recorded in the header is used rather than the size in the
+	// trailer, which wraps for files larger than 4GiB
+	var buf bytes.Buffer
+	enc := gzip.NewWriter(&buf)
+	enc.Extra = gzipSizeExtra(1<<32 + 5)
+	_, err := enc.Write([]byte("abcde"))
+	must.NoError(t, err)
+	must.NoError(t, enc.Close())
+
+	size, err := DecompressedSize(open(buf.Bytes()), int64(buf.Len()), CompressionGzip)
+	must.NoError(t, err)
+	must.Eq(t, 1<<32+5, size)
+
+	// Files compressed without a recorded size are decompressed to count it
+	buf.Reset()
+	enc = gzip.NewWriter(&buf)
+	enc.Extra = []byte{'X', 'Y', 2, 0, 1, 2}
+	_, err = enc.Write([]byte("abcdefg"))
+	must.NoError(t, err)
+	must.NoError(t, enc.Close())
+
+	size, err = DecompressedSize(open(buf.Bytes()), int64(buf.Len()), CompressionGzip)
+	must.NoError(t, err)
+	must.Eq(t, 7, size)
+
+	// compressTo records the size it compressed
+	buf.Reset()
+	must.NoError(t, compressTo(&buf, bytes.NewReader([]byte("abcdefgh")), 6, CompressionGzip))
+	size, err = DecompressedSize(open(buf.Bytes()), int64(buf.Len()), CompressionGzip)
+	must.NoError(t, err)
+	must.Eq(t, 6, size)
+}
+
 func BenchmarkRotator(b *testing.B) {
 	kb := 1024
 	for _, inputSize := range []int{kb, 2 * kb, 4 * kb, 8 * kb, 16 * kb, 32 * kb, 64 * kb, 128 * kb, 256 * kb} {
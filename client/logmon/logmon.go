@@ -44,11 +44,29 @@ type LogConfig struct {
 
 	// MaxFileSizeMB is the max log file size in MB allowed before rotation occures
 	MaxFileSizeMB int
+
+	// RotationInterval is how long a log file is written to before it's
+	// rotated. Zero disables time based rotation.
+	RotationInterval time.Duration
+
+	// Compression is the algorithm rotated log files are compressed with
+	Compression string
+
+	// Metadata identifies the task in the log lines sent to sinks
+	Metadata LogMetadata
+
+	// Sinks are the destinations log lines are forwarded to in addition to
+	// the log files
+	Sinks []*LogSinkConfig
 }
 
 type LogMon interface {
 	Start(*LogConfig) error
 	Stop() error
+
+	// Stats returns the counters of the log sinks of the running task
+	// logger.
+	Stats() ([]*SinkStats, error)
 }
 
 func NewLogMon(logger hclog.Logger) LogMon {
@@ -101,6 +119,15 @@ func (l *logmonImpl) Stop() error {
 	return nil
 }
 
+func (l *logmonImpl) Stats() ([]*SinkStats, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.tl == nil {
+		return nil, nil
+	}
+	return l.tl.SinkStats(), nil
+}
+
 type TaskLogger struct {
 	config *LogConfig
 
@@ -109,6 +136,18 @@ type TaskLogger struct {
 
 	// rotator for stderr
 	lre *logRotatorWrapper
+
+	// sinks the stdout and stderr lines are forwarded to
+	sinks []*logSink
+}
+
+// SinkStats returns the counters of the task logger's sinks.
+func (tl *TaskLogger) SinkStats() []*SinkStats {
+	stats := make([]*SinkStats, 0, len(tl.sinks))
+	for _, s := range tl.sinks {
+		stats = append(stats, s.stats())
+	}
+	return stats
 }
 
 // IsRunning will return true as long as one rotator wrapper is still running
@@ -136,19 +175,38 @@ func (tl *TaskLogger) Close() {
 		}()
 	}
 	wg.Wait()
+
+	// Close the sinks once the streams are closed, so the lines that were
+	// buffered are sent.
+	for _, s := range tl.sinks {
+		s.Close()
+	}
 }
 
 func NewTaskLogger(cfg *LogConfig, logger hclog.Logger) (*TaskLogger, error) {
 	tl := &TaskLogger{config: cfg}
 
+	for _, sinkCfg := range cfg.Sinks {
+		sink, err := newLogSink(sinkCfg, &cfg.Metadata, logger)
+		if err != nil {
+			for _, s := range tl.sinks {
+				s.Close()
+			}
+			return nil, fmt.Errorf("failed to create %s log sink: %v", sinkCfg.Type, err)
+		}
+		tl.sinks = append(tl.sinks, sink)
+	}
+
 	logFileSize := int64(cfg.MaxFileSizeMB * 1024 * 1024)
 	lro, err := logging.NewFileRotator(cfg.LogDir, cfg.StdoutLogFile,
 		cfg.MaxFiles, logFileSize, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create stdout logfile for %q: %v", cfg.StdoutLogFile, err)
 	}
+	lro.RotationInterval = cfg.RotationInterval
+	lro.Compression = cfg.Compression
 
-	wrapperOut, err := newLogRotatorWrapper(cfg.StdoutFifo, logger, lro)
+	wrapperOut, err := newLogRotatorWrapper(cfg.StdoutFifo, logger, lro, tl.lineWriter("stdout"))
 	if err != nil {
 		return nil, err
 	}
@@ -160,8 +218,10 @@ func NewTaskLogger(cfg *LogConfig, logger hclog.Logger) (*TaskLogger, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create stderr logfile for %q: %v", cfg.StderrLogFile, err)
 	}
+	lre.RotationInterval = cfg.RotationInterval
+	lre.Compression = cfg.Compression
 
-	wrapperErr, err := newLogRotatorWrapper(cfg.StderrFifo, logger, lre)
+	wrapperErr, err := newLogRotatorWrapper(cfg.StderrFifo, logger, lre, tl.lineWriter("stderr"))
 	if err != nil {
 		return nil, err
 	}
@@ -172,12 +232,22 @@ func NewTaskLogger(cfg *LogConfig, logger hclog.Logger) (*TaskLogger, error) {
 
 }
 
+// lineWriter returns the writer that forwards the lines of the stream to the
+// sinks, or nil if there are no sinks.
+func (tl *TaskLogger) lineWriter(stream string) *lineWriter {
+	if len(tl.sinks) == 0 {
+		return nil
+	}
+	return newLineWriter(stream, tl.sinks)
+}
+
 // logRotatorWrapper wraps our log rotator and exposes a pipe that can feed the
 // log rotator data. The processOutWriter should be attached to the process and
 // data will be copied from the reader to the rotator.
 type logRotatorWrapper struct {
 	fifoPath          string
 	rotatorWriter     io.WriteCloser
+	lineWriter        *lineWriter
 	hasFinishedCopied chan struct{}
 	logger            hclog.Logger
 
@@ -196,8 +266,9 @@ func (l *logRotatorWrapper) isRunning() bool {
 }
 
 // newLogRotatorWrapper takes a rotator and returns a wrapper that has the
-// processOutWriter to attach to the stdout or stderr of a process.
-func newLogRotatorWrapper(path string, logger hclog.Logger, rotator io.WriteCloser) (*logRotatorWrapper, error) {
+// processOutWriter to attach to the stdout or stderr of a process. If lines is
+// set the output is also forwarded to it.
+func newLogRotatorWrapper(path string, logger hclog.Logger, rotator io.WriteCloser, lines *lineWriter) (*logRotatorWrapper, error) {
 	logger.Debug("opening fifo", "path", path)
 
 	var openFn func() (io.ReadCloser, error)
@@ -220,6 +291,7 @@ func newLogRotatorWrapper(path string, logger hclog.Logger, rotator io.WriteClos
 	wrap := &logRotatorWrapper{
 		fifoPath:          path,
 		rotatorWriter:     rotator,
+		lineWriter:        lines,
 		hasFinishedCopied: make(chan struct{}),
 		openCompleted:     make(chan struct{}),
 		logger:            logger,
@@ -243,7 +315,13 @@ func (l *logRotatorWrapper) start(openFn func() (io.ReadCloser, error)) {
 		l.processOutReader = reader
 		close(l.openCompleted)
 
-		_, err = io.Copy(l.rotatorWriter, reader)
+		var w io.Writer = l.rotatorWriter
+		if l.lineWriter != nil {
+			w = io.MultiWriter(l.rotatorWriter, l.lineWriter)
+			defer l.lineWriter.Flush()
+		}
+
+		_, err = io.Copy(w, reader)
 		if err != nil {
 			l.logger.Warn("failed to read from log fifo", "error", err)
 			// Close reader to propagate io error across pipe.
@@ -300,7 +300,7 @@ func TestLogmon_NewError(t *testing.T) {
 	// No code that uses the writer should get hit
 	rotator := panicWriter{}
 
-	w, err := newLogRotatorWrapper(path, logger, rotator)
+	w, err := newLogRotatorWrapper(path, logger, rotator, nil)
 	must.Error(t, err)
 	must.Nil(t, w)
 }
@@ -5,6 +5,7 @@ package logmon
 
 import (
 	"context"
+	"time"
 
 	"github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/nomad/client/logmon/proto"
@@ -17,13 +18,30 @@ type logmonServer struct {
 
 func (s *logmonServer) Start(ctx context.Context, req *proto.StartRequest) (*proto.StartResponse, error) {
 	cfg := &LogConfig{
-		LogDir:        req.LogDir,
-		StdoutLogFile: req.StdoutFileName,
-		StderrLogFile: req.StderrFileName,
-		MaxFiles:      int(req.MaxFiles),
-		MaxFileSizeMB: int(req.MaxFileSizeMb),
-		StdoutFifo:    req.StdoutFifo,
-		StderrFifo:    req.StderrFifo,
+		LogDir:           req.LogDir,
+		StdoutLogFile:    req.StdoutFileName,
+		StderrLogFile:    req.StderrFileName,
+		MaxFiles:         int(req.MaxFiles),
+		MaxFileSizeMB:    int(req.MaxFileSizeMb),
+		RotationInterval: time.Duration(req.RotationIntervalNs),
+		Compression:      req.Compression,
+		StdoutFifo:       req.StdoutFifo,
+		StderrFifo:       req.StderrFifo,
+		Metadata: LogMetadata{
+			AllocID:   req.AllocId,
+			JobID:     req.JobId,
+			Namespace: req.Namespace,
+			GroupName: req.GroupName,
+			TaskName:  req.TaskName,
+		},
+	}
+	for _, sink := range req.Sinks {
+		cfg.Sinks = append(cfg.Sinks, &LogSinkConfig{
+			Type:       sink.Type,
+			Address:    sink.Address,
+			Headers:    sink.Headers,
+			BufferSize: int(sink.BufferSize),
+		})
 	}
 
 	err := s.impl.Start(cfg)
@@ -37,3 +55,22 @@ func (s *logmonServer) Start(ctx context.Context, req *proto.StartRequest) (*pro
 func (s *logmonServer) Stop(ctx context.Context, req *proto.StopRequest) (*proto.StopResponse, error) {
 	return &proto.StopResponse{}, s.impl.Stop()
 }
+
+func (s *logmonServer) Stats(ctx context.Context, req *proto.StatsRequest) (*proto.StatsResponse, error) {
+	stats, err := s.impl.Stats()
+	if err != nil {
+		return nil, err
+	}
+	resp := &proto.StatsResponse{}
+	for _, st := range stats {
+		resp.Sinks = append(resp.Sinks, &proto.SinkStats{
+			Type:     st.Type,
+			Address:  st.Address,
+			Sent:     st.Sent,
+			Dropped:  st.Dropped,
+			Failed:   st.Failed,
+			Buffered: uint32(st.Buffered),
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,105 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package logmon
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// defaultJournaldAddress is the path of the journald native protocol socket.
+const defaultJournaldAddress = "/run/systemd/journal/socket"
+
+// journaldWriter sends log lines to journald using its native protocol, with
+// one datagram per entry. Entries that are too large for a datagram are
+// written to a sealed memfd whose descriptor is sent instead.
+type journaldWriter struct {
+	address string
+	fields  [][2]string
+
+	conn net.Conn
+}
+
+func newJournaldWriter(cfg *LogSinkConfig, meta *LogMetadata) (*journaldWriter, error) {
+	address := cfg.Address
+	if address == "" {
+		address = defaultJournaldAddress
+	}
+	return &journaldWriter{
+		address: address,
+		fields: [][2]string{
+			{"SYSLOG_IDENTIFIER", meta.TaskName},
+			{"NOMAD_ALLOC_ID", meta.AllocID},
+			{"NOMAD_JOB_ID", meta.JobID},
+			{"NOMAD_NAMESPACE", meta.Namespace},
+			{"NOMAD_GROUP_NAME", meta.GroupName},
+			{"NOMAD_TASK_NAME", meta.TaskName},
+		},
+	}, nil
+}
+
+func (w *journaldWriter) write(lines []*logLine) error {
+	if w.conn == nil {
+		conn, err := net.Dial("unixgram", w.address)
+		if err != nil {
+			return err
+		}
+		w.conn = conn
+	}
+
+	for _, l := range lines {
+		entry := w.entry(l)
+		_, err := w.conn.Write(entry)
+		if errors.Is(err, syscall.EMSGSIZE) || errors.Is(err, syscall.ENOBUFS) {
+			err = writeJournaldMemfd(w.conn, entry)
+		}
+		if err != nil {
+			w.Close()
+			return err
+		}
+	}
+	return nil
+}
+
+// entry returns the native protocol serialization of the log line.
+func (w *journaldWriter) entry(l *logLine) []byte {
+	var buf bytes.Buffer
+	writeJournaldField(&buf, "MESSAGE", string(l.line))
+	writeJournaldField(&buf, "PRIORITY", strconv.Itoa(severity(l.stream)))
+	writeJournaldField(&buf, "NOMAD_LOG_STREAM", l.stream)
+	for _, f := range w.fields {
+		writeJournaldField(&buf, f[0], f[1])
+	}
+	return buf.Bytes()
+}
+
+func (w *journaldWriter) Close() error {
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
+
+// writeJournaldField writes a field in the native protocol format. Values
+// with newlines are written with an explicit length.
+func writeJournaldField(buf *bytes.Buffer, key, value string) {
+	buf.WriteString(key)
+	if !strings.Contains(value, "\n") {
+		buf.WriteByte('=')
+		buf.WriteString(value)
+		buf.WriteByte('\n')
+		return
+	}
+	buf.WriteByte('\n')
+	_ = binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
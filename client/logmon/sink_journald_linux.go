@@ -0,0 +1,56 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package logmon
+
+import (
+	"fmt"
+	"net"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// writeJournaldMemfd sends an entry that's too large for a datagram by writing
+// it to a sealed memfd and passing its descriptor to journald in an empty
+// datagram, as described by the journald native protocol.
+func writeJournaldMemfd(conn net.Conn, entry []byte) error {
+	uconn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return fmt.Errorf("journald connection is a %T, not a unix socket", conn)
+	}
+
+	fd, err := unix.MemfdCreate("journald-entry", unix.MFD_CLOEXEC|unix.MFD_ALLOW_SEALING)
+	if err != nil {
+		return fmt.Errorf("failed to create memfd: %w", err)
+	}
+	f := os.NewFile(uintptr(fd), "journald-entry")
+	defer f.Close()
+
+	if _, err := f.Write(entry); err != nil {
+		return fmt.Errorf("failed to write memfd: %w", err)
+	}
+	seals := unix.F_SEAL_SHRINK | unix.F_SEAL_GROW | unix.F_SEAL_WRITE | unix.F_SEAL_SEAL
+	if _, err := unix.FcntlInt(f.Fd(), unix.F_ADD_SEALS, seals); err != nil {
+		return fmt.Errorf("failed to seal memfd: %w", err)
+	}
+
+	// WriteMsgUnix rejects connected datagram sockets, so the descriptor is
+	// sent on the raw connection.
+	raw, err := uconn.SyscallConn()
+	if err != nil {
+		return err
+	}
+	rights := unix.UnixRights(int(f.Fd()))
+	var sendErr error
+	err = raw.Write(func(sock uintptr) bool {
+		sendErr = unix.Sendmsg(int(sock), nil, rights, nil, 0)
+		return sendErr != unix.EAGAIN
+	})
+	if err != nil {
+		return err
+	}
+	return sendErr
+}
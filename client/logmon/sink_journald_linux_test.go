@@ -0,0 +1,63 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package logmon
+
+import (
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+	"golang.org/x/sys/unix"
+)
+
+func TestJournaldWriter_Memfd(t *testing.T) {
+	ci.Parallel(t)
+
+	path := filepath.Join(t.TempDir(), "sock")
+	lconn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	must.NoError(t, err)
+	t.Cleanup(func() { lconn.Close() })
+
+	w, err := newJournaldWriter(&LogSinkConfig{Type: "journald", Address: path}, testLogMetadata)
+	must.NoError(t, err)
+	t.Cleanup(func() { w.Close() })
+
+	// Shrink the send buffer so the entry doesn't fit in a datagram
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: path, Net: "unixgram"})
+	must.NoError(t, err)
+	must.NoError(t, conn.SetWriteBuffer(4096))
+	w.conn = conn
+
+	line := strings.Repeat("x", sinkMaxLineSize)
+	must.NoError(t, w.write([]*logLine{{stream: "stdout", line: []byte(line)}}))
+
+	// The entry is received as an empty datagram with the memfd it was
+	// written to
+	buf := make([]byte, 1024)
+	oob := make([]byte, unix.CmsgSpace(4))
+	n, oobn, _, _, err := lconn.ReadMsgUnix(buf, oob)
+	must.NoError(t, err)
+	must.Zero(t, n)
+
+	msgs, err := unix.ParseSocketControlMessage(oob[:oobn])
+	must.NoError(t, err)
+	must.Len(t, 1, msgs)
+	fds, err := unix.ParseUnixRights(&msgs[0])
+	must.NoError(t, err)
+	must.Len(t, 1, fds)
+
+	f := os.NewFile(uintptr(fds[0]), "entry")
+	defer f.Close()
+	entry, err := io.ReadAll(io.NewSectionReader(f, 0, 2*sinkMaxLineSize))
+	must.NoError(t, err)
+	must.True(t, strings.Contains(string(entry), "MESSAGE="+line+"\n"))
+	must.StrContains(t, string(entry), "NOMAD_ALLOC_ID="+testLogMetadata.AllocID+"\n")
+}
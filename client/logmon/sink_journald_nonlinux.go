@@ -0,0 +1,16 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+
+package logmon
+
+import (
+	"errors"
+	"net"
+)
+
+// writeJournaldMemfd is only supported on linux, where journald runs.
+func writeJournaldMemfd(net.Conn, []byte) error {
+	return errors.New("journald entries larger than a datagram are only supported on linux")
+}
@@ -0,0 +1,148 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package logmon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// otlpRequestTimeout is the timeout of a request to an OTLP logs endpoint.
+const otlpRequestTimeout = 10 * time.Second
+
+// otlpWriter sends log lines to an OTLP/HTTP logs endpoint using the JSON
+// encoding.
+type otlpWriter struct {
+	address  string
+	headers  map[string]string
+	resource otlpResource
+	client   *http.Client
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano         string         `json:"timeUnixNano"`
+	ObservedTimeUnixNano string         `json:"observedTimeUnixNano"`
+	SeverityNumber       int            `json:"severityNumber"`
+	SeverityText         string         `json:"severityText"`
+	Body                 otlpAnyValue   `json:"body"`
+	Attributes           []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpScopeLogs struct {
+	Scope      otlpScope       `json:"scope"`
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type otlpResourceLogs struct {
+	Resource  otlpResource    `json:"resource"`
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpLogsRequest struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+func newOTLPWriter(cfg *LogSinkConfig, meta *LogMetadata) (*otlpWriter, error) {
+	if cfg.Address == "" {
+		return nil, fmt.Errorf("otlp sink requires an address")
+	}
+	attr := func(k, v string) otlpKeyValue {
+		return otlpKeyValue{Key: k, Value: otlpAnyValue{StringValue: v}}
+	}
+	return &otlpWriter{
+		address: cfg.Address,
+		headers: cfg.Headers,
+		resource: otlpResource{Attributes: []otlpKeyValue{
+			attr("service.name", meta.JobID),
+			attr("nomad.alloc_id", meta.AllocID),
+			attr("nomad.job_id", meta.JobID),
+			attr("nomad.namespace", meta.Namespace),
+			attr("nomad.group_name", meta.GroupName),
+			attr("nomad.task_name", meta.TaskName),
+		}},
+		client: &http.Client{Timeout: otlpRequestTimeout},
+	}, nil
+}
+
+func (w *otlpWriter) write(lines []*logLine) error {
+	now := strconv.FormatInt(time.Now().UnixNano(), 10)
+	records := make([]otlpLogRecord, 0, len(lines))
+	for _, l := range lines {
+		// Severity numbers are INFO and ERROR from the OpenTelemetry log
+		// data model.
+		number, text := 9, "INFO"
+		if l.stream == "stderr" {
+			number, text = 17, "ERROR"
+		}
+		records = append(records, otlpLogRecord{
+			TimeUnixNano:         strconv.FormatInt(l.time.UnixNano(), 10),
+			ObservedTimeUnixNano: now,
+			SeverityNumber:       number,
+			SeverityText:         text,
+			Body:                 otlpAnyValue{StringValue: string(l.line)},
+			Attributes: []otlpKeyValue{
+				{Key: "log.iostream", Value: otlpAnyValue{StringValue: l.stream}},
+			},
+		})
+	}
+
+	body, err := json.Marshal(otlpLogsRequest{ResourceLogs: []otlpResourceLogs{{
+		Resource: w.resource,
+		ScopeLogs: []otlpScopeLogs{{
+			Scope:      otlpScope{Name: "nomad.logmon"},
+			LogRecords: records,
+		}},
+	}}})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, w.address, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range w.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (w *otlpWriter) Close() error {
+	w.client.CloseIdleConnections()
+	return nil
+}
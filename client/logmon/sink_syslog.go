@@ -0,0 +1,152 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package logmon
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultSyslogAddress is the address of the local syslog daemon.
+	defaultSyslogAddress = "unixgram:///dev/log"
+
+	// syslogFacility is the user-level messages facility.
+	syslogFacility = 1
+
+	// syslogSDID is the structured data ID of the task metadata. 32473 is the
+	// private enterprise number reserved for documentation.
+	syslogSDID = "nomad@32473"
+
+	// syslogDialTimeout is the timeout for connecting to the syslog server.
+	syslogDialTimeout = 5 * time.Second
+)
+
+// syslogWriter sends log lines as RFC5424 messages. Stream sockets use octet
+// counting framing from RFC6587, and datagram sockets send one message per
+// datagram.
+type syslogWriter struct {
+	network  string
+	address  string
+	stream   bool
+	hostname string
+	appName  string
+	sd       string
+
+	conn net.Conn
+}
+
+func newSyslogWriter(cfg *LogSinkConfig, meta *LogMetadata) (*syslogWriter, error) {
+	address := cfg.Address
+	if address == "" {
+		address = defaultSyslogAddress
+	}
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid syslog address: %w", err)
+	}
+
+	w := &syslogWriter{network: u.Scheme}
+	switch u.Scheme {
+	case "tcp", "udp":
+		w.address = u.Host
+	case "unix", "unixgram":
+		w.address = u.Path
+	default:
+		return nil, fmt.Errorf("unsupported syslog address scheme %q", u.Scheme)
+	}
+	w.stream = u.Scheme == "tcp" || u.Scheme == "unix"
+
+	w.hostname, _ = os.Hostname()
+	w.hostname = syslogHeaderField(w.hostname, 255)
+	w.appName = syslogHeaderField(meta.TaskName, 48)
+	w.sd = fmt.Sprintf(`[%s alloc_id="%s" job_id="%s" namespace="%s" group="%s" task="%s"]`,
+		syslogSDID,
+		syslogParamValue(meta.AllocID),
+		syslogParamValue(meta.JobID),
+		syslogParamValue(meta.Namespace),
+		syslogParamValue(meta.GroupName),
+		syslogParamValue(meta.TaskName))
+	return w, nil
+}
+
+func (w *syslogWriter) write(lines []*logLine) error {
+	if w.conn == nil {
+		conn, err := net.DialTimeout(w.network, w.address, syslogDialTimeout)
+		if err != nil {
+			return err
+		}
+		w.conn = conn
+	}
+
+	var buf bytes.Buffer
+	for _, l := range lines {
+		msg := w.format(l)
+		if w.stream {
+			fmt.Fprintf(&buf, "%d %s", len(msg), msg)
+			continue
+		}
+		if _, err := w.conn.Write([]byte(msg)); err != nil {
+			w.Close()
+			return err
+		}
+	}
+
+	if buf.Len() > 0 {
+		if _, err := w.conn.Write(buf.Bytes()); err != nil {
+			w.Close()
+			return err
+		}
+	}
+	return nil
+}
+
+// format returns the RFC5424 message of the log line.
+func (w *syslogWriter) format(l *logLine) string {
+	return fmt.Sprintf("<%d>1 %s %s %s - %s %s %s",
+		syslogFacility*8+severity(l.stream),
+		l.time.UTC().Format(time.RFC3339Nano),
+		w.hostname,
+		w.appName,
+		l.stream,
+		w.sd,
+		l.line)
+}
+
+func (w *syslogWriter) Close() error {
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
+
+// syslogHeaderField returns the value as a header field, which must be
+// printable ASCII without spaces and no longer than maxLen.
+func syslogHeaderField(value string, maxLen int) string {
+	value = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return -1
+		}
+		return r
+	}, value)
+	if value == "" {
+		return "-"
+	}
+	if len(value) > maxLen {
+		value = value[:maxLen]
+	}
+	return value
+}
+
+// syslogParamValue escapes the value of a structured data parameter.
+func syslogParamValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value)
+}
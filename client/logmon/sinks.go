@@ -0,0 +1,321 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package logmon
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+)
+
+const (
+	// defaultSinkBufferSize is the number of log lines buffered for a sink if
+	// the sink doesn't set a buffer size.
+	defaultSinkBufferSize = 1024
+
+	// sinkBatchSize is the maximum number of log lines sent to a sink at once.
+	sinkBatchSize = 128
+
+	// sinkMaxLineSize is the maximum size of a log line sent to a sink. Longer
+	// lines are split.
+	sinkMaxLineSize = 64 * 1024
+
+	// sinkCloseTimeout is how long closing a sink waits for the buffered log
+	// lines to be sent.
+	sinkCloseTimeout = 5 * time.Second
+
+	// sinkRetryInterval is how long a sink waits before sending a batch of log
+	// lines again after it failed to send it. The interval doubles after each
+	// failure, up to sinkMaxRetryInterval.
+	sinkRetryInterval = time.Second
+
+	// sinkMaxRetryInterval is the maximum interval between attempts to send a
+	// batch of log lines.
+	sinkMaxRetryInterval = 30 * time.Second
+)
+
+// LogSinkConfig configures a destination that log lines are forwarded to, in
+// addition to the rotated log files.
+type LogSinkConfig struct {
+	// Type is one of syslog, journald or otlp
+	Type string
+
+	// Address is where the logs are sent, or the empty string for the sink's
+	// default address.
+	Address string
+
+	// Headers are added to the requests of otlp sinks
+	Headers map[string]string
+
+	// BufferSize is the number of log lines buffered before lines are dropped
+	BufferSize int
+}
+
+// LogMetadata identifies the task whose logs are forwarded to sinks.
+type LogMetadata struct {
+	AllocID   string
+	JobID     string
+	Namespace string
+	GroupName string
+	TaskName  string
+}
+
+// SinkStats are the counters of a log sink since it was started.
+type SinkStats struct {
+	Type    string
+	Address string
+
+	// Sent is the number of log lines sent to the sink
+	Sent uint64
+
+	// Dropped is the number of log lines dropped because the buffer was full
+	Dropped uint64
+
+	// Failed is the number of log lines that couldn't be sent to the sink
+	// before it was closed
+	Failed uint64
+
+	// Buffered is the number of log lines waiting to be sent
+	Buffered int
+}
+
+// logLine is a single line of task output.
+type logLine struct {
+	time   time.Time
+	stream string
+	line   []byte
+}
+
+// sinkWriter sends log lines to a destination using its protocol.
+type sinkWriter interface {
+	write(lines []*logLine) error
+	Close() error
+}
+
+// newSinkWriter returns the sink writer for the sink type.
+func newSinkWriter(cfg *LogSinkConfig, meta *LogMetadata) (sinkWriter, error) {
+	switch cfg.Type {
+	case "syslog":
+		return newSyslogWriter(cfg, meta)
+	case "journald":
+		return newJournaldWriter(cfg, meta)
+	case "otlp":
+		return newOTLPWriter(cfg, meta)
+	}
+	return nil, fmt.Errorf("unknown log sink type %q", cfg.Type)
+}
+
+// logSink buffers log lines and sends them to a sink writer in the
+// background, so a slow or unavailable destination never blocks the task.
+// Lines are dropped once the buffer is full, and batches that fail to send
+// are retried until the sink is closed.
+type logSink struct {
+	cfg    *LogSinkConfig
+	writer sinkWriter
+	logger hclog.Logger
+
+	ch     chan *logLine
+	doneCh chan struct{}
+
+	// abortCh is closed when the sink gives up sending the buffered lines
+	// while closing.
+	abortCh chan struct{}
+
+	// closed is set once the sink is closed and guarded by lock, so lines
+	// aren't sent on the closed channel.
+	closed bool
+	lock   sync.RWMutex
+
+	sent    atomic.Uint64
+	dropped atomic.Uint64
+	failed  atomic.Uint64
+}
+
+func newLogSink(cfg *LogSinkConfig, meta *LogMetadata, logger hclog.Logger) (*logSink, error) {
+	writer, err := newSinkWriter(cfg, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	size := cfg.BufferSize
+	if size <= 0 {
+		size = defaultSinkBufferSize
+	}
+	s := &logSink{
+		cfg:     cfg,
+		writer:  writer,
+		logger:  logger.With("sink", cfg.Type),
+		ch:      make(chan *logLine, size),
+		doneCh:  make(chan struct{}),
+		abortCh: make(chan struct{}),
+	}
+	go s.run()
+	return s, nil
+}
+
+// enqueue buffers the log line, or drops it if the buffer is full.
+func (s *logSink) enqueue(l *logLine) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.closed {
+		return
+	}
+
+	select {
+	case s.ch <- l:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+func (s *logSink) run() {
+	defer close(s.doneCh)
+
+	batch := make([]*logLine, 0, sinkBatchSize)
+	for l := range s.ch {
+		batch = append(batch[:0], l)
+	DRAIN:
+		for len(batch) < sinkBatchSize {
+			select {
+			case l, ok := <-s.ch:
+				if !ok {
+					break DRAIN
+				}
+				batch = append(batch, l)
+			default:
+				break DRAIN
+			}
+		}
+
+		if !s.send(batch) {
+			// The sink gave up sending logs while closing, so the lines
+			// still buffered can't be sent either.
+			for range s.ch {
+				s.failed.Add(1)
+			}
+			return
+		}
+	}
+}
+
+// send writes the batch to the sink, retrying with backoff until it succeeds
+// or the sink gives up while closing. New log lines are buffered while the
+// batch is retried, and dropped once the buffer is full. It returns false if
+// the batch couldn't be sent.
+func (s *logSink) send(batch []*logLine) bool {
+	backoff := sinkRetryInterval
+	for {
+		err := s.writer.write(batch)
+		if err == nil {
+			s.sent.Add(uint64(len(batch)))
+			return true
+		}
+		s.logger.Warn("failed to send logs to sink",
+			"lines", len(batch), "retry_in", backoff, "error", err)
+
+		select {
+		case <-time.After(backoff):
+		case <-s.abortCh:
+			s.failed.Add(uint64(len(batch)))
+			return false
+		}
+		backoff = min(2*backoff, sinkMaxRetryInterval)
+	}
+}
+
+// stats returns the counters of the sink.
+func (s *logSink) stats() *SinkStats {
+	return &SinkStats{
+		Type:     s.cfg.Type,
+		Address:  s.cfg.Address,
+		Sent:     s.sent.Load(),
+		Dropped:  s.dropped.Load(),
+		Failed:   s.failed.Load(),
+		Buffered: len(s.ch),
+	}
+}
+
+// Close stops accepting log lines and waits for the buffered lines to be sent
+// before closing the writer.
+func (s *logSink) Close() {
+	s.lock.Lock()
+	if s.closed {
+		s.lock.Unlock()
+		return
+	}
+	s.closed = true
+	close(s.ch)
+	s.lock.Unlock()
+
+	select {
+	case <-s.doneCh:
+	case <-time.After(sinkCloseTimeout):
+		s.logger.Warn("timed out sending buffered logs to sink", "lines", len(s.ch))
+		close(s.abortCh)
+	}
+	s.writer.Close()
+}
+
+// lineWriter splits the output of a stream into lines and forwards them to
+// the sinks.
+type lineWriter struct {
+	stream string
+	sinks  []*logSink
+	buf    []byte
+}
+
+func newLineWriter(stream string, sinks []*logSink) *lineWriter {
+	return &lineWriter{stream: stream, sinks: sinks}
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			w.buf = append(w.buf, p...)
+			for len(w.buf) >= sinkMaxLineSize {
+				w.emit(w.buf[:sinkMaxLineSize])
+				w.buf = w.buf[sinkMaxLineSize:]
+			}
+			break
+		}
+		w.buf = append(w.buf, p[:i]...)
+		w.emit(w.buf)
+		w.buf = w.buf[:0]
+		p = p[i+1:]
+	}
+	return n, nil
+}
+
+// Flush forwards the partial line that's buffered, if any.
+func (w *lineWriter) Flush() {
+	if len(w.buf) > 0 {
+		w.emit(w.buf)
+		w.buf = nil
+	}
+}
+
+func (w *lineWriter) emit(line []byte) {
+	l := &logLine{
+		time:   time.Now(),
+		stream: w.stream,
+		line:   bytes.TrimSuffix(bytes.Clone(line), []byte("\r")),
+	}
+	for _, s := range w.sinks {
+		s.enqueue(l)
+	}
+}
+
+// severity returns the syslog severity of the stream.
+func severity(stream string) int {
+	if stream == "stderr" {
+		return 3 // err
+	}
+	return 6 // info
+}
@@ -0,0 +1,354 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package logmon
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/lib/fifo"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+var testLogMetadata = &LogMetadata{
+	AllocID:   "7b3d6a4e-0c41-4ae0-bd0a-9a1d1f0e3c11",
+	JobID:     "web",
+	Namespace: "default",
+	GroupName: "group",
+	TaskName:  "server",
+}
+
+// testUnixgramListener listens on a unix datagram socket in a temporary
+// directory and returns the socket path and a channel of received datagrams.
+func testUnixgramListener(t *testing.T) (string, <-chan string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("unix datagram sockets are not supported on windows")
+	}
+
+	path := filepath.Join(t.TempDir(), "sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	must.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	ch := make(chan string, 16)
+	go func() {
+		buf := make([]byte, 64*1024)
+		for {
+			n, err := conn.Read(buf)
+			if err != nil {
+				return
+			}
+			ch <- string(buf[:n])
+		}
+	}()
+	return path, ch
+}
+
+func receive(t *testing.T, ch <-chan string) string {
+	t.Helper()
+	select {
+	case msg := <-ch:
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for log line")
+	}
+	return ""
+}
+
+func TestLogSink_Syslog(t *testing.T) {
+	ci.Parallel(t)
+
+	path, ch := testUnixgramListener(t)
+	sink, err := newLogSink(&LogSinkConfig{Type: "syslog", Address: "unixgram://" + path},
+		testLogMetadata, testlog.HCLogger(t))
+	must.NoError(t, err)
+
+	w := newLineWriter("stderr", []*logSink{sink})
+	_, err = w.Write([]byte("hello\nwor"))
+	must.NoError(t, err)
+	_, err = w.Write([]byte("ld\r\n"))
+	must.NoError(t, err)
+
+	msg := receive(t, ch)
+	must.StrHasPrefix(t, "<11>1 ", msg)
+	must.StrContains(t, msg, " server - stderr ")
+	must.StrContains(t, msg, `[nomad@32473 alloc_id="7b3d6a4e-0c41-4ae0-bd0a-9a1d1f0e3c11" job_id="web" namespace="default" group="group" task="server"] hello`)
+	must.StrHasSuffix(t, "] world", receive(t, ch))
+
+	sink.Close()
+	must.Eq(t, 2, sink.stats().Sent)
+}
+
+func TestLogSink_Syslog_TCP(t *testing.T) {
+	ci.Parallel(t)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	must.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	ch := make(chan string, 16)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		// Read messages with octet counting framing
+		r := bufio.NewReader(conn)
+		for {
+			size, err := r.ReadString(' ')
+			if err != nil {
+				return
+			}
+			n, _ := strconv.Atoi(strings.TrimSpace(size))
+			buf := make([]byte, n)
+			if _, err := io.ReadFull(r, buf); err != nil {
+				return
+			}
+			ch <- string(buf)
+		}
+	}()
+
+	sink, err := newLogSink(&LogSinkConfig{Type: "syslog", Address: "tcp://" + ln.Addr().String()},
+		testLogMetadata, testlog.HCLogger(t))
+	must.NoError(t, err)
+	t.Cleanup(sink.Close)
+
+	w := newLineWriter("stdout", []*logSink{sink})
+	_, err = w.Write([]byte("first line\nsecond line\n"))
+	must.NoError(t, err)
+
+	msg := receive(t, ch)
+	must.StrHasPrefix(t, "<14>1 ", msg)
+	must.StrHasSuffix(t, "] first line", msg)
+	must.StrHasSuffix(t, "] second line", receive(t, ch))
+}
+
+func TestLogSink_Journald(t *testing.T) {
+	ci.Parallel(t)
+
+	path, ch := testUnixgramListener(t)
+	sink, err := newLogSink(&LogSinkConfig{Type: "journald", Address: path},
+		testLogMetadata, testlog.HCLogger(t))
+	must.NoError(t, err)
+	t.Cleanup(sink.Close)
+
+	w := newLineWriter("stdout", []*logSink{sink})
+	_, err = w.Write([]byte("hello journal"))
+	must.NoError(t, err)
+	w.Flush()
+
+	entry := receive(t, ch)
+	must.StrContains(t, entry, "MESSAGE=hello journal\n")
+	must.StrContains(t, entry, "PRIORITY=6\n")
+	must.StrContains(t, entry, "SYSLOG_IDENTIFIER=server\n")
+	must.StrContains(t, entry, "NOMAD_ALLOC_ID=7b3d6a4e-0c41-4ae0-bd0a-9a1d1f0e3c11\n")
+	must.StrContains(t, entry, "NOMAD_JOB_ID=web\n")
+	must.StrContains(t, entry, "NOMAD_LOG_STREAM=stdout\n")
+}
+
+func TestLogSink_OTLP(t *testing.T) {
+	ci.Parallel(t)
+
+	ch := make(chan otlpLogsRequest, 16)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var req otlpLogsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		ch <- req
+	}))
+	t.Cleanup(srv.Close)
+
+	sink, err := newLogSink(&LogSinkConfig{
+		Type:    "otlp",
+		Address: srv.URL + "/v1/logs",
+		Headers: map[string]string{"Authorization": "Bearer secret"},
+	}, testLogMetadata, testlog.HCLogger(t))
+	must.NoError(t, err)
+	t.Cleanup(sink.Close)
+
+	w := newLineWriter("stderr", []*logSink{sink})
+	_, err = w.Write([]byte("something failed\n"))
+	must.NoError(t, err)
+
+	var req otlpLogsRequest
+	select {
+	case req = <-ch:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for request")
+	}
+
+	must.Len(t, 1, req.ResourceLogs)
+	must.SliceContains(t, req.ResourceLogs[0].Resource.Attributes,
+		otlpKeyValue{Key: "nomad.alloc_id", Value: otlpAnyValue{StringValue: testLogMetadata.AllocID}})
+	records := req.ResourceLogs[0].ScopeLogs[0].LogRecords
+	must.Len(t, 1, records)
+	must.Eq(t, "something failed", records[0].Body.StringValue)
+	must.Eq(t, 17, records[0].SeverityNumber)
+}
+
+// blockingWriter is a sink writer that blocks until it's unblocked.
+type blockingWriter struct {
+	unblockCh chan struct{}
+}
+
+func (w *blockingWriter) write([]*logLine) error {
+	<-w.unblockCh
+	return nil
+}
+
+func (w *blockingWriter) Close() error { return nil }
+
+func TestLogSink_Backpressure(t *testing.T) {
+	ci.Parallel(t)
+
+	writer := &blockingWriter{unblockCh: make(chan struct{})}
+	sink := &logSink{
+		cfg:     &LogSinkConfig{Type: "syslog"},
+		writer:  writer,
+		logger:  testlog.HCLogger(t),
+		ch:      make(chan *logLine, 4),
+		doneCh:  make(chan struct{}),
+		abortCh: make(chan struct{}),
+	}
+
+	// Writing to a full buffer drops lines without blocking
+	w := newLineWriter("stdout", []*logSink{sink})
+	for i := range 20 {
+		_, err := fmt.Fprintf(w, "line %d\n", i)
+		must.NoError(t, err)
+	}
+	stats := sink.stats()
+	must.Eq(t, 16, stats.Dropped)
+	must.Eq(t, 4, stats.Buffered)
+
+	go sink.run()
+	close(writer.unblockCh)
+	sink.Close()
+
+	stats = sink.stats()
+	must.Eq(t, 4, stats.Sent)
+	must.Eq(t, 0, stats.Buffered)
+}
+
+// failingWriter is a sink writer that fails the first writes and records the
+// lines of the writes that succeed.
+type failingWriter struct {
+	failures int
+	lines    chan string
+}
+
+func (w *failingWriter) write(lines []*logLine) error {
+	if w.failures > 0 {
+		w.failures--
+		return errors.New("sink unavailable")
+	}
+	for _, l := range lines {
+		w.lines <- string(l.line)
+	}
+	return nil
+}
+
+func (w *failingWriter) Close() error { return nil }
+
+func TestLogSink_Retry(t *testing.T) {
+	ci.Parallel(t)
+
+	writer := &failingWriter{failures: 1, lines: make(chan string, 16)}
+	sink := &logSink{
+		cfg:     &LogSinkConfig{Type: "syslog"},
+		writer:  writer,
+		logger:  testlog.HCLogger(t),
+		ch:      make(chan *logLine, 4),
+		doneCh:  make(chan struct{}),
+		abortCh: make(chan struct{}),
+	}
+
+	w := newLineWriter("stdout", []*logSink{sink})
+	for i := range 3 {
+		_, err := fmt.Fprintf(w, "line %d\n", i)
+		must.NoError(t, err)
+	}
+
+	// The batch that failed is sent again instead of being dropped
+	go sink.run()
+	for i := range 3 {
+		must.Eq(t, fmt.Sprintf("line %d", i), receive(t, writer.lines))
+	}
+	sink.Close()
+
+	stats := sink.stats()
+	must.Eq(t, 3, stats.Sent)
+	must.Eq(t, 0, stats.Failed)
+	must.Eq(t, 0, stats.Dropped)
+}
+
+func TestLogmon_Start_sinks(t *testing.T) {
+	ci.Parallel(t)
+
+	if runtime.GOOS == "windows" {
+		t.Skip("unix datagram sockets are not supported on windows")
+	}
+
+	path, ch := testUnixgramListener(t)
+	dir := t.TempDir()
+	cfg := &LogConfig{
+		LogDir:        dir,
+		StdoutLogFile: "stdout",
+		StdoutFifo:    filepath.Join(dir, "stdout.fifo"),
+		StderrLogFile: "stderr",
+		StderrFifo:    filepath.Join(dir, "stderr.fifo"),
+		MaxFiles:      2,
+		MaxFileSizeMB: 1,
+		Metadata:      *testLogMetadata,
+		Sinks:         []*LogSinkConfig{{Type: "journald", Address: path}},
+	}
+
+	lm := NewLogMon(testlog.HCLogger(t))
+	must.NoError(t, lm.Start(cfg))
+	t.Cleanup(func() { lm.Stop() })
+
+	stdout, err := fifo.OpenWriter(cfg.StdoutFifo)
+	must.NoError(t, err)
+	_, err = stdout.Write([]byte("to file and sink\n"))
+	must.NoError(t, err)
+
+	must.StrContains(t, receive(t, ch), "MESSAGE=to file and sink\n")
+	must.Wait(t, wait.InitialSuccess(wait.ErrorFunc(func() error {
+		stats, err := lm.Stats()
+		if err != nil {
+			return err
+		}
+		if len(stats) != 1 || stats[0].Sent != 1 {
+			return fmt.Errorf("unexpected stats %#v", stats)
+		}
+		return nil
+	}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+}
@@ -0,0 +1,235 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+	metrics "github.com/hashicorp/go-metrics/compat"
+
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/client/lib/cgroupslib"
+	"github.com/hashicorp/nomad/helper"
+)
+
+const (
+	// memoryPressureInterval is how often the memory pressure of the node is
+	// checked.
+	memoryPressureInterval = 5 * time.Second
+
+	// memoryPressureCooldown is how long to wait after evicting an allocation
+	// before checking the memory pressure again, so that the memory of the
+	// evicted allocation has been released.
+	memoryPressureCooldown = 30 * time.Second
+)
+
+// memoryPressureWatcher watches the memory pressure and memory events of the
+// cgroup holding every allocation on the node. When the node runs out of
+// memory, because allocations use more memory than they reserved with
+// memory_max, it evicts the allocation of the lowest priority job that uses
+// the most memory above its reservation instead of leaving the kernel OOM
+// killer to pick a task at random. Evicted allocations are failed so that the
+// servers reschedule them on another node.
+type memoryPressureWatcher struct {
+	getRunners   func() map[string]interfaces.AllocRunner
+	readPressure func() (*cgroupslib.Pressure, error)
+	readEvents   func() (*cgroupslib.MemoryEvents, error)
+	interval     time.Duration
+	cooldown     time.Duration
+	threshold    float64
+	window       time.Duration
+	logger       hclog.Logger
+	shutdownCh   <-chan struct{}
+
+	// events are the memory events at the last check.
+	events cgroupslib.MemoryEvents
+}
+
+// memoryPressureCandidate is an allocation that uses more memory than it
+// reserved.
+type memoryPressureCandidate struct {
+	runner   interfaces.AllocRunner
+	allocID  string
+	priority int
+	usage    uint64
+	reserved uint64
+}
+
+func (c *memoryPressureCandidate) overage() uint64 {
+	return c.usage - c.reserved
+}
+
+func newMemoryPressureWatcher(
+	getRunners func() map[string]interfaces.AllocRunner,
+	conf *config.MemoryPressureConfig,
+	logger hclog.Logger,
+	shutdownCh <-chan struct{}) *memoryPressureWatcher {
+
+	return &memoryPressureWatcher{
+		getRunners:   getRunners,
+		readPressure: cgroupslib.ReadMemoryPressure,
+		readEvents:   cgroupslib.ReadMemoryEvents,
+		interval:     memoryPressureInterval,
+		cooldown:     memoryPressureCooldown,
+		threshold:    conf.Threshold,
+		window:       conf.Window,
+		logger:       logger.Named("memory_pressure"),
+		shutdownCh:   shutdownCh,
+	}
+}
+
+// watch is a loop that checks the memory pressure of the node until the
+// client is shutdown. It returns immediately if memory pressure can't be
+// read on this node.
+func (w *memoryPressureWatcher) watch() {
+	events, err := w.readEvents()
+	if err != nil {
+		if errors.Is(err, cgroupslib.ErrPressureUnsupported) {
+			w.logger.Debug("memory pressure is not supported on this node, allocations will not be evicted")
+		} else {
+			w.logger.Warn("failed to read memory events, allocations will not be evicted", "error", err)
+		}
+		return
+	}
+	w.events = *events
+
+	timer, stop := helper.NewSafeTimer(w.interval)
+	defer stop()
+
+	for {
+		select {
+		case <-w.shutdownCh:
+			return
+		case <-timer.C:
+		}
+
+		if w.check() {
+			timer.Reset(w.cooldown)
+		} else {
+			timer.Reset(w.interval)
+		}
+	}
+}
+
+// check evicts an allocation if the node is under memory pressure or the OOM
+// killer killed a task because the node ran out of memory since the last
+// check. It returns true if an
+// allocation was evicted.
+func (w *memoryPressureWatcher) check() bool {
+	pressure, err := w.readPressure()
+	if err != nil {
+		w.logger.Warn("failed to read memory pressure", "error", err)
+		return false
+	}
+	events, err := w.readEvents()
+	if err != nil {
+		w.logger.Warn("failed to read memory events", "error", err)
+		return false
+	}
+
+	// The memory events of the parent cgroup count the OOM kills of every
+	// task, including the tasks killed for exceeding their own memory_max.
+	// Those kills also count as the task's cgroup running out of memory, so
+	// only the OOM kills beyond those are kills of the node running out of
+	// memory.
+	oomKilled := false
+	if events.OOMKill >= w.events.OOMKill && events.OOM >= w.events.OOM {
+		oomKilled = events.OOMKill-w.events.OOMKill > events.OOM-w.events.OOM
+	}
+	w.events = *events
+
+	stalled := w.stalled(pressure)
+	if stalled < w.threshold && !oomKilled {
+		return false
+	}
+
+	candidate := w.nextCandidate()
+	if candidate == nil {
+		w.logger.Debug("node is under memory pressure but no allocation exceeds its reserved memory",
+			"pressure", stalled, "oom_killed", oomKilled)
+		return false
+	}
+
+	w.logger.Warn("evicting allocation due to node memory pressure",
+		"alloc_id", candidate.allocID, "priority", candidate.priority,
+		"usage", candidate.usage, "reserved", candidate.reserved,
+		"pressure", stalled, "oom_killed", oomKilled)
+	metrics.IncrCounter([]string{"client", "allocs", "memory_pressure_evicted"}, 1)
+
+	// Evicting an allocation kills its tasks, which must not block the
+	// watcher from being shutdown.
+	go candidate.runner.EvictMemoryPressure(candidate.usage, candidate.reserved)
+	return true
+}
+
+// stalled returns the percentage of time that all tasks were stalled waiting
+// on memory over the configured window.
+func (w *memoryPressureWatcher) stalled(pressure *cgroupslib.Pressure) float64 {
+	switch w.window {
+	case time.Minute:
+		return pressure.Full.Avg60
+	case 5 * time.Minute:
+		return pressure.Full.Avg300
+	default:
+		return pressure.Full.Avg10
+	}
+}
+
+// nextCandidate returns the allocation to evict, or nil if no running
+// allocation uses more memory than it reserved. Allocations of lower priority
+// jobs are evicted first, and allocations of the same priority are evicted in
+// order of how much memory they use above their reservation. The memory used
+// by an allocation is its resident memory rather than its cgroup's usage,
+// which includes page cache that the kernel reclaims under memory pressure.
+func (w *memoryPressureWatcher) nextCandidate() *memoryPressureCandidate {
+	var candidates []*memoryPressureCandidate
+	for id, ar := range w.getRunners() {
+		alloc := ar.Alloc()
+		if alloc == nil || alloc.TerminalStatus() || alloc.AllocatedResources == nil {
+			continue
+		}
+		if state := ar.AllocState(); state != nil && state.MemoryPressureEvicted {
+			continue
+		}
+
+		stats, err := ar.StatsReporter().LatestAllocStats("")
+		if err != nil || stats == nil || stats.ResourceUsage == nil || stats.ResourceUsage.MemoryStats == nil {
+			continue
+		}
+		usage := stats.ResourceUsage.MemoryStats.RSS
+
+		reserved := uint64(alloc.AllocatedResources.Comparable().Flattened.Memory.MemoryMB) * 1024 * 1024
+		if usage <= reserved {
+			continue
+		}
+
+		var priority int
+		if alloc.Job != nil {
+			priority = alloc.Job.Priority
+		}
+		candidates = append(candidates, &memoryPressureCandidate{
+			runner:   ar,
+			allocID:  id,
+			priority: priority,
+			usage:    usage,
+			reserved: reserved,
+		})
+	}
+
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].priority != candidates[j].priority {
+			return candidates[i].priority < candidates[j].priority
+		}
+		return candidates[i].overage() > candidates[j].overage()
+	})
+	return candidates[0]
+}
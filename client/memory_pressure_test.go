@@ -0,0 +1,142 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/allocrunner/state"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/client/lib/cgroupslib"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	sconfig "github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/shoenig/test/must"
+)
+
+// memoryPressureAllocRunner is an alloc runner reporting a fixed memory usage
+// and recording whether it was evicted.
+type memoryPressureAllocRunner struct {
+	*emptyAllocRunner
+	usage   uint64
+	evicted chan struct{}
+}
+
+func newMemoryPressureAllocRunner(priority, memoryMB int, usageMB uint64) *memoryPressureAllocRunner {
+	alloc := mock.Alloc()
+	alloc.Job.Priority = priority
+	alloc.AllocatedResources.Tasks["web"].Memory.MemoryMB = int64(memoryMB)
+	return &memoryPressureAllocRunner{
+		emptyAllocRunner: &emptyAllocRunner{alloc: alloc, allocState: &state.State{}},
+		usage:            usageMB * 1024 * 1024,
+		evicted:          make(chan struct{}),
+	}
+}
+
+func (ar *memoryPressureAllocRunner) StatsReporter() interfaces.AllocStatsReporter { return ar }
+
+func (ar *memoryPressureAllocRunner) LatestAllocStats(string) (*cstructs.AllocResourceUsage, error) {
+	return &cstructs.AllocResourceUsage{
+		ResourceUsage: &cstructs.ResourceUsage{
+			// The cgroup's usage includes reclaimable page cache, which
+			// doesn't count towards the memory the allocation uses
+			MemoryStats: &cstructs.MemoryStats{RSS: ar.usage, Usage: ar.usage * 4},
+		},
+	}, nil
+}
+
+func (ar *memoryPressureAllocRunner) EvictMemoryPressure(usage, reserved uint64) {
+	close(ar.evicted)
+}
+
+func TestMemoryPressureWatcher_Check(t *testing.T) {
+	ci.Parallel(t)
+
+	// high priority job far above its reservation
+	high := newMemoryPressureAllocRunner(80, 256, 1024)
+	// low priority jobs, one further above its reservation than the other
+	lowSmall := newMemoryPressureAllocRunner(20, 256, 300)
+	lowLarge := newMemoryPressureAllocRunner(20, 256, 512)
+	// lowest priority job within its reservation
+	within := newMemoryPressureAllocRunner(10, 256, 200)
+
+	runners := map[string]interfaces.AllocRunner{
+		"high":      high,
+		"low-small": lowSmall,
+		"low-large": lowLarge,
+		"within":    within,
+	}
+
+	pressure := &cgroupslib.Pressure{}
+	events := &cgroupslib.MemoryEvents{}
+	conf, err := config.MemoryPressureConfigFromAgent(nil)
+	must.NoError(t, err)
+	w := newMemoryPressureWatcher(func() map[string]interfaces.AllocRunner { return runners },
+		conf, testlog.HCLogger(t), make(chan struct{}))
+	w.readPressure = func() (*cgroupslib.Pressure, error) { return pressure, nil }
+	w.readEvents = func() (*cgroupslib.MemoryEvents, error) { return events, nil }
+
+	// nothing is evicted without memory pressure
+	must.False(t, w.check())
+
+	// nothing is evicted when a task is killed for exceeding its own
+	// memory_max
+	events.OOM = 1
+	events.OOMKill = 1
+	must.False(t, w.check())
+
+	// the lowest priority allocation with the largest overage is evicted
+	pressure.Full.Avg10 = 25
+	must.True(t, w.check())
+	<-lowLarge.evicted
+	lowLarge.alloc.ClientStatus = structs.AllocClientStatusFailed
+
+	// an OOM kill of the node running out of memory also evicts an
+	// allocation
+	pressure.Full.Avg10 = 0
+	events.OOMKill = 2
+	must.True(t, w.check())
+	<-lowSmall.evicted
+	lowSmall.alloc.ClientStatus = structs.AllocClientStatusFailed
+
+	// a new check without new OOM kills doesn't evict anything
+	must.False(t, w.check())
+
+	pressure.Full.Avg10 = 25
+	must.True(t, w.check())
+	<-high.evicted
+	high.alloc.ClientStatus = structs.AllocClientStatusFailed
+
+	// allocations within their reservation are never evicted
+	must.False(t, w.check())
+}
+
+func TestMemoryPressureWatcher_Window(t *testing.T) {
+	ci.Parallel(t)
+
+	runner := newMemoryPressureAllocRunner(50, 256, 512)
+	runners := map[string]interfaces.AllocRunner{"alloc": runner}
+
+	// stalled for a short burst, which doesn't count over a longer window
+	pressure := &cgroupslib.Pressure{Full: cgroupslib.PressureStats{Avg10: 50, Avg60: 10, Avg300: 2}}
+	conf, err := config.MemoryPressureConfigFromAgent(&sconfig.MemoryPressureConfig{
+		Threshold: new(20.0),
+		Window:    new("60s"),
+	})
+	must.NoError(t, err)
+	w := newMemoryPressureWatcher(func() map[string]interfaces.AllocRunner { return runners },
+		conf, testlog.HCLogger(t), make(chan struct{}))
+	w.readPressure = func() (*cgroupslib.Pressure, error) { return pressure, nil }
+	w.readEvents = func() (*cgroupslib.MemoryEvents, error) { return &cgroupslib.MemoryEvents{}, nil }
+
+	must.False(t, w.check())
+
+	pressure.Full.Avg60 = 25
+	must.True(t, w.check())
+	<-runner.evicted
+}
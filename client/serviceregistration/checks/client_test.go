@@ -21,6 +21,9 @@ import (
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/shoenig/test/must"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"oss.indeed.com/go/libtime/libtimetest"
 )
 
@@ -469,6 +472,102 @@ func TestChecker_Do_HTTPS_TLS(t *testing.T) {
 	}
 }
 
+func TestChecker_Do_GRPC(t *testing.T) {
+	ci.Parallel(t)
+
+	// create a grpc server exposing the standard health service
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	must.NoError(t, err)
+
+	hs := health.NewServer()
+	hs.SetServingStatus("ok.Service", healthpb.HealthCheckResponse_SERVING)
+	hs.SetServingStatus("sick.Service", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	gs := grpc.NewServer()
+	healthpb.RegisterHealthServer(gs, hs)
+	go func() {
+		_ = gs.Serve(l)
+	}()
+	t.Cleanup(gs.Stop)
+
+	addr, port, err := net.SplitHostPort(l.Addr().String())
+	must.NoError(t, err)
+
+	// create a mock clock so we can assert time is set
+	now := time.Date(2022, 1, 2, 3, 4, 5, 6, time.UTC)
+	clock := libtimetest.NewClockMock(t).NowMock.Return(now)
+
+	queryContext := &QueryContext{
+		ID:               "abc123",
+		CustomAddress:    addr,
+		ServicePortLabel: port,
+		Networks:         nil,
+		NetworkStatus:    mock.NewNetworkStatus(addr),
+		Ports:            nil,
+		Group:            "group",
+		Task:             "task",
+		Service:          "service",
+		Check:            "check",
+	}
+
+	makeQuery := func(service string, useTLS bool) *Query {
+		return &Query{
+			Mode:        structs.Healthiness,
+			Type:        "grpc",
+			Timeout:     1 * time.Second,
+			AddressMode: "auto",
+			PortLabel:   port,
+			GRPCService: service,
+			GRPCUseTLS:  useTLS,
+		}
+	}
+
+	cases := []struct {
+		name      string
+		q         *Query
+		expStatus structs.CheckStatus
+		expOutput string
+	}{{
+		name:      "server ok",
+		q:         makeQuery("", false),
+		expStatus: structs.CheckSuccess,
+		expOutput: "nomad: grpc ok",
+	}, {
+		name:      "service ok",
+		q:         makeQuery("ok.Service", false),
+		expStatus: structs.CheckSuccess,
+		expOutput: "nomad: grpc ok",
+	}, {
+		name:      "service not serving",
+		q:         makeQuery("sick.Service", false),
+		expStatus: structs.CheckFailure,
+		expOutput: "nomad: grpc service status NOT_SERVING",
+	}, {
+		name:      "service unknown",
+		q:         makeQuery("missing.Service", false),
+		expStatus: structs.CheckFailure,
+		expOutput: "code = NotFound",
+	}, {
+		name:      "tls against plaintext server",
+		q:         makeQuery("ok.Service", true),
+		expStatus: structs.CheckFailure,
+		expOutput: "code = Unavailable",
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := New(testlog.HCLogger(t))
+			c.(*checker).clock = clock
+
+			result := c.Do(context.Background(), queryContext, tc.q)
+			must.Eq(t, tc.expStatus, result.Status)
+			must.StrContains(t, result.Output, tc.expOutput)
+			must.Eq(t, now.Unix(), result.Timestamp)
+			must.Eq(t, "check", result.Check)
+		})
+	}
+}
+
 func TestChecker_Do_TCP(t *testing.T) {
 	ci.Parallel(t)
 
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -45,6 +46,33 @@ type ServiceRegistrationHandler struct {
 
 	backoffMax     time.Duration
 	backoffInitial time.Duration
+
+	// registered tracks the services registered by this handler by their
+	// registration ID, so that the aggregated status of their checks can be
+	// kept up to date in the registrations.
+	registered     map[string]*registeredService
+	registeredLock sync.Mutex
+
+	// statusLock serializes check status updates with the removal of
+	// services, so that an update never registers a removed service again.
+	statusLock sync.Mutex
+}
+
+// registeredService is a service registered by the handler along with the
+// check status of its last upserted registration.
+type registeredService struct {
+	spec        *structs.Service
+	workload    *serviceregistration.WorkloadServices
+	checkStatus structs.CheckStatus
+
+	// pendingStatus is the check status observed by the last poll if it
+	// differs from the upserted status. A status is only upserted once it has
+	// been observed by consecutive polls.
+	pendingStatus structs.CheckStatus
+
+	// statusUpdated is when the check status was last upserted after the
+	// service was registered.
+	statusUpdated time.Time
 }
 
 // ServiceRegistrationHandlerCfg holds critical information used during the
@@ -74,6 +102,21 @@ type ServiceRegistrationHandlerCfg struct {
 	// and restarts associated tasks in accordance with their check_restart block.
 	CheckWatcher serviceregistration.CheckWatcher
 
+	// CheckStatusGetter returns the status of the checks of services in the
+	// Nomad service provider. When set, the aggregated status of the checks of
+	// each service is kept up to date in its registration so that consumers
+	// such as upstream proxies can avoid unhealthy instances.
+	CheckStatusGetter serviceregistration.CheckStatusGetter
+
+	// CheckStatusInterval is how often the status of checks is compared to the
+	// registered status, defaults to 5s.
+	CheckStatusInterval time.Duration
+
+	// CheckStatusMinInterval is the minimum time between updates of the check
+	// status of a service, so that flapping checks don't cause a write to the
+	// servers' state on every change, defaults to 30s.
+	CheckStatusMinInterval time.Duration
+
 	// BackoffMax is the maximum amont of time failed RemoveWorkload RPCs will
 	// be retried, defaults to 1s
 	BackoffMax time.Duration
@@ -98,6 +141,7 @@ func NewServiceRegistrationHandler(log hclog.Logger, cfg *ServiceRegistrationHan
 		shutDownCh:          make(chan struct{}),
 		backoffMax:          cfg.BackoffMax,
 		backoffInitial:      cfg.BackoffInitial,
+		registered:          make(map[string]*registeredService),
 	}
 	if s.backoffInitial == 0 {
 		s.backoffInitial = 100 * time.Millisecond
@@ -105,6 +149,17 @@ func NewServiceRegistrationHandler(log hclog.Logger, cfg *ServiceRegistrationHan
 	if s.backoffMax == 0 {
 		s.backoffMax = time.Second
 	}
+	if cfg.CheckStatusGetter != nil {
+		interval := cfg.CheckStatusInterval
+		if interval == 0 {
+			interval = 5 * time.Second
+		}
+		minInterval := cfg.CheckStatusMinInterval
+		if minInterval == 0 {
+			minInterval = 30 * time.Second
+		}
+		go s.watchCheckStatus(interval, minInterval)
+	}
 	return s
 }
 
@@ -125,12 +180,13 @@ func (s *ServiceRegistrationHandler) RegisterWorkload(workload *serviceregistrat
 	var mErr multierror.Error
 
 	registrations := make([]*structs.ServiceRegistration, len(workload.Services))
+	statuses := s.checkStatuses()
 
 	// Iterate over the services and generate a hydrated registration object for
 	// each. All services are part of a single allocation, therefore we cannot
 	// have one failure without all becoming a failure.
 	for i, serviceSpec := range workload.Services {
-		serviceRegistration, err := s.generateNomadServiceRegistration(serviceSpec, workload)
+		serviceRegistration, err := s.generateNomadServiceRegistration(serviceSpec, workload, statuses)
 		if err != nil {
 			mErr.Errors = append(mErr.Errors, err)
 		} else if mErr.ErrorOrNil() == nil {
@@ -148,7 +204,7 @@ func (s *ServiceRegistrationHandler) RegisterWorkload(workload *serviceregistrat
 	// this is the same as the Consul flow so hopefully things just work out.
 	for _, service := range workload.Services {
 		for _, check := range service.Checks {
-			if check.TriggersRestarts() {
+			if check.IsWatched() {
 				checkID := string(structs.NomadCheckID(workload.AllocInfo.AllocID, workload.AllocInfo.Group, check))
 				s.checkWatcher.Watch(workload.AllocInfo.AllocID, workload.Name(), checkID, check, workload.Restarter)
 			}
@@ -165,7 +221,20 @@ func (s *ServiceRegistrationHandler) RegisterWorkload(workload *serviceregistrat
 
 	var resp structs.ServiceRegistrationUpsertResponse
 
-	return s.cfg.RPCFn(structs.ServiceRegistrationUpsertRPCMethod, &args, &resp)
+	if err := s.cfg.RPCFn(structs.ServiceRegistrationUpsertRPCMethod, &args, &resp); err != nil {
+		return err
+	}
+
+	s.registeredLock.Lock()
+	defer s.registeredLock.Unlock()
+	for i, serviceSpec := range workload.Services {
+		s.registered[registrations[i].ID] = &registeredService{
+			spec:        serviceSpec,
+			workload:    workload,
+			checkStatus: registrations[i].CheckStatus,
+		}
+	}
+	return nil
 }
 
 // RemoveWorkload iterates the services and removes them from the service
@@ -207,6 +276,12 @@ func (s *ServiceRegistrationHandler) removeWorkload(
 	// Generate the consistent ID for this service, so we know what to remove.
 	id := serviceregistration.MakeAllocServiceID(workload.AllocInfo.AllocID, workload.Name(), serviceSpec)
 
+	s.statusLock.Lock()
+	s.registeredLock.Lock()
+	delete(s.registered, id)
+	s.registeredLock.Unlock()
+	s.statusLock.Unlock()
+
 	deleteArgs := structs.ServiceRegistrationDeleteByIDRequest{
 		ID: id,
 		WriteRequest: structs.WriteRequest{
@@ -360,7 +435,8 @@ func (s *ServiceRegistrationHandler) Shutdown() { close(s.shutDownCh) }
 // generateNomadServiceRegistration is a helper to build the Nomad specific
 // registration object on a per-service basis.
 func (s *ServiceRegistrationHandler) generateNomadServiceRegistration(
-	serviceSpec *structs.Service, workload *serviceregistration.WorkloadServices) (*structs.ServiceRegistration, error) {
+	serviceSpec *structs.Service, workload *serviceregistration.WorkloadServices,
+	statuses map[string]string) (*structs.ServiceRegistration, error) {
 
 	// Service address modes default to auto.
 	addrMode := serviceSpec.AddressMode
@@ -399,9 +475,146 @@ func (s *ServiceRegistrationHandler) generateNomadServiceRegistration(
 		Tags:        tags,
 		Address:     ip,
 		Port:        port,
+		CheckStatus: s.checkStatus(serviceSpec, workload, statuses),
 	}, nil
 }
 
+// checkStatuses returns the current status of every check indexed by check ID,
+// or nil if the handler doesn't track check status.
+func (s *ServiceRegistrationHandler) checkStatuses() map[string]string {
+	if s.cfg.CheckStatusGetter == nil {
+		return nil
+	}
+	statuses, err := s.cfg.CheckStatusGetter.Get()
+	if err != nil {
+		s.log.Warn("failed to get check statuses", "error", err)
+		return nil
+	}
+	return statuses
+}
+
+// checkStatus returns the aggregated status of the checks of the service. Any
+// failing check fails the service, and checks that haven't run yet are
+// pending. Services without checks, or registered while check status isn't
+// tracked, have no status.
+func (s *ServiceRegistrationHandler) checkStatus(serviceSpec *structs.Service,
+	workload *serviceregistration.WorkloadServices, statuses map[string]string) structs.CheckStatus {
+
+	if statuses == nil || len(serviceSpec.Checks) == 0 {
+		return ""
+	}
+
+	status := structs.CheckSuccess
+	for _, check := range serviceSpec.Checks {
+		checkID := string(structs.NomadCheckID(workload.AllocInfo.AllocID, workload.AllocInfo.Group, check))
+		switch structs.CheckStatus(statuses[checkID]) {
+		case structs.CheckFailure:
+			return structs.CheckFailure
+		case structs.CheckSuccess:
+		default:
+			status = structs.CheckPending
+		}
+	}
+	return status
+}
+
+// watchCheckStatus periodically upserts the registrations of the services
+// whose aggregated check status changed since they were last upserted. A
+// changed status is only upserted once it has been observed by consecutive
+// polls, at most once per minInterval for each service, and the registrations
+// of all changed services are upserted together.
+func (s *ServiceRegistrationHandler) watchCheckStatus(interval, minInterval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.shutDownCh:
+			return
+		case <-ticker.C:
+		}
+
+		statuses := s.checkStatuses()
+		if statuses == nil {
+			continue
+		}
+
+		now := time.Now()
+		s.registeredLock.Lock()
+		var changed []*structs.ServiceRegistration
+		for id, rs := range s.registered {
+			status := s.checkStatus(rs.spec, rs.workload, statuses)
+			if status == rs.checkStatus {
+				rs.pendingStatus = ""
+				continue
+			}
+			if status != rs.pendingStatus {
+				rs.pendingStatus = status
+				continue
+			}
+			if now.Sub(rs.statusUpdated) < minInterval {
+				continue
+			}
+
+			reg, err := s.generateNomadServiceRegistration(rs.spec, rs.workload, statuses)
+			if err != nil {
+				s.log.Warn("failed to generate service registration", "service_id", id, "error", err)
+				continue
+			}
+			changed = append(changed, reg)
+		}
+		s.registeredLock.Unlock()
+
+		if len(changed) > 0 {
+			s.upsertCheckStatuses(changed)
+		}
+	}
+}
+
+// upsertCheckStatuses upserts the registrations to update their check status,
+// and records the new statuses once the registrations are upserted.
+func (s *ServiceRegistrationHandler) upsertCheckStatuses(regs []*structs.ServiceRegistration) {
+	s.statusLock.Lock()
+	defer s.statusLock.Unlock()
+
+	// The services may have been removed since their status changed.
+	s.registeredLock.Lock()
+	regs = slices.DeleteFunc(regs, func(reg *structs.ServiceRegistration) bool {
+		_, ok := s.registered[reg.ID]
+		return !ok
+	})
+	s.registeredLock.Unlock()
+	if len(regs) == 0 {
+		return
+	}
+
+	args := structs.ServiceRegistrationUpsertRequest{
+		Services: regs,
+		WriteRequest: structs.WriteRequest{
+			Region:    s.cfg.Region,
+			AuthToken: s.authToken(),
+		},
+	}
+
+	var resp structs.ServiceRegistrationUpsertResponse
+	if err := s.cfg.RPCFn(structs.ServiceRegistrationUpsertRPCMethod, &args, &resp); err != nil {
+		s.log.Warn("failed to update service registration check status",
+			"services", len(regs), "error", err)
+		return
+	}
+
+	now := time.Now()
+	s.registeredLock.Lock()
+	defer s.registeredLock.Unlock()
+	for _, reg := range regs {
+		if rs, ok := s.registered[reg.ID]; ok {
+			rs.checkStatus = reg.CheckStatus
+			rs.pendingStatus = ""
+			rs.statusUpdated = now
+		}
+	}
+}
+
 // authToken returns the current authentication token used for RPC calls. It
 // will use the node identity token if it is set, otherwise it will fallback to
 // the node secret. This handles the case where the node is upgraded before the
@@ -7,16 +7,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/serviceregistration"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/shoenig/test"
 	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -341,6 +344,130 @@ func TestServiceRegistrationHandler_UpdateWorkload(t *testing.T) {
 
 }
 
+func TestServiceRegistrationHandler_CheckStatus(t *testing.T) {
+	ci.Parallel(t)
+
+	statuses := &mockStatusGetter{statuses: map[string]string{}}
+	mockRPC := mockRPC{callCounts: map[string]int{}}
+	cfg := &ServiceRegistrationHandlerCfg{
+		Enabled:                true,
+		CheckWatcher:           new(mockCheckWatcher),
+		CheckStatusGetter:      statuses,
+		CheckStatusInterval:    10 * time.Millisecond,
+		CheckStatusMinInterval: 10 * time.Millisecond,
+		RPCFn:                  mockRPC.RPC,
+	}
+	h := NewServiceRegistrationHandler(testlog.HCLogger(t), cfg)
+	t.Cleanup(h.(*ServiceRegistrationHandler).Shutdown)
+
+	// lastStatus returns the check status of the last upserted registration
+	// of the service
+	lastStatus := func(name string) (structs.CheckStatus, int) {
+		mockRPC.l.RLock()
+		defer mockRPC.l.RUnlock()
+		var status structs.CheckStatus
+		var n int
+		for _, reg := range mockRPC.upserted {
+			if reg.ServiceName == name {
+				status = reg.CheckStatus
+				n++
+			}
+		}
+		return status, n
+	}
+
+	workload := mockWorkload()
+	must.NoError(t, h.RegisterWorkload(workload))
+
+	// checks that haven't run yet are pending, and services without checks
+	// have no status
+	status, _ := lastStatus("redis-http")
+	must.Eq(t, structs.CheckPending, status)
+	status, _ = lastStatus("redis-db")
+	must.Eq(t, "", status)
+
+	checkID := string(structs.NomadCheckID(workload.AllocInfo.AllocID, workload.AllocInfo.Group, workload.Services[1].Checks[0]))
+	statuses.set(checkID, structs.CheckSuccess)
+	must.Wait(t, wait.InitialSuccess(wait.BoolFunc(func() bool {
+		status, _ := lastStatus("redis-http")
+		return status == structs.CheckSuccess
+	}), wait.Timeout(5*time.Second), wait.Gap(10*time.Millisecond)))
+
+	statuses.set(checkID, structs.CheckFailure)
+	must.Wait(t, wait.InitialSuccess(wait.BoolFunc(func() bool {
+		status, _ := lastStatus("redis-http")
+		return status == structs.CheckFailure
+	}), wait.Timeout(5*time.Second), wait.Gap(10*time.Millisecond)))
+
+	// the registration of a removed service is never updated again
+	h.RemoveWorkload(workload)
+	_, upserts := lastStatus("redis-http")
+	statuses.set(checkID, structs.CheckSuccess)
+	time.Sleep(50 * time.Millisecond)
+	_, after := lastStatus("redis-http")
+	must.Eq(t, upserts, after)
+
+	// the service without checks was only upserted on registration
+	_, upserts = lastStatus("redis-db")
+	must.Eq(t, 1, upserts)
+}
+
+func TestServiceRegistrationHandler_CheckStatus_RateLimit(t *testing.T) {
+	ci.Parallel(t)
+
+	statuses := &mockStatusGetter{statuses: map[string]string{}}
+	mockRPC := mockRPC{callCounts: map[string]int{}}
+	cfg := &ServiceRegistrationHandlerCfg{
+		Enabled:                true,
+		CheckWatcher:           new(mockCheckWatcher),
+		CheckStatusGetter:      statuses,
+		CheckStatusInterval:    10 * time.Millisecond,
+		CheckStatusMinInterval: time.Hour,
+		RPCFn:                  mockRPC.RPC,
+	}
+	h := NewServiceRegistrationHandler(testlog.HCLogger(t), cfg)
+	t.Cleanup(h.(*ServiceRegistrationHandler).Shutdown)
+
+	upserts := func() int {
+		mockRPC.l.RLock()
+		defer mockRPC.l.RUnlock()
+		return mockRPC.callCounts[structs.ServiceRegistrationUpsertRPCMethod]
+	}
+
+	workload := mockWorkload()
+	must.NoError(t, h.RegisterWorkload(workload))
+
+	// the first status change after registration is upserted
+	checkID := string(structs.NomadCheckID(workload.AllocInfo.AllocID, workload.AllocInfo.Group, workload.Services[1].Checks[0]))
+	statuses.set(checkID, structs.CheckSuccess)
+	must.Wait(t, wait.InitialSuccess(wait.BoolFunc(func() bool {
+		return upserts() == 2
+	}), wait.Timeout(5*time.Second), wait.Gap(10*time.Millisecond)))
+
+	// later changes wait for the minimum interval
+	statuses.set(checkID, structs.CheckFailure)
+	time.Sleep(100 * time.Millisecond)
+	must.Eq(t, 2, upserts())
+}
+
+// mockStatusGetter is a CheckStatusGetter returning statuses set by tests.
+type mockStatusGetter struct {
+	lock     sync.Mutex
+	statuses map[string]string
+}
+
+func (g *mockStatusGetter) set(checkID string, status structs.CheckStatus) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.statuses[checkID] = string(status)
+}
+
+func (g *mockStatusGetter) Get() (map[string]string, error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return maps.Clone(g.statuses), nil
+}
+
 func TestServiceRegistrationHandler_dedupUpdatedWorkload(t *testing.T) {
 	testCases := []struct {
 		inputOldWorkload  *serviceregistration.WorkloadServices
@@ -664,6 +791,9 @@ type mockRPC struct {
 	callCounts map[string]int
 	l          sync.RWMutex
 
+	// upserted tracks the service registrations upserted, in order.
+	upserted []*structs.ServiceRegistration
+
 	deleteResponseErr error
 	upsertResponseErr error
 }
@@ -677,13 +807,16 @@ func (mr *mockRPC) calls() map[string]int {
 }
 
 // RPC mocks the server RPCs, acting as though any request succeeds.
-func (mr *mockRPC) RPC(method string, _, _ interface{}) error {
+func (mr *mockRPC) RPC(method string, args, _ interface{}) error {
 	mr.l.Lock()
 	defer mr.l.Unlock()
 
 	switch method {
 	case structs.ServiceRegistrationUpsertRPCMethod:
 		mr.callCounts[method]++
+		if mr.upsertResponseErr == nil {
+			mr.upserted = append(mr.upserted, args.(*structs.ServiceRegistrationUpsertRequest).Services...)
+		}
 		return mr.upsertResponseErr
 
 	case structs.ServiceRegistrationDeleteByIDRPCMethod:
@@ -50,10 +50,11 @@ type Handler interface {
 
 type HandlerFunc func(string) Handler
 
-// WorkloadRestarter allows the checkWatcher to restart tasks or entire task
-// groups.
+// WorkloadRestarter allows the checkWatcher to restart or kill tasks or entire
+// task groups.
 type WorkloadRestarter interface {
 	Restart(ctx context.Context, event *structs.TaskEvent, failure bool) error
+	Kill(ctx context.Context, event *structs.TaskEvent) error
 }
 
 // AllocRegistration holds the status of services registered for a particular
@@ -38,6 +38,57 @@ type restarter struct {
 	// graceUntil is when the check's grace period expires and unhealthy
 	// checks should be counted.
 	graceUntil time.Time
+
+	// startup is set for startup checks, which gate the other checks of the
+	// task until they pass once.
+	startup bool
+
+	// started is set once a startup check passed.
+	started bool
+
+	// freshAfter is when the status of a startup check is known to come from
+	// a check run after the check was watched, rather than from a previous
+	// run of the task.
+	freshAfter time.Time
+
+	// startupDeadline is when the task is failed if the startup check hasn't
+	// passed. Zero if the check has no startup timeout.
+	startupDeadline time.Time
+}
+
+// applyStartup updates the state of a startup check, and kills the task as
+// failed if the check didn't pass before its startup deadline. The task is not
+// restarted, as a task that never starts in time is unlikely to after a
+// restart.
+//
+// Returns true if a kill was triggered in which case this check should be
+// removed.
+func (r *restarter) applyStartup(ctx context.Context, now time.Time, status string) bool {
+	if r.started {
+		return false
+	}
+
+	switch status {
+	case "passing", string(structs.CheckSuccess):
+		if !now.Before(r.freshAfter) {
+			r.logger.Debug("startup check passed")
+			r.started = true
+			return false
+		}
+	}
+
+	if r.startupDeadline.IsZero() || now.Before(r.startupDeadline) {
+		return false
+	}
+
+	r.logger.Debug("killing due to startup check not passing before startup timeout")
+
+	reason := fmt.Sprintf("healthcheck: startup check %q did not pass before startup timeout", r.checkName)
+	event := structs.NewTaskEvent(structs.TaskKilling).
+		SetKillReason(reason).
+		SetFailsTask()
+	go asyncKill(ctx, r.logger, r.task, event)
+	return true
 }
 
 // apply restart state for check and restart task if necessary. Current
@@ -118,6 +169,18 @@ func asyncRestart(ctx context.Context, logger hclog.Logger, task WorkloadRestart
 	}
 }
 
+// asyncKill kills the task with the given event, which fails the task.
+func asyncKill(ctx context.Context, logger hclog.Logger, task WorkloadRestarter, event *structs.TaskEvent) {
+	// Killing waits for the task to exit, so there's no reason to allow this
+	// goroutine to block indefinitely.
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if err := task.Kill(ctx, event); err != nil {
+		logger.Debug("failed to kill task", "error", err, "event_time", event.Time, "event_type", event.Type)
+	}
+}
+
 // CheckStatusGetter is implemented per-provider.
 type CheckStatusGetter interface {
 	// Get returns a map from CheckID -> (minimal) CheckStatus
@@ -180,23 +243,33 @@ func NewCheckWatcher(logger hclog.Logger, getter CheckStatusGetter) *UniversalCh
 
 // Watch a check and restart its task if unhealthy.
 func (w *UniversalCheckWatcher) Watch(allocID, taskName, checkID string, check *structs.ServiceCheck, wr WorkloadRestarter) {
-	if !check.TriggersRestarts() {
-		return // check_restart not set; no-op
+	if !check.IsWatched() {
+		return // neither check_restart nor on_start set; no-op
 	}
 
+	now := time.Now()
 	c := &restarter{
-		allocID:        allocID,
-		taskName:       taskName,
-		checkID:        checkID,
-		checkName:      check.Name,
-		taskKey:        key(allocID + taskName),
-		task:           wr,
-		interval:       check.Interval,
-		grace:          check.CheckRestart.Grace,
-		graceUntil:     time.Now().Add(check.CheckRestart.Grace),
-		timeLimit:      check.Interval * time.Duration(check.CheckRestart.Limit-1),
-		ignoreWarnings: check.CheckRestart.IgnoreWarnings,
-		logger:         w.logger.With("alloc_id", allocID, "task", taskName, "check", check.Name),
+		allocID:   allocID,
+		taskName:  taskName,
+		checkID:   checkID,
+		checkName: check.Name,
+		taskKey:   key(allocID + taskName),
+		task:      wr,
+		interval:  check.Interval,
+		logger:    w.logger.With("alloc_id", allocID, "task", taskName, "check", check.Name),
+	}
+
+	if check.OnStart {
+		c.startup = true
+		c.freshAfter = now.Add(check.Interval)
+		if check.StartupTimeout > 0 {
+			c.startupDeadline = now.Add(check.StartupTimeout)
+		}
+	} else {
+		c.grace = check.CheckRestart.Grace
+		c.graceUntil = now.Add(check.CheckRestart.Grace)
+		c.timeLimit = check.Interval * time.Duration(check.CheckRestart.Limit-1)
+		c.ignoreWarnings = check.CheckRestart.IgnoreWarnings
 	}
 
 	select {
@@ -286,16 +359,58 @@ func (w *UniversalCheckWatcher) interval(ctx context.Context, now time.Time, wat
 	}
 	w.failedPreviousInterval = false
 
-	// keep track of tasks restarted this interval
+	// keep track of tasks restarted or killed this interval
 	restarts := set.New[key](len(statuses))
 
+	// keep track of tasks whose startup checks haven't all passed yet
+	starting := set.New[key](0)
+
+	// update startup checks first, as they gate the other checks of their
+	// task
+	for checkID, checkRestarter := range watched {
+		if !checkRestarter.startup {
+			continue
+		}
+		if ctx.Err() != nil {
+			return //  short circuit; caller cancelled us
+		}
+
+		if restarts.Contains(checkRestarter.taskKey) {
+			// skip; task is already being restarted
+			delete(watched, checkID)
+			continue
+		}
+
+		if checkRestarter.applyStartup(ctx, now, statuses[checkID]) {
+			// check will be re-registered & re-watched on startup
+			delete(watched, checkID)
+			restarts.Insert(checkRestarter.taskKey)
+			continue
+		}
+
+		if !checkRestarter.started {
+			starting.Insert(checkRestarter.taskKey)
+		}
+	}
+
 	// iterate over status of all checks, and update the status of checks
 	// we care about watching
 	for checkID, checkRestarter := range watched {
+		if checkRestarter.startup {
+			continue
+		}
 		if ctx.Err() != nil {
 			return //  short circuit; caller cancelled us
 		}
 
+		if starting.Contains(checkRestarter.taskKey) {
+			// skip; the task is still starting, so the grace period of the
+			// check only begins once its startup checks pass
+			checkRestarter.graceUntil = now.Add(checkRestarter.grace)
+			checkRestarter.unhealthyState = time.Time{}
+			continue
+		}
+
 		if restarts.Contains(checkRestarter.taskKey) {
 			// skip; task is already being restarted
 			delete(watched, checkID)
@@ -31,6 +31,9 @@ type fakeWorkloadRestarter struct {
 	// restarts is a slice of all of the restarts triggered by the checkWatcher
 	restarts []restartRecord
 
+	// kills is a slice of all of the kills triggered by the checkWatcher
+	kills []restartRecord
+
 	// need the checkWatcher to re-Watch restarted tasks like TaskRunner
 	watcher *UniversalCheckWatcher
 
@@ -75,6 +78,23 @@ func (c *fakeWorkloadRestarter) Restart(_ context.Context, event *structs.TaskEv
 	return nil
 }
 
+// Kill implements part of the TaskRestarter interface needed for check watching
+// and is normally fulfilled by a TaskRunner.
+//
+// Kills are recorded in the []kills field and do not re-Watch the check.
+func (c *fakeWorkloadRestarter) Kill(_ context.Context, event *structs.TaskEvent) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.kills = append(c.kills, restartRecord{
+		timestamp: time.Now(),
+		source:    event.Type,
+		reason:    event.KillReason,
+		failure:   event.FailsTask,
+	})
+	return nil
+}
+
 // String is useful for debugging.
 func (c *fakeWorkloadRestarter) String() string {
 	c.lock.Lock()
@@ -97,6 +117,16 @@ func (c *fakeWorkloadRestarter) GetRestarts() []restartRecord {
 	return o
 }
 
+// GetKills for testing in a thread-safe way
+func (c *fakeWorkloadRestarter) GetKills() []restartRecord {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	o := make([]restartRecord, len(c.kills))
+	copy(o, c.kills)
+	return o
+}
+
 // response is a response returned by fakeCheckStatusGetter after a certain time
 type response struct {
 	at     time.Time
@@ -239,6 +269,100 @@ func TestCheckWatcher_Unhealthy(t *testing.T) {
 	must.Len(t, 1, restarter1.restarts, must.Sprint("expected check to be restarted once"))
 }
 
+func testStartupCheck() *structs.ServiceCheck {
+	return &structs.ServiceCheck{
+		Name:     "startupcheck",
+		Interval: 100 * time.Millisecond,
+		Timeout:  100 * time.Millisecond,
+		OnStart:  true,
+	}
+}
+
+// TestCheckWatcher_Startup asserts startup checks gate the other checks of
+// their task until they pass.
+func TestCheckWatcher_Startup(t *testing.T) {
+	ci.Parallel(t)
+
+	t.Run("gated", func(t *testing.T) {
+		getter, cw := testWatcherSetup(t)
+
+		// startup check never passes, and the liveness check is always failing
+		getter.add("startupcheck", "critical", before())
+		getter.add("testcheck1", "critical", before())
+
+		startup := testStartupCheck()
+		startupRestarter := newFakeWorkloadRestarter(cw, "testalloc1", "testtask1", "startupcheck", startup)
+		cw.Watch("testalloc1", "testtask1", "startupcheck", startup, startupRestarter)
+
+		check1 := testCheck()
+		check1.CheckRestart.Limit = 1
+		check1.CheckRestart.Grace = 0
+		restarter1 := newFakeWorkloadRestarter(cw, "testalloc1", "testtask1", "testcheck1", check1)
+		cw.Watch("testalloc1", "testtask1", "testcheck1", check1, restarter1)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 400*time.Millisecond)
+		defer cancel()
+		cw.Run(ctx)
+
+		must.SliceEmpty(t, startupRestarter.GetRestarts())
+		must.SliceEmpty(t, restarter1.GetRestarts())
+	})
+
+	t.Run("passed", func(t *testing.T) {
+		getter, cw := testWatcherSetup(t)
+
+		// startup check passes, so the failing liveness check restarts the task
+		getter.add("startupcheck", "passing", before())
+		getter.add("testcheck1", "critical", before())
+
+		// the startup gate is timed from the Watch calls
+		start := time.Now()
+
+		startup := testStartupCheck()
+		startupRestarter := newFakeWorkloadRestarter(cw, "testalloc1", "testtask1", "startupcheck", startup)
+		cw.Watch("testalloc1", "testtask1", "startupcheck", startup, startupRestarter)
+
+		check1 := testCheck()
+		restarter1 := newFakeWorkloadRestarter(cw, "testalloc1", "testtask1", "testcheck1", check1)
+		cw.Watch("testalloc1", "testtask1", "testcheck1", check1, restarter1)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 550*time.Millisecond)
+		defer cancel()
+		cw.Run(ctx)
+
+		// the grace period of the liveness check only begins once the startup
+		// check passed, after its first interval: ungated it would restart
+		// after about 300ms, gated after about 400ms
+		must.SliceEmpty(t, startupRestarter.GetRestarts())
+		restarts := restarter1.GetRestarts()
+		must.Len(t, 1, restarts)
+		must.Greater(t, 350*time.Millisecond, restarts[0].timestamp.Sub(start))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		getter, cw := testWatcherSetup(t)
+
+		// startup check never passes before its startup timeout
+		getter.add("startupcheck", "critical", before())
+
+		startup := testStartupCheck()
+		startup.StartupTimeout = 200 * time.Millisecond
+		startupRestarter := newFakeWorkloadRestarter(cw, "testalloc1", "testtask1", "startupcheck", startup)
+		cw.Watch("testalloc1", "testtask1", "startupcheck", startup, startupRestarter)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+		defer cancel()
+		cw.Run(ctx)
+
+		// the task is failed rather than restarted
+		must.SliceEmpty(t, startupRestarter.GetRestarts())
+		kills := startupRestarter.GetKills()
+		must.Len(t, 1, kills)
+		must.Eq(t, structs.TaskKilling, kills[0].source)
+		must.True(t, kills[0].failure)
+	})
+}
+
 // TestCheckWatcher_HealthyWarning asserts checks in warning with
 // ignore_warnings=true do not restart tasks.
 func TestCheckWatcher_HealthyWarning(t *testing.T) {
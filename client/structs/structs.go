@@ -103,6 +103,29 @@ type AllocFileInfo struct {
 	ContentType string `json:",omitempty"`
 }
 
+// AllocSnapshotEntry describes a file, directory or symlink of an allocation
+// dir snapshot. Clients compare the entries of a remote snapshot against
+// their local copy to migrate only what changed.
+type AllocSnapshotEntry struct {
+	// Path is the path of the entry relative to the alloc dir
+	Path string
+
+	// Typeflag is the tar type of the entry
+	Typeflag byte
+
+	// Mode, Uid and Gid are the permission bits and owner of the entry
+	Mode int64
+	Uid  int
+	Gid  int
+
+	// Size and ModTime are used to detect changed files
+	Size    int64
+	ModTime time.Time
+
+	// Linkname is the target of a symlink
+	Linkname string `json:",omitempty"`
+}
+
 // FsListRequest is used to list an allocation's directory.
 type FsListRequest struct {
 	// AllocID is the allocation to list from
@@ -348,10 +371,25 @@ type AllocResourceUsage struct {
 	// Tasks contains the resource usage of each task
 	Tasks map[string]*TaskResourceUsage
 
+	// DiskStats is the disk usage of the allocation dir, if it's measured
+	DiskStats *AllocDiskStats
+
 	// The max timestamp of all the Tasks
 	Timestamp int64
 }
 
+// AllocDiskStats holds the disk usage of an allocation dir
+type AllocDiskStats struct {
+	// Used is the number of bytes used by the allocation dir
+	Used uint64
+
+	// Size is the ephemeral_disk size of the allocation in bytes
+	Size uint64
+
+	// Timestamp is the time the usage was measured in UnixNano
+	Timestamp int64
+}
+
 // joinStringSet takes two slices of strings and joins them
 func joinStringSet(s1, s2 []string) []string {
 	lookup := make(map[string]struct{}, len(s1))
@@ -724,7 +724,11 @@ func (b *Builder) Build() *TaskEnv {
 	envMap, deviceEnvs := b.buildEnv(b.allocDir, b.localDir, b.secretsDir, nodeAttrs)
 	envMapClient, _ := b.buildEnv(b.clientSharedAllocDir, b.clientTaskLocalDir, b.clientTaskSecretsDir, nodeAttrs)
 
-	return NewTaskEnv(envMap, envMapClient, deviceEnvs, nodeAttrs, b.taskSecrets, b.clientTaskRoot, b.clientSharedAllocDir)
+	// Copy the secrets since the secrets hook may refresh them while the
+	// task env is in use
+	taskSecrets := maps.Clone(b.taskSecrets)
+
+	return NewTaskEnv(envMap, envMapClient, deviceEnvs, nodeAttrs, taskSecrets, b.clientTaskRoot, b.clientSharedAllocDir)
 }
 
 func (b *Builder) SetSecrets(secrets map[string]string) {
@@ -133,6 +133,20 @@ func formatAuthMethodConfig(config *api.ACLAuthMethodConfig) string {
 		fmt.Sprintf("Expiration Leeway|%s", config.ExpirationLeeway.String()),
 		fmt.Sprintf("NotBefore Leeway|%s", config.NotBeforeLeeway.String()),
 		fmt.Sprintf("ClockSkew Leeway|%s", config.ClockSkewLeeway.String()),
+		fmt.Sprintf("LDAP URLs|%s", strings.Join(config.LDAPURLs, ",")),
+		fmt.Sprintf("LDAP StartTLS|%t", config.LDAPStartTLS),
+		fmt.Sprintf("LDAP Insecure TLS|%t", config.LDAPInsecureTLS),
+		fmt.Sprintf("LDAP CA certs|%s", strings.Join(config.LDAPCACerts, ",")),
+		fmt.Sprintf("LDAP Client TLS cert|%s", config.LDAPClientTLSCert),
+		fmt.Sprintf("LDAP Client TLS key|%s", config.LDAPClientTLSKey),
+		fmt.Sprintf("LDAP Bind DN|%s", config.LDAPBindDN),
+		fmt.Sprintf("LDAP Bind password|%s", config.LDAPBindPassword),
+		fmt.Sprintf("LDAP User DN|%s", config.LDAPUserDN),
+		fmt.Sprintf("LDAP User attribute|%s", config.LDAPUserAttr),
+		fmt.Sprintf("LDAP User filter|%s", config.LDAPUserFilter),
+		fmt.Sprintf("LDAP Group DN|%s", config.LDAPGroupDN),
+		fmt.Sprintf("LDAP Group attribute|%s", config.LDAPGroupAttr),
+		fmt.Sprintf("LDAP Group filter|%s", config.LDAPGroupFilter),
 		fmt.Sprintf("Claim mappings|%s", strings.Join(formatMap(config.ClaimMappings), "; ")),
 		fmt.Sprintf("List claim mappings|%s", strings.Join(formatMap(config.ListClaimMappings), "; ")),
 	)
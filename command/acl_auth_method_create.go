@@ -54,7 +54,8 @@ ACL Auth Method Create Options:
     between 1-128 characters and is a required parameter.
 
   -type
-    Sets the type of the auth method. Supported types are 'OIDC' and 'JWT'.
+    Sets the type of the auth method. Supported types are 'OIDC', 'JWT'
+    and 'LDAP'.
 
   -max-token-ttl
     Sets the duration of time all tokens created by this auth method should be
@@ -90,7 +91,7 @@ func (a *ACLAuthMethodCreateCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(a.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
 			"-name":              complete.PredictAnything,
-			"-type":              complete.PredictSet("OIDC", "JWT"),
+			"-type":              complete.PredictSet("OIDC", "JWT", "LDAP"),
 			"-max-token-ttl":     complete.PredictAnything,
 			"-token-locality":    complete.PredictSet("local", "global"),
 			"-token-name-format": complete.PredictNothing,
@@ -149,8 +150,8 @@ func (a *ACLAuthMethodCreateCommand) Run(args []string) int {
 		a.Ui.Error("Max token TTL must be set to a value between min and max TTL configured for the server.")
 		return 1
 	}
-	if !slices.Contains([]string{"OIDC", "JWT"}, strings.ToUpper(a.methodType)) {
-		a.Ui.Error("ACL auth method type must be set to 'OIDC' or 'JWT'")
+	if !slices.Contains([]string{"OIDC", "JWT", "LDAP"}, strings.ToUpper(a.methodType)) {
+		a.Ui.Error("ACL auth method type must be set to 'OIDC', 'JWT' or 'LDAP'")
 		return 1
 	}
 	if len(a.config) == 0 {
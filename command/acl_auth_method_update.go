@@ -50,7 +50,8 @@ General Options:
 ACL Auth Method Update Options:
 
   -type
-    Updates the type of the auth method. Supported types are 'OIDC' and 'JWT'.
+    Updates the type of the auth method. Supported types are 'OIDC', 'JWT'
+    and 'LDAP'.
 
   -max-token-ttl
     Updates the duration of time all tokens created by this auth method should be
@@ -86,7 +87,7 @@ ACL Auth Method Update Options:
 func (a *ACLAuthMethodUpdateCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(a.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-type":              complete.PredictSet("OIDC", "JWT"),
+			"-type":              complete.PredictSet("OIDC", "JWT", "LDAP"),
 			"-max-token-ttl":     complete.PredictAnything,
 			"-token-locality":    complete.PredictSet("local", "global"),
 			"-token-name-format": complete.PredictNothing,
@@ -174,8 +175,8 @@ func (a *ACLAuthMethodUpdateCommand) Run(args []string) int {
 	}
 
 	if slices.Contains(setFlags, "type") {
-		if !slices.Contains([]string{"OIDC", "JWT"}, strings.ToUpper(a.methodType)) {
-			a.Ui.Error("ACL auth method type must be set to 'OIDC' or 'JWT'")
+		if !slices.Contains([]string{"OIDC", "JWT", "LDAP"}, strings.ToUpper(a.methodType)) {
+			a.Ui.Error("ACL auth method type must be set to 'OIDC', 'JWT' or 'LDAP'")
 			return 1
 		}
 		updatedMethod.Type = a.methodType
@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"io"
 	golog "log"
+	"maps"
 	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -389,6 +391,14 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		conf.JobTrackedVersions = *agentConfig.Server.JobTrackedVersions
 	}
 
+	for name, addr := range agentConfig.Server.CanaryAnalysisProviders {
+		u, err := url.Parse(addr)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("canary_analysis_providers %q has an invalid address %q", name, addr)
+		}
+	}
+	conf.CanaryAnalysisProviders = maps.Clone(agentConfig.Server.CanaryAnalysisProviders)
+
 	conf.OIDCIssuer = agentConfig.Server.OIDCIssuer
 
 	// Set up the bind addresses
@@ -1152,6 +1162,12 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	}
 	conf.Drain = drainConfig
 
+	memoryPressureConfig, err := clientconfig.MemoryPressureConfigFromAgent(agentConfig.Client.MemoryPressure)
+	if err != nil {
+		return nil, fmt.Errorf("invalid memory_pressure config: %v", err)
+	}
+	conf.MemoryPressure = memoryPressureConfig
+
 	conf.Users = clientconfig.UsersConfigFromAgent(agentConfig.Client.Users)
 
 	// Iterate the fingerprinter configs and populate the client mapping. The
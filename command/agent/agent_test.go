@@ -2100,6 +2100,22 @@ func TestAgent_ServerConfig_JobMaxCount(t *testing.T) {
 	}
 }
 
+func TestAgent_ServerConfig_CanaryAnalysisProviders(t *testing.T) {
+	ci.Parallel(t)
+
+	conf := DevConfig(nil)
+	must.NoError(t, conf.normalizeAddrs())
+
+	conf.Server.CanaryAnalysisProviders = map[string]string{"prometheus": "http://127.0.0.1:9090"}
+	serverConf, err := convertServerConfig(conf)
+	must.NoError(t, err)
+	must.Eq(t, map[string]string{"prometheus": "http://127.0.0.1:9090"}, serverConf.CanaryAnalysisProviders)
+
+	conf.Server.CanaryAnalysisProviders = map[string]string{"prometheus": "127.0.0.1:9090"}
+	_, err = convertServerConfig(conf)
+	must.ErrorContains(t, err, `canary_analysis_providers "prometheus" has an invalid address`)
+}
+
 func Test_convertServerConfig_clientIntroduction(t *testing.T) {
 	ci.Parallel(t)
 
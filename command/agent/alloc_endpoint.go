@@ -4,11 +4,13 @@
 package agent
 
 import (
+	"archive/tar"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"net"
 	"net/http"
 	"slices"
@@ -18,6 +20,7 @@ import (
 	"github.com/golang/snappy"
 	"github.com/gorilla/websocket"
 	"github.com/hashicorp/go-msgpack/v2/codec"
+	"github.com/hashicorp/nomad/client/allocdir"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
@@ -161,10 +164,18 @@ func (s *HTTPServer) allocStop(allocID string, resp http.ResponseWriter, req *ht
 		reschedule = new(false)
 	}
 
+	ignoreBudget, err := parseBool(req, "ignore_disruption_budget")
+	if err != nil {
+		return nil, err
+	} else if ignoreBudget == nil {
+		ignoreBudget = new(false)
+	}
+
 	sr := &structs.AllocStopRequest{
-		AllocID:         allocID,
-		NoShutdownDelay: *noShutdownDelay,
-		Reschedule:      *reschedule,
+		AllocID:                allocID,
+		NoShutdownDelay:        *noShutdownDelay,
+		Reschedule:             *reschedule,
+		IgnoreDisruptionBudget: *ignoreBudget,
 	}
 	s.parseWriteRequest(req, &sr.WriteRequest)
 
@@ -512,12 +523,59 @@ func (s *HTTPServer) allocSnapshot(allocID string, resp http.ResponseWriter, req
 	if err != nil {
 		return nil, fmt.Errorf(allocNotFoundErr)
 	}
+
+	// Clients migrating the alloc dir incrementally first fetch the manifest
+	// of the snapshot, then the files that changed, optionally resuming from
+	// an offset.
+	query := req.URL.Query()
+	if _, ok := query["manifest"]; ok {
+		entries, err := allocFS.SnapshotManifest()
+		if err != nil {
+			return nil, fmt.Errorf("error making snapshot manifest: %v", err)
+		}
+		return entries, nil
+	}
+	if path := query.Get("path"); path != "" {
+		return nil, s.allocSnapshotFile(allocFS, path, resp, req)
+	}
+
 	if err := allocFS.Snapshot(resp); err != nil {
 		return nil, fmt.Errorf("error making snapshot: %v", err)
 	}
 	return nil, nil
 }
 
+// allocSnapshotFile writes the contents of a single file of the alloc dir
+// snapshot, starting at the requested offset.
+func (s *HTTPServer) allocSnapshotFile(allocFS allocdir.AllocDirFS, path string, resp http.ResponseWriter, req *http.Request) error {
+	var offset int64
+	if o := req.URL.Query().Get("offset"); o != "" {
+		var err error
+		if offset, err = strconv.ParseInt(o, 10, 64); err != nil || offset < 0 {
+			return CodedError(http.StatusBadRequest, fmt.Sprintf("invalid offset %q", o))
+		}
+	}
+
+	// Only serve files that are part of the snapshot
+	entry, err := allocFS.SnapshotEntry(path)
+	if errors.Is(err, fs.ErrNotExist) || err == nil && entry.Typeflag != tar.TypeReg {
+		return CodedError(http.StatusNotFound, fmt.Sprintf("file %q not found in snapshot", path))
+	} else if err != nil {
+		return fmt.Errorf("error reading snapshot entry: %v", err)
+	}
+
+	r, err := allocFS.ReadAt(entry.Path, offset)
+	if err != nil {
+		return fmt.Errorf("error reading file: %v", err)
+	}
+	defer r.Close()
+
+	if _, err := io.Copy(resp, r); err != nil {
+		return fmt.Errorf("error writing file: %v", err)
+	}
+	return nil
+}
+
 func (s *HTTPServer) allocStats(allocID string, resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 
 	// Build the request and parse the ACL token
@@ -0,0 +1,192 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package audit implements an event.Auditor which writes audit events of the
+// HTTP requests made to the agent to file and HTTP sinks.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/command/agent/event"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+)
+
+// Auditor writes audit events to the sinks of the audit configuration,
+// excluding events matched by its filters.
+type Auditor struct {
+	logger hclog.Logger
+
+	lock    sync.RWMutex
+	enabled bool
+	sinks   []*auditSink
+	filters []*config.AuditFilter
+}
+
+// Ensure Auditor is an Auditor
+var _ event.Auditor = (*Auditor)(nil)
+
+// NewAuditor returns an auditor for the configuration, or an error if the
+// configuration is invalid or a sink could not be opened.
+func NewAuditor(logger hclog.Logger, cfg *config.AuditConfig) (*Auditor, error) {
+	a := &Auditor{
+		logger: logger.Named("audit"),
+	}
+	if err := a.Reload(cfg); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Reload replaces the sinks and filters of the auditor with the ones of the
+// configuration. The previous sinks are kept if the configuration is invalid.
+func (a *Auditor) Reload(cfg *config.AuditConfig) error {
+	if cfg == nil {
+		cfg = &config.AuditConfig{}
+	}
+	enabled := cfg.Enabled != nil && *cfg.Enabled
+
+	for _, f := range cfg.Filters {
+		if err := validateFilter(f); err != nil {
+			return err
+		}
+	}
+
+	var sinks []*auditSink
+	if enabled {
+		if len(cfg.Sinks) == 0 {
+			return errors.New("audit logging requires at least one sink")
+		}
+		for _, sc := range cfg.Sinks {
+			s, err := newSink(a.logger, sc)
+			if err != nil {
+				closeSinks(sinks)
+				return err
+			}
+			sinks = append(sinks, s)
+		}
+	}
+
+	a.lock.Lock()
+	previous := a.sinks
+	a.enabled = enabled
+	a.sinks = sinks
+	a.filters = cfg.Copy().Filters
+	a.lock.Unlock()
+
+	closeSinks(previous)
+	return nil
+}
+
+// Event writes an audit event to all the sinks. It returns an error if the
+// event could not be written to a sink with enforced delivery. Events are
+// queued for best-effort sinks, and dropped if their queue is full.
+func (a *Auditor) Event(ctx context.Context, eventType string, payload interface{}) error {
+	// the sinks are written to without holding the lock, so that slow sinks
+	// don't block reloads; sinks closed by a reload reject later writes
+	a.lock.RLock()
+	enabled, sinks, filters := a.enabled, a.sinks, a.filters
+	a.lock.RUnlock()
+
+	if !enabled {
+		return nil
+	}
+
+	e, ok := payload.(*Event)
+	if !ok {
+		return fmt.Errorf("unexpected audit event payload %T", payload)
+	}
+	if filtered(filters, e) {
+		return nil
+	}
+
+	b, err := json.Marshal(&entry{
+		CreatedAt: time.Now().UTC(),
+		EventType: eventType,
+		Payload:   e,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode audit event: %v", err)
+	}
+	b = append(b, '\n')
+
+	// events must be written even if the request is cancelled
+	ctx = context.WithoutCancel(ctx)
+
+	var mErr *multierror.Error
+	for _, s := range sinks {
+		if err := s.Send(ctx, b); err != nil {
+			a.logger.Error("failed to write audit event", "sink", s.name, "error", err)
+			mErr = multierror.Append(mErr, fmt.Errorf("audit sink %q: %w", s.name, err))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// Enabled returns whether the auditor writes events.
+func (a *Auditor) Enabled() bool {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	return a.enabled
+}
+
+// SetEnabled enables or disables the auditor. Enabling an auditor without
+// sinks has no effect.
+func (a *Auditor) SetEnabled(enabled bool) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.enabled = enabled && len(a.sinks) > 0
+}
+
+// Reopen reopens the files of the file sinks.
+func (a *Auditor) Reopen() error {
+	a.lock.RLock()
+	sinks := a.sinks
+	a.lock.RUnlock()
+
+	var mErr *multierror.Error
+	for _, s := range sinks {
+		if err := s.Reopen(); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("audit sink %q: %w", s.name, err))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// DeliveryEnforced returns whether any sink enforces the delivery of events.
+func (a *Auditor) DeliveryEnforced() bool {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	for _, s := range a.sinks {
+		if s.enforced {
+			return true
+		}
+	}
+	return false
+}
+
+// Close closes the sinks of the auditor and disables it.
+func (a *Auditor) Close() error {
+	a.lock.Lock()
+	sinks := a.sinks
+	a.enabled = false
+	a.sinks = nil
+	a.lock.Unlock()
+
+	closeSinks(sinks)
+	return nil
+}
+
+func closeSinks(sinks []*auditSink) {
+	for _, s := range sinks {
+		_ = s.Close()
+	}
+}
@@ -0,0 +1,412 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+func testEvent(endpoint string) *Event {
+	return NewEvent(
+		NewAuth(&structs.AuthenticatedIdentity{ACLToken: &structs.ACLToken{
+			AccessorID: "accessor",
+			SecretID:   "secret",
+			Name:       "ops",
+			Type:       structs.ACLClientToken,
+			Policies:   []string{"read"},
+		}}),
+		&Request{
+			Operation: http.MethodGet,
+			Endpoint:  endpoint,
+			Namespace: "default",
+		},
+	)
+}
+
+func readEntries(t *testing.T, path string) []*entry {
+	t.Helper()
+
+	f, err := os.Open(path)
+	must.NoError(t, err)
+	defer f.Close()
+
+	var entries []*entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		e := new(entry)
+		must.NoError(t, json.Unmarshal(scanner.Bytes(), e))
+		entries = append(entries, e)
+	}
+	must.NoError(t, scanner.Err())
+	return entries
+}
+
+func TestAuditor_FileSink(t *testing.T) {
+	ci.Parallel(t)
+
+	path := filepath.Join(t.TempDir(), "audit", "audit.log")
+	a, err := NewAuditor(testlog.HCLogger(t), &config.AuditConfig{
+		Enabled: new(true),
+		Sinks: []*config.AuditSink{{
+			Name:              "file",
+			Type:              SinkTypeFile,
+			DeliveryGuarantee: DeliveryEnforced,
+			Path:              path,
+		}},
+		Filters: []*config.AuditFilter{{
+			Name:       "metrics",
+			Type:       FilterTypeHTTPEvent,
+			Endpoints:  []string{"/v1/metrics"},
+			Stages:     []string{"*"},
+			Operations: []string{"*"},
+		}},
+	})
+	must.NoError(t, err)
+	t.Cleanup(func() { _ = a.Close() })
+
+	must.True(t, a.Enabled())
+	must.True(t, a.DeliveryEnforced())
+
+	event := testEvent("/v1/jobs")
+	must.NoError(t, a.Event(context.Background(), EventType, event))
+	must.NoError(t, a.Event(context.Background(), EventType,
+		event.Complete(&Response{StatusCode: http.StatusOK})))
+
+	// filtered events are not written
+	must.NoError(t, a.Event(context.Background(), EventType, testEvent("/v1/metrics")))
+
+	entries := readEntries(t, path)
+	must.Len(t, 2, entries)
+	must.Eq(t, EventType, entries[0].EventType)
+	must.Eq(t, StageOperationReceived, entries[0].Payload.Stage)
+	must.Eq(t, "accessor", entries[0].Payload.Auth.AccessorID)
+	must.Eq(t, "/v1/jobs", entries[0].Payload.Request.Endpoint)
+	must.Nil(t, entries[0].Payload.Response)
+	must.Eq(t, StageOperationComplete, entries[1].Payload.Stage)
+	must.Eq(t, http.StatusOK, entries[1].Payload.Response.StatusCode)
+
+	// secrets are never written
+	b, err := os.ReadFile(path)
+	must.NoError(t, err)
+	must.StrNotContains(t, string(b), "secret")
+
+	stat, err := os.Stat(path)
+	must.NoError(t, err)
+	must.Eq(t, defaultFileMode, stat.Mode().Perm())
+
+	// reopening recreates the file once it was moved away
+	must.NoError(t, os.Rename(path, path+".old"))
+	must.NoError(t, a.Reopen())
+	must.NoError(t, a.Event(context.Background(), EventType, event))
+	must.Len(t, 1, readEntries(t, path))
+
+	// disabling the auditor stops writing events
+	a.SetEnabled(false)
+	must.NoError(t, a.Event(context.Background(), EventType, event))
+	must.Len(t, 1, readEntries(t, path))
+}
+
+func TestAuditor_FileSink_Rotate(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	a, err := NewAuditor(testlog.HCLogger(t), &config.AuditConfig{
+		Enabled: new(true),
+		Sinks: []*config.AuditSink{{
+			Name:           "file",
+			Path:           filepath.Join(dir, "audit.log"),
+			RotateBytes:    1,
+			RotateMaxFiles: 2,
+			Mode:           "0640",
+		}},
+	})
+	must.NoError(t, err)
+	t.Cleanup(func() { _ = a.Close() })
+
+	for range 5 {
+		must.NoError(t, a.Event(context.Background(), EventType, testEvent("/v1/jobs")))
+	}
+
+	// the active file and the two most recent rotated files are kept, each
+	// with a single event
+	rotated, err := filepath.Glob(filepath.Join(dir, "audit-*.log"))
+	must.NoError(t, err)
+	must.Len(t, 2, rotated)
+	for _, path := range append(rotated, filepath.Join(dir, "audit.log")) {
+		must.Len(t, 1, readEntries(t, path))
+	}
+
+	stat, err := os.Stat(filepath.Join(dir, "audit.log"))
+	must.NoError(t, err)
+	must.Eq(t, os.FileMode(0640), stat.Mode().Perm())
+}
+
+func TestAuditor_HTTPSink(t *testing.T) {
+	ci.Parallel(t)
+
+	var lock sync.Mutex
+	var received []*entry
+	var headers []string
+	fail := false
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		e := new(entry)
+		_ = json.Unmarshal(b, e)
+		received = append(received, e)
+		headers = append(headers, r.Header.Get("Authorization"))
+	}))
+	t.Cleanup(ts.Close)
+
+	newAuditor := func(delivery string) *Auditor {
+		a, err := NewAuditor(testlog.HCLogger(t), &config.AuditConfig{
+			Enabled: new(true),
+			Sinks: []*config.AuditSink{{
+				Name:              "webhook",
+				Type:              SinkTypeHTTP,
+				DeliveryGuarantee: delivery,
+				Address:           ts.URL,
+				Headers:           map[string]string{"Authorization": "Bearer webhook"},
+			}},
+		})
+		must.NoError(t, err)
+		t.Cleanup(func() { _ = a.Close() })
+		return a
+	}
+
+	enforced := newAuditor(DeliveryEnforced)
+	must.NoError(t, enforced.Event(context.Background(), EventType, testEvent("/v1/jobs")))
+
+	lock.Lock()
+	must.Len(t, 1, received)
+	must.Eq(t, "/v1/jobs", received[0].Payload.Request.Endpoint)
+	must.Eq(t, []string{"Bearer webhook"}, headers)
+	fail = true
+	lock.Unlock()
+
+	// failures are only returned with enforced delivery
+	err := enforced.Event(context.Background(), EventType, testEvent("/v1/jobs"))
+	must.ErrorContains(t, err, `audit sink "webhook": unexpected response code 503`)
+
+	bestEffort := newAuditor(DeliveryBestEffort)
+	must.False(t, bestEffort.DeliveryEnforced())
+	must.NoError(t, bestEffort.Event(context.Background(), EventType, testEvent("/v1/jobs")))
+}
+
+// TestAuditor_HTTPSink_BestEffort asserts events are written asynchronously to
+// best-effort sinks, and dropped once their queue is full.
+func TestAuditor_HTTPSink_BestEffort(t *testing.T) {
+	ci.Parallel(t)
+
+	var received atomic.Int64
+	unblockCh := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblockCh
+		received.Add(1)
+	}))
+	t.Cleanup(ts.Close)
+
+	a, err := NewAuditor(testlog.HCLogger(t), &config.AuditConfig{
+		Enabled: new(true),
+		Sinks: []*config.AuditSink{{
+			Name:              "webhook",
+			Type:              SinkTypeHTTP,
+			DeliveryGuarantee: DeliveryBestEffort,
+			Address:           ts.URL,
+		}},
+	})
+	must.NoError(t, err)
+	t.Cleanup(func() { _ = a.Close() })
+
+	// the blocked endpoint doesn't block events, and events beyond the queue
+	// and the one in-flight are dropped
+	start := time.Now()
+	total := bestEffortQueueSize + 10
+	for range total {
+		must.NoError(t, a.Event(context.Background(), EventType, testEvent("/v1/jobs")))
+	}
+	must.Less(t, httpSinkTimeout, time.Since(start))
+
+	close(unblockCh)
+	must.Wait(t, wait.InitialSuccess(wait.BoolFunc(func() bool {
+		n := received.Load()
+		return n > bestEffortQueueSize/2 && n < int64(total)
+	}), wait.Timeout(10*time.Second), wait.Gap(50*time.Millisecond)))
+}
+
+// TestAuditor_Reload_InFlight asserts events written while the sinks are
+// replaced by a reload don't block the reload and are not written to the closed
+// sinks.
+func TestAuditor_Reload_InFlight(t *testing.T) {
+	ci.Parallel(t)
+
+	blockedCh := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the body must be read for client disconnects to cancel the request
+		_, _ = io.Copy(io.Discard, r.Body)
+		close(blockedCh)
+		<-r.Context().Done()
+	}))
+	t.Cleanup(ts.Close)
+
+	a, err := NewAuditor(testlog.HCLogger(t), &config.AuditConfig{
+		Enabled: new(true),
+		Sinks: []*config.AuditSink{{
+			Name:              "webhook",
+			Type:              SinkTypeHTTP,
+			DeliveryGuarantee: DeliveryBestEffort,
+			Address:           ts.URL,
+		}},
+	})
+	must.NoError(t, err)
+	t.Cleanup(func() { _ = a.Close() })
+
+	must.NoError(t, a.Event(context.Background(), EventType, testEvent("/v1/jobs")))
+	<-blockedCh
+
+	// the in-flight write is cancelled when its sink is closed by the reload
+	path := filepath.Join(t.TempDir(), "audit.log")
+	must.NoError(t, a.Reload(&config.AuditConfig{
+		Enabled: new(true),
+		Sinks:   []*config.AuditSink{{Name: "file", Path: path}},
+	}))
+	must.NoError(t, a.Event(context.Background(), EventType, testEvent("/v1/jobs")))
+	must.Len(t, 1, readEntries(t, path))
+}
+
+func TestAuditor_Config(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name string
+		cfg  *config.AuditConfig
+		exp  string
+	}{
+		{
+			name: "disabled",
+			cfg:  &config.AuditConfig{},
+		},
+		{
+			name: "no sinks",
+			cfg:  &config.AuditConfig{Enabled: new(true)},
+			exp:  "audit logging requires at least one sink",
+		},
+		{
+			name: "invalid sink type",
+			cfg: &config.AuditConfig{
+				Enabled: new(true),
+				Sinks:   []*config.AuditSink{{Name: "s", Type: "syslog"}},
+			},
+			exp: `audit sink "s" has invalid type "syslog": must be "file" or "http"`,
+		},
+		{
+			name: "invalid delivery guarantee",
+			cfg: &config.AuditConfig{
+				Enabled: new(true),
+				Sinks:   []*config.AuditSink{{Name: "s", Path: "audit.log", DeliveryGuarantee: "maybe"}},
+			},
+			exp: `audit sink "s" has invalid delivery_guarantee "maybe": must be "enforced" or "best-effort"`,
+		},
+		{
+			name: "file sink without path",
+			cfg: &config.AuditConfig{
+				Enabled: new(true),
+				Sinks:   []*config.AuditSink{{Name: "s", Type: SinkTypeFile}},
+			},
+			exp: `audit sink "s" must set a path`,
+		},
+		{
+			name: "http sink without address",
+			cfg: &config.AuditConfig{
+				Enabled: new(true),
+				Sinks:   []*config.AuditSink{{Name: "s", Type: SinkTypeHTTP}},
+			},
+			exp: `audit sink "s" must set an http or https address`,
+		},
+		{
+			name: "invalid filter stage",
+			cfg: &config.AuditConfig{
+				Filters: []*config.AuditFilter{{Name: "f", Type: FilterTypeHTTPEvent, Stages: []string{"Later"}}},
+			},
+			exp: `audit filter "f" has invalid stage "Later"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a, err := NewAuditor(testlog.HCLogger(t), tc.cfg)
+			if tc.exp == "" {
+				must.NoError(t, err)
+				must.False(t, a.Enabled())
+			} else {
+				must.EqError(t, err, tc.exp)
+			}
+		})
+	}
+}
+
+func TestAuditor_filtered(t *testing.T) {
+	ci.Parallel(t)
+
+	filters := []*config.AuditFilter{
+		{
+			Type:       FilterTypeHTTPEvent,
+			Endpoints:  []string{"/v1/metrics", "/v1/agent/*"},
+			Stages:     []string{"*"},
+			Operations: []string{"*"},
+		},
+		{
+			Type:       FilterTypeHTTPEvent,
+			Endpoints:  []string{"*"},
+			Stages:     []string{string(StageOperationReceived)},
+			Operations: []string{"GET"},
+		},
+	}
+
+	testCases := []struct {
+		endpoint  string
+		operation string
+		stage     Stage
+		exp       bool
+	}{
+		{"/v1/metrics", "GET", StageOperationComplete, true},
+		{"/v1/agent/self", "PUT", StageOperationComplete, true},
+		{"/v1/jobs", "GET", StageOperationReceived, true},
+		{"/v1/jobs", "get", StageOperationReceived, true},
+		{"/v1/jobs", "GET", StageOperationComplete, false},
+		{"/v1/jobs", "PUT", StageOperationReceived, false},
+		{"/v1/agentx", "PUT", StageOperationReceived, false},
+	}
+
+	for _, tc := range testCases {
+		e := testEvent(tc.endpoint)
+		e.Request.Operation = tc.operation
+		e.Stage = tc.stage
+		must.Eq(t, tc.exp, filtered(filters, e), must.Sprintf("%s %s %s", tc.operation, tc.endpoint, tc.stage))
+	}
+}
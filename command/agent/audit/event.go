@@ -0,0 +1,138 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package audit
+
+import (
+	"time"
+
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// EventType is the type of the events written by the auditor.
+	EventType = "audit"
+
+	// FilterTypeHTTPEvent is the filter type matching the events of HTTP
+	// requests.
+	FilterTypeHTTPEvent = "HTTPEvent"
+
+	// eventVersion is the version of the audit event format.
+	eventVersion = 1
+)
+
+// Stage is the stage of the request lifecycle an audit event is emitted at.
+type Stage string
+
+const (
+	// StageOperationReceived is the stage of events emitted before a request
+	// is handled.
+	StageOperationReceived Stage = "OperationReceived"
+
+	// StageOperationComplete is the stage of events emitted once a request
+	// has been handled.
+	StageOperationComplete Stage = "OperationComplete"
+
+	// stageAll matches all stages in filters.
+	stageAll = "*"
+)
+
+// Event is an audit event of an HTTP request.
+type Event struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Stage     Stage     `json:"stage"`
+	Timestamp time.Time `json:"timestamp"`
+	Version   int       `json:"version"`
+	Auth      *Auth     `json:"auth,omitempty"`
+	Request   *Request  `json:"request"`
+	Response  *Response `json:"response,omitempty"`
+}
+
+// NewEvent returns the event of a request at its received stage.
+func NewEvent(auth *Auth, request *Request) *Event {
+	return &Event{
+		ID:        uuid.Generate(),
+		Type:      EventType,
+		Stage:     StageOperationReceived,
+		Timestamp: time.Now().UTC(),
+		Version:   eventVersion,
+		Auth:      auth,
+		Request:   request,
+	}
+}
+
+// Complete returns a copy of the event at its complete stage.
+func (e *Event) Complete(response *Response) *Event {
+	ne := *e
+	ne.ID = uuid.Generate()
+	ne.Stage = StageOperationComplete
+	ne.Timestamp = time.Now().UTC()
+	ne.Response = response
+	return &ne
+}
+
+// Auth is the identity which made the request. Only the accessor of ACL tokens
+// is recorded, never their secret.
+type Auth struct {
+	AccessorID string    `json:"accessor_id,omitempty"`
+	Name       string    `json:"name,omitempty"`
+	Type       string    `json:"type,omitempty"`
+	Policies   []string  `json:"policies,omitempty"`
+	RoleIDs    []string  `json:"role_ids,omitempty"`
+	Global     bool      `json:"global,omitempty"`
+	CreateTime time.Time `json:"create_time,omitempty"`
+
+	// Identity describes identities other than ACL tokens, such as workload
+	// identities.
+	Identity string `json:"identity,omitempty"`
+}
+
+// NewAuth returns the auth of an event made by the identity, or nil if the
+// request was not authenticated.
+func NewAuth(ident *structs.AuthenticatedIdentity) *Auth {
+	if ident == nil {
+		return nil
+	}
+	token := ident.GetACLToken()
+	if token == nil {
+		return &Auth{Identity: ident.String()}
+	}
+
+	auth := &Auth{
+		AccessorID: token.AccessorID,
+		Name:       token.Name,
+		Type:       token.Type,
+		Policies:   token.Policies,
+		Global:     token.Global,
+		CreateTime: token.CreateTime,
+	}
+	for _, role := range token.Roles {
+		auth.RoleIDs = append(auth.RoleIDs, role.ID)
+	}
+	return auth
+}
+
+// Request is the HTTP request being audited.
+type Request struct {
+	ID          string            `json:"id"`
+	Operation   string            `json:"operation"`
+	Endpoint    string            `json:"endpoint"`
+	Namespace   string            `json:"namespace"`
+	RequestMeta map[string]string `json:"request_meta,omitempty"`
+	NodeMeta    map[string]string `json:"node_meta,omitempty"`
+}
+
+// Response is the outcome of the HTTP request being audited.
+type Response struct {
+	StatusCode int    `json:"status_code"`
+	Error      string `json:"error,omitempty"`
+}
+
+// entry is the line written to sinks for each event.
+type entry struct {
+	CreatedAt time.Time `json:"created_at"`
+	EventType string    `json:"event_type"`
+	Payload   *Event    `json:"payload"`
+}
@@ -0,0 +1,84 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package audit
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/nomad/nomad/structs/config"
+)
+
+// validateFilter returns an error if the filter is invalid.
+func validateFilter(f *config.AuditFilter) error {
+	if f.Type != FilterTypeHTTPEvent {
+		return fmt.Errorf("audit filter %q has invalid type %q: must be %q", f.Name, f.Type, FilterTypeHTTPEvent)
+	}
+	for _, stage := range f.Stages {
+		switch stage {
+		case stageAll, string(StageOperationReceived), string(StageOperationComplete):
+		default:
+			return fmt.Errorf("audit filter %q has invalid stage %q", f.Name, stage)
+		}
+	}
+	for _, endpoint := range f.Endpoints {
+		if _, err := path.Match(endpoint, ""); err != nil {
+			return fmt.Errorf("audit filter %q has invalid endpoint %q: %v", f.Name, endpoint, err)
+		}
+	}
+	return nil
+}
+
+// filtered returns whether the event is excluded by any of the filters. A
+// filter excludes events matching all of its endpoints, stages and operations,
+// where an empty list matches everything.
+func filtered(filters []*config.AuditFilter, e *Event) bool {
+	for _, f := range filters {
+		if f.Type != FilterTypeHTTPEvent {
+			continue
+		}
+		if matchEndpoint(f.Endpoints, e.Request.Endpoint) &&
+			matchValue(f.Stages, string(e.Stage)) &&
+			matchValue(f.Operations, e.Request.Operation) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchEndpoint returns whether the endpoint matches any of the patterns.
+// Patterns are globs, and a trailing "*" matches any suffix of the endpoint.
+func matchEndpoint(patterns []string, endpoint string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if pattern == "*" {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok && !strings.ContainsAny(prefix, "*?[") {
+			if strings.HasPrefix(endpoint, prefix) {
+				return true
+			}
+			continue
+		}
+		if ok, _ := path.Match(pattern, endpoint); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// matchValue returns whether the value matches any of the values, which may
+// be "*" to match everything.
+func matchValue(values []string, value string) bool {
+	if len(values) == 0 || slices.Contains(values, "*") {
+		return true
+	}
+	return slices.ContainsFunc(values, func(v string) bool {
+		return strings.EqualFold(v, value)
+	})
+}
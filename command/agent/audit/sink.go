@@ -0,0 +1,421 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+)
+
+const (
+	// SinkTypeFile is the type of sinks writing to a rotated file.
+	SinkTypeFile = "file"
+
+	// SinkTypeHTTP is the type of sinks posting events to an HTTP endpoint.
+	SinkTypeHTTP = "http"
+
+	// FormatJSON is the only supported sink format.
+	FormatJSON = "json"
+
+	// DeliveryEnforced fails requests whose events could not be written.
+	DeliveryEnforced = "enforced"
+
+	// DeliveryBestEffort only logs the events which could not be written.
+	DeliveryBestEffort = "best-effort"
+
+	defaultFileMode       = os.FileMode(0600)
+	defaultRotateDuration = 24 * time.Hour
+	httpSinkTimeout       = 10 * time.Second
+
+	// bestEffortQueueSize is the number of events buffered for a best-effort
+	// sink before further events are dropped.
+	bestEffortQueueSize = 1024
+)
+
+// errSinkClosed is returned for events written to a sink after it was closed,
+// such as when the audit configuration was reloaded during the write.
+var errSinkClosed = errors.New("audit sink is closed")
+
+// sink is a destination of audit events.
+type sink interface {
+	// Write writes a single JSON encoded event.
+	Write(ctx context.Context, b []byte) error
+
+	// Reopen reopens any file the sink has open.
+	Reopen() error
+
+	// Close releases the resources of the sink.
+	Close() error
+}
+
+// auditSink is a configured sink. Events are written synchronously to sinks
+// with enforced delivery, and queued for a background writer otherwise so that
+// a slow or unavailable sink doesn't delay requests.
+type auditSink struct {
+	sink
+	name     string
+	enforced bool
+	logger   hclog.Logger
+
+	// lock guards the sink against being written to or reopened once closed,
+	// without blocking the auditor while events are written.
+	lock   sync.RWMutex
+	closed bool
+
+	// queue buffers the events of best-effort sinks, which are written by
+	// run until ctx is cancelled. doneCh is closed once run returns.
+	queue  chan []byte
+	ctx    context.Context
+	cancel context.CancelFunc
+	doneCh chan struct{}
+}
+
+// newSink returns the sink of the configuration, or an error if it is invalid.
+func newSink(logger hclog.Logger, cfg *config.AuditSink) (*auditSink, error) {
+	switch cfg.DeliveryGuarantee {
+	case "", DeliveryEnforced, DeliveryBestEffort:
+	default:
+		return nil, fmt.Errorf("audit sink %q has invalid delivery_guarantee %q: must be %q or %q",
+			cfg.Name, cfg.DeliveryGuarantee, DeliveryEnforced, DeliveryBestEffort)
+	}
+	switch cfg.Format {
+	case "", FormatJSON:
+	default:
+		return nil, fmt.Errorf("audit sink %q has invalid format %q: must be %q", cfg.Name, cfg.Format, FormatJSON)
+	}
+
+	s := &auditSink{
+		name:     cfg.Name,
+		enforced: cfg.DeliveryGuarantee != DeliveryBestEffort,
+		logger:   logger.With("sink", cfg.Name),
+	}
+
+	var err error
+	switch cfg.Type {
+	case "", SinkTypeFile:
+		s.sink, err = newFileSink(cfg)
+	case SinkTypeHTTP:
+		s.sink, err = newHTTPSink(cfg)
+	default:
+		err = fmt.Errorf("audit sink %q has invalid type %q: must be %q or %q", cfg.Name, cfg.Type, SinkTypeFile, SinkTypeHTTP)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.enforced {
+		s.queue = make(chan []byte, bestEffortQueueSize)
+		s.ctx, s.cancel = context.WithCancel(context.Background())
+		s.doneCh = make(chan struct{})
+		go s.run()
+	}
+	return s, nil
+}
+
+// Send writes the event to a sink with enforced delivery, or queues it for a
+// best-effort sink. Events are dropped if the queue of a best-effort sink is
+// full, so only errors of sinks with enforced delivery are returned.
+func (s *auditSink) Send(ctx context.Context, b []byte) error {
+	if s.enforced {
+		return s.write(ctx, b)
+	}
+
+	select {
+	case s.queue <- b:
+	default:
+		s.logger.Warn("audit event queue is full, dropping event")
+	}
+	return nil
+}
+
+// write writes the event to the sink unless it was closed.
+func (s *auditSink) write(ctx context.Context, b []byte) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.closed {
+		return errSinkClosed
+	}
+	return s.sink.Write(ctx, b)
+}
+
+// run writes the queued events of a best-effort sink until the sink is
+// closed, after which the remaining queued events are flushed with a cancelled
+// context so that only sinks which don't block, like files, write them.
+func (s *auditSink) run() {
+	defer close(s.doneCh)
+
+	for {
+		select {
+		case b := <-s.queue:
+			if err := s.write(s.ctx, b); err != nil && s.ctx.Err() == nil {
+				s.logger.Warn("failed to write audit event", "error", err)
+			}
+		case <-s.ctx.Done():
+			for {
+				select {
+				case b := <-s.queue:
+					_ = s.write(s.ctx, b)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// Reopen reopens any file the sink has open unless it was closed.
+func (s *auditSink) Reopen() error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.closed {
+		return nil
+	}
+	return s.sink.Reopen()
+}
+
+// Close stops the background writer of a best-effort sink, and waits for
+// in-flight writes before closing the sink.
+func (s *auditSink) Close() error {
+	if s.cancel != nil {
+		s.cancel()
+		<-s.doneCh
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	return s.sink.Close()
+}
+
+// fileSink writes events to a file, rotated by size and age.
+type fileSink struct {
+	dir            string
+	fileName       string
+	mode           os.FileMode
+	rotateDuration time.Duration
+	rotateBytes    int64
+	rotateMaxFiles int
+
+	lock    sync.Mutex
+	file    *os.File
+	created time.Time
+	written int64
+}
+
+func newFileSink(cfg *config.AuditSink) (*fileSink, error) {
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("audit sink %q must set a path", cfg.Name)
+	}
+	if cfg.RotateBytes < 0 || cfg.RotateMaxFiles < 0 || cfg.RotateDuration < 0 {
+		return nil, fmt.Errorf("audit sink %q rotation settings must be non-negative", cfg.Name)
+	}
+
+	mode := defaultFileMode
+	if cfg.Mode != "" {
+		m, err := strconv.ParseUint(cfg.Mode, 8, 32)
+		if err != nil {
+			return nil, fmt.Errorf("audit sink %q has invalid mode %q: %v", cfg.Name, cfg.Mode, err)
+		}
+		mode = os.FileMode(m)
+	}
+
+	s := &fileSink{
+		dir:            filepath.Dir(cfg.Path),
+		fileName:       filepath.Base(cfg.Path),
+		mode:           mode,
+		rotateDuration: cfg.RotateDuration,
+		rotateBytes:    int64(cfg.RotateBytes),
+		rotateMaxFiles: cfg.RotateMaxFiles,
+	}
+	if s.rotateDuration == 0 {
+		s.rotateDuration = defaultRotateDuration
+	}
+
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return nil, fmt.Errorf("audit sink %q failed to create directory: %v", cfg.Name, err)
+	}
+	if err := s.open(); err != nil {
+		return nil, fmt.Errorf("audit sink %q failed to open file: %v", cfg.Name, err)
+	}
+	return s, nil
+}
+
+// fileNamePattern returns the pattern of the names of rotated files.
+func (s *fileSink) fileNamePattern() string {
+	ext := filepath.Ext(s.fileName)
+	if ext == "" {
+		ext = ".log"
+	}
+	return strings.TrimSuffix(s.fileName, ext) + "-%s" + ext
+}
+
+// open opens the active file. Caller must hold s.lock.
+func (s *fileSink) open() error {
+	f, err := os.OpenFile(filepath.Join(s.dir, s.fileName), os.O_CREATE|os.O_APPEND|os.O_WRONLY, s.mode)
+	if err != nil {
+		return err
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	s.file = f
+	s.written = stat.Size()
+	s.created = time.Now()
+	return nil
+}
+
+// rotate moves the active file to a timestamped file and opens a new active
+// file if it is too large or too old. Caller must hold s.lock.
+func (s *fileSink) rotate(next int) error {
+	tooLarge := s.rotateBytes > 0 && s.written > 0 && s.written+int64(next) > s.rotateBytes
+	if !tooLarge && time.Since(s.created) < s.rotateDuration {
+		return nil
+	}
+
+	_ = s.file.Close()
+	s.file = nil
+
+	rotated := fmt.Sprintf(s.fileNamePattern(), strconv.FormatInt(time.Now().UnixNano(), 10))
+	if err := os.Rename(filepath.Join(s.dir, s.fileName), filepath.Join(s.dir, rotated)); err != nil {
+		return fmt.Errorf("failed to rotate audit log: %v", err)
+	}
+	if err := s.prune(); err != nil {
+		return fmt.Errorf("failed to prune audit logs: %v", err)
+	}
+	return s.open()
+}
+
+// prune removes the oldest rotated files beyond the maximum number of files.
+func (s *fileSink) prune() error {
+	if s.rotateMaxFiles == 0 {
+		return nil
+	}
+	matches, err := filepath.Glob(filepath.Join(s.dir, fmt.Sprintf(s.fileNamePattern(), "*")))
+	if err != nil {
+		return err
+	}
+	sort.Strings(matches)
+
+	for i := 0; i < len(matches)-s.rotateMaxFiles; i++ {
+		if err := os.Remove(matches[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fileSink) Write(_ context.Context, b []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.file == nil {
+		if err := s.open(); err != nil {
+			return err
+		}
+	}
+	if err := s.rotate(len(b)); err != nil {
+		return err
+	}
+
+	n, err := s.file.Write(b)
+	s.written += int64(n)
+	return err
+}
+
+// Reopen closes and reopens the active file, so that it is recreated if it was
+// moved or removed by external log rotation.
+func (s *fileSink) Reopen() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.file != nil {
+		_ = s.file.Close()
+		s.file = nil
+	}
+	return s.open()
+}
+
+func (s *fileSink) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+// httpSink posts each event to an HTTP endpoint.
+type httpSink struct {
+	address string
+	headers map[string]string
+	client  *http.Client
+}
+
+func newHTTPSink(cfg *config.AuditSink) (*httpSink, error) {
+	u, err := url.Parse(cfg.Address)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("audit sink %q must set an http or https address", cfg.Name)
+	}
+	return &httpSink{
+		address: cfg.Address,
+		headers: cfg.Headers,
+		client:  &http.Client{Timeout: httpSinkTimeout},
+	}, nil
+}
+
+func (s *httpSink) Write(ctx context.Context, b []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.address, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *httpSink) Reopen() error { return nil }
+
+func (s *httpSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
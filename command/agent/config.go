@@ -435,6 +435,10 @@ type ClientConfig struct {
 	// Drain specifies whether to drain the client on shutdown; ignored in dev mode.
 	Drain *config.DrainConfig `hcl:"drain_on_shutdown"`
 
+	// MemoryPressure configures evicting allocations when the node runs out
+	// of memory because of memory oversubscription.
+	MemoryPressure *config.MemoryPressureConfig `hcl:"memory_pressure"`
+
 	// Users is used to configure parameters around operating system users.
 	Users *config.UsersConfig `hcl:"users"`
 
@@ -476,6 +480,7 @@ func (c *ClientConfig) Copy() *ClientConfig {
 	nc.NomadServiceDiscovery = pointer.Copy(c.NomadServiceDiscovery)
 	nc.Artifact = c.Artifact.Copy()
 	nc.Drain = c.Drain.Copy()
+	nc.MemoryPressure = c.MemoryPressure.Copy()
 	nc.Users = c.Users.Copy()
 	nc.Fingerprinters = helper.CopySlice(c.Fingerprinters)
 	nc.ExtraKeysHCL = slices.Clone(c.ExtraKeysHCL)
@@ -793,6 +798,11 @@ type ServerConfig struct {
 	// JobTrackedVersions is the number of historic job versions that are kept.
 	JobTrackedVersions *int `hcl:"job_tracked_versions"`
 
+	// CanaryAnalysisProviders maps the names of the providers that canary
+	// analyses may query to the addresses of their Prometheus-compatible HTTP
+	// APIs. Jobs can only reference providers configured here.
+	CanaryAnalysisProviders map[string]string `hcl:"canary_analysis_providers"`
+
 	// OIDCIssuer if set enables OIDC Discovery and uses this value as the
 	// issuer. Third parties such as AWS IAM OIDC Provider expect the issuer to
 	// be a publicly accessible HTTPS URL signed by a trusted well-known CA.
@@ -842,6 +852,7 @@ func (s *ServerConfig) Copy() *ServerConfig {
 	ns.JobMaxPriority = pointer.Copy(s.JobMaxPriority)
 	ns.JobMaxCount = pointer.Copy(s.JobMaxCount)
 	ns.JobTrackedVersions = pointer.Copy(s.JobTrackedVersions)
+	ns.CanaryAnalysisProviders = maps.Clone(s.CanaryAnalysisProviders)
 	ns.ClientIntroduction = s.ClientIntroduction.Copy()
 	return &ns
 }
@@ -2662,6 +2673,12 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 	if b.JobMaxCount != nil {
 		result.JobMaxCount = new(*b.JobMaxCount)
 	}
+	if len(b.CanaryAnalysisProviders) != 0 {
+		if result.CanaryAnalysisProviders == nil {
+			result.CanaryAnalysisProviders = make(map[string]string)
+		}
+		maps.Copy(result.CanaryAnalysisProviders, b.CanaryAnalysisProviders)
+	}
 	if b.EvalGCThreshold != "" {
 		result.EvalGCThreshold = b.EvalGCThreshold
 	}
@@ -3034,6 +3051,7 @@ func (c *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 
 	result.Artifact = c.Artifact.Merge(b.Artifact)
 	result.Drain = c.Drain.Merge(b.Drain)
+	result.MemoryPressure = c.MemoryPressure.Merge(b.MemoryPressure)
 	result.Users = c.Users.Merge(b.Users)
 
 	if b.NodeMaxAllocs != 0 {
@@ -369,7 +369,7 @@ func extraKeys(c *Config) error {
 		helper.RemoveEqualFold(&c.Audit.ExtraKeysHCL, "sink")
 	}
 
-	for _, k := range []string{"enabled_schedulers", "start_join", "retry_join", "server_join"} {
+	for _, k := range []string{"enabled_schedulers", "start_join", "retry_join", "server_join", "canary_analysis_providers"} {
 		helper.RemoveEqualFold(&c.ExtraKeysHCL, k)
 		helper.RemoveEqualFold(&c.ExtraKeysHCL, "server")
 	}
@@ -207,6 +207,14 @@ var basicConfig = &Config{
 				RotateBytes:       100,
 				RotateMaxFiles:    10,
 			},
+			{
+				DeliveryGuarantee: "best-effort",
+				Name:              "webhook",
+				Type:              "http",
+				Format:            "json",
+				Address:           "https://audit.example.com/nomad",
+				Headers:           map[string]string{"Authorization": "Bearer secret"},
+			},
 		},
 		Filters: []*config.AuditFilter{
 			{
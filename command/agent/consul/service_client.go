@@ -1566,7 +1566,7 @@ func (c *ServiceClient) RegisterWorkload(workload *serviceregistration.WorkloadS
 	for _, service := range workload.Services {
 		serviceID := serviceregistration.MakeAllocServiceID(workload.AllocInfo.AllocID, workload.Name(), service)
 		for _, check := range service.Checks {
-			if check.TriggersRestarts() {
+			if check.IsWatched() {
 				checkID := MakeCheckID(serviceID, check)
 				c.checkWatcher.Watch(workload.AllocInfo.AllocID, workload.Name(), checkID, check, workload.Restarter)
 			}
@@ -1604,7 +1604,7 @@ func (c *ServiceClient) UpdateWorkload(old, newWorkload *serviceregistration.Wor
 				ops.deregChecks = append(ops.deregChecks, cid)
 
 				// Unwatch watched checks
-				if check.TriggersRestarts() {
+				if check.IsWatched() {
 					c.checkWatcher.Unwatch(cid)
 				}
 			}
@@ -1661,7 +1661,7 @@ func (c *ServiceClient) UpdateWorkload(old, newWorkload *serviceregistration.Wor
 			}
 
 			// Update all watched checks as CheckRestart fields aren't part of ID
-			if check.TriggersRestarts() {
+			if check.IsWatched() {
 				c.checkWatcher.Watch(newWorkload.AllocInfo.AllocID, newWorkload.Name(), checkID, check, newWorkload.Restarter)
 			}
 		}
@@ -1671,7 +1671,7 @@ func (c *ServiceClient) UpdateWorkload(old, newWorkload *serviceregistration.Wor
 			ops.deregChecks = append(ops.deregChecks, cid)
 
 			// Unwatch checks
-			if check.TriggersRestarts() {
+			if check.IsWatched() {
 				c.checkWatcher.Unwatch(cid)
 			}
 		}
@@ -1703,7 +1703,7 @@ func (c *ServiceClient) UpdateWorkload(old, newWorkload *serviceregistration.Wor
 	// since an error building them could leak watches.
 	for serviceID, service := range newIDs {
 		for _, check := range service.Checks {
-			if check.TriggersRestarts() {
+			if check.IsWatched() {
 				checkID := MakeCheckID(serviceID, check)
 				c.checkWatcher.Watch(newWorkload.AllocInfo.AllocID, newWorkload.Name(), checkID, check, newWorkload.Restarter)
 			}
@@ -1727,7 +1727,7 @@ func (c *ServiceClient) RemoveWorkload(workload *serviceregistration.WorkloadSer
 			cid := MakeCheckID(id, check)
 			ops.deregChecks = append(ops.deregChecks, cid)
 
-			if check.TriggersRestarts() {
+			if check.IsWatched() {
 				c.checkWatcher.Unwatch(cid)
 			}
 		}
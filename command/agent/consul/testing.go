@@ -19,3 +19,7 @@ type noopRestarter struct{}
 func (noopRestarter) Restart(ctx context.Context, event *structs.TaskEvent, failure bool) error {
 	return nil
 }
+
+func (noopRestarter) Kill(ctx context.Context, event *structs.TaskEvent) error {
+	return nil
+}
@@ -67,6 +67,10 @@ func (r *restartRecorder) Restart(ctx context.Context, event *structs.TaskEvent,
 	return nil
 }
 
+func (r *restartRecorder) Kill(ctx context.Context, event *structs.TaskEvent) error {
+	return nil
+}
+
 // testFakeCtx contains a fake Consul AgentAPI
 type testFakeCtx struct {
 	ServiceClient *ServiceClient
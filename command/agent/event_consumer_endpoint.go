@@ -0,0 +1,150 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+func (s *HTTPServer) EventConsumersRequest(resp http.ResponseWriter, req *http.Request) (any, error) {
+	switch req.Method {
+	case http.MethodGet:
+		return s.eventConsumerList(resp, req)
+	case http.MethodPut, http.MethodPost:
+		return s.eventConsumerUpsert(resp, req, "")
+	default:
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) EventConsumerSpecificRequest(resp http.ResponseWriter, req *http.Request) (any, error) {
+	path := strings.TrimPrefix(req.URL.Path, "/v1/event/consumer/")
+	switch {
+	case path == "":
+		return nil, CodedError(http.StatusBadRequest, "Missing event consumer name")
+	case strings.HasSuffix(path, "/ack"):
+		name := strings.TrimSuffix(path, "/ack")
+		return s.eventConsumerAck(resp, req, name)
+	default:
+		return s.eventConsumerCRUD(resp, req, path)
+	}
+}
+
+func (s *HTTPServer) eventConsumerCRUD(resp http.ResponseWriter, req *http.Request, name string) (any, error) {
+	switch req.Method {
+	case http.MethodGet:
+		return s.eventConsumerQuery(resp, req, name)
+	case http.MethodPut, http.MethodPost:
+		return s.eventConsumerUpsert(resp, req, name)
+	case http.MethodDelete:
+		return s.eventConsumerDelete(resp, req, name)
+	default:
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) eventConsumerList(resp http.ResponseWriter, req *http.Request) (any, error) {
+	args := structs.EventConsumerListRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.EventConsumerListResponse
+	if err := s.agent.RPC("Event.ListConsumers", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Consumers == nil {
+		out.Consumers = make([]*structs.EventConsumer, 0)
+	}
+	return out.Consumers, nil
+}
+
+func (s *HTTPServer) eventConsumerQuery(resp http.ResponseWriter, req *http.Request, name string) (any, error) {
+	args := structs.EventConsumerSpecificRequest{
+		Name: name,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.SingleEventConsumerResponse
+	if err := s.agent.RPC("Event.GetConsumer", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Consumer == nil {
+		return nil, CodedError(http.StatusNotFound, "event consumer not found")
+	}
+
+	return out.Consumer, nil
+}
+
+func (s *HTTPServer) eventConsumerUpsert(resp http.ResponseWriter, req *http.Request, name string) (any, error) {
+	var consumer structs.EventConsumer
+	if err := decodeBody(req, &consumer); err != nil {
+		return nil, CodedError(http.StatusBadRequest, err.Error())
+	}
+
+	if name != "" && consumer.Name != name {
+		return nil, CodedError(http.StatusBadRequest, "Event consumer name does not match request path")
+	}
+
+	args := structs.EventConsumerUpsertRequest{
+		Consumer: &consumer,
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.GenericResponse
+	if err := s.agent.RPC("Event.UpsertConsumer", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return nil, nil
+}
+
+func (s *HTTPServer) eventConsumerDelete(resp http.ResponseWriter, req *http.Request, name string) (any, error) {
+	args := structs.EventConsumerDeleteRequest{
+		Name: name,
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.GenericResponse
+	if err := s.agent.RPC("Event.DeleteConsumer", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return nil, nil
+}
+
+func (s *HTTPServer) eventConsumerAck(resp http.ResponseWriter, req *http.Request, name string) (any, error) {
+	if req.Method != http.MethodPut && req.Method != http.MethodPost {
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+
+	var args structs.EventConsumerAckRequest
+	if err := decodeBody(req, &args); err != nil {
+		return nil, CodedError(http.StatusBadRequest, err.Error())
+	}
+	if args.Index == 0 {
+		return nil, CodedError(http.StatusBadRequest, "Missing index to acknowledge")
+	}
+	args.Name = name
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.GenericResponse
+	if err := s.agent.RPC("Event.AckConsumer", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return nil, nil
+}
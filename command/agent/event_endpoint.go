@@ -15,6 +15,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-bexpr"
 	"github.com/hashicorp/go-msgpack/v2/codec"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/moby/moby/v2/pkg/ioutils"
@@ -65,8 +66,9 @@ func (s *HTTPServer) EventStream(resp http.ResponseWriter, req *http.Request) (i
 	}
 
 	args := &structs.EventStreamRequest{
-		Topics: topics,
-		Index:  index,
+		Topics:   topics,
+		Index:    index,
+		Consumer: query.Get("consumer"),
 	}
 	resp.Header().Set("Content-Type", "application/json")
 	resp.Header().Set("Cache-Control", "no-cache")
@@ -74,6 +76,14 @@ func (s *HTTPServer) EventStream(resp http.ResponseWriter, req *http.Request) (i
 	// Set region, namespace and authtoken to args
 	s.parse(resp, req, &args.QueryOptions.Region, &args.QueryOptions)
 
+	// Validate the filter before the response is hijacked, so that an invalid
+	// expression can still be reported with a status code.
+	if args.Filter != "" {
+		if _, err := bexpr.CreateEvaluator(args.Filter); err != nil {
+			return nil, CodedError(400, fmt.Sprintf("Invalid filter query: %v", err))
+		}
+	}
+
 	// Determine the RPC handler to use to find a server
 	var handler structs.StreamingRpcHandler
 	var handlerErr error
@@ -369,6 +369,25 @@ func (s *HTTPServer) Shutdown() {
 	}
 }
 
+// ctxKeyAuthIdentity is the request context key of the identity the request
+// was already authenticated as, so that it isn't authenticated again.
+type ctxKeyAuthIdentity struct{}
+
+// setAuthIdentity stores the identity the request was authenticated as in the
+// request context.
+func setAuthIdentity(req *http.Request, identity *structs.AuthenticatedIdentity) {
+	if identity != nil {
+		*req = *req.WithContext(context.WithValue(req.Context(), ctxKeyAuthIdentity{}, identity))
+	}
+}
+
+// authIdentity returns the identity the request was already authenticated as,
+// or nil if it wasn't.
+func authIdentity(req *http.Request) *structs.AuthenticatedIdentity {
+	identity, _ := req.Context().Value(ctxKeyAuthIdentity{}).(*structs.AuthenticatedIdentity)
+	return identity
+}
+
 // ResolveToken extracts the ACL token secret ID from the request and
 // translates it into an ACL object. Returns nil if ACLs are disabled.
 func (s *HTTPServer) ResolveToken(req *http.Request) (*acl.ACL, error) {
@@ -380,9 +399,13 @@ func (s *HTTPServer) ResolveToken(req *http.Request) (*acl.ACL, error) {
 
 	if srv := s.agent.Server(); srv != nil {
 		r := &structs.GenericRequest{}
-		r.AuthToken = secret
-		if authErr := srv.Authenticate(nil, r); authErr != nil {
-			return nil, fmt.Errorf("ACL token not found or invalid workload identity: %v", authErr)
+		if identity := authIdentity(req); identity != nil {
+			r.SetIdentity(identity)
+		} else {
+			r.AuthToken = secret
+			if authErr := srv.Authenticate(nil, r); authErr != nil {
+				return nil, fmt.Errorf("ACL token not found or invalid workload identity: %v", authErr)
+			}
 		}
 
 		aclObj, err = srv.ResolveACL(r)
@@ -526,8 +549,12 @@ func (s *HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/system/reconcile/summaries", s.wrap(s.ReconcileJobSummaries))
 
 	s.mux.HandleFunc("/v1/operator/scheduler/configuration", s.wrap(s.OperatorSchedulerConfiguration))
+	s.mux.HandleFunc("/v1/operator/scheduler/simulate", s.wrap(s.OperatorSchedulerSimulate))
+	s.mux.HandleFunc("/v1/operator/scheduler/rebalance", s.wrap(s.OperatorSchedulerRebalance))
 
 	s.mux.HandleFunc("/v1/event/stream", s.wrap(s.EventStream))
+	s.mux.HandleFunc("/v1/event/consumers", s.wrap(s.EventConsumersRequest))
+	s.mux.HandleFunc("/v1/event/consumer/", s.wrap(s.EventConsumerSpecificRequest))
 
 	s.mux.HandleFunc("/v1/namespaces", s.wrap(s.NamespacesRequest))
 	s.mux.HandleFunc("/v1/namespace", s.wrap(s.NamespaceCreateRequest))
@@ -1258,5 +1285,6 @@ func (a *authMiddleware) ServeHTTP(resp http.ResponseWriter, req *http.Request)
 	}
 
 	a.srv.logger.Trace("Authenticated request", "id", reply.Identity, "method", req.Method, "url", req.URL)
+	setAuthIdentity(req, reply.Identity)
 	a.wrapped.ServeHTTP(resp, req)
 }
@@ -7,9 +7,18 @@
 package agent
 
 import (
+	"context"
+	"errors"
 	"net/http"
+
+	"github.com/hashicorp/nomad/command/agent/audit"
+	"github.com/hashicorp/nomad/nomad/structs"
 )
 
+// errAuditDelivery is returned for requests whose audit events could not be
+// delivered to a sink with enforced delivery.
+var errAuditDelivery = errors.New("audit event could not be delivered")
+
 // registerEnterpriseHandlers is a no-op for the oss release
 func (s *HTTPServer) registerEnterpriseHandlers() {
 	s.mux.HandleFunc("/v1/sentinel/policies", s.wrap(s.entOnly))
@@ -32,15 +41,157 @@ func (s *HTTPServer) entOnly(resp http.ResponseWriter, req *http.Request) (inter
 
 // auditHandler wraps the passed handlerFn
 func (s *HTTPServer) auditHandler(h handlerFn) handlerFn {
-	return h
+	return func(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+		if !s.auditEnabled() {
+			return h(resp, req)
+		}
+
+		event := s.newAuditEvent(req)
+		if err := s.auditEvent(req.Context(), event); err != nil {
+			return nil, CodedError(http.StatusInternalServerError, errAuditDelivery.Error())
+		}
+
+		obj, err := h(resp, req)
+		if auditErr := s.auditEvent(req.Context(), event.Complete(auditResponse(err))); auditErr != nil {
+			return nil, CodedError(http.StatusInternalServerError, errAuditDelivery.Error())
+		}
+		return obj, err
+	}
 }
 
 // auditNonJSONHandler wraps the passed handlerByteFn
 func (s *HTTPServer) auditNonJSONHandler(h handlerByteFn) handlerByteFn {
-	return h
+	return func(resp http.ResponseWriter, req *http.Request) ([]byte, error) {
+		if !s.auditEnabled() {
+			return h(resp, req)
+		}
+
+		event := s.newAuditEvent(req)
+		if err := s.auditEvent(req.Context(), event); err != nil {
+			return nil, CodedError(http.StatusInternalServerError, errAuditDelivery.Error())
+		}
+
+		obj, err := h(resp, req)
+		if auditErr := s.auditEvent(req.Context(), event.Complete(auditResponse(err))); auditErr != nil {
+			return nil, CodedError(http.StatusInternalServerError, errAuditDelivery.Error())
+		}
+		return obj, err
+	}
 }
 
 // auditHTTPHandler wraps the passed http.Handler
 func (s *HTTPServer) auditHTTPHandler(h http.Handler) http.Handler {
-	return h
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if !s.auditEnabled() {
+			h.ServeHTTP(resp, req)
+			return
+		}
+
+		event := s.newAuditEvent(req)
+		if err := s.auditEvent(req.Context(), event); err != nil {
+			resp.Header().Set(contentTypeHeader, plainContentType)
+			resp.WriteHeader(http.StatusInternalServerError)
+			resp.Write([]byte(errAuditDelivery.Error()))
+			return
+		}
+
+		// the response is already written once the handler returns, so
+		// failures to deliver the complete event are only logged by the
+		// auditor
+		rw := &auditResponseWriter{ResponseWriter: resp, status: http.StatusOK}
+		h.ServeHTTP(rw, req)
+		_ = s.auditEvent(req.Context(), event.Complete(&audit.Response{StatusCode: rw.status}))
+	})
+}
+
+func (s *HTTPServer) auditEnabled() bool {
+	return s.eventAuditor != nil && s.eventAuditor.Enabled()
+}
+
+// auditEvent writes the event to the auditor, and returns an error if it could
+// not be delivered and delivery is enforced.
+func (s *HTTPServer) auditEvent(ctx context.Context, event *audit.Event) error {
+	err := s.eventAuditor.Event(ctx, audit.EventType, event)
+	if err != nil && s.eventAuditor.DeliveryEnforced() {
+		return err
+	}
+	return nil
+}
+
+// newAuditEvent returns the audit event of the request at its received stage.
+func (s *HTTPServer) newAuditEvent(req *http.Request) *audit.Event {
+	var namespace string
+	parseNamespace(req, &namespace)
+
+	return audit.NewEvent(audit.NewAuth(s.resolveAuditIdentity(req)), &audit.Request{
+		ID:        req.Header.Get("X-Request-Id"),
+		Operation: req.Method,
+		Endpoint:  req.URL.Path,
+		Namespace: namespace,
+		RequestMeta: map[string]string{
+			"remote_address": req.RemoteAddr,
+			"user_agent":     req.UserAgent(),
+		},
+		NodeMeta: map[string]string{
+			"ip": s.Addr,
+		},
+	})
+}
+
+// resolveAuditIdentity returns the identity authenticated by the token of the
+// request, or nil if it could not be resolved. The identity the request was
+// already authenticated as is reused, and a resolved identity is stored in the
+// request so that its handler doesn't authenticate it again. Requests with
+// invalid tokens are still audited, and rejected by their handler.
+func (s *HTTPServer) resolveAuditIdentity(req *http.Request) *structs.AuthenticatedIdentity {
+	if identity := authIdentity(req); identity != nil {
+		return identity
+	}
+
+	var secret string
+	s.parseToken(req, &secret)
+
+	var identity *structs.AuthenticatedIdentity
+	if srv := s.agent.Server(); srv != nil {
+		r := &structs.GenericRequest{}
+		r.AuthToken = secret
+		if err := srv.Authenticate(nil, r); err != nil {
+			return nil
+		}
+		identity = r.GetIdentity()
+	} else if c := s.agent.Client(); c != nil {
+		ident, err := c.ResolveIdentity(secret)
+		if err != nil {
+			return nil
+		}
+		identity = ident
+	}
+
+	setAuthIdentity(req, identity)
+	return identity
+}
+
+// auditResponse returns the audited response of a handler.
+func auditResponse(err error) *audit.Response {
+	if err == nil {
+		return &audit.Response{StatusCode: http.StatusOK}
+	}
+	code, errMsg := errCodeFromHandler(err)
+	return &audit.Response{StatusCode: code, Error: errMsg}
+}
+
+// auditResponseWriter records the status code written by an http.Handler.
+type auditResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *auditResponseWriter) WriteHeader(code int) {
+	w.status = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Unwrap allows http.ResponseController to access the wrapped writer.
+func (w *auditResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
 }
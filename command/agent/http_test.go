@@ -16,6 +16,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"path/filepath"
 	"slices"
 	"strconv"
 	"strings"
@@ -1432,6 +1433,109 @@ func TestHTTPServer_Limits_OK(t *testing.T) {
 	}
 }
 
+func TestHTTPServer_Audit(t *testing.T) {
+	ci.Parallel(t)
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	s := makeHTTPServer(t, func(c *Config) {
+		c.Client.Enabled = false
+		c.ACL = &ACLConfig{Enabled: true}
+		c.Audit = &config.AuditConfig{
+			Enabled: new(true),
+			Sinks: []*config.AuditSink{{
+				Name:              "file",
+				Type:              "file",
+				DeliveryGuarantee: "enforced",
+				Format:            "json",
+				Path:              path,
+			}},
+		}
+	})
+	defer s.Shutdown()
+
+	token := mock.CreatePolicyAndToken(t, s.Agent.server.State(), 1000, "ns",
+		mock.NamespacePolicy("default", "read", nil))
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/jobs?namespace=default", nil)
+	must.NoError(t, err)
+	setToken(req, token)
+	respW := httptest.NewRecorder()
+	s.Server.wrap(s.Server.JobsRequest)(respW, req)
+	must.Eq(t, http.StatusOK, respW.Code)
+
+	// the identity resolved for the audit event is kept for the handler
+	must.NotNil(t, authIdentity(req))
+	must.Eq(t, token.AccessorID, authIdentity(req).GetACLToken().AccessorID)
+
+	// requests with invalid tokens are audited with their error
+	req, err = http.NewRequest(http.MethodGet, "/v1/jobs", nil)
+	must.NoError(t, err)
+	setToken(req, mock.ACLToken())
+	respW = httptest.NewRecorder()
+	s.Server.wrap(s.Server.JobsRequest)(respW, req)
+	must.Eq(t, http.StatusForbidden, respW.Code)
+
+	type auditEntry struct {
+		EventType string `json:"event_type"`
+		Payload   struct {
+			Stage string `json:"stage"`
+			Auth  *struct {
+				AccessorID string `json:"accessor_id"`
+			} `json:"auth"`
+			Request struct {
+				Operation string `json:"operation"`
+				Endpoint  string `json:"endpoint"`
+				Namespace string `json:"namespace"`
+			} `json:"request"`
+			Response *struct {
+				StatusCode int    `json:"status_code"`
+				Error      string `json:"error"`
+			} `json:"response"`
+		} `json:"payload"`
+	}
+
+	b, err := os.ReadFile(path)
+	must.NoError(t, err)
+	must.StrNotContains(t, string(b), token.SecretID)
+
+	var entries []*auditEntry
+	dec := json.NewDecoder(bytes.NewReader(b))
+	for dec.More() {
+		e := new(auditEntry)
+		must.NoError(t, dec.Decode(e))
+		entries = append(entries, e)
+	}
+	must.Len(t, 4, entries)
+
+	must.Eq(t, "audit", entries[0].EventType)
+	must.Eq(t, "OperationReceived", entries[0].Payload.Stage)
+	must.Eq(t, token.AccessorID, entries[0].Payload.Auth.AccessorID)
+	must.Eq(t, http.MethodGet, entries[0].Payload.Request.Operation)
+	must.Eq(t, "/v1/jobs", entries[0].Payload.Request.Endpoint)
+	must.Eq(t, "default", entries[0].Payload.Request.Namespace)
+	must.Nil(t, entries[0].Payload.Response)
+
+	must.Eq(t, "OperationComplete", entries[1].Payload.Stage)
+	must.Eq(t, http.StatusOK, entries[1].Payload.Response.StatusCode)
+
+	must.Nil(t, entries[2].Payload.Auth)
+	must.Eq(t, http.StatusForbidden, entries[3].Payload.Response.StatusCode)
+	must.Eq(t, structs.ErrPermissionDenied.Error(), entries[3].Payload.Response.Error)
+
+	// requests fail once the audit log can't be written
+	must.NoError(t, os.Remove(path))
+	must.NoError(t, os.Mkdir(path, 0700))
+	must.Error(t, s.Agent.auditor.Reopen())
+
+	req, err = http.NewRequest(http.MethodGet, "/v1/jobs", nil)
+	must.NoError(t, err)
+	setToken(req, token)
+	respW = httptest.NewRecorder()
+	s.Server.wrap(s.Server.JobsRequest)(respW, req)
+	must.Eq(t, http.StatusInternalServerError, respW.Code)
+	must.Eq(t, "audit event could not be delivered", respW.Body.String())
+}
+
 func TestHTTPServer_ResolveToken(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1482,6 +1586,21 @@ func TestHTTPServer_ResolveToken(t *testing.T) {
 		must.True(t, got.AllowNodeWrite())
 	})
 
+	t.Run("authenticated identity", func(t *testing.T) {
+		// the identity the request was already authenticated as is used
+		// instead of its token
+		req := &http.Request{
+			Body:   http.NoBody,
+			Header: make(map[string][]string),
+		}
+		setToken(req, mock.ACLToken())
+		setAuthIdentity(req, &structs.AuthenticatedIdentity{ACLToken: token})
+		got, err := ACLServer.Server.ResolveToken(req)
+		must.NoError(t, err)
+		must.NotNil(t, got)
+		must.True(t, got.AllowNodeWrite())
+	})
+
 	t.Run("WI token", func(t *testing.T) {
 		srv, _, encrypter, cleanup := nomad.TestACLServerWithEncrypter(t, nil)
 		t.Cleanup(cleanup)
@@ -1184,6 +1184,26 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 		if job.Periodic.Specs != nil {
 			j.Periodic.Specs = job.Periodic.Specs
 		}
+
+		if job.Periodic.ConcurrencyPolicy != nil {
+			j.Periodic.ConcurrencyPolicy = *job.Periodic.ConcurrencyPolicy
+		}
+
+		if job.Periodic.MissedRuns != nil {
+			j.Periodic.MissedRuns = *job.Periodic.MissedRuns
+		}
+
+		if job.Periodic.MaxMissedRuns != nil {
+			j.Periodic.MaxMissedRuns = *job.Periodic.MaxMissedRuns
+		}
+
+		if job.Periodic.StartingDeadline != nil {
+			j.Periodic.StartingDeadline = *job.Periodic.StartingDeadline
+		}
+
+		if job.Periodic.ChildHistoryLimit != nil {
+			j.Periodic.ChildHistoryLimit = *job.Periodic.ChildHistoryLimit
+		}
 	}
 
 	if job.ParameterizedJob != nil {
@@ -1227,6 +1247,9 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.TaskGroup) {
 	tg.Name = *taskGroup.Name
 	tg.Count = *taskGroup.Count
+	if taskGroup.Gang != nil {
+		tg.Gang = *taskGroup.Gang
+	}
 	tg.Meta = taskGroup.Meta
 	tg.Constraints = ApiConstraintsToStructs(taskGroup.Constraints)
 	tg.Affinities = ApiAffinitiesToStructs(taskGroup.Affinities)
@@ -1234,13 +1257,7 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 	tg.Services = ApiServicesToStructs(taskGroup.Services, true)
 	tg.Consul = apiConsulToStructs(taskGroup.Consul)
 
-	tg.RestartPolicy = &structs.RestartPolicy{
-		Attempts:        *taskGroup.RestartPolicy.Attempts,
-		Interval:        *taskGroup.RestartPolicy.Interval,
-		Delay:           *taskGroup.RestartPolicy.Delay,
-		Mode:            *taskGroup.RestartPolicy.Mode,
-		RenderTemplates: *taskGroup.RestartPolicy.RenderTemplates,
-	}
+	tg.RestartPolicy = apiRestartPolicyToStructs(taskGroup.RestartPolicy)
 
 	if taskGroup.ShutdownDelay != nil {
 		tg.ShutdownDelay = taskGroup.ShutdownDelay
@@ -1285,6 +1302,13 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		}
 	}
 
+	if taskGroup.DisruptionBudget != nil {
+		tg.DisruptionBudget = &structs.DisruptionBudget{
+			MaxUnavailable: *taskGroup.DisruptionBudget.MaxUnavailable,
+			MinAvailable:   *taskGroup.DisruptionBudget.MinAvailable,
+		}
+	}
+
 	if taskGroup.Scaling != nil {
 		tg.Scaling = ApiScalingPolicyToStructs(
 			job, tg, nil, tg.Count, taskGroup.Scaling)
@@ -1295,6 +1319,12 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		SizeMB:  *taskGroup.EphemeralDisk.SizeMB,
 		Migrate: *taskGroup.EphemeralDisk.Migrate,
 	}
+	if taskGroup.EphemeralDisk.PreCopy != nil {
+		tg.EphemeralDisk.PreCopy = *taskGroup.EphemeralDisk.PreCopy
+	}
+	if taskGroup.EphemeralDisk.Enforcement != nil {
+		tg.EphemeralDisk.Enforcement = *taskGroup.EphemeralDisk.Enforcement
+	}
 
 	if len(taskGroup.Spreads) > 0 {
 		tg.Spreads = []*structs.Spread{}
@@ -1353,6 +1383,26 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		if taskGroup.Update.AutoPromote != nil {
 			tg.Update.AutoPromote = *taskGroup.Update.AutoPromote
 		}
+
+		for _, w := range taskGroup.Update.Windows {
+			tg.Update.Windows = append(tg.Update.Windows, &structs.UpdateWindow{
+				Cron:     *w.Cron,
+				Duration: *w.Duration,
+				TimeZone: *w.TimeZone,
+			})
+		}
+
+		if a := taskGroup.Update.Analysis; a != nil {
+			tg.Update.Analysis = &structs.UpdateAnalysis{
+				Provider:     *a.Provider,
+				Query:        *a.Query,
+				Interval:     *a.Interval,
+				Count:        *a.Count,
+				FailureLimit: *a.FailureLimit,
+				Min:          a.Min,
+				Max:          a.Max,
+			}
+		}
 	}
 
 	if len(taskGroup.Tasks) > 0 {
@@ -1409,13 +1459,7 @@ func ApiTaskToStructsTask(job *structs.Job, group *structs.TaskGroup,
 	}
 
 	if apiTask.RestartPolicy != nil {
-		structsTask.RestartPolicy = &structs.RestartPolicy{
-			Attempts:        *apiTask.RestartPolicy.Attempts,
-			Interval:        *apiTask.RestartPolicy.Interval,
-			Delay:           *apiTask.RestartPolicy.Delay,
-			Mode:            *apiTask.RestartPolicy.Mode,
-			RenderTemplates: *apiTask.RestartPolicy.RenderTemplates,
-		}
+		structsTask.RestartPolicy = apiRestartPolicyToStructs(apiTask.RestartPolicy)
 	}
 
 	structsTask.VolumeMounts = apiVolumeMountsToStructs(apiTask.VolumeMounts)
@@ -1468,11 +1512,15 @@ func ApiTaskToStructsTask(job *structs.Job, group *structs.TaskGroup,
 		structsTask.Secrets = []*structs.Secret{}
 		for _, s := range apiTask.Secrets {
 			structsTask.Secrets = append(structsTask.Secrets, &structs.Secret{
-				Name:     s.Name,
-				Provider: s.Provider,
-				Path:     s.Path,
-				Config:   s.Config,
-				Env:      s.Env,
+				Name:            s.Name,
+				Provider:        s.Provider,
+				Path:            s.Path,
+				Config:          s.Config,
+				Env:             s.Env,
+				RefreshInterval: s.RefreshInterval,
+				ChangeMode:      s.ChangeMode,
+				ChangeSignal:    s.ChangeSignal,
+				ChangeScript:    apiChangeScriptToStructsChangeScript(s.ChangeScript),
 			})
 		}
 	}
@@ -1689,6 +1737,18 @@ func ApiNetworkResourceToStructs(in []*api.NetworkResource) []*structs.NetworkRe
 				out[i].ReservedPorts[j] = ApiPortToStructs(rp)
 			}
 		}
+
+		if l := len(nw.Upstreams); l != 0 {
+			out[i].Upstreams = make(structs.NetworkUpstreams, l)
+			for j, u := range nw.Upstreams {
+				out[i].Upstreams[j] = &structs.NetworkUpstream{
+					DestinationName:      u.DestinationName,
+					DestinationNamespace: u.DestinationNamespace,
+					LocalBindAddress:     u.LocalBindAddress,
+					LocalBindPort:        u.LocalBindPort,
+				}
+			}
+		}
 	}
 
 	return out
@@ -1761,6 +1821,8 @@ func ApiServicesToStructs(in []*api.Service, group bool) []*structs.Service {
 					FailuresBeforeCritical: check.FailuresBeforeCritical,
 					FailuresBeforeWarning:  check.FailuresBeforeWarning,
 					OnUpdate:               onUpdate,
+					OnStart:                check.OnStart,
+					StartupTimeout:         check.StartupTimeout,
 				}
 
 				if group {
@@ -2187,6 +2249,25 @@ func apiVolumeMountsToStructs(in []*api.VolumeMount) []*structs.VolumeMount {
 	return out
 }
 
+// apiRestartPolicyToStructs converts a canonicalized api.RestartPolicy to
+// its structs counterpart.
+func apiRestartPolicyToStructs(rp *api.RestartPolicy) *structs.RestartPolicy {
+	out := &structs.RestartPolicy{
+		Attempts:        *rp.Attempts,
+		Interval:        *rp.Interval,
+		Delay:           *rp.Delay,
+		Mode:            *rp.Mode,
+		RenderTemplates: *rp.RenderTemplates,
+	}
+	if rp.DelayFunction != nil {
+		out.DelayFunction = *rp.DelayFunction
+	}
+	if rp.MaxDelay != nil {
+		out.MaxDelay = *rp.MaxDelay
+	}
+	return out
+}
+
 func apiConsulToStructs(in *api.Consul) *structs.Consul {
 	if in == nil {
 		return nil
@@ -2203,11 +2284,26 @@ func apiLogConfigToStructs(in *api.LogConfig) *structs.LogConfig {
 		return nil
 	}
 
-	return &structs.LogConfig{
+	out := &structs.LogConfig{
 		Disabled:      dereferenceBool(in.Disabled),
 		MaxFiles:      dereferenceInt(in.MaxFiles),
 		MaxFileSizeMB: dereferenceInt(in.MaxFileSizeMB),
 	}
+	if in.RotationInterval != nil {
+		out.RotationInterval = *in.RotationInterval
+	}
+	if in.Compression != nil {
+		out.Compression = *in.Compression
+	}
+	for _, sink := range in.Sinks {
+		out.Sinks = append(out.Sinks, &structs.LogSink{
+			Type:       sink.Type,
+			Address:    sink.Address,
+			Headers:    maps.Clone(sink.Headers),
+			BufferSize: dereferenceInt(sink.BufferSize),
+		})
+	}
+	return out
 }
 
 func dereferenceBool(in *bool) bool {
@@ -2311,6 +2407,7 @@ func ApiSpreadToStructs(a1 *api.Spread) *structs.Spread {
 	ret := &structs.Spread{}
 	ret.Attribute = a1.Attribute
 	ret.Weight = *a1.Weight
+	ret.MaxSkew = a1.MaxSkew
 	if a1.SpreadTarget != nil {
 		ret.SpreadTarget = make([]*structs.SpreadTarget, len(a1.SpreadTarget))
 		for i, st := range a1.SpreadTarget {
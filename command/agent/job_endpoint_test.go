@@ -2739,12 +2739,17 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 			},
 		},
 		Periodic: &api.PeriodicConfig{
-			Enabled:         new(true),
-			Spec:            new("spec"),
-			Specs:           []string{"spec"},
-			SpecType:        new("cron"),
-			ProhibitOverlap: new(true),
-			TimeZone:        new("test zone"),
+			Enabled:           new(true),
+			Spec:              new("spec"),
+			Specs:             []string{"spec"},
+			SpecType:          new("cron"),
+			ProhibitOverlap:   new(true),
+			TimeZone:          new("test zone"),
+			ConcurrencyPolicy: new("forbid"),
+			MissedRuns:        new("run-all"),
+			MaxMissedRuns:     new(3),
+			StartingDeadline:  new(time.Hour),
+			ChildHistoryLimit: new(5),
 		},
 		ParameterizedJob: &api.ParameterizedJobConfig{
 			Payload:      "payload",
@@ -3177,12 +3182,17 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 			MaxParallel: 5,
 		},
 		Periodic: &structs.PeriodicConfig{
-			Enabled:         true,
-			Spec:            "spec",
-			Specs:           []string{"spec"},
-			SpecType:        "cron",
-			ProhibitOverlap: true,
-			TimeZone:        "test zone",
+			Enabled:           true,
+			Spec:              "spec",
+			Specs:             []string{"spec"},
+			SpecType:          "cron",
+			ProhibitOverlap:   true,
+			TimeZone:          "test zone",
+			ConcurrencyPolicy: "forbid",
+			MissedRuns:        "run-all",
+			MaxMissedRuns:     3,
+			StartingDeadline:  time.Hour,
+			ChildHistoryLimit: 5,
 		},
 		ParameterizedJob: &structs.ParameterizedJobConfig{
 			Payload:      "payload",
@@ -3523,6 +3533,7 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 							Disabled:      true,
 							MaxFiles:      10,
 							MaxFileSizeMB: 100,
+							Compression:   "none",
 						},
 						Artifacts: []*structs.TaskArtifact{
 							{
@@ -3829,6 +3840,7 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 							Disabled:      true,
 							MaxFiles:      10,
 							MaxFileSizeMB: 100,
+							Compression:   "none",
 						},
 						Artifacts: []*structs.TaskArtifact{
 							{
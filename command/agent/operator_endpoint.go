@@ -306,19 +306,7 @@ func (s *HTTPServer) schedulerUpdateConfig(resp http.ResponseWriter, req *http.R
 		return nil, CodedError(http.StatusBadRequest, fmt.Sprintf("Error parsing scheduler config: %v", err))
 	}
 
-	args.Config = structs.SchedulerConfiguration{
-		SchedulerAlgorithm:            structs.SchedulerAlgorithm(conf.SchedulerAlgorithm),
-		MemoryOversubscriptionEnabled: conf.MemoryOversubscriptionEnabled,
-		RejectJobRegistration:         conf.RejectJobRegistration,
-		PauseEvalBroker:               conf.PauseEvalBroker,
-		NodeLimitForFeasibilityChecks: conf.NodeLimitForFeasibilityChecks,
-		PreemptionConfig: structs.PreemptionConfig{
-			SystemSchedulerEnabled:   conf.PreemptionConfig.SystemSchedulerEnabled,
-			SysBatchSchedulerEnabled: conf.PreemptionConfig.SysBatchSchedulerEnabled,
-			BatchSchedulerEnabled:    conf.PreemptionConfig.BatchSchedulerEnabled,
-			ServiceSchedulerEnabled:  conf.PreemptionConfig.ServiceSchedulerEnabled,
-		},
-	}
+	args.Config = ApiSchedulerConfigToStructs(&conf)
 
 	if err := args.Config.Validate(); err != nil {
 		return nil, CodedError(http.StatusBadRequest, err.Error())
@@ -343,6 +331,94 @@ func (s *HTTPServer) schedulerUpdateConfig(resp http.ResponseWriter, req *http.R
 	return reply, nil
 }
 
+// ApiSchedulerConfigToStructs converts the API scheduler configuration to its
+// structs counterpart.
+func ApiSchedulerConfigToStructs(conf *api.SchedulerConfiguration) structs.SchedulerConfiguration {
+	return structs.SchedulerConfiguration{
+		SchedulerAlgorithm:            structs.SchedulerAlgorithm(conf.SchedulerAlgorithm),
+		MemoryOversubscriptionEnabled: conf.MemoryOversubscriptionEnabled,
+		RejectJobRegistration:         conf.RejectJobRegistration,
+		PauseEvalBroker:               conf.PauseEvalBroker,
+		NodeLimitForFeasibilityChecks: conf.NodeLimitForFeasibilityChecks,
+		NamespaceWeights:              conf.NamespaceWeights,
+		PreemptionConfig: structs.PreemptionConfig{
+			SystemSchedulerEnabled:   conf.PreemptionConfig.SystemSchedulerEnabled,
+			SysBatchSchedulerEnabled: conf.PreemptionConfig.SysBatchSchedulerEnabled,
+			BatchSchedulerEnabled:    conf.PreemptionConfig.BatchSchedulerEnabled,
+			ServiceSchedulerEnabled:  conf.PreemptionConfig.ServiceSchedulerEnabled,
+		},
+	}
+}
+
+// OperatorSchedulerSimulate is used to run the schedulers against hypothetical
+// changes to the cluster state.
+func (s *HTTPServer) OperatorSchedulerSimulate(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodPut && req.Method != http.MethodPost {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var args structs.SchedulerSimulateRequest
+	if done := s.parse(resp, req, &args.Region, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	var body api.SchedulerSimulateRequest
+	if err := decodeBody(req, &body); err != nil {
+		return nil, CodedError(http.StatusBadRequest, fmt.Sprintf("Error parsing simulation request: %v", err))
+	}
+
+	queryNamespace := req.URL.Query().Get("namespace")
+	for _, job := range body.Jobs {
+		if job == nil || job.ID == nil {
+			return nil, CodedError(http.StatusBadRequest, "Job must have a valid ID")
+		}
+		job.Namespace = new(namespaceForJob(job.Namespace, queryNamespace, ""))
+		args.Jobs = append(args.Jobs, ApiJobToStructJob(job))
+	}
+
+	args.RemoveNodes = body.RemoveNodes
+	args.NodePools = body.NodePools
+	if body.SchedulerConfig != nil {
+		config := ApiSchedulerConfigToStructs(body.SchedulerConfig)
+		args.SchedulerConfig = &config
+	}
+
+	var reply structs.SchedulerSimulateResponse
+	if err := s.agent.RPC("Operator.SchedulerSimulate", &args, &reply); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &reply.QueryMeta)
+	return reply, nil
+}
+
+func (s *HTTPServer) OperatorSchedulerRebalance(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodPut && req.Method != http.MethodPost {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var body api.SchedulerRebalanceRequest
+	if err := decodeBody(req, &body); err != nil {
+		return nil, CodedError(http.StatusBadRequest, fmt.Sprintf("Error parsing rebalance request: %v", err))
+	}
+	if body.MaxMoves < 0 {
+		return nil, CodedError(http.StatusBadRequest, "MaxMoves must not be negative")
+	}
+
+	args := structs.SchedulerRebalanceRequest{
+		NodePool: body.NodePool,
+		MaxMoves: body.MaxMoves,
+		DryRun:   body.DryRun,
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var reply structs.SchedulerRebalanceResponse
+	if err := s.agent.RPC("Operator.SchedulerRebalance", &args, &reply); err != nil {
+		return nil, err
+	}
+	setIndex(resp, reply.Index)
+	return reply, nil
+}
+
 func (s *HTTPServer) SnapshotRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	switch req.Method {
 	case http.MethodGet:
@@ -208,6 +208,17 @@ audit {
     rotate_max_files   = 10
   }
 
+  sink "webhook" {
+    type               = "http"
+    delivery_guarantee = "best-effort"
+    format             = "json"
+    address            = "https://audit.example.com/nomad"
+
+    headers {
+      Authorization = "Bearer secret"
+    }
+  }
+
   filter "default" {
     type       = "HTTPEvent"
     endpoints  = ["/v1/metrics"]
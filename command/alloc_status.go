@@ -44,7 +44,9 @@ Alloc Status Options:
     Display short output. Shows only the most recent task event.
 
   -stats
-    Display detailed resource usage statistics.
+    Display detailed resource usage statistics, including the disk usage of
+    the allocation's ephemeral disk on Linux clients when its size is
+    enforced.
 
   -verbose
     Show full information.
@@ -234,6 +236,9 @@ func (c *AllocStatusCommand) Run(args []string) int {
 				c.Ui.Output("Omitting resource statistics since the node is down.")
 			}
 		}
+		if displayStats && stats != nil && stats.DiskStats != nil {
+			c.outputAllocDiskStats(stats.DiskStats)
+		}
 		c.outputTaskDetails(alloc, stats, displayStats, verbose)
 	}
 
@@ -424,6 +429,21 @@ func futureEvalTimePretty(evalID string, client *api.Client) string {
 	return prettyTimeDiff(evaluation.WaitUntil, time.Now())
 }
 
+// outputAllocDiskStats prints the disk usage of the allocation's ephemeral
+// disk.
+func (c *AllocStatusCommand) outputAllocDiskStats(stats *api.AllocDiskStats) {
+	usage := "N/A"
+	if stats.Size > 0 {
+		usage = fmt.Sprintf("%.2f%%", float64(stats.Used)/float64(stats.Size)*100)
+	}
+	c.Ui.Output(c.Colorize().Color("\n[bold]Ephemeral Disk[reset]"))
+	c.Ui.Output(formatKV([]string{
+		fmt.Sprintf("Used|%s", humanize.IBytes(stats.Used)),
+		fmt.Sprintf("Size|%s", humanize.IBytes(stats.Size)),
+		fmt.Sprintf("Usage|%s", usage),
+	}))
+}
+
 // outputTaskDetails prints task details for each task in the allocation,
 // optionally printing verbose statistics if displayStats is set
 func (c *AllocStatusCommand) outputTaskDetails(alloc *api.Allocation, stats *api.AllocResourceUsage, displayStats bool, verbose bool) {
@@ -440,7 +460,12 @@ func (c *AllocStatusCommand) outputTaskDetails(alloc *api.Allocation, stats *api
 			lcIndicator = " (" + lifecycleDisplayName(lc) + ")"
 		}
 
-		c.Ui.Output(c.Colorize().Color(fmt.Sprintf("\n[bold]Task %q%v is %q[reset]", task, lcIndicator, state.State)))
+		stateIndicator := ""
+		if state.CrashLooping {
+			stateIndicator = " (crash looping)"
+		}
+
+		c.Ui.Output(c.Colorize().Color(fmt.Sprintf("\n[bold]Task %q%v is %q%v[reset]", task, lcIndicator, state.State, stateIndicator)))
 		c.outputTaskResources(alloc, task, stats, displayStats)
 		c.Ui.Output("")
 		c.outputTaskVolumes(alloc, task, verbose)
@@ -561,6 +586,12 @@ func buildDisplayMessage(event *api.TaskEvent) string {
 		} else {
 			desc = "Task exceeded restart policy"
 		}
+	case api.TaskCrashLooping:
+		if event.RestartReason != "" {
+			desc = fmt.Sprintf("Task is crash looping - %s", event.RestartReason)
+		} else {
+			desc = "Task is crash looping"
+		}
 	case api.TaskSiblingFailed:
 		if event.FailedSibling != "" {
 			desc = fmt.Sprintf("Task's sibling %q failed", event.FailedSibling)
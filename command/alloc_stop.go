@@ -43,6 +43,10 @@ Stop Specific Options:
     screen, which can be used to examine the rescheduling evaluation using the
     eval-status command.
 
+  -ignore-disruption-budget
+    Stop the allocation even if doing so violates the disruption_budget of
+    its task group.
+
   -no-shutdown-delay
     Ignore the group and task shutdown_delay configuration so there is no
     delay between service deregistration and task shutdown. Note that using
@@ -58,13 +62,14 @@ Stop Specific Options:
 func (c *AllocStopCommand) Name() string { return "alloc stop" }
 
 func (c *AllocStopCommand) Run(args []string) int {
-	var detach, verbose, noShutdownDelay bool
+	var detach, verbose, noShutdownDelay, ignoreBudget bool
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&detach, "detach", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
 	flags.BoolVar(&noShutdownDelay, "no-shutdown-delay", false, "")
+	flags.BoolVar(&ignoreBudget, "ignore-disruption-budget", false, "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -137,6 +142,10 @@ func (c *AllocStopCommand) Run(args []string) int {
 		opts.Params["no_shutdown_delay"] = "true"
 	}
 
+	if ignoreBudget {
+		opts.Params["ignore_disruption_budget"] = "true"
+	}
+
 	resp, err := client.Allocations().Stop(alloc, opts)
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error stopping allocation: %s", err))
@@ -159,9 +168,10 @@ func (c *AllocStopCommand) Synopsis() string {
 func (c *AllocStopCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-detach":            complete.PredictNothing,
-			"-verbose":           complete.PredictNothing,
-			"-no-shutdown-delay": complete.PredictNothing,
+			"-detach":                   complete.PredictNothing,
+			"-verbose":                  complete.PredictNothing,
+			"-no-shutdown-delay":        complete.PredictNothing,
+			"-ignore-disruption-budget": complete.PredictNothing,
 		})
 }
 
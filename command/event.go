@@ -0,0 +1,47 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"strings"
+
+	"github.com/hashicorp/cli"
+)
+
+type EventCommand struct {
+	Meta
+}
+
+func (f *EventCommand) Help() string {
+	helpText := `
+Usage: nomad event <subcommand> [options] [args]
+
+  This command groups subcommands for interacting with the event stream. The
+  event stream emits events for changes to the cluster state, such as jobs
+  being registered or allocations being updated.
+
+  Stream all events:
+
+      $ nomad event stream
+
+  Stream the events of failed allocations of a job:
+
+      $ nomad event stream -topic Allocation \
+          -filter 'Payload.Allocation.JobID == "example" and Payload.Allocation.ClientStatus == "failed"'
+
+  Please see the individual subcommand help for detailed usage information.
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (f *EventCommand) Synopsis() string {
+	return "Interact with the event stream"
+}
+
+func (f *EventCommand) Name() string { return "event" }
+
+func (f *EventCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
@@ -0,0 +1,174 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/hashicorp/nomad/api"
+	flaghelper "github.com/hashicorp/nomad/helper/flags"
+	"github.com/posener/complete"
+)
+
+type EventStreamCommand struct {
+	Meta
+}
+
+func (c *EventStreamCommand) Help() string {
+	helpText := `
+Usage: nomad event stream [options]
+
+  Stream events from the cluster event stream. Each event is written as a
+  single line of JSON until the command is interrupted.
+
+  When ACLs are enabled, this command requires a token with the capabilities
+  required by the streamed topics, such as 'read-job' for the Job topic.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Event Stream Options:
+
+  -topic <topic[:key]>
+    Stream the events of the topic, optionally only those for the key, such
+    as "Job:example". May be specified multiple times. Defaults to all topics.
+
+  -index <index>
+    Stream the events starting at the raft index. Defaults to the latest
+    events.
+
+  -filter <expression>
+    Only stream the events matching the filter expression. The expression is
+    evaluated by the server against each event, and uses the same syntax as
+    the filter of list commands. For example:
+    'Payload.Allocation.ClientStatus == "failed"'
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *EventStreamCommand) Synopsis() string {
+	return "Stream events from the event stream"
+}
+
+func (c *EventStreamCommand) Name() string { return "event stream" }
+
+func (c *EventStreamCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-topic":  complete.PredictAnything,
+			"-index":  complete.PredictAnything,
+			"-filter": complete.PredictAnything,
+		})
+}
+
+func (c *EventStreamCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *EventStreamCommand) Run(args []string) int {
+	var topicArgs []string
+	var index uint64
+	var filter string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.Var((*flaghelper.StringFlag)(&topicArgs), "topic", "")
+	flags.Uint64Var(&index, "index", 0, "")
+	flags.StringVar(&filter, "filter", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if len(flags.Args()) != 0 {
+		c.Ui.Error(uiMessageNoArguments)
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	topics, err := parseEventStreamTopics(topicArgs)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Stop streaming when the command receives an interrupt or terminate
+	// signal.
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signalCh)
+	go func() {
+		select {
+		case <-signalCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	eventsCh, err := client.EventStream().Stream(ctx, topics, index, &api.QueryOptions{Filter: filter})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error streaming events: %s", err))
+		return 1
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return 0
+		case events, ok := <-eventsCh:
+			if !ok {
+				return 0
+			}
+			if events.Err != nil {
+				if ctx.Err() != nil {
+					return 0
+				}
+				c.Ui.Error(fmt.Sprintf("Error streaming events: %s", events.Err))
+				return 1
+			}
+			for _, event := range events.Events {
+				out, err := json.Marshal(event)
+				if err != nil {
+					c.Ui.Error(fmt.Sprintf("Error encoding event: %s", err))
+					return 1
+				}
+				c.Ui.Output(string(out))
+			}
+		}
+	}
+}
+
+// parseEventStreamTopics parses the topic flags into the topics of the event
+// stream. A topic without a key streams all the events of the topic.
+func parseEventStreamTopics(args []string) (map[api.Topic][]string, error) {
+	topics := make(map[api.Topic][]string, len(args))
+	for _, arg := range args {
+		topic, key, found := strings.Cut(arg, ":")
+		if topic == "" || (found && (key == "" || strings.Contains(key, ":"))) {
+			return nil, fmt.Errorf("Invalid topic %q: must be of the form topic[:key]", arg)
+		}
+		if !found {
+			key = string(api.TopicAll)
+		}
+		topics[api.Topic(topic)] = append(topics[api.Topic(topic)], key)
+	}
+	return topics, nil
+}
@@ -0,0 +1,64 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestEventStreamCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &EventStreamCommand{}
+}
+
+func TestEventStreamCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &EventStreamCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	// Fails on an invalid topic
+	code = cmd.Run([]string{"-address=" + url, "-topic=Job:"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), `Invalid topic "Job:"`)
+	ui.ErrorWriter.Reset()
+
+	// Fails on an invalid filter expression
+	code = cmd.Run([]string{"-address=" + url, "-filter=Key =="})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Invalid filter query")
+}
+
+func TestEventStreamCommand_parseTopics(t *testing.T) {
+	ci.Parallel(t)
+
+	topics, err := parseEventStreamTopics(nil)
+	must.NoError(t, err)
+	must.MapEmpty(t, topics)
+
+	topics, err = parseEventStreamTopics([]string{"Job", "Allocation:one", "Allocation:two"})
+	must.NoError(t, err)
+	must.Eq(t, map[api.Topic][]string{
+		api.TopicJob:        {"*"},
+		api.TopicAllocation: {"one", "two"},
+	}, topics)
+
+	for _, arg := range []string{"", ":key", "Job:", "Job:a:b"} {
+		_, err = parseEventStreamTopics([]string{arg})
+		must.Error(t, err, must.Sprintf("topic %q", arg))
+	}
+}
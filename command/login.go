@@ -5,6 +5,7 @@ package command
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
@@ -29,6 +30,7 @@ type LoginCommand struct {
 	authMethodName string
 	callbackAddr   string
 	loginToken     string
+	username       string
 
 	template string
 	json     bool
@@ -58,7 +60,12 @@ Login Options:
 
   -login-token
     Login token used for authentication that will be exchanged for a Nomad ACL
-    Token. It is only required if using auth method type other than OIDC.
+    Token. It is only required if using the JWT auth method type.
+
+  -username
+    Username used for authentication with the LDAP auth method type. The
+    username is prompted for if not set, and the password is always prompted
+    for.
 
   -json
     Output the ACL token in JSON format.
@@ -80,6 +87,7 @@ func (l *LoginCommand) AutocompleteFlags() complete.Flags {
 			"-method":             complete.PredictAnything,
 			"-oidc-callback-addr": complete.PredictAnything,
 			"-login-token":        complete.PredictAnything,
+			"-username":           complete.PredictAnything,
 			"-json":               complete.PredictNothing,
 			"-t":                  complete.PredictAnything,
 		})
@@ -96,6 +104,7 @@ func (l *LoginCommand) Run(args []string) int {
 	flags.StringVar(&l.authMethodName, "method", "", "")
 	flags.StringVar(&l.authMethodType, "type", "", "")
 	flags.StringVar(&l.loginToken, "login-token", "", "")
+	flags.StringVar(&l.username, "username", "", "")
 	flags.StringVar(&l.callbackAddr, "oidc-callback-addr", "localhost:4649", "")
 	flags.BoolVar(&l.json, "json", false, "")
 	flags.StringVar(&l.template, "t", "", "")
@@ -164,8 +173,8 @@ func (l *LoginCommand) Run(args []string) int {
 		}
 	}
 
-	// Make sure we got the login token if we're not using OIDC
-	if methodType != api.ACLAuthMethodTypeOIDC && l.loginToken == "" {
+	// Make sure we got the login token if we're using JWT
+	if methodType == api.ACLAuthMethodTypeJWT && l.loginToken == "" {
 		l.Ui.Error("You need to provide a login token.")
 		return 1
 	}
@@ -180,6 +189,8 @@ func (l *LoginCommand) Run(args []string) int {
 		authFn = l.loginOIDC
 	case api.ACLAuthMethodTypeJWT:
 		authFn = l.loginJWT
+	case api.ACLAuthMethodTypeLDAP:
+		authFn = l.loginLDAP
 	default:
 		l.Ui.Error(fmt.Sprintf("Unsupported authentication type %q", methodType))
 		return 1
@@ -272,6 +283,36 @@ func (l *LoginCommand) loginJWT(ctx context.Context, client *api.Client) (*api.A
 	return token, err
 }
 
+func (l *LoginCommand) loginLDAP(ctx context.Context, client *api.Client) (*api.ACLToken, error) {
+	username := l.username
+	if username == "" {
+		answer, err := l.Ui.Ask("Username:")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read username: %w", err)
+		}
+		username = strings.TrimSpace(answer)
+		if username == "" {
+			return nil, errors.New("username cannot be empty")
+		}
+	}
+
+	password, err := l.Ui.AskSecret("Password:")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read password: %w", err)
+	}
+	if password == "" {
+		return nil, errors.New("password cannot be empty")
+	}
+
+	authArgs := api.ACLLoginRequest{
+		AuthMethodName: l.authMethodName,
+		Username:       username,
+		Password:       password,
+	}
+	token, _, err := client.ACLAuth().Login(&authArgs, nil)
+	return token, err
+}
+
 const (
 	// oidcErrorVisitURLMsg is a message to show users when opening the OIDC
 	// provider URL automatically fails. This type of message is otherwise not
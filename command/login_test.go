@@ -4,13 +4,19 @@
 package command
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/hashicorp/cli"
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
+	"github.com/jimlambrt/gldap/testdirectory"
 	"github.com/shoenig/test/must"
 )
 
@@ -77,3 +83,60 @@ func TestLoginCommand_Run(t *testing.T) {
 	// TODO(jrasell) find a way to test the full login flow from the CLI
 	//  perspective.
 }
+
+func TestLoginCommand_LDAP(t *testing.T) {
+	ci.Parallel(t)
+
+	// Build a test server with ACLs enabled.
+	srv, _, agentURL := testServer(t, false, func(c *agent.Config) {
+		c.ACL.Enabled = true
+	})
+	defer srv.Shutdown()
+
+	// Wait for the server to start fully.
+	testutil.WaitForLeader(t, srv.Agent.RPC)
+
+	directory := testdirectory.Start(t, testdirectory.WithLogger(t, hclog.NewNullLogger()))
+	directory.SetUsers(testdirectory.NewUsers(t, []string{"alice"})...)
+	directory.SetGroups(testdirectory.NewGroup(t, "admins", []string{"alice"}))
+
+	// Store an LDAP auth method and a binding rule for the admins group.
+	state := srv.Agent.Server().State()
+	method := mock.ACLLDAPAuthMethod()
+	method.Config.LDAPURLs = []string{
+		fmt.Sprintf("ldaps://%s:%d", directory.Host(), directory.Port())}
+	method.Config.LDAPCACerts = []string{directory.Cert()}
+	method.Config.LDAPUserDN = testdirectory.DefaultUserDN
+	method.Config.LDAPGroupDN = testdirectory.DefaultGroupDN
+	method.SetHash()
+	must.NoError(t, state.UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{method}))
+
+	policy := mock.ACLPolicy()
+	must.NoError(t, state.UpsertACLPolicies(
+		structs.MsgTypeTestSetup, 1010, []*structs.ACLPolicy{policy}))
+
+	rule := mock.ACLBindingRule()
+	rule.AuthMethod = method.Name
+	rule.BindType = structs.ACLBindingRuleBindTypePolicy
+	rule.Selector = "admins in list.groups"
+	rule.BindName = policy.Name
+	must.NoError(t, state.UpsertACLBindingRules(1020, []*structs.ACLBindingRule{rule}, true))
+
+	// The username and password are prompted for when not set. The input is
+	// read one byte at a time, as the mock UI buffers it on each prompt.
+	ui := cli.NewMockUi()
+	ui.InputReader = iotest.OneByteReader(strings.NewReader("alice\npassword\n"))
+	cmd := &LoginCommand{Meta: Meta{Ui: ui, flagAddress: agentURL}}
+	must.Eq(t, 0, cmd.Run([]string{"-address=" + agentURL, "-method=" + method.Name}),
+		must.Sprint(ui.ErrorWriter.String()))
+	must.StrContains(t, ui.OutputWriter.String(), "Successfully logged in via LDAP")
+	must.StrContains(t, ui.OutputWriter.String(), policy.Name)
+
+	// Only the password is prompted for when the username is set.
+	ui = cli.NewMockUi()
+	ui.InputReader = strings.NewReader("invalid\n")
+	cmd = &LoginCommand{Meta: Meta{Ui: ui, flagAddress: agentURL}}
+	must.Eq(t, 1, cmd.Run([]string{
+		"-address=" + agentURL, "-method=" + method.Name, "-username=alice"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "unable to authenticate with LDAP")
+}
@@ -31,6 +31,14 @@ Usage: nomad operator scheduler <subcommand> [options]
 
       $ nomad operator scheduler set-config -scheduler-algorithm=spread
 
+  Simulate placing a job after removing a node:
+
+      $ nomad operator scheduler simulate -remove-node=f7476465 example.nomad.hcl
+
+  Print the allocation moves which would improve the packing of the cluster:
+
+      $ nomad operator scheduler rebalance -dry-run
+
   Please see the individual subcommand help for detailed usage information.
 `
 	return strings.TrimSpace(helpText)
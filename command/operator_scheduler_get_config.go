@@ -5,6 +5,8 @@ package command
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/cli"
@@ -87,6 +89,7 @@ func (o *OperatorSchedulerGetConfig) Run(args []string) int {
 		fmt.Sprintf("Preemption Batch Scheduler|%v", schedConfig.PreemptionConfig.BatchSchedulerEnabled),
 		fmt.Sprintf("Preemption SysBatch Scheduler|%v", schedConfig.PreemptionConfig.SysBatchSchedulerEnabled),
 		fmt.Sprintf("Node Limit For Feasibility Checks|%v", schedConfig.NodeLimitForFeasibilityChecks),
+		fmt.Sprintf("Namespace Weights|%s", formatNamespaceWeights(schedConfig.NamespaceWeights)),
 		fmt.Sprintf("Modify Index|%v", resp.SchedulerConfig.ModifyIndex),
 	}))
 	return 0
@@ -120,3 +123,16 @@ Scheduler Get Config Options:
 
 	return strings.TrimSpace(helpText)
 }
+
+// formatNamespaceWeights returns the namespace weights as a sorted, comma
+// separated list of namespace=weight pairs.
+func formatNamespaceWeights(weights map[string]int) string {
+	if len(weights) == 0 {
+		return "<none>"
+	}
+	out := make([]string, 0, len(weights))
+	for _, namespace := range slices.Sorted(maps.Keys(weights)) {
+		out = append(out, fmt.Sprintf("%s=%d", namespace, weights[namespace]))
+	}
+	return strings.Join(out, ",")
+}
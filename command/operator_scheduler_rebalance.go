@@ -0,0 +1,173 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+// Ensure OperatorSchedulerRebalanceCommand satisfies the cli.Command interface.
+var _ cli.Command = &OperatorSchedulerRebalanceCommand{}
+
+type OperatorSchedulerRebalanceCommand struct {
+	Meta
+
+	nodePool string
+	maxMoves int
+	dryRun   bool
+	json     bool
+	tmpl     string
+}
+
+func (o *OperatorSchedulerRebalanceCommand) Help() string {
+	helpText := `
+Usage: nomad operator scheduler rebalance [options]
+
+  Moves healthy service allocations between nodes to improve how the cluster
+  is packed. When the scheduler algorithm is binpack, the least utilized
+  nodes are evacuated onto fuller nodes. When it is spread, allocations are
+  moved from the most utilized to the least utilized nodes.
+
+  The allocations are migrated the same way as when draining a node, so
+  replacements are placed by the scheduler before the allocations are
+  stopped. No task group has more allocations moved at once than its migrate
+  block's max_parallel and its disruption budget allow, and jobs with an
+  active deployment are left alone. Run the command again to continue
+  rebalancing once the moves have completed.
+
+  Print the moves which would be made without migrating any allocations:
+
+      $ nomad operator scheduler rebalance -dry-run
+
+  When ACLs are enabled, this command requires a token with the
+  'operator:write' capability, or 'operator:read' for a dry run.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsNoNamespace) + `
+
+Scheduler Rebalance Options:
+
+  -node-pool=<node-pool>
+    Only rebalance the nodes of the node pool. Defaults to all node pools.
+
+  -max-moves=<count>
+    The maximum number of allocations to move. Defaults to 10.
+
+  -dry-run
+    Print the moves which would be made without migrating any allocations.
+
+  -json
+    Output the rebalance results in their JSON format.
+
+  -t
+    Format and display the rebalance results using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (o *OperatorSchedulerRebalanceCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(o.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-node-pool": nodePoolPredictor(o.Client, nil),
+			"-max-moves": complete.PredictAnything,
+			"-dry-run":   complete.PredictNothing,
+			"-json":      complete.PredictNothing,
+			"-t":         complete.PredictAnything,
+		},
+	)
+}
+
+func (o *OperatorSchedulerRebalanceCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (o *OperatorSchedulerRebalanceCommand) Synopsis() string {
+	return "Move allocations to improve the packing of the cluster"
+}
+
+func (o *OperatorSchedulerRebalanceCommand) Name() string { return "operator scheduler rebalance" }
+
+func (o *OperatorSchedulerRebalanceCommand) Run(args []string) int {
+	flags := o.Meta.FlagSet(o.Name(), FlagSetClient)
+	flags.Usage = func() { o.Ui.Output(o.Help()) }
+	flags.StringVar(&o.nodePool, "node-pool", "", "")
+	flags.IntVar(&o.maxMoves, "max-moves", 10, "")
+	flags.BoolVar(&o.dryRun, "dry-run", false, "")
+	flags.BoolVar(&o.json, "json", false, "")
+	flags.StringVar(&o.tmpl, "t", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got no arguments.
+	if len(flags.Args()) != 0 {
+		o.Ui.Error("This command takes no arguments")
+		o.Ui.Error(commandErrorText(o))
+		return 1
+	}
+
+	if o.maxMoves < 1 {
+		o.Ui.Error("The -max-moves flag must be at least 1")
+		return 1
+	}
+
+	client, err := o.Meta.Client()
+	if err != nil {
+		o.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	resp, _, err := client.Operator().SchedulerRebalance(&api.SchedulerRebalanceRequest{
+		NodePool: o.nodePool,
+		MaxMoves: o.maxMoves,
+		DryRun:   o.dryRun,
+	}, nil)
+	if err != nil {
+		o.Ui.Error(fmt.Sprintf("Error rebalancing cluster: %s", err))
+		return 1
+	}
+
+	if o.json || len(o.tmpl) > 0 {
+		out, err := Format(o.json, o.tmpl, resp)
+		if err != nil {
+			o.Ui.Error(err.Error())
+			return 1
+		}
+		o.Ui.Output(out)
+		return 0
+	}
+
+	if len(resp.Moves) == 0 {
+		o.Ui.Output("No allocations to move")
+		return 0
+	}
+
+	moves := []string{"Alloc ID|Job ID|Task Group|Node Pool|Source Node|Target Node"}
+	for _, move := range resp.Moves {
+		moves = append(moves, fmt.Sprintf("%s|%s|%s|%s|%s|%s",
+			limit(move.AllocID, shortId), move.JobID, move.TaskGroup, move.NodePool,
+			limit(move.SourceNodeID, shortId), limit(move.TargetNodeID, shortId)))
+	}
+
+	if o.dryRun {
+		o.Ui.Output(o.Colorize().Color("[bold]Proposed Moves[reset]"))
+		o.Ui.Output(formatList(moves))
+		return 0
+	}
+
+	o.Ui.Output(o.Colorize().Color("[bold]Moves[reset]"))
+	o.Ui.Output(formatList(moves))
+	o.Ui.Output(fmt.Sprintf("\nCreated %d evaluations to migrate the allocations:", len(resp.EvalIDs)))
+	for _, id := range resp.EvalIDs {
+		o.Ui.Output(fmt.Sprintf("  %s", limit(id, shortId)))
+	}
+	return 0
+}
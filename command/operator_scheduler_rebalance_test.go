@@ -0,0 +1,40 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestOperatorSchedulerRebalance_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &OperatorSchedulerRebalanceCommand{}
+}
+
+func TestOperatorSchedulerRebalance_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	ui := cli.NewMockUi()
+	cmd := &OperatorSchedulerRebalanceCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	must.One(t, cmd.Run([]string{"some", "bad", "args"}))
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	// Fails on an invalid number of moves
+	cmd = &OperatorSchedulerRebalanceCommand{Meta: Meta{Ui: ui}}
+	must.One(t, cmd.Run([]string{"-max-moves=0"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "The -max-moves flag must be at least 1")
+	ui.ErrorWriter.Reset()
+
+	// Fails on connection failure
+	cmd = &OperatorSchedulerRebalanceCommand{Meta: Meta{Ui: ui}}
+	must.One(t, cmd.Run([]string{"-address=nope"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "Error rebalancing cluster")
+}
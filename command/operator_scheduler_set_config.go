@@ -5,6 +5,7 @@ package command
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/cli"
@@ -32,6 +33,7 @@ type OperatorSchedulerSetConfig struct {
 	preemptSysBatchScheduler      flagHelper.BoolValue
 	preemptSystemScheduler        flagHelper.BoolValue
 	nodeLimitForFeasibilityChecks flagHelper.UintValue
+	namespaceWeights              flagHelper.StringFlag
 }
 
 func (o *OperatorSchedulerSetConfig) AutocompleteFlags() complete.Flags {
@@ -50,6 +52,7 @@ func (o *OperatorSchedulerSetConfig) AutocompleteFlags() complete.Flags {
 			"-preempt-sysbatch-scheduler":        complete.PredictSet("true", "false"),
 			"-preempt-system-scheduler":          complete.PredictSet("true", "false"),
 			"-node-limit-for-feasibility-checks": complete.PredictAnything,
+			"-namespace-weight":                  complete.PredictAnything,
 		},
 	)
 }
@@ -75,6 +78,7 @@ func (o *OperatorSchedulerSetConfig) Run(args []string) int {
 	flags.Var(&o.preemptSysBatchScheduler, "preempt-sysbatch-scheduler", "")
 	flags.Var(&o.preemptSystemScheduler, "preempt-system-scheduler", "")
 	flags.Var(&o.nodeLimitForFeasibilityChecks, "node-limit-for-feasibility-checks", "")
+	flags.Var(&o.namespaceWeights, "namespace-weight", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -95,6 +99,17 @@ func (o *OperatorSchedulerSetConfig) Run(args []string) int {
 		return 1
 	}
 
+	namespaceWeights := make(map[string]int, len(o.namespaceWeights))
+	for _, kv := range o.namespaceWeights {
+		namespace, raw, ok := strings.Cut(kv, "=")
+		weight, err := strconv.Atoi(raw)
+		if !ok || namespace == "" || err != nil {
+			o.Ui.Error(fmt.Sprintf("Invalid -namespace-weight value %q: must be of the form <namespace>=<weight>", kv))
+			return 1
+		}
+		namespaceWeights[namespace] = weight
+	}
+
 	// Convert the check index string and handle any errors before adding this
 	// to our request. This parsing handles empty values correctly.
 	checkIndex, _, err := parseCheckIndex(o.checkIndex)
@@ -139,6 +154,19 @@ func (o *OperatorSchedulerSetConfig) Run(args []string) int {
 	o.preemptSystemScheduler.Merge(&schedulerConfig.PreemptionConfig.SystemSchedulerEnabled)
 	o.nodeLimitForFeasibilityChecks.Merge(&schedulerConfig.NodeLimitForFeasibilityChecks)
 
+	// A weight of zero removes the namespace's weight, returning it to the
+	// default weight of 1.
+	if len(namespaceWeights) > 0 && schedulerConfig.NamespaceWeights == nil {
+		schedulerConfig.NamespaceWeights = make(map[string]int, len(namespaceWeights))
+	}
+	for namespace, weight := range namespaceWeights {
+		if weight == 0 {
+			delete(schedulerConfig.NamespaceWeights, namespace)
+			continue
+		}
+		schedulerConfig.NamespaceWeights[namespace] = weight
+	}
+
 	// Check-and-set the new configuration.
 	result, _, err := client.Operator().SchedulerCASConfiguration(schedulerConfig, nil)
 	if err != nil {
@@ -219,6 +247,13 @@ Scheduler Set Config Options:
 	numbers result in better scheduler performance and more randomization of jobs
 	across nodes. Higher numbers result in more deterministic application of
 	feasibility checks.
+
+  -namespace-weight=<namespace>=<weight>
+    Sets the relative share of evaluations dequeued for the namespace when
+    evaluations from several namespaces are waiting to be scheduled. A
+    namespace with a weight of 2 receives twice the scheduling capacity of a
+    namespace with the default weight of 1. Setting a weight of 0 removes the
+    namespace's weight. This flag may be repeated.
 `
 	return strings.TrimSpace(helpText)
 }
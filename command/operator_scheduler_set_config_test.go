@@ -52,6 +52,7 @@ func TestOperatorSchedulerSetConfig_Run(t *testing.T) {
 		"-preempt-sysbatch-scheduler=true",
 		"-preempt-system-scheduler=false",
 		"-node-limit-for-feasibility-checks=200",
+		"-namespace-weight=prod=4",
 	}
 	must.Zero(t, c.Run(modifyingArgs))
 	s := ui.OutputWriter.String()
@@ -71,6 +72,7 @@ func TestOperatorSchedulerSetConfig_Run(t *testing.T) {
 		RejectJobRegistration:         true,
 		PauseEvalBroker:               true,
 		NodeLimitForFeasibilityChecks: 200,
+		NamespaceWeights:              map[string]int{"prod": 4},
 	}, modifiedConfig.SchedulerConfig)
 
 	ui.ErrorWriter.Reset()
@@ -83,6 +85,14 @@ func TestOperatorSchedulerSetConfig_Run(t *testing.T) {
 	ui.ErrorWriter.Reset()
 	ui.OutputWriter.Reset()
 
+	// Ensure malformed namespace weights are rejected.
+	c = &OperatorSchedulerSetConfig{Meta: Meta{Ui: ui}}
+	must.One(t, c.Run([]string{"-address=" + addr, "-namespace-weight=prod"}))
+	c = &OperatorSchedulerSetConfig{Meta: Meta{Ui: ui}}
+	must.StrContains(t, ui.ErrorWriter.String(), `Invalid -namespace-weight value "prod"`)
+	ui.ErrorWriter.Reset()
+	ui.OutputWriter.Reset()
+
 	// Try updating the config using an incorrect check-index value.
 	must.One(t, c.Run([]string{
 		"-address=" + addr,
@@ -111,4 +121,5 @@ func schedulerConfigEquals(t *testing.T, expected, actual *api.SchedulerConfigur
 	must.Eq(t, expected.PauseEvalBroker, actual.PauseEvalBroker)
 	must.Eq(t, expected.PreemptionConfig, actual.PreemptionConfig)
 	must.Eq(t, expected.NodeLimitForFeasibilityChecks, actual.NodeLimitForFeasibilityChecks)
+	must.MapEq(t, expected.NamespaceWeights, actual.NamespaceWeights)
 }
@@ -0,0 +1,381 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/command/agent"
+	flagHelper "github.com/hashicorp/nomad/helper/flags"
+	"github.com/hashicorp/nomad/helper/raftutil"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler"
+	"github.com/mitchellh/colorstring"
+	"github.com/posener/complete"
+)
+
+// Ensure OperatorSchedulerSimulateCommand satisfies the cli.Command interface.
+var _ cli.Command = &OperatorSchedulerSimulateCommand{}
+
+type OperatorSchedulerSimulateCommand struct {
+	Meta
+	JobGetter
+
+	snapshot                string
+	removeNodes             flagHelper.StringFlag
+	nodePools               flagHelper.StringFlag
+	schedulerAlgorithm      string
+	memoryOversubscription  flagHelper.BoolValue
+	preemptBatchScheduler   flagHelper.BoolValue
+	preemptServiceScheduler flagHelper.BoolValue
+	json                    bool
+	tmpl                    string
+
+	// configSet is whether any of the scheduler configuration flags were set
+	configSet bool
+}
+
+func (o *OperatorSchedulerSimulateCommand) Help() string {
+	helpText := `
+Usage: nomad operator scheduler simulate [options] [<path>...]
+
+  Runs the scheduler against a copy of the cluster state after applying a set
+  of hypothetical changes, and displays the resulting placements, placement
+  failures, and cluster utilization. The cluster state is never modified.
+
+  Each job file given is registered in the simulated state and evaluated in
+  order, so later jobs observe the placements of earlier ones. Jobs with
+  allocations on removed nodes, or on nodes moved to another node pool, are
+  evaluated before any job files.
+
+  Simulate placing a job after removing two nodes from the cluster:
+
+      $ nomad operator scheduler simulate -remove-node=f7476465 \
+          -remove-node=8f4a2b1c example.nomad.hcl
+
+  Simulate the spread algorithm against a snapshot file:
+
+      $ nomad operator scheduler simulate -snapshot=backup.snap \
+          -scheduler-algorithm=spread example.nomad.hcl
+
+  When simulating against the live cluster state and ACLs are enabled, this
+  command requires a token with the 'operator:read' capability, and the
+  'submit-job' or 'plan-job' capability for the namespace of each job.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Scheduler Simulate Options:
+
+  -snapshot=<file>
+    Simulate against the state in a snapshot file, as written by
+    "nomad operator snapshot save", instead of the live cluster state. No
+    Nomad agent is contacted.
+
+  -remove-node=<node-id>
+    Remove the node from the simulated state. Can be specified multiple times.
+
+  -node-pool=<node-id>=<node-pool>
+    Move the node to the node pool in the simulated state. Can be specified
+    multiple times.
+
+  -scheduler-algorithm=["binpack"|"spread"]
+    Specifies the scheduling algorithm to simulate.
+
+  -memory-oversubscription=[true|false]
+    Specifies whether memory oversubscription is enabled in the simulation.
+
+  -preempt-batch-scheduler=[true|false]
+    Specifies whether preemption for batch jobs is enabled in the simulation.
+
+  -preempt-service-scheduler=[true|false]
+    Specifies whether preemption for service jobs is enabled in the simulation.
+
+  -var 'key=value'
+    Variable for template, can be used multiple times.
+
+  -var-file=path
+    Path to HCL2 file containing user variables.
+
+  -json
+    Output the simulation results in their JSON format.
+
+  -t
+    Format and display the simulation results using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (o *OperatorSchedulerSimulateCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(o.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-snapshot":    complete.PredictFiles("*.snap"),
+			"-remove-node": complete.PredictAnything,
+			"-node-pool":   complete.PredictAnything,
+			"-scheduler-algorithm": complete.PredictSet(
+				string(api.SchedulerAlgorithmBinpack),
+				string(api.SchedulerAlgorithmSpread),
+			),
+			"-memory-oversubscription":   complete.PredictSet("true", "false"),
+			"-preempt-batch-scheduler":   complete.PredictSet("true", "false"),
+			"-preempt-service-scheduler": complete.PredictSet("true", "false"),
+			"-var":                       complete.PredictAnything,
+			"-var-file":                  complete.PredictFiles("*.var"),
+			"-json":                      complete.PredictNothing,
+			"-t":                         complete.PredictAnything,
+		},
+	)
+}
+
+func (o *OperatorSchedulerSimulateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictOr(
+		complete.PredictFiles("*.nomad"),
+		complete.PredictFiles("*.hcl"),
+	)
+}
+
+func (o *OperatorSchedulerSimulateCommand) Synopsis() string {
+	return "Simulate scheduling against hypothetical cluster changes"
+}
+
+func (o *OperatorSchedulerSimulateCommand) Name() string { return "operator scheduler simulate" }
+
+func (o *OperatorSchedulerSimulateCommand) Run(args []string) int {
+	flags := o.Meta.FlagSet(o.Name(), FlagSetClient)
+	flags.Usage = func() { o.Ui.Output(o.Help()) }
+	flags.StringVar(&o.snapshot, "snapshot", "", "")
+	flags.Var(&o.removeNodes, "remove-node", "")
+	flags.Var(&o.nodePools, "node-pool", "")
+	flags.StringVar(&o.schedulerAlgorithm, "scheduler-algorithm", "", "")
+	flags.Var(&o.memoryOversubscription, "memory-oversubscription", "")
+	flags.Var(&o.preemptBatchScheduler, "preempt-batch-scheduler", "")
+	flags.Var(&o.preemptServiceScheduler, "preempt-service-scheduler", "")
+	flags.Var(&o.JobGetter.Vars, "var", "")
+	flags.Var(&o.JobGetter.VarFiles, "var-file", "")
+	flags.BoolVar(&o.json, "json", false, "")
+	flags.StringVar(&o.tmpl, "t", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "scheduler-algorithm", "memory-oversubscription",
+			"preempt-batch-scheduler", "preempt-service-scheduler":
+			o.configSet = true
+		}
+	})
+	o.JobGetter.Strict = true
+
+	nodePools := make(map[string]string, len(o.nodePools))
+	for _, kv := range o.nodePools {
+		nodeID, pool, ok := strings.Cut(kv, "=")
+		if !ok || nodeID == "" || pool == "" {
+			o.Ui.Error(fmt.Sprintf("Invalid -node-pool value %q: must be of the form <node-id>=<node-pool>", kv))
+			return 1
+		}
+		nodePools[nodeID] = pool
+	}
+
+	var jobs []*api.Job
+	for _, path := range flags.Args() {
+		_, job, err := o.JobGetter.Get(path)
+		if err != nil {
+			o.Ui.Error(fmt.Sprintf("Error getting job struct: %s", err))
+			return 1
+		}
+		jobs = append(jobs, job)
+	}
+
+	if len(jobs) == 0 && len(o.removeNodes) == 0 && len(nodePools) == 0 {
+		o.Ui.Error("At least one job file, -remove-node, or -node-pool must be given")
+		o.Ui.Error(commandErrorText(o))
+		return 1
+	}
+
+	var resp *api.SchedulerSimulateResponse
+	var err error
+	if o.snapshot != "" {
+		resp, err = o.simulateSnapshot(jobs, nodePools)
+	} else {
+		resp, err = o.simulateCluster(jobs, nodePools)
+	}
+	if err != nil {
+		o.Ui.Error(fmt.Sprintf("Error running simulation: %s", err))
+		return 1
+	}
+
+	if o.json || len(o.tmpl) > 0 {
+		out, err := Format(o.json, o.tmpl, resp)
+		if err != nil {
+			o.Ui.Error(err.Error())
+			return 1
+		}
+		o.Ui.Output(out)
+		return 0
+	}
+
+	o.Ui.Output(o.Colorize().Color(formatSimulation(resp, o.Colorize())))
+	return 0
+}
+
+// simulateCluster runs the simulation on the servers against the live cluster
+// state.
+func (o *OperatorSchedulerSimulateCommand) simulateCluster(jobs []*api.Job, nodePools map[string]string) (*api.SchedulerSimulateResponse, error) {
+	client, err := o.Meta.Client()
+	if err != nil {
+		return nil, fmt.Errorf("Error initializing client: %w", err)
+	}
+
+	req := &api.SchedulerSimulateRequest{
+		Jobs:        jobs,
+		RemoveNodes: o.removeNodes,
+		NodePools:   nodePools,
+	}
+
+	if o.configSet {
+		current, _, err := client.Operator().SchedulerGetConfiguration(nil)
+		if err != nil {
+			return nil, fmt.Errorf("Error querying scheduler configuration: %w", err)
+		}
+
+		config := current.SchedulerConfig
+		if o.schedulerAlgorithm != "" {
+			config.SchedulerAlgorithm = api.SchedulerAlgorithm(o.schedulerAlgorithm)
+		}
+		o.memoryOversubscription.Merge(&config.MemoryOversubscriptionEnabled)
+		o.preemptBatchScheduler.Merge(&config.PreemptionConfig.BatchSchedulerEnabled)
+		o.preemptServiceScheduler.Merge(&config.PreemptionConfig.ServiceSchedulerEnabled)
+		req.SchedulerConfig = config
+	}
+
+	resp, _, err := client.Operator().SchedulerSimulate(req, nil)
+	return resp, err
+}
+
+// simulateSnapshot runs the simulation locally against the state restored from
+// a snapshot file.
+func (o *OperatorSchedulerSimulateCommand) simulateSnapshot(jobs []*api.Job, nodePools map[string]string) (*api.SchedulerSimulateResponse, error) {
+	f, err := os.Open(o.snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("Error opening snapshot file: %w", err)
+	}
+	defer f.Close()
+
+	_, store, _, err := raftutil.RestoreFromArchive(f, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read archive file: %w", err)
+	}
+
+	req := &structs.SchedulerSimulateRequest{
+		RemoveNodes: o.removeNodes,
+		NodePools:   nodePools,
+	}
+	for _, job := range jobs {
+		if job.Namespace == nil || *job.Namespace == "" {
+			job.Namespace = new(structs.DefaultNamespace)
+		}
+		req.Jobs = append(req.Jobs, agent.ApiJobToStructJob(job))
+	}
+
+	if o.configSet {
+		_, config, err := store.SchedulerConfig()
+		if err != nil {
+			return nil, err
+		}
+		config = config.Copy()
+		if config == nil {
+			config = &structs.SchedulerConfiguration{}
+		}
+		if o.schedulerAlgorithm != "" {
+			config.SchedulerAlgorithm = structs.SchedulerAlgorithm(o.schedulerAlgorithm)
+		}
+		o.memoryOversubscription.Merge(&config.MemoryOversubscriptionEnabled)
+		o.preemptBatchScheduler.Merge(&config.PreemptionConfig.BatchSchedulerEnabled)
+		o.preemptServiceScheduler.Merge(&config.PreemptionConfig.ServiceSchedulerEnabled)
+		req.SchedulerConfig = config
+	}
+
+	result, err := scheduler.Simulate(hclog.L(), store, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// The API and structs responses share the same wire format
+	buf, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	var resp api.SchedulerSimulateResponse
+	if err := json.Unmarshal(buf, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func formatSimulation(resp *api.SchedulerSimulateResponse, colorize *colorstring.Colorize) string {
+	var out strings.Builder
+
+	for _, job := range resp.Jobs {
+		out.WriteString(fmt.Sprintf("[bold]Job %q (namespace %q)[reset]\n", job.JobID, job.Namespace))
+
+		if len(job.Placements) > 0 {
+			placements := []string{"Name|Node ID|Node Name|Node Pool"}
+			for _, p := range job.Placements {
+				placements = append(placements, fmt.Sprintf("%s|%s|%s|%s",
+					p.Name, limit(p.NodeID, shortId), p.NodeName, p.NodePool))
+			}
+			out.WriteString(formatList(placements))
+			out.WriteString("\n")
+		}
+
+		out.WriteString(formatKV([]string{
+			fmt.Sprintf("Placed|%d", len(job.Placements)),
+			fmt.Sprintf("Stopped|%d", job.Stopped),
+			fmt.Sprintf("Preempted|%d", len(job.Preempted)),
+		}))
+		out.WriteString("\n")
+
+		for _, tg := range sortedTaskGroupFromMetrics(job.FailedTGAllocs) {
+			metrics := job.FailedTGAllocs[tg]
+			noun := "allocation"
+			if metrics.CoalescedFailures > 0 {
+				noun += "s"
+			}
+			out.WriteString(fmt.Sprintf("[yellow]Task Group %q (failed to place %d %s):\n[reset]",
+				tg, metrics.CoalescedFailures+1, noun))
+			out.WriteString(fmt.Sprintf("[yellow]%s[reset]\n",
+				formatAllocMetrics(metrics, colorize, false, strings.Repeat(" ", 2))))
+		}
+		out.WriteString("\n")
+	}
+
+	if util := resp.Utilization; util != nil {
+		out.WriteString("[bold]Utilization[reset]\n")
+		out.WriteString(formatKV([]string{
+			fmt.Sprintf("Ready Nodes|%d", util.Nodes),
+			fmt.Sprintf("CPU|%d/%d MHz (%s)", util.CPUAllocated, util.CPUCapacity,
+				formatPercent(util.CPUAllocated, util.CPUCapacity)),
+			fmt.Sprintf("Memory|%d/%d MiB (%s)", util.MemoryAllocatedMB, util.MemoryCapacityMB,
+				formatPercent(util.MemoryAllocatedMB, util.MemoryCapacityMB)),
+		}))
+	}
+
+	return strings.TrimSpace(out.String())
+}
+
+func formatPercent(used, total int64) string {
+	if total == 0 {
+		return "0.00%"
+	}
+	return fmt.Sprintf("%.2f%%", float64(used)/float64(total)*100)
+}
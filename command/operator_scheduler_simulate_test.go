@@ -0,0 +1,40 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestOperatorSchedulerSimulate_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &OperatorSchedulerSimulateCommand{}
+}
+
+func TestOperatorSchedulerSimulate_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	ui := cli.NewMockUi()
+	cmd := &OperatorSchedulerSimulateCommand{Meta: Meta{Ui: ui}}
+
+	// Fails with no changes to simulate
+	must.One(t, cmd.Run([]string{}))
+	must.StrContains(t, ui.ErrorWriter.String(), "At least one job file, -remove-node, or -node-pool must be given")
+	ui.ErrorWriter.Reset()
+
+	// Fails on malformed node pool moves
+	cmd = &OperatorSchedulerSimulateCommand{Meta: Meta{Ui: ui}}
+	must.One(t, cmd.Run([]string{"-node-pool=f7476465"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "must be of the form <node-id>=<node-pool>")
+	ui.ErrorWriter.Reset()
+
+	// Fails on a missing snapshot file
+	cmd = &OperatorSchedulerSimulateCommand{Meta: Meta{Ui: ui}}
+	must.One(t, cmd.Run([]string{"-snapshot=/nope/nope.snap", "-remove-node=f7476465"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "Error opening snapshot file")
+}
@@ -0,0 +1,306 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package hostdev is a builtin device plugin exposing device nodes of the host,
+// such as /dev/kvm, /dev/fuse, /dev/net/tun or serial adapters, as schedulable
+// devices.
+package hostdev
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/helper/pluginutils/loader"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/device"
+	"github.com/hashicorp/nomad/plugins/shared/hclspec"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// pluginName is the name of the plugin
+	pluginName = "host-device"
+
+	// vendor is the vendor of every device group fingerprinted by the plugin.
+	// The type and name of a group are the name of the device block that
+	// configured it, so jobs request devices as "<name>" or "host/<name>".
+	vendor = "host"
+
+	// envPrefix is the prefix of the environment variable holding the paths
+	// of the reserved devices of a group.
+	envPrefix = "NOMAD_HOST_DEVICE_"
+)
+
+var (
+	// PluginID is the host device plugin metadata registered in the plugin
+	// catalog.
+	PluginID = loader.PluginID{
+		Name:       pluginName,
+		PluginType: base.PluginTypeDevice,
+	}
+
+	// PluginConfig is the host device plugin factory function registered in
+	// the plugin catalog.
+	PluginConfig = &loader.InternalPluginConfig{
+		Config:  map[string]interface{}{},
+		Factory: func(ctx context.Context, l hclog.Logger) interface{} { return NewHostDevice(ctx, l) },
+	}
+
+	// pluginInfo describes the plugin
+	pluginInfo = &base.PluginInfoResponse{
+		Type:              base.PluginTypeDevice,
+		PluginApiVersions: []string{device.ApiVersion010},
+		PluginVersion:     "0.1.0",
+		Name:              pluginName,
+	}
+
+	// configSpec is the specification of the plugin's configuration
+	configSpec = hclspec.NewObject(map[string]*hclspec.Spec{
+		"fingerprint_period": hclspec.NewDefault(
+			hclspec.NewAttr("fingerprint_period", "string", false),
+			hclspec.NewLiteral("\"1m\""),
+		),
+		"device": hclspec.NewBlockList("device", hclspec.NewObject(map[string]*hclspec.Spec{
+			"name":  hclspec.NewAttr("name", "string", true),
+			"paths": hclspec.NewAttr("paths", "list(string)", true),
+			"permissions": hclspec.NewDefault(
+				hclspec.NewAttr("permissions", "string", false),
+				hclspec.NewLiteral("\"rwm\""),
+			),
+		})),
+	})
+
+	// validName matches the valid names of a device block
+	validName = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+)
+
+// Config contains configuration information for the plugin.
+type Config struct {
+	FingerprintPeriod string          `codec:"fingerprint_period"`
+	Devices           []*DeviceConfig `codec:"device"`
+}
+
+// DeviceConfig configures a group of host devices.
+type DeviceConfig struct {
+	// Name is the type and name of the device group.
+	Name string `codec:"name"`
+
+	// Paths are glob patterns matching the device nodes of the group.
+	Paths []string `codec:"paths"`
+
+	// Permissions are the cgroup permissions given to tasks on the devices,
+	// any combination of "r", "w" and "m".
+	Permissions string `codec:"permissions"`
+}
+
+// HostDevice is a device plugin that fingerprints device nodes of the host
+// matching configured glob patterns, and mounts the reserved devices into the
+// tasks. Drivers isolating the filesystem of tasks, such as exec and docker,
+// mount the devices at their host path and grant the configured cgroup
+// permissions. Drivers without isolation, such as raw_exec, can already access
+// the host path. In both cases the paths of the reserved devices are set in the
+// NOMAD_HOST_DEVICE_<NAME> environment variable.
+type HostDevice struct {
+	ctx    context.Context
+	logger hclog.Logger
+
+	// devices are the configured device groups
+	devices []*DeviceConfig
+
+	// fingerprintPeriod is how often the device nodes are scanned
+	fingerprintPeriod time.Duration
+
+	// fingerprinted maps the path of every fingerprinted device node to the
+	// config of its group
+	fingerprinted map[string]*DeviceConfig
+	lock          sync.RWMutex
+}
+
+// NewHostDevice returns a new host device plugin.
+func NewHostDevice(ctx context.Context, log hclog.Logger) *HostDevice {
+	return &HostDevice{
+		ctx:           ctx,
+		logger:        log.Named(pluginName),
+		fingerprinted: make(map[string]*DeviceConfig),
+	}
+}
+
+// PluginInfo returns information describing the plugin.
+func (d *HostDevice) PluginInfo() (*base.PluginInfoResponse, error) {
+	return pluginInfo, nil
+}
+
+// ConfigSchema returns the plugins configuration schema.
+func (d *HostDevice) ConfigSchema() (*hclspec.Spec, error) {
+	return configSpec, nil
+}
+
+// SetConfig is used to set the configuration of the plugin.
+func (d *HostDevice) SetConfig(cfg *base.Config) error {
+	var config Config
+	if len(cfg.PluginConfig) != 0 {
+		if err := base.MsgPackDecode(cfg.PluginConfig, &config); err != nil {
+			return err
+		}
+	}
+
+	if err := config.validate(); err != nil {
+		return err
+	}
+
+	period := time.Minute
+	if config.FingerprintPeriod != "" {
+		var err error
+		period, err = time.ParseDuration(config.FingerprintPeriod)
+		if err != nil {
+			return fmt.Errorf("failed to parse fingerprint period %q: %v", config.FingerprintPeriod, err)
+		}
+	}
+
+	d.fingerprintPeriod = period
+	d.devices = config.Devices
+	return nil
+}
+
+// validate returns an error if the configuration is invalid.
+func (c *Config) validate() error {
+	names := make(map[string]struct{}, len(c.Devices))
+	for _, dev := range c.Devices {
+		if !validName.MatchString(dev.Name) {
+			return fmt.Errorf("invalid device name %q: must only contain letters, numbers, underscores and dashes", dev.Name)
+		}
+		if _, ok := names[dev.Name]; ok {
+			return fmt.Errorf("device %q is configured more than once", dev.Name)
+		}
+		names[dev.Name] = struct{}{}
+
+		if len(dev.Paths) == 0 {
+			return fmt.Errorf("device %q must configure at least one path", dev.Name)
+		}
+		for _, p := range dev.Paths {
+			if !filepath.IsAbs(p) {
+				return fmt.Errorf("device %q path %q must be absolute", dev.Name, p)
+			}
+			if _, err := filepath.Match(p, ""); err != nil {
+				return fmt.Errorf("device %q path %q is invalid: %v", dev.Name, p, err)
+			}
+		}
+
+		if dev.Permissions == "" {
+			dev.Permissions = "rwm"
+		}
+		if strings.Trim(dev.Permissions, "rwm") != "" {
+			return fmt.Errorf("device %q permissions %q must only contain r, w and m", dev.Name, dev.Permissions)
+		}
+	}
+	return nil
+}
+
+// Fingerprint streams the detected devices, and emits a new fingerprint every
+// time device nodes are added or removed.
+func (d *HostDevice) Fingerprint(ctx context.Context) (<-chan *device.FingerprintResponse, error) {
+	if len(d.devices) == 0 {
+		return nil, device.ErrPluginDisabled
+	}
+
+	outCh := make(chan *device.FingerprintResponse)
+	go d.fingerprint(ctx, outCh)
+	return outCh, nil
+}
+
+// fingerprint is the long running goroutine that detects device nodes
+func (d *HostDevice) fingerprint(ctx context.Context, devices chan<- *device.FingerprintResponse) {
+	defer close(devices)
+
+	// Create a timer that will fire immediately for the first detection
+	ticker := time.NewTimer(0)
+	defer ticker.Stop()
+
+	first := true
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.ctx.Done():
+			return
+		case <-ticker.C:
+			ticker.Reset(d.fingerprintPeriod)
+		}
+
+		groups, changed := d.scan()
+		if !changed && !first {
+			continue
+		}
+		first = false
+
+		select {
+		case devices <- device.NewFingerprint(groups...):
+		case <-ctx.Done():
+			return
+		case <-d.ctx.Done():
+			return
+		}
+	}
+}
+
+// Reserve returns information on how to mount the given devices.
+func (d *HostDevice) Reserve(deviceIDs []string) (*device.ContainerReservation, error) {
+	if len(deviceIDs) == 0 {
+		return nil, status.New(codes.InvalidArgument, "no device ids given").Err()
+	}
+
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	resp := &device.ContainerReservation{
+		Envs: map[string]string{},
+	}
+	paths := map[string][]string{}
+	for _, id := range deviceIDs {
+		dev, ok := d.fingerprinted[id]
+		if !ok {
+			return nil, status.Newf(codes.InvalidArgument, "unknown device %q", id).Err()
+		}
+
+		resp.Devices = append(resp.Devices, &device.DeviceSpec{
+			TaskPath:    id,
+			HostPath:    id,
+			CgroupPerms: dev.Permissions,
+		})
+		paths[dev.Name] = append(paths[dev.Name], id)
+	}
+
+	for name, p := range paths {
+		sort.Strings(p)
+		resp.Envs[envName(name)] = strings.Join(p, ",")
+	}
+	return resp, nil
+}
+
+// envName returns the name of the environment variable holding the paths of
+// the reserved devices of the group with the given name.
+func envName(name string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// Stats streams statistics for the detected devices. Device nodes have no
+// statistics, so nothing is ever sent.
+func (d *HostDevice) Stats(ctx context.Context, _ time.Duration) (<-chan *device.StatsResponse, error) {
+	outCh := make(chan *device.StatsResponse)
+	go func() {
+		defer close(outCh)
+		select {
+		case <-ctx.Done():
+		case <-d.ctx.Done():
+		}
+	}()
+	return outCh, nil
+}
@@ -0,0 +1,169 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package hostdev
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pluginutils/hclutils"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/device"
+	"github.com/shoenig/test/must"
+)
+
+func TestConfig_Parse(t *testing.T) {
+	ci.Parallel(t)
+
+	cfgStr := `
+config {
+  fingerprint_period = "30s"
+
+  device {
+    name  = "kvm"
+    paths = ["/dev/kvm"]
+  }
+
+  device {
+    name        = "serial"
+    paths       = ["/dev/ttyUSB*", "/dev/ttyACM*"]
+    permissions = "rw"
+  }
+}`
+
+	expected := &Config{
+		FingerprintPeriod: "30s",
+		Devices: []*DeviceConfig{
+			{Name: "kvm", Paths: []string{"/dev/kvm"}, Permissions: "rwm"},
+			{Name: "serial", Paths: []string{"/dev/ttyUSB*", "/dev/ttyACM*"}, Permissions: "rw"},
+		},
+	}
+
+	var config *Config
+	hclutils.NewConfigParser(configSpec).ParseHCL(t, cfgStr, &config)
+	must.Eq(t, expected, config)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name   string
+		device *DeviceConfig
+		err    string
+	}{
+		{
+			name:   "valid",
+			device: &DeviceConfig{Name: "net-tun", Paths: []string{"/dev/net/tun"}, Permissions: "rw"},
+		},
+		{
+			name:   "invalid name",
+			device: &DeviceConfig{Name: "a/b", Paths: []string{"/dev/kvm"}},
+			err:    "invalid device name",
+		},
+		{
+			name:   "no paths",
+			device: &DeviceConfig{Name: "kvm"},
+			err:    "at least one path",
+		},
+		{
+			name:   "relative path",
+			device: &DeviceConfig{Name: "kvm", Paths: []string{"dev/kvm"}},
+			err:    "must be absolute",
+		},
+		{
+			name:   "invalid pattern",
+			device: &DeviceConfig{Name: "serial", Paths: []string{"/dev/tty[USB"}},
+			err:    "is invalid",
+		},
+		{
+			name:   "invalid permissions",
+			device: &DeviceConfig{Name: "kvm", Paths: []string{"/dev/kvm"}, Permissions: "rwx"},
+			err:    "must only contain r, w and m",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &Config{Devices: []*DeviceConfig{tc.device}}
+			err := config.validate()
+			if tc.err == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+
+	config := &Config{Devices: []*DeviceConfig{
+		{Name: "kvm", Paths: []string{"/dev/kvm"}},
+		{Name: "kvm", Paths: []string{"/dev/kvm"}},
+	}}
+	must.ErrorContains(t, config.validate(), "configured more than once")
+}
+
+func TestHostDevice_Fingerprint(t *testing.T) {
+	ci.Parallel(t)
+
+	// a regular file matching a pattern is not a device
+	file := filepath.Join(t.TempDir(), "file")
+	must.NoError(t, os.WriteFile(file, nil, 0644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	d := NewHostDevice(ctx, testlog.HCLogger(t))
+	must.NoError(t, d.SetConfig(&base.Config{}))
+
+	// the plugin is disabled without devices
+	_, err := d.Fingerprint(ctx)
+	must.Eq(t, device.ErrPluginDisabled, err)
+
+	d.devices = []*DeviceConfig{
+		{Name: "null", Paths: []string{"/dev/null", "/dev/zer?", file}, Permissions: "rw"},
+		{Name: "missing", Paths: []string{"/dev/does-not-exist*"}, Permissions: "rwm"},
+	}
+	d.fingerprintPeriod = time.Minute
+
+	outCh, err := d.Fingerprint(ctx)
+	must.NoError(t, err)
+
+	var resp *device.FingerprintResponse
+	select {
+	case resp = <-outCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for fingerprint")
+	}
+	must.NoError(t, resp.Error)
+	must.Len(t, 1, resp.Devices)
+
+	group := resp.Devices[0]
+	must.Eq(t, "host", group.Vendor)
+	must.Eq(t, "null", group.Type)
+	must.Eq(t, "null", group.Name)
+	must.Eq(t, []*device.Device{
+		{ID: "/dev/null", Healthy: true},
+		{ID: "/dev/zero", Healthy: true},
+	}, group.Devices)
+
+	// attributes that differ between the devices are not set on the group
+	must.MapContainsKey(t, group.Attributes, "kind")
+	must.MapNotContainsKey(t, group.Attributes, "minor")
+
+	res, err := d.Reserve([]string{"/dev/zero", "/dev/null"})
+	must.NoError(t, err)
+	must.Eq(t, []*device.DeviceSpec{
+		{TaskPath: "/dev/zero", HostPath: "/dev/zero", CgroupPerms: "rw"},
+		{TaskPath: "/dev/null", HostPath: "/dev/null", CgroupPerms: "rw"},
+	}, res.Devices)
+	must.Eq(t, map[string]string{"NOMAD_HOST_DEVICE_NULL": "/dev/null,/dev/zero"}, res.Envs)
+
+	_, err = d.Reserve([]string{file})
+	must.ErrorContains(t, err, "unknown device")
+}
@@ -0,0 +1,110 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package hostdev
+
+import (
+	"maps"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/nomad/plugins/device"
+	"github.com/hashicorp/nomad/plugins/shared/structs"
+)
+
+// hostDevice is a device node of the host.
+type hostDevice struct {
+	// path is the path of the device node, which is used as the ID of the
+	// device
+	path string
+
+	// attributes are read from sysfs
+	attributes map[string]string
+}
+
+// scan finds the device nodes matching the configured paths and returns the
+// device groups, and whether the fingerprinted device nodes changed since the
+// last scan.
+func (d *HostDevice) scan() ([]*device.DeviceGroup, bool) {
+	fingerprinted := make(map[string]*DeviceConfig)
+	groups := make([]*device.DeviceGroup, 0, len(d.devices))
+
+	for _, dev := range d.devices {
+		var found []*hostDevice
+		for _, pattern := range dev.Paths {
+			matches, err := filepath.Glob(pattern)
+			if err != nil {
+				d.logger.Warn("failed to match device path", "device", dev.Name, "path", pattern, "error", err)
+				continue
+			}
+
+			for _, path := range matches {
+				if _, ok := fingerprinted[path]; ok {
+					continue
+				}
+
+				hd, err := readDevice(path)
+				if err != nil {
+					d.logger.Warn("failed to read device", "device", dev.Name, "path", path, "error", err)
+					continue
+				}
+				if hd == nil {
+					d.logger.Trace("skipping path that is not a device node", "device", dev.Name, "path", path)
+					continue
+				}
+
+				fingerprinted[path] = dev
+				found = append(found, hd)
+			}
+		}
+
+		if len(found) == 0 {
+			continue
+		}
+		groups = append(groups, deviceGroup(dev.Name, found))
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	changed := !maps.EqualFunc(d.fingerprinted, fingerprinted, func(a, b *DeviceConfig) bool {
+		return a.Name == b.Name
+	})
+	d.fingerprinted = fingerprinted
+	return groups, changed
+}
+
+// deviceGroup builds the device group of the given device nodes. The
+// attributes of the group are the attributes shared by all the device nodes.
+func deviceGroup(name string, found []*hostDevice) *device.DeviceGroup {
+	sort.Slice(found, func(i, j int) bool { return found[i].path < found[j].path })
+
+	shared := maps.Clone(found[0].attributes)
+	for _, hd := range found[1:] {
+		for k, v := range shared {
+			if hd.attributes[k] != v {
+				delete(shared, k)
+			}
+		}
+	}
+
+	attributes := make(map[string]*structs.Attribute, len(shared))
+	for k, v := range shared {
+		attributes[k] = structs.NewStringAttribute(v)
+	}
+
+	devices := make([]*device.Device, 0, len(found))
+	for _, hd := range found {
+		devices = append(devices, &device.Device{
+			ID:      hd.path,
+			Healthy: true,
+		})
+	}
+
+	return &device.DeviceGroup{
+		Vendor:     vendor,
+		Type:       name,
+		Name:       name,
+		Devices:    devices,
+		Attributes: attributes,
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+
+package hostdev
+
+import (
+	"os"
+)
+
+// readDevice returns the device node at the given path, or nil if the path
+// isn't a device node. Attributes are only read from sysfs on Linux.
+func readDevice(path string) (*hostDevice, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if fi.Mode()&os.ModeDevice == 0 {
+		return nil, nil
+	}
+
+	kind := "block"
+	if fi.Mode()&os.ModeCharDevice != 0 {
+		kind = "char"
+	}
+	return &hostDevice{path: path, attributes: map[string]string{"kind": kind}}, nil
+}
@@ -0,0 +1,82 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package hostdev
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"golang.org/x/sys/unix"
+)
+
+// sysfsRoot is the mount point of sysfs
+var sysfsRoot = "/sys"
+
+// readDevice returns the device node at the given path with its attributes
+// read from sysfs, or nil if the path isn't a device node.
+func readDevice(path string) (*hostDevice, error) {
+	var st unix.Stat_t
+	if err := unix.Stat(path, &st); err != nil {
+		return nil, err
+	}
+
+	var kind string
+	switch st.Mode & unix.S_IFMT {
+	case unix.S_IFCHR:
+		kind = "char"
+	case unix.S_IFBLK:
+		kind = "block"
+	default:
+		return nil, nil
+	}
+
+	rdev := uint64(st.Rdev) //nolint:unconvert
+	major, minor := unix.Major(rdev), unix.Minor(rdev)
+	attrs := map[string]string{
+		"kind":  kind,
+		"major": strconv.FormatUint(uint64(major), 10),
+		"minor": strconv.FormatUint(uint64(minor), 10),
+	}
+
+	dir := filepath.Join(sysfsRoot, "dev", kind, fmt.Sprintf("%d:%d", major, minor))
+	if link, err := os.Readlink(filepath.Join(dir, "subsystem")); err == nil {
+		attrs["subsystem"] = filepath.Base(link)
+	}
+	if link, err := os.Readlink(filepath.Join(dir, "device", "driver")); err == nil {
+		attrs["driver"] = filepath.Base(link)
+	}
+
+	// PCI devices expose their vendor and device IDs on the device, while USB
+	// devices such as serial adapters expose them on a parent of the
+	// interface the device node belongs to.
+	if parent, err := filepath.EvalSymlinks(filepath.Join(dir, "device")); err == nil {
+		for p := parent; p != sysfsRoot && p != "/" && p != "."; p = filepath.Dir(p) {
+			if vendorID, productID := readSysfs(p, "idVendor"), readSysfs(p, "idProduct"); vendorID != "" {
+				attrs["vendor_id"], attrs["product_id"] = vendorID, productID
+				break
+			}
+			if vendorID, productID := readSysfs(p, "vendor"), readSysfs(p, "device"); vendorID != "" && productID != "" {
+				attrs["vendor_id"], attrs["product_id"] = vendorID, productID
+				break
+			}
+		}
+	}
+
+	return &hostDevice{path: path, attributes: attrs}, nil
+}
+
+// readSysfs returns the trimmed content of the given sysfs file, or an empty
+// string if it can't be read.
+func readSysfs(dir, name string) string {
+	b, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return ""
+	}
+	return string(bytes.TrimSpace(b))
+}
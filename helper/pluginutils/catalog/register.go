@@ -6,6 +6,7 @@
 package catalog
 
 import (
+	"github.com/hashicorp/nomad/devices/hostdev"
 	"github.com/hashicorp/nomad/drivers/docker"
 	"github.com/hashicorp/nomad/drivers/java"
 	"github.com/hashicorp/nomad/drivers/qemu"
@@ -20,4 +21,5 @@ func init() {
 	Register(qemu.PluginID, qemu.PluginConfig)
 	Register(java.PluginID, java.PluginConfig)
 	RegisterDeferredConfig(docker.PluginID, docker.PluginConfig, docker.PluginLoader)
+	Register(hostdev.PluginID, hostdev.PluginConfig)
 }
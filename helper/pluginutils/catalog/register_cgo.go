@@ -6,6 +6,7 @@
 package catalog
 
 import (
+	"github.com/hashicorp/nomad/devices/hostdev"
 	"github.com/hashicorp/nomad/drivers/docker"
 	"github.com/hashicorp/nomad/drivers/exec"
 	"github.com/hashicorp/nomad/drivers/java"
@@ -22,4 +23,5 @@ func init() {
 	Register(qemu.PluginID, qemu.PluginConfig)
 	Register(java.PluginID, java.PluginConfig)
 	RegisterDeferredConfig(docker.PluginID, docker.PluginConfig, docker.PluginLoader)
+	Register(hostdev.PluginID, hostdev.PluginConfig)
 }
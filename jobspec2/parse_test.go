@@ -1155,6 +1155,29 @@ func TestRestartRenderTemplates(t *testing.T) {
 	require.False(t, *tg.Tasks[1].RestartPolicy.RenderTemplates)
 }
 
+func TestUpdateWindow(t *testing.T) {
+	t.Parallel()
+	hclBytes, err := os.ReadFile("test-fixtures/update-window.hcl")
+	must.NoError(t, err)
+	job, err := ParseWithConfig(&ParseConfig{
+		Path:    "test-fixtures/update-window.hcl",
+		Body:    hclBytes,
+		AllowFS: false,
+	})
+	must.NoError(t, err)
+	must.Len(t, 2, job.Update.Windows)
+
+	window := job.Update.Windows[0]
+	must.Eq(t, "0 22 * * 1-5", *window.Cron)
+	must.Eq(t, 4*time.Hour, *window.Duration)
+	must.Eq(t, "Europe/London", *window.TimeZone)
+
+	window = job.Update.Windows[1]
+	must.Eq(t, "0 * * * 6,0", *window.Cron)
+	must.Eq(t, 30*time.Minute, *window.Duration)
+	must.Nil(t, window.TimeZone)
+}
+
 // TestIdentity asserts that the default identity will be moved from the
 // Identities slice to the pre-1.7 Identity field in case >=1.7 CLIs are used
 // with <1.7 APIs.
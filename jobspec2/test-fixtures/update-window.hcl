@@ -0,0 +1,22 @@
+# Copyright IBM Corp. 2015, 2026
+# SPDX-License-Identifier: MPL-2.0
+
+job "example" {
+  update {
+    window {
+      cron      = "0 22 * * 1-5"
+      duration  = "4h"
+      time_zone = "Europe/London"
+    }
+
+    window {
+      cron     = "0 * * * 6,0"
+      duration = "30m"
+    }
+  }
+
+  group "group" {
+    task "foo" {
+    }
+  }
+}
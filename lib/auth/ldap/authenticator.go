@@ -0,0 +1,99 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package ldap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	capldap "github.com/hashicorp/cap/ldap"
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// Authenticate binds to the LDAP directory of the auth method as the user, and
+// returns the claims of the authenticated user in case of success. The claims
+// contain the "username", the "dn" of the user entry, the "groups" the user is
+// a member of, and the user entry "attributes", keyed by attribute name.
+// Single-valued attributes are strings and multi-valued attributes are lists,
+// so both can be used in claim mappings, such as "/attributes/mail".
+func Authenticate(ctx context.Context, username, password string, methodConf *structs.ACLAuthMethodConfig) (map[string]any, error) {
+	// Measure the LDAP directory performance.
+	defer metrics.MeasureSince([]string{"nomad", "acl", "ldap", "authenticate"}, time.Now())
+
+	client, err := capldap.NewClient(ctx, clientConfig(ctx, methodConf))
+	if err != nil {
+		return nil, fmt.Errorf("unable to configure LDAP client: %v", err)
+	}
+	defer client.Close(ctx)
+
+	result, err := client.Authenticate(ctx, username, password,
+		capldap.WithGroups(), capldap.WithUserAttributes())
+	if err != nil {
+		return nil, fmt.Errorf("unable to authenticate user: %v", err)
+	}
+	if !result.Success {
+		return nil, errors.New("unable to authenticate user")
+	}
+
+	groups := make([]any, 0, len(result.Groups))
+	for _, group := range result.Groups {
+		groups = append(groups, group)
+	}
+
+	attributes := make(map[string]any, len(result.UserAttributes))
+	for name, values := range result.UserAttributes {
+		switch len(values) {
+		case 0:
+		case 1:
+			attributes[name] = values[0]
+		default:
+			list := make([]any, 0, len(values))
+			for _, v := range values {
+				list = append(list, v)
+			}
+			attributes[name] = list
+		}
+	}
+
+	return map[string]any{
+		"username":   username,
+		"dn":         result.UserDN,
+		"groups":     groups,
+		"attributes": attributes,
+	}, nil
+}
+
+// clientConfig converts the auth method configuration into the LDAP client
+// configuration. The requests to the directory time out with the context.
+func clientConfig(ctx context.Context, methodConf *structs.ACLAuthMethodConfig) *capldap.ClientConfig {
+	conf := &capldap.ClientConfig{
+		URLs:          methodConf.LDAPURLs,
+		StartTLS:      methodConf.LDAPStartTLS,
+		InsecureTLS:   methodConf.LDAPInsecureTLS,
+		Certificates:  methodConf.LDAPCACerts,
+		ClientTLSCert: methodConf.LDAPClientTLSCert,
+		ClientTLSKey:  methodConf.LDAPClientTLSKey,
+		BindDN:        methodConf.LDAPBindDN,
+		BindPassword:  methodConf.LDAPBindPassword,
+		UserDN:        methodConf.LDAPUserDN,
+		UserAttr:      methodConf.LDAPUserAttr,
+		UserFilter:    methodConf.LDAPUserFilter,
+		GroupDN:       methodConf.LDAPGroupDN,
+		GroupAttr:     methodConf.LDAPGroupAttr,
+		GroupFilter:   methodConf.LDAPGroupFilter,
+
+		// match the CN of group DNs case-insensitively, so that groups are
+		// named the same regardless of the directory conventions
+		DeprecatedVaultPre111GroupCNBehavior: new(false),
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conf.RequestTimeout = max(1, int(math.Ceil(time.Until(deadline).Seconds())))
+	}
+	return conf
+}
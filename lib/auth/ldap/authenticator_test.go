@@ -0,0 +1,129 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package ldap
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/jimlambrt/gldap/testdirectory"
+	"github.com/shoenig/test/must"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+func TestAuthenticate(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := hclog.New(&hclog.LoggerOptions{Level: hclog.Error})
+	tlsDirectory := startTestDirectory(t, testdirectory.WithLogger(t, logger))
+	plainDirectory := startTestDirectory(t, testdirectory.WithLogger(t, logger),
+		testdirectory.WithNoTLS(t))
+
+	ldapsConf := &structs.ACLAuthMethodConfig{
+		LDAPURLs:    []string{fmt.Sprintf("ldaps://%s:%d", tlsDirectory.Host(), tlsDirectory.Port())},
+		LDAPCACerts: []string{tlsDirectory.Cert()},
+		LDAPUserDN:  testdirectory.DefaultUserDN,
+		LDAPGroupDN: testdirectory.DefaultGroupDN,
+	}
+
+	startTLSConf := ldapsConf.Copy()
+	startTLSConf.LDAPURLs = []string{fmt.Sprintf("ldap://%s:%d", plainDirectory.Host(), plainDirectory.Port())}
+	startTLSConf.LDAPCACerts = []string{plainDirectory.Cert()}
+	startTLSConf.LDAPStartTLS = true
+
+	bindConf := ldapsConf.Copy()
+	bindConf.LDAPBindDN = fmt.Sprintf("cn=svc,%s", testdirectory.DefaultUserDN)
+	bindConf.LDAPBindPassword = "password"
+	bindConf.LDAPUserFilter = "({{.UserAttr}}={{.Username}})"
+
+	testCases := []struct {
+		name     string
+		conf     *structs.ACLAuthMethodConfig
+		username string
+		password string
+		groups   []any
+		errMsg   string
+	}{
+		{
+			name:     "ldaps",
+			conf:     ldapsConf,
+			username: "alice",
+			password: "password",
+			groups:   []any{"admins", "developers"},
+		},
+		{
+			name:     "starttls",
+			conf:     startTLSConf,
+			username: "alice",
+			password: "password",
+			groups:   []any{"admins", "developers"},
+		},
+		{
+			name:     "bind dn search",
+			conf:     bindConf,
+			username: "bob",
+			password: "password",
+			groups:   []any{"developers"},
+		},
+		{
+			name:     "invalid password",
+			conf:     ldapsConf,
+			username: "alice",
+			password: "invalid",
+			errMsg:   "unable to authenticate user",
+		},
+		{
+			name:     "unknown user",
+			conf:     bindConf,
+			username: "eve",
+			password: "password",
+			errMsg:   "unable to authenticate user",
+		},
+		{
+			name:     "untrusted certificate",
+			conf:     &structs.ACLAuthMethodConfig{LDAPURLs: ldapsConf.LDAPURLs, LDAPUserDN: ldapsConf.LDAPUserDN},
+			username: "alice",
+			password: "password",
+			errMsg:   "failed to connect",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			claims, err := Authenticate(ctx, tc.username, tc.password, tc.conf)
+			if tc.errMsg != "" {
+				must.ErrorContains(t, err, tc.errMsg)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq[any](t, tc.username, claims["username"])
+			must.Eq[any](t, fmt.Sprintf("cn=%s,%s", tc.username, testdirectory.DefaultUserDN), claims["dn"])
+			must.SliceContainsAll(t, tc.groups, claims["groups"].([]any))
+
+			attributes := claims["attributes"].(map[string]any)
+			must.Eq[any](t, tc.username+"@example.com", attributes["email"])
+		})
+	}
+}
+
+// startTestDirectory starts a local LDAP directory with users and groups:
+// alice is a member of admins and developers, bob of developers, and svc is a
+// service account used to search the directory.
+func startTestDirectory(t *testing.T, opts ...testdirectory.Option) *testdirectory.Directory {
+	directory := testdirectory.Start(t, opts...)
+	directory.SetUsers(testdirectory.NewUsers(t, []string{"alice", "bob", "svc"})...)
+	directory.SetGroups(
+		testdirectory.NewGroup(t, "admins", []string{"alice"}),
+		testdirectory.NewGroup(t, "developers", []string{"alice", "bob"}),
+	)
+	return directory
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/lib/auth"
 	"github.com/hashicorp/nomad/lib/auth/jwt"
+	"github.com/hashicorp/nomad/lib/auth/ldap"
 	"github.com/hashicorp/nomad/lib/auth/oidc"
 	"github.com/hashicorp/nomad/nomad/peers"
 	"github.com/hashicorp/nomad/nomad/state"
@@ -1931,6 +1932,14 @@ func (a *ACL) UpsertAuthMethods(
 
 		authMethod.Canonicalize()
 
+		// LDAP auth methods can only be used once all servers in all federated
+		// regions have been upgraded to support them.
+		if authMethod.Type == structs.ACLAuthMethodTypeLDAP &&
+			!a.srv.peersCache.ServersMeetMinimumVersion(peers.AllRegions, minACLLDAPAuthMethodVersion, false) {
+			return fmt.Errorf("all servers should be running version %v or later to use LDAP ACL auth methods",
+				minACLLDAPAuthMethodVersion)
+		}
+
 		if err := authMethod.Validate(
 			a.srv.config.ACLTokenMinExpirationTTL,
 			a.srv.config.ACLTokenMaxExpirationTTL); err != nil {
@@ -2983,15 +2992,18 @@ func (a *ACL) Login(args *structs.ACLLoginRequest, reply *structs.ACLLoginRespon
 	}
 
 	// Generate a context with a deadline. This is used when making remote HTTP
-	// requests.
+	// and LDAP requests.
 	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(aclLoginRequestExpiryTime))
 	defer cancel()
 
 	var claims map[string]interface{}
 
-	// Validate the token depending on its method type
+	// Validate the token or credentials depending on its method type
 	switch authMethod.Type {
 	case structs.ACLAuthMethodTypeJWT:
+		if args.LoginToken == "" {
+			return structs.NewErrRPCCoded(http.StatusBadRequest, "invalid login request: missing login token")
+		}
 		claims, err = jwt.Validate(ctx, args.LoginToken, authMethod.Config)
 		if err != nil {
 			return structs.NewErrRPCCodedf(
@@ -3000,6 +3012,26 @@ func (a *ACL) Login(args *structs.ACLLoginRequest, reply *structs.ACLLoginRespon
 				err,
 			)
 		}
+	case structs.ACLAuthMethodTypeLDAP:
+		// The LDAP auth method type was introduced after the login endpoint,
+		// so all servers need to be able to handle it.
+		if !a.srv.peersCache.ServersMeetMinimumVersion(peers.AllRegions, minACLLDAPAuthMethodVersion, false) {
+			return fmt.Errorf(
+				"all servers should be running version %v or later to use LDAP ACL auth methods",
+				minACLLDAPAuthMethodVersion,
+			)
+		}
+		if args.Username == "" {
+			return structs.NewErrRPCCoded(http.StatusBadRequest, "invalid login request: missing username")
+		}
+		claims, err = ldap.Authenticate(ctx, args.Username, args.Password, authMethod.Config)
+		if err != nil {
+			return structs.NewErrRPCCodedf(
+				http.StatusUnauthorized,
+				"unable to authenticate with LDAP: %v",
+				err,
+			)
+		}
 	default:
 		return structs.NewErrRPCCodedf(
 			http.StatusBadRequest,
@@ -3033,7 +3065,7 @@ func (a *ACL) Login(args *structs.ACLLoginRequest, reply *structs.ACLLoginRespon
 		if err != nil {
 			vlog.Debug("failed to marshal token claims")
 		}
-		vlog.Debug("jwt token claims", "token_claims", string(idTokenClaimBytes))
+		vlog.Debug("login claims", "token_claims", string(idTokenClaimBytes))
 
 		internalClaimBytes, err := json.MarshalIndent(jwtClaims.List, "", " ")
 		if err != nil {
@@ -3054,7 +3086,7 @@ func (a *ACL) Login(args *structs.ACLLoginRequest, reply *structs.ACLLoginRespon
 	// logic, so we do not want to call Raft directly or copy that here. In the
 	// future we should try and extract out the logic into an interface, or at
 	// least a separate function.
-	name, err := formatTokenName(authMethod.TokenNameFormat, structs.ACLAuthMethodTypeJWT, authMethod.Name, jwtClaims.Value)
+	name, err := formatTokenName(authMethod.TokenNameFormat, authMethod.Type, authMethod.Name, jwtClaims.Value)
 	if err != nil {
 		return err
 	}
@@ -25,6 +25,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
+	"github.com/jimlambrt/gldap/testdirectory"
 	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -4626,3 +4627,87 @@ func TestACL_ClientIntroductionToken(t *testing.T) {
 		must.True(t, nodeWriteExpiry.After(timeNow.Add(req.TTL).Add(-10*time.Second)))
 	})
 }
+
+func TestACL_Login_LDAP(t *testing.T) {
+	ci.Parallel(t)
+
+	testServer, _, testServerCleanupFn := TestACLServer(t, nil)
+	defer testServerCleanupFn()
+	codec := rpcClient(t, testServer)
+	testutil.WaitForLeader(t, testServer.RPC)
+
+	// Start a local LDAP directory where alice is a member of the admins
+	// group and bob is not.
+	directory := testdirectory.Start(t, testdirectory.WithLogger(t, hclog.NewNullLogger()))
+	directory.SetUsers(testdirectory.NewUsers(t, []string{"alice", "bob"})...)
+	directory.SetGroups(testdirectory.NewGroup(t, "admins", []string{"alice"}))
+
+	mockedAuthMethod := mock.ACLLDAPAuthMethod()
+	mockedAuthMethod.Config.LDAPURLs = []string{
+		fmt.Sprintf("ldaps://%s:%d", directory.Host(), directory.Port())}
+	mockedAuthMethod.Config.LDAPCACerts = []string{directory.Cert()}
+	mockedAuthMethod.Config.LDAPUserDN = testdirectory.DefaultUserDN
+	mockedAuthMethod.Config.LDAPGroupDN = testdirectory.DefaultGroupDN
+	mockedAuthMethod.SetHash()
+	must.NoError(t, testServer.fsm.State().UpsertACLAuthMethods(10, []*structs.ACLAuthMethod{mockedAuthMethod}))
+
+	mockACLPolicy := mock.ACLPolicy()
+	must.NoError(t, testServer.fsm.State().UpsertACLPolicies(
+		structs.MsgTypeTestSetup, 20, []*structs.ACLPolicy{mockACLPolicy}))
+
+	mockBindingRule := mock.ACLBindingRule()
+	mockBindingRule.AuthMethod = mockedAuthMethod.Name
+	mockBindingRule.BindType = structs.ACLBindingRuleBindTypePolicy
+	mockBindingRule.Selector = "admins in list.groups"
+	mockBindingRule.BindName = mockACLPolicy.Name
+	must.NoError(t, testServer.fsm.State().UpsertACLBindingRules(
+		30, []*structs.ACLBindingRule{mockBindingRule}, true))
+
+	loginFn := func(username, password string) (*structs.ACLLoginResponse, error) {
+		req := structs.ACLLoginRequest{
+			AuthMethodName: mockedAuthMethod.Name,
+			Username:       username,
+			Password:       password,
+			WriteRequest: structs.WriteRequest{
+				Region: DefaultRegion,
+			},
+		}
+		var resp structs.ACLLoginResponse
+		err := msgpackrpc.CallWithCodec(codec, structs.ACLLoginRPCMethod, &req, &resp)
+		return &resp, err
+	}
+
+	// A username without a password fails validation.
+	_, err := loginFn("alice", "")
+	must.ErrorContains(t, err, "missing password")
+
+	// Invalid credentials are rejected by the directory.
+	_, err = loginFn("alice", "invalid")
+	must.ErrorContains(t, err, "401")
+	must.ErrorContains(t, err, "unable to authenticate with LDAP")
+
+	// bob authenticates, but is not in a group that matches a binding rule.
+	_, err = loginFn("bob", "password")
+	must.ErrorContains(t, err, "400")
+	must.ErrorContains(t, err, "no role or policy bindings matched")
+
+	// alice is in the admins group, so gets a token with the bound policy.
+	resp, err := loginFn("alice", "password")
+	must.NoError(t, err)
+	must.NotNil(t, resp.ACLToken)
+	must.Eq(t, []string{mockACLPolicy.Name}, resp.ACLToken.Policies)
+	must.Eq(t, mockedAuthMethod.Type+"-"+mockedAuthMethod.Name, resp.ACLToken.Name)
+
+	// LDAP logins do not accept login tokens.
+	req := structs.ACLLoginRequest{
+		AuthMethodName: mockedAuthMethod.Name,
+		LoginToken:     "token",
+		WriteRequest: structs.WriteRequest{
+			Region: DefaultRegion,
+		},
+	}
+	var loginResp structs.ACLLoginResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLLoginRPCMethod, &req, &loginResp)
+	must.ErrorContains(t, err, "400")
+	must.ErrorContains(t, err, "missing username")
+}
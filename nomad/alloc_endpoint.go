@@ -302,6 +302,12 @@ func (a *Alloc) Stop(args *structs.AllocStopRequest, reply *structs.AllocStopRes
 		return structs.ErrPermissionDenied
 	}
 
+	if !args.IgnoreDisruptionBudget {
+		if err := checkDisruptionBudget(a.srv.State(), alloc); err != nil {
+			return err
+		}
+	}
+
 	now := time.Now().UTC().UnixNano()
 	eval := &structs.Evaluation{
 		ID:             uuid.Generate(),
@@ -340,6 +346,38 @@ func (a *Alloc) Stop(args *structs.AllocStopRequest, reply *structs.AllocStopRes
 	return nil
 }
 
+// checkDisruptionBudget returns an error if stopping the allocation would take
+// its task group below the floor set by the group's disruption budget.
+func checkDisruptionBudget(store *state.StateStore, alloc *structs.Allocation) error {
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil || tg.DisruptionBudget == nil {
+		return nil
+	}
+
+	// Stopping an allocation which isn't available doesn't disrupt the group
+	if !alloc.AvailableForDisruptionBudget() {
+		return nil
+	}
+
+	allocs, err := store.AllocsByJob(nil, alloc.Namespace, alloc.JobID, false)
+	if err != nil {
+		return err
+	}
+
+	available := 0
+	for _, a := range allocs {
+		if a.TaskGroup == tg.Name && a.AvailableForDisruptionBudget() {
+			available++
+		}
+	}
+
+	if tg.DisruptionBudget.Allowed(tg.Count, available) <= 0 {
+		return fmt.Errorf("stopping allocation would violate the disruption budget of task group %q: %d healthy allocations must remain available",
+			tg.Name, tg.DisruptionBudget.MinHealthy(tg.Count))
+	}
+	return nil
+}
+
 // UpdateDesiredTransition is used to update the desired transitions of an
 // allocation.
 func (a *Alloc) UpdateDesiredTransition(args *structs.AllocUpdateDesiredTransitionRequest, reply *structs.GenericResponse) error {
@@ -1234,6 +1234,55 @@ func TestAllocEndpoint_Stop(t *testing.T) {
 		must.True(t, chkAlloc.DesiredTransition.ShouldMigrate())
 		must.True(t, chkAlloc.DesiredTransition.ShouldIgnoreShutdownDelay())
 	})
+
+	t.Run("with disruption budget", func(t *testing.T) {
+		srv, cleanup := TestServer(t, nil)
+		defer cleanup()
+		codec := rpcClient(t, srv)
+		testutil.WaitForLeader(t, srv.RPC)
+
+		job := mock.Job()
+		job.TaskGroups[0].Count = 2
+		job.TaskGroups[0].DisruptionBudget = &structs.DisruptionBudget{MinAvailable: 2}
+
+		var allocs []*structs.Allocation
+		for range 2 {
+			alloc := mock.Alloc()
+			alloc.Job = job
+			alloc.JobID = job.ID
+			alloc.ClientStatus = structs.AllocClientStatusRunning
+			alloc.DeploymentStatus = &structs.AllocDeploymentStatus{Healthy: new(true)}
+			allocs = append(allocs, alloc)
+		}
+
+		state := srv.fsm.State()
+		must.NoError(t, state.UpsertJobSummary(998, mock.JobSummary(job.ID)))
+		must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 999, nil, job))
+		must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, allocs))
+
+		req := &structs.AllocStopRequest{
+			AllocID: allocs[0].ID,
+			WriteRequest: structs.WriteRequest{
+				Namespace: structs.DefaultNamespace,
+				Region:    job.Region,
+			},
+		}
+		var resp structs.AllocStopResponse
+		err := msgpackrpc.CallWithCodec(codec, "Alloc.Stop", req, &resp)
+		must.ErrorContains(t, err, "would violate the disruption budget")
+
+		chkAlloc, err := state.AllocByID(nil, allocs[0].ID)
+		must.NoError(t, err)
+		must.False(t, chkAlloc.DesiredTransition.ShouldMigrate())
+
+		// Operators may override the budget
+		req.IgnoreDisruptionBudget = true
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, "Alloc.Stop", req, &resp))
+
+		chkAlloc, err = state.AllocByID(nil, allocs[0].ID)
+		must.NoError(t, err)
+		must.True(t, chkAlloc.DesiredTransition.ShouldMigrate())
+	})
 }
 
 func TestAllocEndpoint_Stop_ACL(t *testing.T) {
@@ -5,6 +5,7 @@ package nomad
 
 import (
 	"io"
+	"maps"
 	"net"
 	"os"
 	"runtime"
@@ -445,6 +446,11 @@ type Config struct {
 	// JobMaxCount is the maximum total task group counts for a single Job.
 	JobMaxCount int
 
+	// CanaryAnalysisProviders maps the names of the providers that canary
+	// analyses may query to the addresses of their Prometheus-compatible HTTP
+	// APIs.
+	CanaryAnalysisProviders map[string]string
+
 	Reporting *config.ReportingConfig
 
 	// OIDCIssuer is the URL for the OIDC Issuer field in Workload Identity JWTs.
@@ -529,6 +535,7 @@ func (c *Config) Copy() *Config {
 	nc.RaftLogStoreConfig = pointer.Copy(c.RaftLogStoreConfig)
 	nc.KEKProviderConfigs = helper.CopySlice(c.KEKProviderConfigs)
 	nc.NodeIntroductionConfig = c.NodeIntroductionConfig.Copy()
+	nc.CanaryAnalysisProviders = maps.Clone(c.CanaryAnalysisProviders)
 
 	return &nc
 }
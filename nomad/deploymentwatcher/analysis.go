@@ -0,0 +1,272 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package deploymentwatcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// analysisQueryTimeout is the maximum time a single analysis query may
+	// take before the measurement is considered failed.
+	analysisQueryTimeout = 10 * time.Second
+
+	// analysisMaxResponseSize is the maximum size of an analysis query
+	// response that is read.
+	analysisMaxResponseSize = 1 << 20
+)
+
+// canaryAnalysis tracks the measurements taken of a task group's canaries
+// since they all became healthy. It's only accessed from the watch loop, and
+// isn't persisted, so measurements start over after a leader election.
+type canaryAnalysis struct {
+	// next is the time the next measurement is due
+	next time.Time
+
+	// pending is whether a measurement is being taken
+	pending bool
+
+	successes int
+	failures  int
+}
+
+// analysisResult is the result of a canary analysis query, which is sent back
+// to the watch loop once the query is complete.
+type analysisResult struct {
+	group string
+
+	// state is the analysis the query was made for, so that results of
+	// queries made before the analysis started over are ignored
+	state *canaryAnalysis
+
+	value float64
+	err   error
+}
+
+// groupAnalysis returns the canary analysis of the task group, or nil if it
+// has none.
+func (w *deploymentWatcher) groupAnalysis(group string) *structs.UpdateAnalysis {
+	tg := w.j.LookupTaskGroup(group)
+	if tg == nil || tg.Update == nil {
+		return nil
+	}
+	return tg.Update.Analysis
+}
+
+// canariesHealthy returns whether all of the group's canaries are placed and
+// healthy.
+func canariesHealthy(dstate *structs.DeploymentState) bool {
+	return len(dstate.PlacedCanaries) >= dstate.DesiredCanaries &&
+		dstate.HealthyAllocs >= dstate.DesiredCanaries
+}
+
+// runCanaryAnalyses starts the measurements of the canary analyses that are
+// due. The queries are made in the background, and their results are recorded
+// with recordAnalysisResult. It promotes the deployment once every group with
+// canaries has either passed its analysis or is healthy and set to auto
+// promote, and it marks the deployment to be failed once any analysis has too
+// many failed measurements. It returns the next time a measurement is due, or
+// the zero time if there are no more analyses to run.
+func (w *deploymentWatcher) runCanaryAnalyses(now time.Time) (allocUpdateResult, time.Time, error) {
+	var res allocUpdateResult
+	d := w.getDeployment()
+
+	var interval time.Duration
+	for name, dstate := range d.TaskGroups {
+		if a := w.groupAnalysis(name); a != nil && dstate.DesiredCanaries > 0 && !dstate.Promoted {
+			if interval == 0 || a.Interval < interval {
+				interval = a.Interval
+			}
+		}
+	}
+	if interval == 0 {
+		return res, time.Time{}, nil
+	}
+
+	// Measurements are only taken while the deployment is running and
+	// waiting on promotion, and start over if it's paused.
+	if !d.RequiresPromotion() {
+		clear(w.analyses)
+		return res, now.Add(interval), nil
+	}
+
+	next := now.Add(interval)
+	promote := true
+	for name, dstate := range d.TaskGroups {
+		if dstate.DesiredCanaries == 0 || dstate.Promoted {
+			continue
+		}
+
+		analysis := w.groupAnalysis(name)
+		if analysis == nil {
+			promote = promote && dstate.AutoPromote && canariesHealthy(dstate)
+			continue
+		}
+
+		// Start measuring an interval after the canaries are healthy.
+		state, ok := w.analyses[name]
+		if !canariesHealthy(dstate) {
+			delete(w.analyses, name)
+			promote = false
+			continue
+		}
+		if !ok {
+			state = &canaryAnalysis{next: now.Add(analysis.Interval)}
+			w.analyses[name] = state
+		}
+
+		if state.failures > analysis.FailureLimit {
+			res.failDeployment = true
+			res.rollback = dstate.AutoRevert
+			return res, time.Time{}, nil
+		}
+
+		if !state.pending && !now.Before(state.next) && state.successes < analysis.Count {
+			state.pending = true
+			go w.queryAnalysisAsync(name, state, analysis)
+		}
+
+		if state.successes < analysis.Count {
+			promote = false
+			if !state.pending && state.next.Before(next) {
+				next = state.next
+			}
+		}
+	}
+
+	if !promote {
+		return res, next, nil
+	}
+
+	w.logger.Debug("promoting deployment after canary analysis")
+	_, err := w.upsertDeploymentPromotion(&structs.ApplyDeploymentPromoteRequest{
+		DeploymentPromoteRequest: structs.DeploymentPromoteRequest{DeploymentID: d.GetID(), All: true},
+		Eval:                     w.getEval(),
+	})
+	if err != nil {
+		return res, now.Add(interval), err
+	}
+	return res, time.Time{}, nil
+}
+
+// recordAnalysisResult records the result of a canary analysis query. Results
+// for analyses that started over while the query was made are ignored.
+func (w *deploymentWatcher) recordAnalysisResult(res *analysisResult, now time.Time) {
+	state, ok := w.analyses[res.group]
+	if !ok || state != res.state {
+		return
+	}
+	analysis := w.groupAnalysis(res.group)
+	if analysis == nil {
+		return
+	}
+
+	state.pending = false
+	state.next = now.Add(analysis.Interval)
+	switch {
+	case res.err != nil:
+		state.failures++
+		w.logger.Warn("canary analysis query failed", "task_group", res.group, "error", res.err)
+	case analysis.Passes(res.value):
+		state.successes++
+		w.logger.Debug("canary analysis passed", "task_group", res.group, "value", res.value)
+	default:
+		state.failures++
+		w.logger.Warn("canary analysis failed", "task_group", res.group, "value", res.value)
+	}
+}
+
+// queryAnalysisAsync evaluates the analysis query and sends the result to the
+// watch loop. It's run in its own goroutine so that slow queries don't block
+// the watch loop.
+func (w *deploymentWatcher) queryAnalysisAsync(group string, state *canaryAnalysis, a *structs.UpdateAnalysis) {
+	value, err := w.queryAnalysis(a)
+	select {
+	case w.analysisResultCh <- &analysisResult{group: group, state: state, value: value, err: err}:
+	case <-w.ctx.Done():
+	}
+}
+
+// analysisQueryResponse is the response of a Prometheus-compatible instant
+// query API.
+type analysisQueryResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// queryAnalysis evaluates the analysis query against its provider and returns
+// its result.
+func (w *deploymentWatcher) queryAnalysis(a *structs.UpdateAnalysis) (float64, error) {
+	address, ok := w.analysisProviders[a.Provider]
+	if !ok {
+		return 0, fmt.Errorf("provider %q is not configured", a.Provider)
+	}
+	u, err := url.Parse(address)
+	if err != nil {
+		return 0, fmt.Errorf("invalid provider address: %w", err)
+	}
+	u = u.JoinPath("api", "v1", "query")
+	u.RawQuery = url.Values{"query": []string{a.Query}}.Encode()
+
+	ctx, cancel := context.WithTimeout(w.ctx, analysisQueryTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var out analysisQueryResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, analysisMaxResponseSize)).Decode(&out); err != nil {
+		return 0, fmt.Errorf("failed to decode response with status %d: %w", resp.StatusCode, err)
+	}
+	if out.Status != "success" {
+		return 0, fmt.Errorf("query failed: %s", out.Error)
+	}
+
+	var sample [2]json.RawMessage
+	switch out.Data.ResultType {
+	case "scalar":
+		if err := json.Unmarshal(out.Data.Result, &sample); err != nil {
+			return 0, fmt.Errorf("failed to decode scalar result: %w", err)
+		}
+	case "vector":
+		var vector []struct {
+			Value [2]json.RawMessage `json:"value"`
+		}
+		if err := json.Unmarshal(out.Data.Result, &vector); err != nil {
+			return 0, fmt.Errorf("failed to decode vector result: %w", err)
+		}
+		if len(vector) == 0 {
+			return 0, errors.New("query returned no results")
+		}
+		sample = vector[0].Value
+	default:
+		return 0, fmt.Errorf("unsupported result type %q", out.Data.ResultType)
+	}
+
+	var value string
+	if err := json.Unmarshal(sample[1], &value); err != nil {
+		return 0, fmt.Errorf("failed to decode sample value: %w", err)
+	}
+	return strconv.ParseFloat(value, 64)
+}
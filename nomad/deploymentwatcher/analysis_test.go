@@ -0,0 +1,161 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package deploymentwatcher
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+// testAnalysisServer returns a Prometheus-compatible query API that always
+// returns the given vector sample value.
+func testAnalysisServer(t *testing.T, value string) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/query" || r.URL.Query().Get("query") != "error_ratio" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"status":"error","error":"bad query"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,%q]}]}}`, value)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// testAnalysisDeployment upserts a job with a canary analysis against the
+// provider and a deployment whose canary is healthy.
+func testAnalysisDeployment(t *testing.T, m *mockBackend, provider string) *structs.Deployment {
+	j := mock.Job()
+	j.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	j.TaskGroups[0].Update.Canary = 1
+	j.TaskGroups[0].Update.ProgressDeadline = 0
+	j.TaskGroups[0].Update.Analysis = &structs.UpdateAnalysis{
+		Provider:     provider,
+		Query:        "error_ratio",
+		Interval:     10 * time.Millisecond,
+		Count:        3,
+		FailureLimit: 1,
+		Max:          new(0.05),
+	}
+	d := mock.Deployment()
+	d.JobID = j.ID
+	a := mock.Alloc()
+	a.Job = j
+	a.JobID = j.ID
+	d.TaskGroups[a.TaskGroup].DesiredCanaries = 1
+	d.TaskGroups[a.TaskGroup].PlacedCanaries = []string{a.ID}
+	d.TaskGroups[a.TaskGroup].HealthyAllocs = 1
+	a.DeploymentStatus = &structs.AllocDeploymentStatus{
+		Healthy: new(true),
+		Canary:  true,
+	}
+	a.DeploymentID = d.ID
+	must.NoError(t, m.state.UpsertJob(structs.MsgTypeTestSetup, m.nextIndex(), nil, j))
+	must.NoError(t, m.state.UpsertDeployment(m.nextIndex(), d))
+	must.NoError(t, m.state.UpsertAllocs(structs.MsgTypeTestSetup, m.nextIndex(), []*structs.Allocation{a}))
+	return d
+}
+
+func TestWatcher_CanaryAnalysis_Promote(t *testing.T) {
+	ci.Parallel(t)
+	w, m := defaultTestDeploymentWatcher(t)
+	srv := testAnalysisServer(t, "0.01")
+	w.analysisProviders = map[string]string{"prometheus": srv.URL}
+	d := testAnalysisDeployment(t, m, "prometheus")
+
+	w.SetEnabled(true, m.state)
+	waitForWatchers(t, w, 1)
+
+	must.Wait(t, wait.InitialSuccess(wait.ErrorFunc(func() error {
+		d, _ := m.state.DeploymentByID(nil, d.ID)
+		if !d.TaskGroups["web"].Promoted {
+			return fmt.Errorf("expected task group to be promoted")
+		}
+		return nil
+	}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+	m.assertCalls(t, "UpdateDeploymentPromotion", 1)
+}
+
+func TestWatcher_CanaryAnalysis_Fail(t *testing.T) {
+	ci.Parallel(t)
+	w, m := defaultTestDeploymentWatcher(t)
+	srv := testAnalysisServer(t, "0.5")
+	w.analysisProviders = map[string]string{"prometheus": srv.URL}
+	d := testAnalysisDeployment(t, m, "prometheus")
+
+	w.SetEnabled(true, m.state)
+	waitForWatchers(t, w, 1)
+
+	must.Wait(t, wait.InitialSuccess(wait.ErrorFunc(func() error {
+		d, _ := m.state.DeploymentByID(nil, d.ID)
+		if d.Status != structs.DeploymentStatusFailed {
+			return fmt.Errorf("bad status %q", d.Status)
+		}
+		if d.StatusDescription != structs.DeploymentStatusDescriptionFailedAnalysis {
+			return fmt.Errorf("bad status description %q", d.StatusDescription)
+		}
+		return nil
+	}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+	m.assertCalls(t, "UpdateDeploymentPromotion", 0)
+}
+
+func TestWatcher_CanaryAnalysis_Query(t *testing.T) {
+	ci.Parallel(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("query") {
+		case "scalar":
+			fmt.Fprint(w, `{"status":"success","data":{"resultType":"scalar","result":[1700000000,"2.5"]}}`)
+		case "vector":
+			fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"a":"b"},"value":[1700000000,"0.25"]}]}}`)
+		case "empty":
+			fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[]}}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"status":"error","error":"parse error"}`)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	dw := &deploymentWatcher{
+		ctx:               t.Context(),
+		analysisProviders: map[string]string{"prometheus": srv.URL},
+	}
+	query := func(q string) (float64, error) {
+		return dw.queryAnalysis(&structs.UpdateAnalysis{Provider: "prometheus", Query: q})
+	}
+
+	value, err := query("scalar")
+	must.NoError(t, err)
+	must.Eq(t, 2.5, value)
+
+	value, err = query("vector")
+	must.NoError(t, err)
+	must.Eq(t, 0.25, value)
+
+	_, err = query("empty")
+	must.ErrorContains(t, err, "no results")
+
+	_, err = query("invalid(")
+	must.ErrorContains(t, err, "parse error")
+
+	// only the configured providers can be queried
+	_, err = dw.queryAnalysis(&structs.UpdateAnalysis{Provider: "unknown", Query: "scalar"})
+	must.ErrorContains(t, err, `provider "unknown" is not configured`)
+}
@@ -11,6 +11,7 @@ import (
 
 	log "github.com/hashicorp/go-hclog"
 	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -21,6 +22,10 @@ const (
 	// perJobEvalBatchPeriod is the batching length before creating an evaluation to
 	// trigger the scheduler when allocations are marked as healthy.
 	perJobEvalBatchPeriod = 1 * time.Second
+
+	// updateWindowRetryInterval is how long to wait before retrying to pause
+	// or resume a deployment for its update windows after a failure.
+	updateWindowRetryInterval = 10 * time.Second
 )
 
 var (
@@ -100,6 +105,18 @@ type deploymentWatcher struct {
 	// by holding the lock or using the setter and getter methods.
 	latestEval uint64
 
+	// analyses tracks the canary analysis of each task group. It's only
+	// accessed from the watch loop.
+	analyses map[string]*canaryAnalysis
+
+	// analysisProviders maps the names of the canary analysis providers to
+	// their addresses
+	analysisProviders map[string]string
+
+	// analysisResultCh receives the results of the canary analysis queries,
+	// which are made outside of the watch loop
+	analysisResultCh chan *analysisResult
+
 	logger log.Logger
 	ctx    context.Context
 	exitFn context.CancelFunc
@@ -111,7 +128,8 @@ type deploymentWatcher struct {
 func newDeploymentWatcher(parent context.Context, queryLimiter *rate.Limiter,
 	logger log.Logger, state *state.StateStore, d *structs.Deployment,
 	j *structs.Job, triggers deploymentTriggers,
-	deploymentRPC DeploymentRPC, jobRPC JobRPC) *deploymentWatcher {
+	deploymentRPC DeploymentRPC, jobRPC JobRPC,
+	analysisProviders map[string]string) *deploymentWatcher {
 
 	ctx, exitFn := context.WithCancel(parent)
 	w := &deploymentWatcher{
@@ -124,6 +142,9 @@ func newDeploymentWatcher(parent context.Context, queryLimiter *rate.Limiter,
 		deploymentTriggers: triggers,
 		DeploymentRPC:      deploymentRPC,
 		JobRPC:             jobRPC,
+		analyses:           make(map[string]*canaryAnalysis),
+		analysisProviders:  analysisProviders,
+		analysisResultCh:   make(chan *analysisResult),
 		logger:             logger.With("deployment_id", d.ID, "job", j.NamespacedID()),
 		ctx:                ctx,
 		exitFn:             exitFn,
@@ -288,7 +309,7 @@ func (w *deploymentWatcher) autoPromoteDeployment(allocs []*structs.AllocListStu
 
 	// AutoPromote iff every task group with canaries is marked auto_promote and is healthy. The whole
 	// job version has been incremented, so we promote together. See also AutoRevert
-	for _, dstate := range d.TaskGroups {
+	for name, dstate := range d.TaskGroups {
 
 		// skip auto promote canary validation if the task group has no canaries
 		// to prevent auto promote hanging on mixed canary/non-canary taskgroup deploys
@@ -296,6 +317,11 @@ func (w *deploymentWatcher) autoPromoteDeployment(allocs []*structs.AllocListStu
 			continue
 		}
 
+		// deployments with a canary analysis are promoted once it passes
+		if w.groupAnalysis(name) != nil {
+			return nil
+		}
+
 		if !dstate.AutoPromote || len(dstate.PlacedCanaries) < dstate.DesiredCanaries {
 			return nil
 		}
@@ -429,11 +455,21 @@ func (w *deploymentWatcher) watch() {
 		deadlineTimer = time.NewTimer(time.Until(currentDeadline))
 	}
 
+	// Check the job's update windows straight away, and then again whenever
+	// one of them opens or closes.
+	windowTimer, windowTimerStop := helper.NewSafeTimer(0)
+	defer windowTimerStop()
+
+	// Measure the canaries of task groups with an analysis until the
+	// deployment is promoted or failed.
+	analysisTimer, analysisTimerStop := helper.NewSafeTimer(0)
+	defer analysisTimerStop()
+
 	allocIndex := uint64(1)
 	allocsCh := w.getAllocsCh(allocIndex)
 	var updates *allocUpdates
 
-	rollback, deadlineHit := false, false
+	rollback, deadlineHit, analysisFailed := false, false, false
 
 FAIL:
 	for {
@@ -466,6 +502,36 @@ FAIL:
 				w.logger.Error("multiregion deployment error", "error", err)
 			}
 			break FAIL
+		case <-windowTimer.C:
+			next, err := w.handleUpdateWindows(time.Now())
+			if err != nil {
+				w.logger.Error("failed to update deployment for update window", "error", err)
+				next = time.Now().Add(updateWindowRetryInterval)
+			}
+			if !next.IsZero() {
+				windowTimer.Reset(time.Until(next))
+			}
+		case <-analysisTimer.C:
+			res, next, err := w.runCanaryAnalyses(time.Now())
+			if err != nil {
+				w.logger.Error("failed to promote deployment after canary analysis", "error", err)
+			}
+			if res.failDeployment {
+				rollback, analysisFailed = res.rollback, true
+				err := w.nextRegion(structs.DeploymentStatusFailed)
+				if err != nil {
+					w.logger.Error("multiregion deployment error", "error", err)
+				}
+				break FAIL
+			}
+			if !next.IsZero() {
+				analysisTimer.Reset(time.Until(next))
+			}
+		case res := <-w.analysisResultCh:
+			// Record the measurement and run the analyses again, which
+			// promotes or fails the deployment if the measurement decided it.
+			w.recordAnalysisResult(res, time.Now())
+			analysisTimer.Reset(0)
 		case <-w.deploymentUpdateCh:
 			// Get the updated deployment and check if we should change the
 			// deadline timer
@@ -557,6 +623,9 @@ FAIL:
 	if deadlineHit {
 		desc = structs.DeploymentStatusDescriptionProgressDeadline
 	}
+	if analysisFailed {
+		desc = structs.DeploymentStatusDescriptionFailedAnalysis
+	}
 
 	// Rollback to the old job if necessary
 	var j *structs.Job
@@ -584,6 +653,69 @@ FAIL:
 	}
 }
 
+// handleUpdateWindows pauses the deployment when the update windows of its
+// task groups are closed, and resumes it when they open again. Only
+// deployments paused by their update windows are resumed, so a deployment
+// paused by an operator stays paused. It returns the next time the windows
+// open or close, or the zero time if the job has no update windows.
+func (w *deploymentWatcher) handleUpdateWindows(now time.Time) (time.Time, error) {
+	d := w.getDeployment()
+
+	// The deployment may progress only while the windows of all of its task
+	// groups are open. Recheck at the first time any of them changes.
+	open, hasWindows := true, false
+	var next time.Time
+	for name := range d.TaskGroups {
+		tg := w.j.LookupTaskGroup(name)
+		if tg == nil || tg.Update == nil || len(tg.Update.Windows) == 0 {
+			continue
+		}
+		hasWindows = true
+
+		tgOpen, change := tg.Update.WindowOpen(now)
+		open = open && tgOpen
+		if !change.IsZero() && (next.IsZero() || change.Before(next)) {
+			next = change
+		}
+	}
+	if !hasWindows {
+		return time.Time{}, nil
+	}
+
+	switch {
+	case !open && d.Status == structs.DeploymentStatusRunning:
+		w.logger.Debug("pausing deployment outside of update window", "next", next)
+		update := w.getDeploymentStatusUpdate(structs.DeploymentStatusPaused,
+			structs.DeploymentStatusDescriptionPausedUpdateWindow)
+		if _, err := w.upsertDeploymentStatusUpdate(update, nil, nil); err != nil {
+			return time.Time{}, err
+		}
+
+	case open && d.Status == structs.DeploymentStatusPaused &&
+		d.StatusDescription == structs.DeploymentStatusDescriptionPausedUpdateWindow:
+		w.logger.Debug("resuming deployment inside update window", "next", next)
+
+		// Restore the description the deployment would have had if it had
+		// never been paused.
+		running := d.Copy()
+		running.Status = structs.DeploymentStatusRunning
+		desc := structs.DeploymentStatusDescriptionRunning
+		if running.RequiresPromotion() {
+			desc = structs.DeploymentStatusDescriptionRunningNeedsPromotion
+			if running.HasAutoPromote() {
+				desc = structs.DeploymentStatusDescriptionRunningAutoPromotion
+			}
+		}
+
+		update := w.getDeploymentStatusUpdate(structs.DeploymentStatusRunning, desc)
+		if _, err := w.upsertDeploymentStatusUpdate(update, w.getEval(), nil); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	return next, nil
+}
+
 // allocUpdateResult is used to return the desired actions given the newest set
 // of allocations for the deployment.
 type allocUpdateResult struct {
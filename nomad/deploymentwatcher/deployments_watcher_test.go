@@ -26,7 +26,7 @@ import (
 
 func testDeploymentWatcher(t *testing.T, qps float64, batchDur time.Duration) (*Watcher, *mockBackend) {
 	m := newMockBackend(t)
-	w := NewDeploymentsWatcher(testlog.HCLogger(t), m, nil, nil, qps, batchDur)
+	w := NewDeploymentsWatcher(testlog.HCLogger(t), m, nil, nil, qps, batchDur, nil)
 	return w, m
 }
 
@@ -47,7 +47,7 @@ func logRecorderTestDeploymentWatcher(t *testing.T) (*Watcher, *mockBackend, fun
 		DisableTime:     true,
 	})
 	m := newMockBackend(t)
-	w := NewDeploymentsWatcher(logger, m, nil, nil, LimitStateQueriesPerSecond, CrossDeploymentUpdateBatchDuration)
+	w := NewDeploymentsWatcher(logger, m, nil, nil, LimitStateQueriesPerSecond, CrossDeploymentUpdateBatchDuration, nil)
 	return w, m, func() string {
 		bts, err := io.ReadAll(buf)
 		test.NoError(t, err)
@@ -726,6 +726,110 @@ func TestWatcher_PauseDeployment_Pause_Paused(t *testing.T) {
 	must.Eq(t, structs.DeploymentStatusDescriptionPaused, d.StatusDescription)
 }
 
+// Test that a deployment is paused outside of its update window and resumed
+// inside it
+func TestWatcher_UpdateWindow(t *testing.T) {
+	ci.Parallel(t)
+	w, m := defaultTestDeploymentWatcher(t)
+
+	// Create a job whose update window is only open for a minute a year
+	j := mock.Job()
+	j.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	j.TaskGroups[0].Update.Windows = []*structs.UpdateWindow{{
+		Cron:     "0 0 1 1 *",
+		Duration: time.Minute,
+	}}
+	if open, _ := j.TaskGroups[0].Update.WindowOpen(time.Now()); open {
+		t.Skip("update window is open")
+	}
+	d := mock.Deployment()
+	d.JobID = j.ID
+	must.NoError(t, m.state.UpsertJob(structs.MsgTypeTestSetup, m.nextIndex(), nil, j))
+	must.NoError(t, m.state.UpsertDeployment(m.nextIndex(), d))
+
+	w.SetEnabled(true, m.state)
+	waitForWatchers(t, w, 1)
+
+	must.Wait(t, wait.InitialSuccess(wait.ErrorFunc(func() error {
+		d, _ := m.state.DeploymentByID(nil, d.ID)
+		if d.Status != structs.DeploymentStatusPaused {
+			return fmt.Errorf("bad status %q", d.Status)
+		}
+		if d.StatusDescription != structs.DeploymentStatusDescriptionPausedUpdateWindow {
+			return fmt.Errorf("bad status description %q", d.StatusDescription)
+		}
+		return nil
+	}),
+		wait.Timeout(time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+	must.Eq(t, 1, watchersCount(w), must.Sprint("watcher should still be active"))
+
+	// Open the window and restart the watcher, which should resume the
+	// deployment and create an eval to push it along
+	w.SetEnabled(false, m.state)
+	j = j.Copy()
+	j.TaskGroups[0].Update.Windows[0].Cron = "* * * * *"
+	j.TaskGroups[0].Update.Windows[0].Duration = time.Hour
+	must.NoError(t, m.state.UpsertJob(structs.MsgTypeTestSetup, m.nextIndex(), nil, j))
+	w.SetEnabled(true, m.state)
+
+	must.Wait(t, wait.InitialSuccess(wait.ErrorFunc(func() error {
+		d, _ := m.state.DeploymentByID(nil, d.ID)
+		if d.Status != structs.DeploymentStatusRunning {
+			return fmt.Errorf("bad status %q", d.Status)
+		}
+		if d.StatusDescription != structs.DeploymentStatusDescriptionRunning {
+			return fmt.Errorf("bad status description %q", d.StatusDescription)
+		}
+		return nil
+	}),
+		wait.Timeout(time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	evals, err := m.state.EvalsByJob(nil, j.Namespace, j.ID)
+	must.NoError(t, err)
+	must.Len(t, 1, evals)
+	must.Eq(t, d.ID, evals[0].DeploymentID)
+}
+
+// Test that a manually paused deployment isn't resumed when its update window
+// is open
+func TestWatcher_UpdateWindow_ManuallyPaused(t *testing.T) {
+	ci.Parallel(t)
+	w, m := defaultTestDeploymentWatcher(t)
+
+	j := mock.Job()
+	j.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	j.TaskGroups[0].Update.Windows = []*structs.UpdateWindow{{
+		Cron:     "* * * * *",
+		Duration: time.Hour,
+	}}
+	d := mock.Deployment()
+	d.JobID = j.ID
+	d.Status = structs.DeploymentStatusPaused
+	d.StatusDescription = structs.DeploymentStatusDescriptionPaused
+	must.NoError(t, m.state.UpsertJob(structs.MsgTypeTestSetup, m.nextIndex(), nil, j))
+	must.NoError(t, m.state.UpsertDeployment(m.nextIndex(), d))
+
+	w.SetEnabled(true, m.state)
+	waitForWatchers(t, w, 1)
+
+	w.l.RLock()
+	dw := w.watchers[d.ID]
+	w.l.RUnlock()
+	next, err := dw.handleUpdateWindows(time.Now())
+	must.NoError(t, err)
+	must.False(t, next.IsZero())
+
+	d, err = m.state.DeploymentByID(nil, d.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.DeploymentStatusPaused, d.Status)
+	must.Eq(t, structs.DeploymentStatusDescriptionPaused, d.StatusDescription)
+	m.assertCalls(t, "UpdateDeploymentStatus", 0)
+}
+
 // Test that the timeline check is skipped for paused deployment
 func TestWatcher_PauseDeployment_IgnoreProgressDeadline(t *testing.T) {
 	ci.Parallel(t)
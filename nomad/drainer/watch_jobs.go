@@ -348,6 +348,7 @@ func handleTaskGroup(snap *state.StateSnapshot, batch bool, tg *structs.TaskGrou
 	// Determine how many allocations can be drained
 	drainingNodes := make(map[string]bool, 4)
 	healthy := 0
+	available := 0
 	remainingDrainingAlloc := false
 	var drainable []*structs.Allocation
 
@@ -384,6 +385,11 @@ func handleTaskGroup(snap *state.StateSnapshot, batch bool, tg *structs.TaskGrou
 			healthy++
 		}
 
+		// Track the allocations which count toward the disruption budget
+		if !batch && alloc.AvailableForDisruptionBudget() {
+			available++
+		}
+
 		// An alloc can't be considered for migration if:
 		// - It isn't on a draining node
 		// - It is already terminal on the client
@@ -417,6 +423,11 @@ func handleTaskGroup(snap *state.StateSnapshot, batch bool, tg *structs.TaskGrou
 	numToDrain := healthy - thresholdCount
 	numToDrain = min(len(drainable), numToDrain)
 
+	// Never drain more than the disruption budget of the group allows
+	if tg.DisruptionBudget != nil {
+		numToDrain = min(numToDrain, tg.DisruptionBudget.Allowed(tg.Count, available))
+	}
+
 	if numToDrain <= 0 {
 		return nil
 	}
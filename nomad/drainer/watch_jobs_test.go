@@ -331,6 +331,9 @@ func TestDrainingJobWatcher_HandleTaskGroup(t *testing.T) {
 		allocCount  int  // number of allocs in test (defaults to 10)
 		maxParallel int  // max_parallel (defaults to 1)
 
+		// budget is the disruption budget of the group, if any
+		budget *structs.DisruptionBudget
+
 		// addAllocFn will be called allocCount times to create test allocs,
 		// and the allocs default to be healthy on the draining node
 		addAllocFn func(idx int, a *structs.Allocation, drainingID, runningID string)
@@ -369,6 +372,33 @@ func TestDrainingJobWatcher_HandleTaskGroup(t *testing.T) {
 				}
 			},
 		},
+		{
+			// running allocs on draining node, should respect the budget
+			name:           "drain-respects-disruption-budget",
+			expectDrained:  2,
+			expectMigrated: 0,
+			expectDone:     false,
+			maxParallel:    5,
+			budget:         &structs.DisruptionBudget{MaxUnavailable: 2},
+			addAllocFn: func(i int, a *structs.Allocation, drainingID, runningID string) {
+				a.ClientStatus = structs.AllocClientStatusRunning
+			},
+		},
+		{
+			// a migrating alloc consumes the whole budget
+			name:           "disruption-budget-exhausted",
+			expectDrained:  0,
+			expectMigrated: 0,
+			expectDone:     false,
+			maxParallel:    5,
+			budget:         &structs.DisruptionBudget{MinAvailable: 9},
+			addAllocFn: func(i int, a *structs.Allocation, drainingID, runningID string) {
+				a.ClientStatus = structs.AllocClientStatusRunning
+				if i == 0 {
+					a.DesiredTransition.Migrate = new(true)
+				}
+			},
+		},
 		{
 			// allocs on a non-draining node, should not be drained
 			name:           "allocs-on-non-draining-node-should-not-drain",
@@ -624,6 +654,7 @@ func TestDrainingJobWatcher_HandleTaskGroup(t *testing.T) {
 			if tc.maxParallel > 0 {
 				job.TaskGroups[0].Migrate.MaxParallel = tc.maxParallel
 			}
+			job.TaskGroups[0].DisruptionBudget = tc.budget
 			must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 102, nil, job))
 
 			var allocs []*structs.Allocation
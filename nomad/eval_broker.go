@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"math/rand"
 	"strconv"
 	"sync"
@@ -74,8 +75,12 @@ type EvalBroker struct {
 	// now safe for the Eval.Ack RPC to cancel in batches
 	cancelable []*structs.Evaluation
 
-	// ready tracks the ready jobs by scheduler in a priority queue
-	ready map[string]ReadyEvaluations
+	// ready tracks the ready jobs by scheduler, fairly queued by namespace
+	ready map[string]*namespaceQueues
+
+	// namespaceWeights is the relative share of dequeues each namespace
+	// receives from a scheduler's ready queue
+	namespaceWeights map[string]int
 
 	// unack is a map of evalID to an un-acknowledged evaluation
 	unack map[string]*unackEval
@@ -157,7 +162,7 @@ func NewEvalBroker(ctx context.Context, timeout, initialNackDelay, subsequentNac
 		jobEvals:             make(map[structs.NamespacedID]string),
 		pending:              make(map[structs.NamespacedID]PendingEvaluations),
 		cancelable:           make([]*structs.Evaluation, 0, structs.MaxUUIDsPerWriteRequest),
-		ready:                make(map[string]ReadyEvaluations),
+		ready:                make(map[string]*namespaceQueues),
 		unack:                make(map[string]*unackEval),
 		waiting:              make(map[string]chan struct{}),
 		requeue:              make(map[string]*structs.Evaluation),
@@ -170,11 +175,30 @@ func NewEvalBroker(ctx context.Context, timeout, initialNackDelay, subsequentNac
 		delayedEvalsUpdateCh: make(chan struct{}, 1),
 	}
 	b.stats.ByScheduler = make(map[string]*SchedulerStats)
+	b.stats.ByNamespace = make(map[string]*NamespaceStats)
 	b.stats.DelayedEvals = make(map[string]*structs.Evaluation)
 
 	return b, nil
 }
 
+// SetNamespaceWeights sets the relative share of dequeues each namespace
+// receives when several namespaces have evaluations ready for a scheduler.
+// Namespaces without a weight have a weight of 1.
+func (b *EvalBroker) SetNamespaceWeights(weights map[string]int) {
+	b.l.Lock()
+	defer b.l.Unlock()
+	b.namespaceWeights = maps.Clone(weights)
+}
+
+// namespaceWeight returns the weight of the namespace. This assumes the lock
+// is held.
+func (b *EvalBroker) namespaceWeight(namespace string) int {
+	if weight := b.namespaceWeights[namespace]; weight > 0 {
+		return weight
+	}
+	return 1
+}
+
 // Enabled is used to check if the broker is enabled.
 func (b *EvalBroker) Enabled() bool {
 	b.l.RLock()
@@ -351,15 +375,15 @@ func (b *EvalBroker) enqueueLocked(eval *structs.Evaluation, sched string, track
 	// Find the next ready eval by scheduler class
 	readyQueue, ok := b.ready[sched]
 	if !ok {
-		readyQueue = make([]*structs.Evaluation, 0, 16)
+		readyQueue = newNamespaceQueues()
+		b.ready[sched] = readyQueue
 		if _, ok := b.waiting[sched]; !ok {
 			b.waiting[sched] = make(chan struct{}, 1)
 		}
 	}
 
-	// Push onto the heap
-	heap.Push(&readyQueue, eval)
-	b.ready[sched] = readyQueue
+	// Push onto the namespace's heap
+	readyQueue.push(eval)
 
 	// Update the stats
 	b.stats.TotalReady += 1
@@ -369,6 +393,12 @@ func (b *EvalBroker) enqueueLocked(eval *structs.Evaluation, sched string, track
 		b.stats.ByScheduler[sched] = bySched
 	}
 	bySched.Ready += 1
+	byNamespace, ok := b.stats.ByNamespace[eval.Namespace]
+	if !ok {
+		byNamespace = &NamespaceStats{}
+		b.stats.ByNamespace[eval.Namespace] = byNamespace
+	}
+	byNamespace.Ready += 1
 
 	// Unblock any pending dequeues
 	select {
@@ -452,7 +482,7 @@ func (b *EvalBroker) scanForSchedulers(schedulers []string) (*structs.Evaluation
 		}
 
 		// Peek at the next item
-		ready := readyQueue.Peek()
+		ready := readyQueue.peek(b.namespaceWeight)
 		if ready == nil {
 			continue
 		}
@@ -491,10 +521,7 @@ func (b *EvalBroker) scanForSchedulers(schedulers []string) (*structs.Evaluation
 // dequeueForSched is used to dequeue the next work item for a given scheduler.
 // This assumes locks are held and that this scheduler has work
 func (b *EvalBroker) dequeueForSched(sched string) (*structs.Evaluation, string, error) {
-	readyQueue := b.ready[sched]
-	raw := heap.Pop(&readyQueue)
-	b.ready[sched] = readyQueue
-	eval := raw.(*structs.Evaluation)
+	eval := b.ready[sched].pop(b.namespaceWeight)
 
 	// Generate a UUID for the token
 	token := uuid.Generate()
@@ -520,6 +547,7 @@ func (b *EvalBroker) dequeueForSched(sched string) (*structs.Evaluation, string,
 	bySched := b.stats.ByScheduler[sched]
 	bySched.Ready -= 1
 	bySched.Unacked += 1
+	b.stats.ByNamespace[eval.Namespace].Ready -= 1
 
 	return eval, token, nil
 }
@@ -852,11 +880,12 @@ func (b *EvalBroker) flush() {
 	b.stats.TotalCancelable = 0
 	b.stats.DelayedEvals = make(map[string]*structs.Evaluation)
 	b.stats.ByScheduler = make(map[string]*SchedulerStats)
+	b.stats.ByNamespace = make(map[string]*NamespaceStats)
 	b.evals = make(map[string]int)
 	b.jobEvals = make(map[structs.NamespacedID]string)
 	b.pending = make(map[structs.NamespacedID]PendingEvaluations)
 	b.cancelable = make([]*structs.Evaluation, 0, structs.MaxUUIDsPerWriteRequest)
-	b.ready = make(map[string]ReadyEvaluations)
+	b.ready = make(map[string]*namespaceQueues)
 	b.unack = make(map[string]*unackEval)
 	b.timeWait = make(map[string]*time.Timer)
 	b.delayHeap = delayheap.NewDelayHeap()
@@ -941,6 +970,7 @@ func (b *EvalBroker) Stats() *BrokerStats {
 	stats := new(BrokerStats)
 	stats.DelayedEvals = make(map[string]*structs.Evaluation)
 	stats.ByScheduler = make(map[string]*SchedulerStats)
+	stats.ByNamespace = make(map[string]*NamespaceStats)
 
 	b.l.RLock()
 	defer b.l.RUnlock()
@@ -959,6 +989,10 @@ func (b *EvalBroker) Stats() *BrokerStats {
 		subStatCopy := *subStat
 		stats.ByScheduler[sched] = &subStatCopy
 	}
+	for namespace, subStat := range b.stats.ByNamespace {
+		subStatCopy := *subStat
+		stats.ByNamespace[namespace] = &subStatCopy
+	}
 	return stats
 }
 
@@ -1008,6 +1042,11 @@ func (b *EvalBroker) EmitStats(period time.Duration, stopCh <-chan struct{}) {
 				metrics.SetGauge([]string{"nomad", "broker", sched, "ready"}, float32(schedStats.Ready))
 				metrics.SetGauge([]string{"nomad", "broker", sched, "unacked"}, float32(schedStats.Unacked))
 			}
+			for namespace, nsStats := range stats.ByNamespace {
+				metrics.SetGaugeWithLabels([]string{"nomad", "broker", "namespace_ready"},
+					float32(nsStats.Ready),
+					[]metrics.Label{{Name: "namespace", Value: namespace}})
+			}
 
 		case <-stopCh:
 			return
@@ -1024,6 +1063,7 @@ type BrokerStats struct {
 	TotalCancelable int
 	DelayedEvals    map[string]*structs.Evaluation
 	ByScheduler     map[string]*SchedulerStats
+	ByNamespace     map[string]*NamespaceStats
 }
 
 // SchedulerStats returns the stats per scheduler
@@ -1032,6 +1072,124 @@ type SchedulerStats struct {
 	Unacked int
 }
 
+// NamespaceStats returns the stats per namespace
+type NamespaceStats struct {
+	Ready int
+}
+
+// namespaceQueues holds the ready evaluations of a scheduler in a priority
+// queue per namespace. Namespaces are dequeued from using weighted fair
+// queuing, so that a namespace with many ready evaluations can't starve the
+// others, while evaluations within a namespace are dequeued by priority.
+type namespaceQueues struct {
+	queues map[string]ReadyEvaluations
+
+	// finish is the virtual time each namespace has been served until: the
+	// number of evaluations dequeued from it divided by its weight
+	finish map[string]float64
+
+	// vtime is the virtual time of the last dequeue. A namespace with no
+	// ready evaluations is caught up to it when it becomes ready, so idle
+	// namespaces can't build up a share to burst with later.
+	vtime float64
+
+	// turn orders namespaces with the same finish time and equally ranked
+	// next evaluations by when they were last pushed to or dequeued from
+	turn     map[string]uint64
+	lastTurn uint64
+}
+
+func newNamespaceQueues() *namespaceQueues {
+	return &namespaceQueues{
+		queues: make(map[string]ReadyEvaluations),
+		finish: make(map[string]float64),
+		turn:   make(map[string]uint64),
+	}
+}
+
+// push adds a ready evaluation to its namespace's queue.
+func (q *namespaceQueues) push(eval *structs.Evaluation) {
+	queue := q.queues[eval.Namespace]
+	if len(queue) == 0 {
+		q.finish[eval.Namespace] = max(q.finish[eval.Namespace], q.vtime)
+		q.lastTurn++
+		q.turn[eval.Namespace] = q.lastTurn
+	}
+	heap.Push(&queue, eval)
+	q.queues[eval.Namespace] = queue
+}
+
+// next returns the namespace which should be dequeued from next: the one
+// that would finish being served earliest in virtual time.
+func (q *namespaceQueues) next(weight func(string) int) string {
+	var next string
+	var nextFinish float64
+	for namespace := range q.queues {
+		finish := q.finish[namespace] + 1/float64(weight(namespace))
+		if next == "" || finish < nextFinish || (finish == nextFinish && q.before(namespace, next)) {
+			next, nextFinish = namespace, finish
+		}
+	}
+	return next
+}
+
+// before breaks a tie in finish time between two namespaces, preferring the
+// one whose next evaluation would be dequeued first from a single queue.
+func (q *namespaceQueues) before(a, b string) bool {
+	heads := ReadyEvaluations{q.queues[a][0], q.queues[b][0]}
+	switch {
+	case heads.Less(0, 1):
+		return true
+	case heads.Less(1, 0):
+		return false
+	}
+	return q.turn[a] < q.turn[b]
+}
+
+// peek returns the evaluation that would be dequeued next, or nil if there
+// are no ready evaluations.
+func (q *namespaceQueues) peek(weight func(string) int) *structs.Evaluation {
+	if len(q.queues) == 0 {
+		return nil
+	}
+	return q.queues[q.next(weight)][0]
+}
+
+// pop dequeues the next evaluation. There must be a ready evaluation.
+func (q *namespaceQueues) pop(weight func(string) int) *structs.Evaluation {
+	namespace := q.next(weight)
+	queue := q.queues[namespace]
+	eval := heap.Pop(&queue).(*structs.Evaluation)
+	q.vtime = q.finish[namespace]
+	q.finish[namespace] += 1 / float64(weight(namespace))
+
+	if len(queue) != 0 {
+		q.queues[namespace] = queue
+		q.lastTurn++
+		q.turn[namespace] = q.lastTurn
+		return eval
+	}
+
+	// The state of drained namespaces is dropped once it can no longer
+	// affect the order, so that it doesn't grow with namespace churn. The
+	// turn is reset when a namespace becomes ready again, and so is the
+	// finish time once the virtual time caught up with it. Once no namespace
+	// is ready, the virtual time restarts from zero.
+	delete(q.queues, namespace)
+	delete(q.turn, namespace)
+	if len(q.queues) == 0 {
+		clear(q.finish)
+		q.vtime = 0
+		return eval
+	}
+	for idle, finish := range q.finish {
+		if _, ok := q.queues[idle]; !ok && finish <= q.vtime {
+			delete(q.finish, idle)
+		}
+	}
+	return eval
+}
+
 // Len is for the sorting interface
 func (r ReadyEvaluations) Len() int {
 	return len(r)
@@ -436,6 +436,7 @@ func TestEvalBroker_Serialize_DuplicateJobID(t *testing.T) {
 		stats := b.Stats()
 		stats.DelayedEvals = nil
 		stats.ByScheduler = nil
+		stats.ByNamespace = nil
 		return *stats
 	}
 
@@ -460,32 +461,31 @@ func TestEvalBroker_Serialize_DuplicateJobID(t *testing.T) {
 
 	// eval4 and eval5 are ready
 	// eval6 and eval7 are pending
-	// Dequeue should get 4th eval
+	// Dequeue should get 5th eval because namespace-one was just served
 	out, token, err = b.Dequeue(defaultSched, time.Second)
 	must.NoError(t, err)
-	must.Eq(t, out, eval4, must.Sprint("expected 4th eval"))
+	must.Eq(t, out, eval5, must.Sprint("expected 5th eval"))
 
 	must.Eq(t, BrokerStats{TotalReady: 1, TotalUnacked: 1,
 		TotalPending: 2, TotalCancelable: 2}, getStats())
 
-	// Ack should clear the rest of namespace-one pending but leave
-	// namespace-two untouched
-	err = b.Ack(eval4.ID, token)
+	// Ack should clear the rest of namespace-two pending but leave
+	// namespace-one untouched
+	err = b.Ack(eval5.ID, token)
 	must.NoError(t, err)
 
-	must.Eq(t, BrokerStats{TotalReady: 1, TotalUnacked: 0,
-		TotalPending: 2, TotalCancelable: 2}, getStats())
+	must.Eq(t, BrokerStats{TotalReady: 2, TotalUnacked: 0,
+		TotalPending: 0, TotalCancelable: 3}, getStats())
 
-	// Dequeue should get 5th eval
+	// Dequeue should get 4th eval
 	out, token, err = b.Dequeue(defaultSched, time.Second)
 	must.NoError(t, err)
-	must.Eq(t, out, eval5, must.Sprint("expected 5th eval"))
+	must.Eq(t, out, eval4, must.Sprint("expected 4th eval"))
 
-	must.Eq(t, BrokerStats{TotalReady: 0, TotalUnacked: 1,
-		TotalPending: 2, TotalCancelable: 2}, getStats())
+	must.Eq(t, BrokerStats{TotalReady: 1, TotalUnacked: 1,
+		TotalPending: 0, TotalCancelable: 3}, getStats())
 
-	// Ack should clear remaining namespace-two pending evals
-	err = b.Ack(eval5.ID, token)
+	err = b.Ack(eval4.ID, token)
 	must.NoError(t, err)
 
 	must.Eq(t, BrokerStats{TotalReady: 1, TotalUnacked: 0,
@@ -752,6 +752,102 @@ func TestEvalBroker_Dequeue_Fairness(t *testing.T) {
 	}
 }
 
+// Ensure a namespace with many ready evals can't starve other namespaces
+func TestEvalBroker_Dequeue_NamespaceFairness(t *testing.T) {
+	ci.Parallel(t)
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+
+	// Flood the broker with high priority evals from one namespace before
+	// enqueueing a few evals from another.
+	for i := 0; i < 100; i++ {
+		eval := mock.Eval()
+		eval.Namespace = "flood"
+		eval.Priority = 90
+		b.Enqueue(eval)
+	}
+	for i := 0; i < 5; i++ {
+		eval := mock.Eval()
+		eval.Namespace = "quiet"
+		b.Enqueue(eval)
+	}
+
+	stats := b.Stats()
+	must.Eq(t, 100, stats.ByNamespace["flood"].Ready)
+	must.Eq(t, 5, stats.ByNamespace["quiet"].Ready)
+
+	// The namespaces should alternate until the quiet namespace is drained.
+	counts := map[string]int{}
+	for i := 0; i < 10; i++ {
+		out, _, err := b.Dequeue(defaultSched, time.Second)
+		must.NoError(t, err)
+		counts[out.Namespace]++
+	}
+	must.Eq(t, 5, counts["flood"])
+	must.Eq(t, 5, counts["quiet"])
+
+	stats = b.Stats()
+	must.Eq(t, 95, stats.ByNamespace["flood"].Ready)
+	must.Eq(t, 0, stats.ByNamespace["quiet"].Ready)
+}
+
+// Ensure namespaces are dequeued from in proportion to their weights, and by
+// priority within a namespace
+func TestEvalBroker_Dequeue_NamespaceWeights(t *testing.T) {
+	ci.Parallel(t)
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+	b.SetNamespaceWeights(map[string]int{"heavy": 3})
+
+	for i := 0; i < 30; i++ {
+		for _, ns := range []string{"heavy", "light"} {
+			eval := mock.Eval()
+			eval.Namespace = ns
+			eval.Priority = 10 + i
+			b.Enqueue(eval)
+		}
+	}
+
+	counts := map[string]int{}
+	lastPriority := map[string]int{}
+	for i := 0; i < 20; i++ {
+		out, _, err := b.Dequeue(defaultSched, time.Second)
+		must.NoError(t, err)
+		counts[out.Namespace]++
+		if last, ok := lastPriority[out.Namespace]; ok {
+			must.Less(t, last, out.Priority)
+		}
+		lastPriority[out.Namespace] = out.Priority
+	}
+	must.Eq(t, 15, counts["heavy"])
+	must.Eq(t, 5, counts["light"])
+}
+
+// Ensure the queuing state of namespaces is dropped once they're drained, so
+// that it doesn't grow with namespace churn
+func TestEvalBroker_Dequeue_NamespaceChurn(t *testing.T) {
+	ci.Parallel(t)
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+
+	for i := 0; i < 50; i++ {
+		eval := mock.Eval()
+		eval.Namespace = fmt.Sprintf("ns-%d", i)
+		b.Enqueue(eval)
+	}
+	for i := 0; i < 50; i++ {
+		_, _, err := b.Dequeue(defaultSched, time.Second)
+		must.NoError(t, err)
+	}
+
+	b.l.RLock()
+	defer b.l.RUnlock()
+	queues := b.ready[mock.Eval().Type]
+	must.MapEmpty(t, queues.queues)
+	must.MapEmpty(t, queues.finish)
+	must.MapEmpty(t, queues.turn)
+}
+
 // Ensure we get unblocked
 func TestEvalBroker_Dequeue_Blocked(t *testing.T) {
 	ci.Parallel(t)
@@ -1585,6 +1681,7 @@ func TestEvalBroker_IntegrationTest(t *testing.T) {
 		stats := srv.evalBroker.Stats()
 		stats.DelayedEvals = nil
 		stats.ByScheduler = nil
+		stats.ByNamespace = nil
 		return *stats
 	}
 
@@ -5,24 +5,33 @@ package nomad
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
+	"net/http"
 	"sync/atomic"
 	"time"
 
+	"github.com/hashicorp/go-memdb"
+	metrics "github.com/hashicorp/go-metrics/compat"
 	"github.com/hashicorp/go-msgpack/v2/codec"
 
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/nomad/peers"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/state/paginator"
 	"github.com/hashicorp/nomad/nomad/stream"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
+// Event endpoint is used to stream events and manage durable event consumers.
 type Event struct {
 	srv *Server
+	ctx *RPCContext
 }
 
-func NewEventEndpoint(srv *Server) *Event {
-	return &Event{srv: srv}
+func NewEventEndpoint(srv *Server, ctx *RPCContext) *Event {
+	return &Event{srv: srv, ctx: ctx}
 }
 
 func (e *Event) register() {
@@ -58,6 +67,26 @@ func (e *Event) stream(conn io.ReadWriteCloser) {
 
 	e.srv.MeasureRPCRate("event", structs.RateMetricRead, &args)
 
+	// Durable consumers stream their own topics and resume after the last
+	// acknowledged index, replaying events no longer in the buffer.
+	replay := false
+	if args.Consumer != "" {
+		consumer, err := e.srv.State().EventConsumerByName(nil, args.Consumer)
+		if err != nil {
+			handleJsonResultError(err, new(int64(500)), encoder)
+			return
+		}
+		if consumer == nil {
+			handleJsonResultError(errEventConsumerNotFound, new(int64(http.StatusNotFound)), encoder)
+			return
+		}
+		args.Namespace = consumer.Namespace
+		args.Topics = consumer.Topics
+		args.Filter = consumer.Filter
+		args.Index = int(consumer.AckIndex + 1)
+		replay = true
+	}
+
 	resolvedACL, err := e.srv.ResolveACL(&args)
 	if err != nil {
 		handleJsonResultError(structs.ErrPermissionDenied, new(int64(403)), encoder)
@@ -95,6 +124,8 @@ func (e *Event) stream(conn io.ReadWriteCloser) {
 		// more NSes, the current event stream will not include the new NSes.
 		Namespaces: validatedNses,
 		FilterFn:   variableFilterFn,
+		Filter:     args.Filter,
+		Replay:     replay,
 		Authenticate: func() error {
 			if err := e.srv.Authenticate(nil, &args); err != nil {
 				return err
@@ -122,7 +153,13 @@ func (e *Event) stream(conn io.ReadWriteCloser) {
 	var subErr error
 
 	subscription, subErr = publisher.Subscribe(subReq)
-	if subErr != nil {
+	if errors.Is(subErr, stream.ErrInvalidFilter) {
+		handleJsonResultError(subErr, new(int64(http.StatusBadRequest)), encoder)
+		return
+	} else if errors.Is(subErr, stream.ErrCursorTruncated) {
+		handleJsonResultError(subErr, new(int64(http.StatusGone)), encoder)
+		return
+	} else if subErr != nil {
 		handleJsonResultError(subErr, new(int64(500)), encoder)
 		return
 	}
@@ -333,3 +370,260 @@ func validateNsOp(namespace string, topics map[structs.Topic][]string, aclObj *a
 	return nil
 
 }
+
+// errEventConsumerNotFound is returned when a durable event consumer doesn't
+// exist.
+var errEventConsumerNotFound = errors.New("event consumer not found")
+
+// ListConsumers is used to list the durable event consumers. It requires a
+// management token.
+func (e *Event) ListConsumers(args *structs.EventConsumerListRequest, reply *structs.EventConsumerListResponse) error {
+	authErr := e.srv.Authenticate(e.ctx, args)
+	if done, err := e.srv.forward("Event.ListConsumers", args, args, reply); done {
+		return err
+	}
+	e.srv.MeasureRPCRate("event", structs.RateMetricList, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "event", "list_consumers"}, time.Now())
+
+	aclObj, err := e.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			iter, err := store.EventConsumers(ws, state.SortOption(args.Reverse))
+			if err != nil {
+				return err
+			}
+
+			pager, err := paginator.NewPaginator(iter, args.QueryOptions, nil,
+				paginator.IDTokenizer[*structs.EventConsumer](args.NextToken),
+				func(c *structs.EventConsumer) (*structs.EventConsumer, error) { return c, nil })
+			if err != nil {
+				return structs.NewErrRPCCodedf(http.StatusBadRequest, "failed to create result paginator: %v", err)
+			}
+
+			consumers, nextToken, err := pager.Page()
+			if err != nil {
+				return structs.NewErrRPCCodedf(http.StatusBadRequest, "failed to read result page: %v", err)
+			}
+
+			reply.QueryMeta.NextToken = nextToken
+			reply.Consumers = consumers
+
+			// Use the last index that affected the event consumers table.
+			index, err := store.Index(state.TableEventConsumers)
+			if err != nil {
+				return err
+			}
+			reply.Index = max(1, index)
+			e.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return e.srv.blockingRPC(&opts)
+}
+
+// GetConsumer returns the durable event consumer requested, or nil if it
+// doesn't exist. It requires the permissions needed to stream the topics of
+// the consumer.
+func (e *Event) GetConsumer(args *structs.EventConsumerSpecificRequest, reply *structs.SingleEventConsumerResponse) error {
+	authErr := e.srv.Authenticate(e.ctx, args)
+	if done, err := e.srv.forward("Event.GetConsumer", args, args, reply); done {
+		return err
+	}
+	e.srv.MeasureRPCRate("event", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "event", "get_consumer"}, time.Now())
+
+	aclObj, err := e.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			consumer, err := store.EventConsumerByName(ws, args.Name)
+			if err != nil {
+				return err
+			}
+
+			if consumer != nil {
+				if _, err := e.validateACL(consumer.Namespace, consumer.Topics, aclObj); err != nil {
+					return err
+				}
+				reply.Consumer = consumer
+				reply.Index = consumer.ModifyIndex
+			} else {
+				// Return the last index that affected the event consumers
+				// table if the requested consumer doesn't exist.
+				index, err := store.Index(state.TableEventConsumers)
+				if err != nil {
+					return err
+				}
+				reply.Index = max(1, index)
+			}
+			return nil
+		}}
+	return e.srv.blockingRPC(&opts)
+}
+
+// UpsertConsumer creates or updates a durable event consumer. Updating a
+// consumer keeps its acknowledged index. It requires a management token.
+func (e *Event) UpsertConsumer(args *structs.EventConsumerUpsertRequest, reply *structs.GenericResponse) error {
+	authErr := e.srv.Authenticate(e.ctx, args)
+	if done, err := e.srv.forward("Event.UpsertConsumer", args, args, reply); done {
+		return err
+	}
+	e.srv.MeasureRPCRate("event", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "event", "upsert_consumer"}, time.Now())
+
+	aclObj, err := e.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	if !e.srv.peersCache.ServersMeetMinimumVersion(e.srv.Region(), minVersionEventConsumers, true) {
+		return fmt.Errorf("all servers must be running version %v or later to upsert event consumers", minVersionEventConsumers)
+	}
+
+	if args.Consumer == nil {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "missing event consumer")
+	}
+	args.Consumer.Canonicalize()
+	if err := args.Consumer.Validate(); err != nil {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "invalid event consumer %q: %v", args.Consumer.Name, err)
+	}
+
+	_, index, err := e.srv.raftApply(structs.EventConsumerUpsertRequestType, args)
+	if err != nil {
+		return err
+	}
+	reply.Index = index
+	return nil
+}
+
+// DeleteConsumer deletes a durable event consumer. It requires a management
+// token.
+func (e *Event) DeleteConsumer(args *structs.EventConsumerDeleteRequest, reply *structs.GenericResponse) error {
+	authErr := e.srv.Authenticate(e.ctx, args)
+	if done, err := e.srv.forward("Event.DeleteConsumer", args, args, reply); done {
+		return err
+	}
+	e.srv.MeasureRPCRate("event", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "event", "delete_consumer"}, time.Now())
+
+	aclObj, err := e.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	if !e.srv.peersCache.ServersMeetMinimumVersion(e.srv.Region(), minVersionEventConsumers, true) {
+		return fmt.Errorf("all servers must be running version %v or later to delete event consumers", minVersionEventConsumers)
+	}
+
+	if args.Name == "" {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "missing event consumer name")
+	}
+	consumer, err := e.srv.State().EventConsumerByName(nil, args.Name)
+	if err != nil {
+		return err
+	}
+	if consumer == nil {
+		return structs.NewErrRPCCoded(http.StatusNotFound, errEventConsumerNotFound.Error())
+	}
+
+	_, index, err := e.srv.raftApply(structs.EventConsumerDeleteRequestType, args)
+	if err != nil {
+		return err
+	}
+	reply.Index = index
+	return nil
+}
+
+// AckConsumer acknowledges the events streamed to a durable event consumer up
+// to and including the index, so that streaming resumes after it. It requires
+// the permissions needed to stream the topics of the consumer.
+func (e *Event) AckConsumer(args *structs.EventConsumerAckRequest, reply *structs.GenericResponse) error {
+	authErr := e.srv.Authenticate(e.ctx, args)
+	if done, err := e.srv.forward("Event.AckConsumer", args, args, reply); done {
+		return err
+	}
+	e.srv.MeasureRPCRate("event", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "event", "ack_consumer"}, time.Now())
+
+	aclObj, err := e.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+
+	if !e.srv.peersCache.ServersMeetMinimumVersion(e.srv.Region(), minVersionEventConsumers, true) {
+		return fmt.Errorf("all servers must be running version %v or later to acknowledge event consumers", minVersionEventConsumers)
+	}
+
+	if args.Name == "" {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "missing event consumer name")
+	}
+
+	store := e.srv.State()
+	consumer, err := store.EventConsumerByName(nil, args.Name)
+	if err != nil {
+		return err
+	}
+	if consumer == nil {
+		return structs.NewErrRPCCoded(http.StatusNotFound, errEventConsumerNotFound.Error())
+	}
+	if _, err := e.validateACL(consumer.Namespace, consumer.Topics, aclObj); err != nil {
+		return err
+	}
+
+	latest, err := store.LatestIndex()
+	if err != nil {
+		return err
+	}
+	if args.Index > latest {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest,
+			"index %d is after the latest index %d", args.Index, latest)
+	}
+
+	// Acknowledging an index already acknowledged is a no-op, so avoid the
+	// raft write.
+	if args.Index <= consumer.AckIndex {
+		reply.Index = consumer.ModifyIndex
+		return nil
+	}
+
+	_, index, err := e.srv.raftApply(structs.EventConsumerAckRequestType, args)
+	if err != nil {
+		return err
+	}
+	reply.Index = index
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"net/http"
 	"strings"
 	"testing"
 	"time"
@@ -991,3 +992,320 @@ OUTER:
 		},
 	}, got)
 }
+
+func TestEventStream_Filter(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.EnableEventBroker = true
+	})
+	defer cleanupS1()
+
+	publisher, err := s1.State().EventBroker()
+	must.NoError(t, err)
+
+	subscribe := func(filter string) (*codec.Encoder, <-chan *structs.EventStreamWrapper) {
+		handler, err := s1.StreamingRpcHandler("Event.Stream")
+		must.NoError(t, err)
+
+		p1, p2 := net.Pipe()
+		t.Cleanup(func() { p1.Close(); p2.Close() })
+		go handler(p2)
+
+		streamMsg := make(chan *structs.EventStreamWrapper, 10)
+		go func() {
+			decoder := codec.NewDecoder(p1, structs.MsgpackHandle)
+			for {
+				var msg structs.EventStreamWrapper
+				if err := decoder.Decode(&msg); err != nil {
+					return
+				}
+				streamMsg <- &msg
+			}
+		}()
+
+		encoder := codec.NewEncoder(p1, structs.MsgpackHandle)
+		must.NoError(t, encoder.Encode(structs.EventStreamRequest{
+			Topics: map[structs.Topic][]string{"*": {"*"}},
+			QueryOptions: structs.QueryOptions{
+				Region: s1.Region(),
+				Filter: filter,
+			},
+		}))
+		return encoder, streamMsg
+	}
+
+	// an invalid filter is rejected as a bad request
+	_, streamMsg := subscribe(`Key ==`)
+	select {
+	case <-time.After(3 * time.Second):
+		t.Fatal("timeout waiting for event stream error")
+	case msg := <-streamMsg:
+		must.NotNil(t, msg.Error)
+		must.StrContains(t, msg.Error.Error(), "failed to read filter expression")
+		must.Eq(t, int64(http.StatusBadRequest), *msg.Error.Code)
+	}
+
+	// only the events matching the filter are streamed
+	_, streamMsg = subscribe(`Key == "two"`)
+	time.Sleep(100 * time.Millisecond)
+	publisher.Publish(&structs.Events{Index: 1, Events: []structs.Event{
+		{Topic: "test", Key: "one"},
+		{Topic: "test", Key: "two"},
+	}})
+
+	timeout := time.After(3 * time.Second)
+	for {
+		select {
+		case <-timeout:
+			t.Fatal("timeout waiting for event stream")
+		case msg := <-streamMsg:
+			must.Nil(t, msg.Error)
+			if bytes.Equal(msg.Event.Data, stream.JsonHeartbeat.Data) {
+				continue
+			}
+
+			var events structs.Events
+			must.NoError(t, json.Unmarshal(msg.Event.Data, &events))
+			must.Len(t, 1, events.Events)
+			must.Eq(t, "two", events.Events[0].Key)
+			return
+		}
+	}
+}
+
+func TestEvent_Consumers(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.EnableEventBroker = true
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	readToken := mock.CreatePolicyAndToken(t, s1.fsm.State(), 1001, "read-job",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+	nodeToken := mock.CreatePolicyAndToken(t, s1.fsm.State(), 1003, "read-node",
+		mock.NodePolicy(acl.PolicyRead))
+
+	consumer := mock.EventConsumer()
+	upsert := func(token string, consumer *structs.EventConsumer) error {
+		req := &structs.EventConsumerUpsertRequest{
+			Consumer: consumer,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				AuthToken: token,
+			},
+		}
+		var resp structs.GenericResponse
+		return msgpackrpc.CallWithCodec(codec, "Event.UpsertConsumer", req, &resp)
+	}
+
+	// only management tokens can create consumers
+	must.EqError(t, upsert("", consumer), structs.ErrPermissionDenied.Error())
+	must.EqError(t, upsert(readToken.SecretID, consumer), structs.ErrPermissionDenied.Error())
+	must.ErrorContains(t, upsert(root.SecretID, &structs.EventConsumer{Name: "not valid"}),
+		"invalid event consumer")
+	must.NoError(t, upsert(root.SecretID, consumer))
+
+	// only management tokens can list consumers
+	list := func(token string) ([]*structs.EventConsumer, error) {
+		req := &structs.EventConsumerListRequest{
+			QueryOptions: structs.QueryOptions{
+				Region:    "global",
+				AuthToken: token,
+			},
+		}
+		var resp structs.EventConsumerListResponse
+		err := msgpackrpc.CallWithCodec(codec, "Event.ListConsumers", req, &resp)
+		return resp.Consumers, err
+	}
+	_, err := list(readToken.SecretID)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+	consumers, err := list(root.SecretID)
+	must.NoError(t, err)
+	must.Len(t, 1, consumers)
+	must.Eq(t, consumer.Name, consumers[0].Name)
+
+	// reading a consumer requires access to its topics
+	get := func(token string) (*structs.EventConsumer, error) {
+		req := &structs.EventConsumerSpecificRequest{
+			Name: consumer.Name,
+			QueryOptions: structs.QueryOptions{
+				Region:    "global",
+				AuthToken: token,
+			},
+		}
+		var resp structs.SingleEventConsumerResponse
+		err := msgpackrpc.CallWithCodec(codec, "Event.GetConsumer", req, &resp)
+		return resp.Consumer, err
+	}
+	_, err = get(nodeToken.SecretID)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+	got, err := get(readToken.SecretID)
+	must.NoError(t, err)
+	must.Eq(t, got.CreateIndex, got.AckIndex)
+
+	// acknowledging requires access to the consumer topics and an index that
+	// isn't ahead of the state
+	ack := func(token string, index uint64) error {
+		req := &structs.EventConsumerAckRequest{
+			Name:  consumer.Name,
+			Index: index,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				AuthToken: token,
+			},
+		}
+		var resp structs.GenericResponse
+		return msgpackrpc.CallWithCodec(codec, "Event.AckConsumer", req, &resp)
+	}
+	latest, err := s1.fsm.State().LatestIndex()
+	must.NoError(t, err)
+	must.EqError(t, ack(nodeToken.SecretID, latest), structs.ErrPermissionDenied.Error())
+	must.ErrorContains(t, ack(readToken.SecretID, latest+100), "is after the latest index")
+
+	must.NoError(t, s1.fsm.State().UpsertNodePools(structs.MsgTypeTestSetup, latest+10,
+		[]*structs.NodePool{mock.NodePool()}))
+	must.NoError(t, ack(readToken.SecretID, latest+10))
+	got, err = get(readToken.SecretID)
+	must.NoError(t, err)
+	must.Eq(t, latest+10, got.AckIndex)
+
+	// only management tokens can delete consumers
+	del := func(token string) error {
+		req := &structs.EventConsumerDeleteRequest{
+			Name: consumer.Name,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				AuthToken: token,
+			},
+		}
+		var resp structs.GenericResponse
+		return msgpackrpc.CallWithCodec(codec, "Event.DeleteConsumer", req, &resp)
+	}
+	must.EqError(t, del(readToken.SecretID), structs.ErrPermissionDenied.Error())
+	must.NoError(t, del(root.SecretID))
+	must.ErrorContains(t, del(root.SecretID), "event consumer not found")
+
+	got, err = get(root.SecretID)
+	must.NoError(t, err)
+	must.Nil(t, got)
+}
+
+func TestEventStream_Consumer(t *testing.T) {
+	ci.Parallel(t)
+
+	// use a small buffer so that the events are replayed from the event log
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.EnableEventBroker = true
+		c.EventBufferSize = 2
+	})
+	defer cleanupS1()
+	rpcCodec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	consumer := mock.EventConsumer()
+	upsertReq := &structs.EventConsumerUpsertRequest{
+		Consumer:     consumer,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var upsertResp structs.GenericResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(rpcCodec, "Event.UpsertConsumer", upsertReq, &upsertResp))
+
+	// write some jobs to generate events, followed by node pools to evict the
+	// job events from the event buffer
+	index := upsertResp.Index
+	var jobIndexes []uint64
+	for range 3 {
+		index++
+		must.NoError(t, s1.State().UpsertJob(structs.JobRegisterRequestType, index, nil, mock.Job()))
+		jobIndexes = append(jobIndexes, index)
+	}
+	for range 3 {
+		index++
+		must.NoError(t, s1.State().UpsertNodePools(structs.NodePoolUpsertRequestType, index,
+			[]*structs.NodePool{mock.NodePool()}))
+	}
+
+	// streamIndexes streams the consumer and returns the indexes of the
+	// first n events received, or the error of the stream
+	streamIndexes := func(n int) ([]uint64, *structs.RpcError) {
+		handler, err := s1.StreamingRpcHandler("Event.Stream")
+		must.NoError(t, err)
+
+		p1, p2 := net.Pipe()
+		defer p1.Close()
+		defer p2.Close()
+		go handler(p2)
+
+		streamMsg := make(chan *structs.EventStreamWrapper, 10)
+		go func() {
+			decoder := codec.NewDecoder(p1, structs.MsgpackHandle)
+			for {
+				var msg structs.EventStreamWrapper
+				if err := decoder.Decode(&msg); err != nil {
+					return
+				}
+				streamMsg <- &msg
+			}
+		}()
+
+		encoder := codec.NewEncoder(p1, structs.MsgpackHandle)
+		must.NoError(t, encoder.Encode(structs.EventStreamRequest{
+			Consumer:     consumer.Name,
+			QueryOptions: structs.QueryOptions{Region: s1.Region()},
+		}))
+
+		var indexes []uint64
+		timeout := time.After(3 * time.Second)
+		for len(indexes) < n {
+			select {
+			case <-timeout:
+				t.Fatalf("timeout waiting for events, got %v", indexes)
+			case msg := <-streamMsg:
+				if msg.Error != nil {
+					return nil, msg.Error
+				}
+				if bytes.Equal(msg.Event.Data, stream.JsonHeartbeat.Data) {
+					continue
+				}
+
+				var events structs.Events
+				must.NoError(t, json.Unmarshal(msg.Event.Data, &events))
+				for _, e := range events.Events {
+					must.Eq(t, structs.TopicJob, e.Topic)
+				}
+				indexes = append(indexes, events.Index)
+			}
+		}
+		return indexes, nil
+	}
+
+	// all the job events are replayed
+	indexes, rpcErr := streamIndexes(3)
+	must.Nil(t, rpcErr)
+	must.Eq(t, jobIndexes, indexes)
+
+	// streaming resumes after the acknowledged index
+	ackReq := &structs.EventConsumerAckRequest{
+		Name:         consumer.Name,
+		Index:        jobIndexes[0],
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var ackResp structs.GenericResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(rpcCodec, "Event.AckConsumer", ackReq, &ackResp))
+	indexes, rpcErr = streamIndexes(2)
+	must.Nil(t, rpcErr)
+	must.Eq(t, jobIndexes[1:], indexes)
+
+	// streaming fails once events after the acknowledged index are
+	// truncated from the event log
+	_, err := s1.eventLog.Truncate(jobIndexes[1])
+	must.NoError(t, err)
+	_, rpcErr = streamIndexes(1)
+	must.NotNil(t, rpcErr)
+	must.Eq(t, http.StatusGone, *rpcErr.Code)
+	must.StrContains(t, rpcErr.Message, stream.ErrCursorTruncated.Error())
+}
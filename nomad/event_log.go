@@ -0,0 +1,84 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/stream"
+)
+
+const (
+	// eventLogFile is the file of the event log in the data directory.
+	eventLogFile = "event_log.db"
+
+	// eventLogPruneInterval is the interval at which the events acknowledged
+	// by all durable event consumers are deleted from the event log.
+	eventLogPruneInterval = time.Minute
+)
+
+// setupEventLog opens the disk-backed event log used to replay events to
+// durable event consumers. The log is only used when the event broker is
+// enabled and the server has a data directory.
+func (s *Server) setupEventLog() error {
+	if !s.config.EnableEventBroker || s.config.DataDir == "" {
+		return nil
+	}
+
+	eventLog, err := stream.NewEventLog(s.logger, filepath.Join(s.config.DataDir, eventLogFile))
+	if err != nil {
+		return err
+	}
+	s.eventLog = eventLog
+	return nil
+}
+
+// pruneEventLog periodically deletes the events acknowledged by all the
+// durable event consumers from the event log.
+func (s *Server) pruneEventLog(ctx context.Context) {
+	if s.eventLog == nil {
+		return
+	}
+
+	timer, stop := helper.NewSafeTimer(eventLogPruneInterval)
+	defer stop()
+
+	for {
+		timer.Reset(eventLogPruneInterval)
+
+		select {
+		case <-timer.C:
+			s.pruneEventLogOnce()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *Server) pruneEventLogOnce() {
+	// The last index must be read before the consumers, so that the events
+	// written for a consumer created in between are never deleted.
+	index := s.eventLog.LastIndex()
+
+	minAckIndex, found, err := s.State().MinEventConsumerAckIndex()
+	if err != nil {
+		s.logger.Error("failed to look up event consumers", "error", err)
+		return
+	}
+	if found {
+		index = min(index, minAckIndex)
+	}
+
+	deleted, err := s.eventLog.Truncate(index)
+	if err != nil {
+		s.logger.Error("failed to prune event log", "error", err)
+		return
+	}
+	if deleted > 0 {
+		s.logger.Debug("pruned event log", "index", index, "deleted", deleted)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/stream"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestServer_PruneEventLog(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.EnableEventBroker = true
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+	must.NotNil(t, s1.eventLog)
+
+	store := s1.fsm.State()
+	consumer := mock.EventConsumer()
+	must.NoError(t, store.UpsertEventConsumer(structs.MsgTypeTestSetup, 1000, consumer))
+	s1.eventLog.SetActive(true)
+
+	for index := uint64(1001); index <= 1003; index++ {
+		must.NoError(t, s1.eventLog.Append(&structs.Events{
+			Index:  index,
+			Events: []structs.Event{{Topic: structs.TopicJob, Index: index}},
+		}))
+	}
+
+	// events not acknowledged by the consumer are kept
+	must.NoError(t, store.AckEventConsumer(structs.MsgTypeTestSetup, 1004, consumer.Name, 1001))
+	s1.pruneEventLogOnce()
+	events, err := s1.eventLog.Read(1002, 2000)
+	must.NoError(t, err)
+	must.Len(t, 2, events)
+	must.Eq(t, 1002, events[0].Index)
+
+	_, err = s1.eventLog.Read(1001, 2000)
+	must.ErrorIs(t, err, stream.ErrCursorTruncated)
+
+	// all events are pruned once there are no consumers
+	must.NoError(t, store.DeleteEventConsumer(structs.MsgTypeTestSetup, 1005, consumer.Name))
+	s1.pruneEventLogOnce()
+	events, err = s1.eventLog.Read(1004, 2000)
+	must.NoError(t, err)
+	must.Len(t, 0, events)
+
+	_, err = s1.eventLog.Read(1002, 2000)
+	must.ErrorIs(t, err, stream.ErrCursorTruncated)
+}
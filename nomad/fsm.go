@@ -18,6 +18,7 @@ import (
 	"github.com/hashicorp/go-msgpack/v2/codec"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/stream"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/scheduler"
 	sstructs "github.com/hashicorp/nomad/scheduler/structs"
@@ -58,6 +59,7 @@ const (
 	JobSubmissionSnapshot                SnapshotType = 29
 	RootKeySnapshot                      SnapshotType = 30
 	HostVolumeSnapshot                   SnapshotType = 31
+	EventConsumerSnapshot                SnapshotType = 32
 
 	// TimeTableSnapshot
 	// Deprecated: Nomad no longer supports TimeTable snapshots since 1.9.2
@@ -109,6 +111,7 @@ var snapshotTypeStrings = map[SnapshotType]string{
 	JobSubmissionSnapshot:                "JobSubmission",
 	RootKeySnapshot:                      "WrappedRootKeys",
 	HostVolumeSnapshot:                   "HostVolumeSnapshot",
+	EventConsumerSnapshot:                "EventConsumer",
 	NamespaceSnapshot:                    "Namespace",
 }
 
@@ -193,6 +196,11 @@ type FSMConfig struct {
 	// EventBufferSize is the amount of messages to hold in memory
 	EventBufferSize int64
 
+	// EventLog is an optional disk-backed log of the published events used to
+	// replay them to durable event consumers. It is shared by the state stores
+	// created on snapshot restores.
+	EventLog *stream.EventLog
+
 	// JobTrackedVersions is the number of historic job versions that are kept.
 	JobTrackedVersions int
 }
@@ -205,6 +213,7 @@ func NewFSM(config *FSMConfig) (*nomadFSM, error) {
 		Region:             config.Region,
 		EnablePublisher:    config.EnableEventBroker,
 		EventBufferSize:    config.EventBufferSize,
+		EventLog:           config.EventLog,
 		JobTrackedVersions: config.JobTrackedVersions,
 	}
 	state, err := state.NewStateStore(sconfig)
@@ -396,6 +405,12 @@ func (n *nomadFSM) Apply(log *raft.Log) interface{} {
 		return n.applyHostVolumeDelete(msgType, buf[1:], log.Index)
 	case structs.TaskGroupHostVolumeClaimDeleteRequestType:
 		return n.applyTaskGroupHostVolumeClaimDelete(buf[1:], log.Index)
+	case structs.EventConsumerUpsertRequestType:
+		return n.applyEventConsumerUpsert(msgType, buf[1:], log.Index)
+	case structs.EventConsumerDeleteRequestType:
+		return n.applyEventConsumerDelete(msgType, buf[1:], log.Index)
+	case structs.EventConsumerAckRequestType:
+		return n.applyEventConsumerAck(msgType, buf[1:], log.Index)
 	}
 
 	// Check enterprise only message types.
@@ -1579,6 +1594,7 @@ func (n *nomadFSM) restoreImpl(old io.ReadCloser, filter *FSMFilter) error {
 		Region:             n.config.Region,
 		EnablePublisher:    n.config.EnableEventBroker,
 		EventBufferSize:    n.config.EventBufferSize,
+		EventLog:           n.config.EventLog,
 		JobTrackedVersions: n.config.JobTrackedVersions,
 	}
 	newState, err := state.NewStateStore(config)
@@ -1997,6 +2013,17 @@ func (n *nomadFSM) restoreImpl(old io.ReadCloser, filter *FSMFilter) error {
 				}
 			}
 
+		case EventConsumerSnapshot:
+			consumer := new(structs.EventConsumer)
+			if err := dec.Decode(consumer); err != nil {
+				return err
+			}
+			if filter.Include(consumer) {
+				if err := restore.EventConsumerRestore(consumer); err != nil {
+					return err
+				}
+			}
+
 		default:
 			// Check if this is an enterprise only object being restored
 			restorer, ok := n.enterpriseRestorers[snapType]
@@ -2033,6 +2060,7 @@ func (n *nomadFSM) restoreImpl(old io.ReadCloser, filter *FSMFilter) error {
 	// blocking queries won't see any changes and need to be woken up.
 	stateOld.Abandon()
 
+	n.setEventLogActive()
 	return nil
 }
 
@@ -2511,6 +2539,72 @@ func (n *nomadFSM) applyTaskGroupHostVolumeClaimDelete(buf []byte, index uint64)
 	return nil
 }
 
+func (n *nomadFSM) applyEventConsumerUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_event_consumer_upsert"}, time.Now())
+
+	var req structs.EventConsumerUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertEventConsumer(msgType, index, req.Consumer); err != nil {
+		n.logger.Error("UpsertEventConsumer failed", "error", err)
+		return err
+	}
+
+	n.setEventLogActive()
+	return nil
+}
+
+func (n *nomadFSM) applyEventConsumerDelete(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_event_consumer_delete"}, time.Now())
+
+	var req structs.EventConsumerDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteEventConsumer(msgType, index, req.Name); err != nil {
+		n.logger.Error("DeleteEventConsumer failed", "error", err)
+		return err
+	}
+
+	n.setEventLogActive()
+	return nil
+}
+
+func (n *nomadFSM) applyEventConsumerAck(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_event_consumer_ack"}, time.Now())
+
+	var req structs.EventConsumerAckRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.AckEventConsumer(msgType, index, req.Name, req.Index); err != nil {
+		n.logger.Error("AckEventConsumer failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// setEventLogActive activates the event log while durable event consumers
+// exist, so that events are only written to disk when they may be replayed.
+// It is called while applying changes to the consumers, so that the events
+// published after the creation of a consumer are always logged.
+func (n *nomadFSM) setEventLogActive() {
+	if n.config.EventLog == nil {
+		return
+	}
+
+	_, found, err := n.state.MinEventConsumerAckIndex()
+	if err != nil {
+		n.logger.Error("failed to look up event consumers", "error", err)
+		return
+	}
+	n.config.EventLog.SetActive(found)
+}
+
 func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "persist"}, time.Now())
 	// Register the nodes
@@ -2640,6 +2734,10 @@ func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 		sink.Cancel()
 		return err
 	}
+	if err := s.persistEventConsumers(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
 	return nil
 }
 
@@ -3348,6 +3446,22 @@ func (s *nomadSnapshot) persistHostVolumes(sink raft.SnapshotSink, encoder *code
 	return nil
 }
 
+func (s *nomadSnapshot) persistEventConsumers(sink raft.SnapshotSink, encoder *codec.Encoder) error {
+	iter, err := s.snap.EventConsumers(nil, state.SortDefault)
+	if err != nil {
+		return err
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		consumer := raw.(*structs.EventConsumer)
+
+		sink.Write([]byte{byte(EventConsumerSnapshot)})
+		if err := encoder.Encode(consumer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Release is a no-op, as we just need to GC the pointer
 // to the state store snapshot. There is nothing to explicitly
 // cleanup.
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -866,6 +867,58 @@ func TestFSM_RegisterPeriodicJob_NonLeader(t *testing.T) {
 	}
 }
 
+func TestFSM_RegisterPeriodicJob_MissedRuns(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+	dispatcher := fsm.periodicDispatcher.dispatcher.(*MockJobEvalDispatcher)
+
+	now := time.Now().Round(time.Second)
+	past := now.Add(-5 * time.Second)
+	job := testPeriodicJob(past, now.Add(time.Hour))
+	job.Periodic.MissedRuns = structs.PeriodicMissedRunsRunAll
+	job.Periodic.MaxMissedRuns = 5
+
+	register := func(job *structs.Job) {
+		req := structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Namespace: job.Namespace,
+			},
+		}
+		buf, err := structs.Encode(structs.JobRegisterRequestType, req)
+		must.NoError(t, err)
+		must.Nil(t, fsm.Apply(makeLog(buf)))
+	}
+
+	// Register the job and stop it.
+	register(job)
+	stopped := job.Copy()
+	stopped.Stop = true
+	register(stopped)
+	must.False(t, fsm.periodicDispatcher.IsTracked(job.Namespace, job.ID))
+
+	// Start the job again with a last launch before the missed launch. The
+	// dispatcher launches it for the missed launch once it tracks it again.
+	dispatcher.SetLastLaunch(job, now.Add(-10*time.Second))
+	register(job.Copy())
+	must.True(t, fsm.periodicDispatcher.IsTracked(job.Namespace, job.ID))
+
+	// Verify the missed launch was run.
+	must.Wait(t, wait.InitialSuccess(wait.ErrorFunc(func() error {
+		launches, err := dispatcher.LaunchTimes(fsm.periodicDispatcher, job.Namespace, job.ID)
+		if err != nil {
+			return err
+		}
+		if len(launches) != 1 || !launches[0].Equal(past) {
+			return fmt.Errorf("expected launch at %v, got %v", past, launches)
+		}
+		return nil
+	}),
+		wait.Timeout(time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+}
+
 func TestFSM_RegisterJob_BadNamespace(t *testing.T) {
 	ci.Parallel(t)
 	fsm := testFSM(t)
@@ -2341,6 +2394,27 @@ func TestFSM_SnapshotRestore_NodePools(t *testing.T) {
 	must.Eq(t, pool, out)
 }
 
+func TestFSM_SnapshotRestore_EventConsumers(t *testing.T) {
+	ci.Parallel(t)
+
+	// Add some state
+	testFSM := testFSM(t)
+	testState := testFSM.State()
+	consumer := mock.EventConsumer()
+	must.NoError(t, testState.UpsertEventConsumer(structs.MsgTypeTestSetup, 1000, consumer))
+	must.NoError(t, testState.AckEventConsumer(structs.MsgTypeTestSetup, 1001, consumer.Name, 1001))
+
+	// Verify the contents
+	testFSM2 := testSnapshotRestore(t, testFSM)
+	testState2 := testFSM2.State()
+	out, err := testState2.EventConsumerByName(nil, consumer.Name)
+	must.NoError(t, err)
+	must.NotNil(t, out)
+	must.Eq(t, consumer.Topics, out.Topics)
+	must.Eq(t, 1001, out.AckIndex)
+	must.Eq(t, 1000, out.CreateIndex)
+}
+
 func TestFSM_SnapshotRestore_NodePoolsPreTTL(t *testing.T) {
 	ci.Parallel(t)
 
@@ -3875,3 +3949,54 @@ func TestFSM_ApplyJob_IdempotencyToken(t *testing.T) {
 	must.NoError(t, err)
 	must.Eq(t, 1, store.IterCount(iter))
 }
+
+func TestFSM_EventConsumers(t *testing.T) {
+	ci.Parallel(t)
+
+	fsm := testFSM(t)
+	eventLog, err := stream.NewEventLog(testlog.HCLogger(t), filepath.Join(t.TempDir(), "event_log.db"))
+	must.NoError(t, err)
+	t.Cleanup(func() { eventLog.Close() })
+	fsm.config.EventLog = eventLog
+
+	apply := func(index uint64, msgType structs.MessageType, req any) any {
+		buf, err := structs.Encode(msgType, req)
+		must.NoError(t, err)
+		return fsm.Apply(&raft.Log{Index: index, Term: 1, Type: raft.LogCommand, Data: buf})
+	}
+
+	// Creating a consumer activates the event log.
+	consumer := mock.EventConsumer()
+	must.Nil(t, apply(1000, structs.EventConsumerUpsertRequestType,
+		structs.EventConsumerUpsertRequest{Consumer: consumer}))
+	must.True(t, eventLog.Active())
+
+	got, err := fsm.State().EventConsumerByName(nil, consumer.Name)
+	must.NoError(t, err)
+	must.NotNil(t, got)
+	must.Eq(t, 1000, got.AckIndex)
+
+	// Acknowledging moves the index forward only.
+	must.Nil(t, apply(1001, structs.EventConsumerAckRequestType,
+		structs.EventConsumerAckRequest{Name: consumer.Name, Index: 1001}))
+	must.Nil(t, apply(1002, structs.EventConsumerAckRequestType,
+		structs.EventConsumerAckRequest{Name: consumer.Name, Index: 900}))
+
+	got, err = fsm.State().EventConsumerByName(nil, consumer.Name)
+	must.NoError(t, err)
+	must.Eq(t, 1001, got.AckIndex)
+
+	// Acknowledging a missing consumer fails.
+	resp := apply(1003, structs.EventConsumerAckRequestType,
+		structs.EventConsumerAckRequest{Name: "missing", Index: 1003})
+	must.Error(t, resp.(error))
+
+	// Deleting the last consumer deactivates the event log.
+	must.Nil(t, apply(1004, structs.EventConsumerDeleteRequestType,
+		structs.EventConsumerDeleteRequest{Name: consumer.Name}))
+	must.False(t, eventLog.Active())
+
+	got, err = fsm.State().EventConsumerByName(nil, consumer.Name)
+	must.NoError(t, err)
+	must.Nil(t, got)
+}
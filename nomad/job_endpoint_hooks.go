@@ -525,6 +525,14 @@ func (v *jobValidate) Validate(job *structs.Job) (warnings []error, err error) {
 	for _, tg := range job.TaskGroups {
 		totalCount += tg.Count
 
+		if tg.Update != nil && tg.Update.Analysis != nil && tg.Update.Analysis.Provider != "" {
+			if _, ok := v.srv.config.CanaryAnalysisProviders[tg.Update.Analysis.Provider]; !ok {
+				multierror.Append(validationErrors, fmt.Errorf(
+					"task group %s: canary analysis provider %q is not configured on the servers",
+					tg.Name, tg.Update.Analysis.Provider))
+			}
+		}
+
 		for _, s := range tg.Services {
 			serviceErrs := v.validateServiceIdentity(
 				s, fmt.Sprintf("task group %s", tg.Name), okForIdentity)
@@ -41,6 +41,29 @@ func Test_jobValidate_Validate(t *testing.T) {
 		_, err := impl.Validate(job)
 		must.NoError(t, err)
 	})
+
+	t.Run("error if canary analysis provider is not configured", func(t *testing.T) {
+		impl := jobValidate{srv: &Server{config: &Config{
+			JobMaxPriority:          100,
+			CanaryAnalysisProviders: map[string]string{"prometheus": "http://127.0.0.1:9090"},
+		}}}
+		job := mock.Job()
+		job.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+		job.TaskGroups[0].Update.Canary = 1
+		job.TaskGroups[0].Update.Analysis = &structs.UpdateAnalysis{
+			Provider: "prometheus",
+			Query:    "error_ratio",
+			Interval: time.Minute,
+			Count:    3,
+			Max:      new(0.05),
+		}
+		_, err := impl.Validate(job)
+		must.NoError(t, err)
+
+		job.TaskGroups[0].Update.Analysis.Provider = "internal"
+		_, err = impl.Validate(job)
+		must.ErrorContains(t, err, `canary analysis provider "internal" is not configured on the servers`)
+	})
 }
 
 func Test_jobValidate_Validate_consul_service(t *testing.T) {
@@ -66,6 +66,11 @@ var minACLAuthMethodVersion = version.Must(version.NewVersion("1.5.0"))
 // meet before the feature can be used.
 var minACLJWTAuthMethodVersion = version.Must(version.NewVersion("1.5.4"))
 
+// minACLLDAPAuthMethodVersion is the Nomad version at which the ACL LDAP auth
+// method type was introduced. It forms the minimum version all federated
+// servers must meet before the feature can be used.
+var minACLLDAPAuthMethodVersion = version.Must(version.NewVersion("2.0.5"))
+
 // minACLBindingRuleVersion is the Nomad version at which the ACL binding rules
 // table was introduced. It forms the minimum version all federated servers
 // must meet before the feature can be used.
@@ -105,6 +110,11 @@ var minVersionNodeIntro = version.Must(version.NewVersion("1.11.0"))
 // we submit a full Job object like we used to before.
 var minVersionPlanLeanJob = version.Must(version.NewVersion("2.0.0"))
 
+// minVersionEventConsumers is the Nomad version at which durable event stream
+// consumers were introduced. It forms the minimum version all local servers
+// must meet before the feature can be used.
+var minVersionEventConsumers = version.Must(version.NewVersion("2.0.5"))
+
 // monitorLeadership is used to monitor if we acquire or lose our role
 // as the leader in the Raft cluster. There is some work the leader is
 // expected to do, so we must react to changes
@@ -840,9 +850,10 @@ func (s *Server) restoreEvals() error {
 
 // restorePeriodicDispatcher is used to restore all periodic jobs into the
 // periodic dispatcher. It also determines if a periodic job should have been
-// created during the leadership transition and force runs them. The periodic
-// dispatcher is maintained only by the leader, so it must be restored anytime a
-// leadership transition takes place.
+// created during the leadership transition and force runs them, or has the
+// periodic dispatcher run them according to their missed runs policy. The
+// periodic dispatcher is maintained only by the
+// leader, so it must be restored anytime a leadership transition takes place.
 func (s *Server) restorePeriodicDispatcher() error {
 	logger := s.logger.Named("periodic")
 	ws := memdb.NewWatchSet()
@@ -851,7 +862,6 @@ func (s *Server) restorePeriodicDispatcher() error {
 		return fmt.Errorf("failed to get periodic jobs: %v", err)
 	}
 
-	now := time.Now()
 	for i := iter.Next(); i != nil; i = iter.Next() {
 		job := i.(*structs.Job)
 
@@ -883,57 +893,28 @@ func (s *Server) restorePeriodicDispatcher() error {
 				job.ID, job.Namespace)
 		}
 
-		// nextLaunch is the next launch that should occur.
-		nextLaunch, err := job.Periodic.Next(launch.Launch.In(job.Periodic.GetLocation()))
-		if err != nil {
-			logger.Error("failed to determine next periodic launch for job", "job", job.NamespacedID(), "error", err)
+		// Jobs with a missed runs policy are launched for the launches they
+		// missed by the periodic dispatcher when added.
+		if job.Periodic.MissedRuns != "" {
 			continue
 		}
 
-		// We skip force launching the job if  there should be no next launch
-		// (the zero case) or if the next launch time is in the future. If it is
-		// in the future, it will be handled by the periodic dispatcher.
-		if nextLaunch.IsZero() || !nextLaunch.Before(now) {
-			continue
-		}
-
-		// We skip if the job doesn't allow overlap and there are already
-		// instances running
-		allowed, err := s.cronJobOverlapAllowed(job)
+		// Launch the job once if it missed a launch during the leadership
+		// transition. Launches in the future will be handled by the periodic
+		// dispatcher.
+		launches, err := s.periodicDispatcher.CatchUp(job, launch.Launch)
 		if err != nil {
-			return fmt.Errorf("failed to get job status: %v", err)
-		}
-		if !allowed {
-			continue
-		}
-
-		if _, err := s.periodicDispatcher.ForceEval(job.Namespace, job.ID); err != nil {
 			logger.Error("force run of periodic job failed", "job", job.NamespacedID(), "error", err)
 			return fmt.Errorf("force run of periodic job %q failed: %v", job.NamespacedID(), err)
 		}
-
-		logger.Debug("periodic job force run during leadership establishment", "job", job.NamespacedID())
-	}
-
-	return nil
-}
-
-// cronJobOverlapAllowed checks if the job allows for overlap and if there are already
-// instances of the job running in order to determine if a new evaluation needs to
-// be created upon periodic dispatcher restore
-func (s *Server) cronJobOverlapAllowed(job *structs.Job) (bool, error) {
-	if job.Periodic.ProhibitOverlap {
-		running, err := s.periodicDispatcher.dispatcher.RunningChildren(job)
-		if err != nil {
-			return false, fmt.Errorf("failed to determine if periodic job has running children %q error %q", job.NamespacedID(), err)
+		if launches == 0 {
+			continue
 		}
 
-		if running {
-			return false, nil
-		}
+		logger.Debug("periodic job force run during leadership establishment", "job", job.NamespacedID(), "launches", launches)
 	}
 
-	return true, nil
+	return nil
 }
 
 // schedulePeriodic is used to do periodic job dispatch while we are leader
@@ -2784,8 +2765,10 @@ func (s *Server) handleEvalBrokerStateChange(schedConfig *structs.SchedulerConfi
 	switch schedConfig {
 	case nil:
 		enableBrokers = !s.config.DefaultSchedulerConfig.PauseEvalBroker
+		s.evalBroker.SetNamespaceWeights(s.config.DefaultSchedulerConfig.NamespaceWeights)
 	default:
 		enableBrokers = !schedConfig.PauseEvalBroker
+		s.evalBroker.SetNamespaceWeights(schedConfig.NamespaceWeights)
 	}
 
 	// If the evalBroker status is changing, set the new state.
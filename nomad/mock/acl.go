@@ -321,6 +321,30 @@ func ACLJWTAuthMethod() *structs.ACLAuthMethod {
 	return &method
 }
 
+func ACLLDAPAuthMethod() *structs.ACLAuthMethod {
+	maxTokenTTL, _ := time.ParseDuration("3600s")
+	method := structs.ACLAuthMethod{
+		Name:          fmt.Sprintf("acl-auth-method-%s", uuid.Short()),
+		Type:          "LDAP",
+		TokenLocality: structs.ACLAuthMethodTokenLocalityLocal,
+		MaxTokenTTL:   maxTokenTTL,
+		Default:       false,
+		Config: &structs.ACLAuthMethodConfig{
+			LDAPURLs:          []string{"ldaps://ldap.example.com"},
+			LDAPUserDN:        "ou=people,dc=example,dc=com",
+			LDAPGroupDN:       "ou=groups,dc=example,dc=com",
+			ClaimMappings:     map[string]string{"username": "user"},
+			ListClaimMappings: map[string]string{"groups": "groups"},
+		},
+		CreateTime:  time.Now().UTC(),
+		CreateIndex: 10,
+		ModifyIndex: 10,
+	}
+	method.Canonicalize()
+	method.SetHash()
+	return &method
+}
+
 // SampleJWTokenWithKeys takes a set of claims (can be nil) and optionally
 // a private RSA key that should be used for signing the JWT, and returns:
 // - a JWT signed with a randomly generated RSA key
@@ -255,6 +255,15 @@ func NodePool() *structs.NodePool {
 	return pool
 }
 
+// EventConsumer generates a durable event consumer of the Job topic.
+func EventConsumer() *structs.EventConsumer {
+	return &structs.EventConsumer{
+		Name:      fmt.Sprintf("consumer-%s", uuid.Short()),
+		Namespace: structs.DefaultNamespace,
+		Topics:    map[structs.Topic][]string{structs.TopicJob: {"*"}},
+	}
+}
+
 // ServiceRegistrations generates an array containing two unique service
 // registrations.
 func ServiceRegistrations() []*structs.ServiceRegistration {
@@ -21,9 +21,11 @@ import (
 	"github.com/hashicorp/nomad/acl"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper/snapshot"
+	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/peers"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler"
 )
 
 // Operator endpoint is used to perform low-level operator tasks for Nomad.
@@ -511,6 +513,161 @@ func (op *Operator) SchedulerGetConfiguration(args *structs.GenericRequest, repl
 	return nil
 }
 
+// SchedulerSimulate runs the schedulers against a snapshot of the cluster
+// state after applying the hypothetical changes of the request. The results
+// are never written to the cluster state.
+func (op *Operator) SchedulerSimulate(args *structs.SchedulerSimulateRequest, reply *structs.SchedulerSimulateResponse) error {
+
+	authErr := op.srv.Authenticate(op.ctx, args)
+	if done, err := op.srv.forward("Operator.SchedulerSimulate", args, args, reply); done {
+		return err
+	}
+	op.srv.MeasureRPCRate("operator", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	// This action requires operator read access, and the permission to plan
+	// each of the jobs being simulated.
+	aclObj, err := op.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	} else if !aclObj.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+	for _, job := range args.Jobs {
+		if job == nil {
+			return fmt.Errorf("job required for simulation")
+		}
+		if job.Namespace == "" {
+			job.Namespace = structs.DefaultNamespace
+		}
+		if !aclObj.AllowNsOpAnyOf(job.Namespace,
+			acl.NamespaceCapabilitySubmitJob,
+			acl.NamespaceCapabilityPlanJob,
+		) {
+			return structs.ErrPermissionDenied
+		}
+	}
+
+	// Run the simulation against a snapshot, so the cluster state is never
+	// modified
+	snap, err := op.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	index, err := snap.LatestIndex()
+	if err != nil {
+		return err
+	}
+
+	resp, err := scheduler.Simulate(op.logger, &snap.StateStore, args)
+	if err != nil {
+		return err
+	}
+
+	*reply = *resp
+	reply.Index = index
+	op.srv.setQueryMeta(&reply.QueryMeta)
+	return nil
+}
+
+// SchedulerRebalance computes moves of healthy service allocations which
+// improve the packing or spread of the cluster, and unless it is a dry run
+// marks the allocations for migration so the schedulers replace them.
+func (op *Operator) SchedulerRebalance(args *structs.SchedulerRebalanceRequest, reply *structs.SchedulerRebalanceResponse) error {
+
+	authErr := op.srv.Authenticate(op.ctx, args)
+	if done, err := op.srv.forward("Operator.SchedulerRebalance", args, args, reply); done {
+		return err
+	}
+	op.srv.MeasureRPCRate("operator", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	// A dry run only requires operator read access, while migrating the
+	// allocations requires operator write access.
+	aclObj, err := op.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	} else if args.DryRun && !aclObj.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	} else if !args.DryRun && !aclObj.AllowOperatorWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	snap, err := op.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	moves, err := scheduler.Rebalance(op.logger, &snap.StateStore, args)
+	if err != nil {
+		return err
+	}
+	reply.Moves = moves
+
+	if args.DryRun || len(moves) == 0 {
+		index, err := snap.LatestIndex()
+		if err != nil {
+			return err
+		}
+		reply.Index = index
+		return nil
+	}
+
+	// Mark the allocations for migration and create an evaluation for each
+	// of their jobs, as the drainer does
+	transitions := make(map[string]*structs.DesiredTransition, len(moves))
+	jobs := make(map[structs.NamespacedID]struct{})
+	var evals []*structs.Evaluation
+	now := time.Now().UTC().UnixNano()
+	for _, move := range moves {
+		transitions[move.AllocID] = &structs.DesiredTransition{Migrate: new(true)}
+
+		id := structs.NewNamespacedID(move.JobID, move.Namespace)
+		if _, ok := jobs[id]; ok {
+			continue
+		}
+		jobs[id] = struct{}{}
+
+		job, err := snap.JobByID(nil, move.Namespace, move.JobID)
+		if err != nil {
+			return err
+		}
+		if job == nil {
+			continue
+		}
+		evals = append(evals, &structs.Evaluation{
+			ID:          uuid.Generate(),
+			Namespace:   job.Namespace,
+			Priority:    job.Priority,
+			Type:        job.Type,
+			TriggeredBy: structs.EvalTriggerRebalance,
+			JobID:       job.ID,
+			Status:      structs.EvalStatusPending,
+			CreateTime:  now,
+			ModifyTime:  now,
+		})
+		reply.EvalIDs = append(reply.EvalIDs, evals[len(evals)-1].ID)
+	}
+
+	req := &structs.AllocUpdateDesiredTransitionRequest{
+		Allocs:       transitions,
+		Evals:        evals,
+		WriteRequest: structs.WriteRequest{Region: op.srv.config.Region},
+	}
+	_, index, err := op.srv.raftApply(structs.AllocUpdateDesiredTransitionRequestType, req)
+	if err != nil {
+		op.logger.Error("failed to migrate allocations for rebalance", "error", err)
+		return err
+	}
+
+	reply.Index = index
+	return nil
+}
+
 func (op *Operator) forwardStreamingRPC(region string, method string, args interface{}, in io.ReadWriteCloser) error {
 	server, err := op.srv.findRegionServer(region)
 	if err != nil {
@@ -588,6 +588,133 @@ func TestOperator_SchedulerSetConfiguration(t *testing.T) {
 	require.False(t, s1.blockedEvals.Enabled())
 }
 
+func TestOperator_SchedulerSimulate(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	node := mock.Node()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+
+	arg := structs.SchedulerSimulateRequest{
+		Jobs: []*structs.Job{job},
+		QueryOptions: structs.QueryOptions{
+			Region: s1.config.Region,
+		},
+	}
+
+	// Operator read alone isn't enough to simulate jobs
+	operatorToken := mock.CreatePolicyAndToken(t, state, 1001, "operator-read",
+		`operator { policy = "read" }`)
+	arg.AuthToken = operatorToken.SecretID
+	var reply structs.SchedulerSimulateResponse
+	err := msgpackrpc.CallWithCodec(codec, "Operator.SchedulerSimulate", &arg, &reply)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	arg.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.SchedulerSimulate", &arg, &reply))
+	must.Len(t, 1, reply.Jobs)
+	must.Len(t, 2, reply.Jobs[0].Placements)
+	must.Eq(t, node.ID, reply.Jobs[0].Placements[0].NodeID)
+	must.Eq(t, 1, reply.Utilization.Nodes)
+
+	// The cluster state is left untouched
+	out, err := state.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Nil(t, out)
+	allocs, err := state.AllocsByNode(nil, node.ID)
+	must.NoError(t, err)
+	must.Len(t, 0, allocs)
+}
+
+func TestOperator_SchedulerRebalance(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	var nodes []*structs.Node
+	for i := 0; i < 2; i++ {
+		node := mock.Node()
+		must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000+uint64(i), node))
+		nodes = append(nodes, node)
+	}
+
+	// Run a healthy alloc of a job on each node, with the second node the
+	// fuller one
+	var allocs []*structs.Allocation
+	for i, cpu := range []int64{500, 2000} {
+		job := mock.Job()
+		job.TaskGroups[0].Count = 1
+		must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1010+uint64(i), nil, job))
+
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = nodes[i].ID
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		alloc.DeploymentStatus = &structs.AllocDeploymentStatus{Healthy: new(true)}
+		alloc.AllocatedResources.Shared.Networks = nil
+		alloc.AllocatedResources.Tasks["web"].Networks = nil
+		alloc.AllocatedResources.Tasks["web"].Cpu.CpuShares = cpu
+		allocs = append(allocs, alloc)
+	}
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1020, allocs))
+
+	arg := structs.SchedulerRebalanceRequest{
+		DryRun: true,
+		WriteRequest: structs.WriteRequest{
+			Region: s1.config.Region,
+		},
+	}
+
+	// Operator read is enough for a dry run
+	operatorToken := mock.CreatePolicyAndToken(t, state, 1030, "operator-read",
+		`operator { policy = "read" }`)
+	arg.AuthToken = operatorToken.SecretID
+	var reply structs.SchedulerRebalanceResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.SchedulerRebalance", &arg, &reply))
+	must.Len(t, 1, reply.Moves)
+	must.Eq(t, allocs[0].ID, reply.Moves[0].AllocID)
+	must.Eq(t, nodes[1].ID, reply.Moves[0].TargetNodeID)
+	must.Len(t, 0, reply.EvalIDs)
+
+	out, err := state.AllocByID(nil, allocs[0].ID)
+	must.NoError(t, err)
+	must.False(t, out.DesiredTransition.ShouldMigrate())
+
+	// Migrating the allocs requires operator write
+	arg.DryRun = false
+	err = msgpackrpc.CallWithCodec(codec, "Operator.SchedulerRebalance", &arg, &reply)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	arg.AuthToken = root.SecretID
+	reply = structs.SchedulerRebalanceResponse{}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.SchedulerRebalance", &arg, &reply))
+	must.Len(t, 1, reply.Moves)
+	must.Len(t, 1, reply.EvalIDs)
+
+	out, err = state.AllocByID(nil, allocs[0].ID)
+	must.NoError(t, err)
+	must.True(t, out.DesiredTransition.ShouldMigrate())
+
+	eval, err := state.EvalByID(nil, reply.EvalIDs[0])
+	must.NoError(t, err)
+	must.NotNil(t, eval)
+	must.Eq(t, structs.EvalTriggerRebalance, eval.TriggeredBy)
+	must.Eq(t, allocs[0].JobID, eval.JobID)
+}
+
 func TestOperator_SchedulerGetConfiguration_ACL(t *testing.T) {
 	ci.Parallel(t)
 
@@ -7,6 +7,7 @@ import (
 	"container/heap"
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -45,6 +46,17 @@ type JobEvalDispatcher interface {
 
 	// RunningChildren returns whether the passed job has any running children.
 	RunningChildren(job *structs.Job) (bool, error)
+
+	// StopChildren stops the running children of the passed job.
+	StopChildren(job *structs.Job) error
+
+	// PruneChildren garbage collects the dead children of the passed job,
+	// keeping the most recent ones up to the limit.
+	PruneChildren(job *structs.Job, limit int) error
+
+	// LastLaunch returns the last launch time of the passed job, or the zero
+	// time if it has none.
+	LastLaunch(job *structs.Job) (time.Time, error)
 }
 
 // DispatchJob creates an evaluation for the passed job and commits both the
@@ -90,48 +102,164 @@ func (s *Server) RunningChildren(job *structs.Job) (bool, error) {
 		return false, err
 	}
 
+	children, err := periodicChildren(snap, job)
+	if err != nil {
+		return false, err
+	}
+
+	for _, child := range children {
+		running, err := childRunning(snap, child)
+		if err != nil || running {
+			return running, err
+		}
+	}
+
+	// There are no evals or allocations that aren't terminal.
+	return false, nil
+}
+
+// StopChildren stops the running children of the passed job.
+func (s *Server) StopChildren(job *structs.Job) error {
+	snap, err := s.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	children, err := periodicChildren(snap, job)
+	if err != nil {
+		return err
+	}
+
+	for _, child := range children {
+		if child.Stop {
+			continue
+		}
+		running, err := childRunning(snap, child)
+		if err != nil {
+			return err
+		}
+		if !running {
+			continue
+		}
+
+		req := &structs.JobDeregisterRequest{
+			JobID: child.ID,
+			WriteRequest: structs.WriteRequest{
+				Region:    s.config.Region,
+				Namespace: child.Namespace,
+				AuthToken: s.getLeaderAcl(),
+			},
+		}
+		var resp structs.JobDeregisterResponse
+		if err := s.RPC("Job.Deregister", req, &resp); err != nil {
+			return fmt.Errorf("failed to stop child job %q: %v", child.ID, err)
+		}
+	}
+	return nil
+}
+
+// LastLaunch returns the last launch time of the passed job. Jobs which were
+// never launched have their registration time as their last launch.
+func (s *Server) LastLaunch(job *structs.Job) (time.Time, error) {
+	launch, err := s.fsm.State().PeriodicLaunchByID(nil, job.Namespace, job.ID)
+	if err != nil || launch == nil {
+		return time.Time{}, err
+	}
+	return launch.Launch, nil
+}
+
+// PruneChildren purges the dead children of the passed job, keeping the most
+// recently created ones up to the limit.
+func (s *Server) PruneChildren(job *structs.Job, limit int) error {
+	snap, err := s.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	children, err := periodicChildren(snap, job)
+	if err != nil {
+		return err
+	}
+
+	var dead []*structs.Job
+	for _, child := range children {
+		if child.Status == structs.JobStatusDead {
+			dead = append(dead, child)
+		}
+	}
+	if len(dead) <= limit {
+		return nil
+	}
+
+	// Sort the dead children newest first and purge the ones beyond the limit.
+	sort.Slice(dead, func(i, j int) bool {
+		return dead[i].CreateIndex > dead[j].CreateIndex
+	})
+
+	req := &structs.JobBatchDeregisterRequest{
+		Jobs: make(map[structs.NamespacedID]*structs.JobDeregisterOptions),
+		WriteRequest: structs.WriteRequest{
+			Region:    s.config.Region,
+			AuthToken: s.getLeaderAcl(),
+		},
+	}
+	for _, child := range dead[limit:] {
+		req.Jobs[child.NamespacedID()] = &structs.JobDeregisterOptions{Purge: true}
+	}
+	var resp structs.JobBatchDeregisterResponse
+	return s.RPC(structs.JobBatchDeregisterRPCMethod, req, &resp)
+}
+
+// periodicChildren returns the children launched for the passed periodic job.
+func periodicChildren(snap *state.StateSnapshot, job *structs.Job) ([]*structs.Job, error) {
 	ws := memdb.NewWatchSet()
 	prefix := fmt.Sprintf("%s%s", job.ID, structs.PeriodicLaunchSuffix)
 	iter, err := snap.JobsByIDPrefix(ws, job.Namespace, prefix, state.SortDefault)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	var child *structs.Job
+	var children []*structs.Job
 	for i := iter.Next(); i != nil; i = iter.Next() {
-		child = i.(*structs.Job)
+		child := i.(*structs.Job)
 
 		// Ensure the job is actually a child.
 		if child.ParentID != job.ID {
 			continue
 		}
+		children = append(children, child)
+	}
+	return children, nil
+}
+
+// childRunning returns whether the child job has any active evaluations or
+// running allocations.
+func childRunning(snap *state.StateSnapshot, child *structs.Job) (bool, error) {
+	ws := memdb.NewWatchSet()
 
-		// Get the childs evaluations.
-		evals, err := snap.EvalsByJob(ws, child.Namespace, child.ID)
+	// Get the childs evaluations.
+	evals, err := snap.EvalsByJob(ws, child.Namespace, child.ID)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if any of the evals are active or have running allocations.
+	for _, eval := range evals {
+		if !eval.TerminalStatus() {
+			return true, nil
+		}
+
+		allocs, err := snap.AllocsByEval(ws, eval.ID)
 		if err != nil {
 			return false, err
 		}
 
-		// Check if any of the evals are active or have running allocations.
-		for _, eval := range evals {
-			if !eval.TerminalStatus() {
+		for _, alloc := range allocs {
+			if !alloc.TerminalStatus() {
 				return true, nil
 			}
-
-			allocs, err := snap.AllocsByEval(ws, eval.ID)
-			if err != nil {
-				return false, err
-			}
-
-			for _, alloc := range allocs {
-				if !alloc.TerminalStatus() {
-					return true, nil
-				}
-			}
 		}
 	}
-
-	// There are no evals or allocations that aren't terminal.
 	return false, nil
 }
 
@@ -184,7 +312,10 @@ func (p *PeriodicDispatch) Tracked() []*structs.Job {
 
 // Add begins tracking of a periodic job. If it is already tracked, it acts as
 // an update to the jobs periodic spec. The method returns whether the job was
-// added and any error that may have occurred.
+// added and any error that may have occurred. A job with a missed runs policy
+// which begins to be tracked, because it was re-enabled or because of a
+// leadership transition, is launched for the launches it missed since its last
+// launch.
 func (p *PeriodicDispatch) Add(job *structs.Job) error {
 	p.l.Lock()
 	defer p.l.Unlock()
@@ -228,6 +359,12 @@ func (p *PeriodicDispatch) Add(job *structs.Job) error {
 			return fmt.Errorf("failed to add job %v: %v", job.ID, err)
 		}
 		p.logger.Debug("registered periodic job", "job", job.NamespacedID())
+
+		// Launching requires raft applies, and Add is called while applying
+		// the job registration, so catch up asynchronously.
+		if job.Periodic.MissedRuns != "" {
+			go p.catchUpAdded(job)
+		}
 	}
 
 	// Signal an update.
@@ -303,6 +440,55 @@ func (p *PeriodicDispatch) ForceEval(namespace, jobID string) (*structs.Evaluati
 	return p.createEval(job, time.Now().In(job.Periodic.GetLocation()))
 }
 
+// IsTracked returns whether the job is tracked by the dispatcher.
+func (p *PeriodicDispatch) IsTracked(namespace, jobID string) bool {
+	p.l.RLock()
+	defer p.l.RUnlock()
+	_, tracked := p.tracked[structs.NamespacedID{ID: jobID, Namespace: namespace}]
+	return tracked
+}
+
+// CatchUp launches the tracked job for the launches it missed since its last
+// launch, according to its missed runs policy, and returns the number of
+// launches. With the run-once policy, the job is launched once at the current
+// time. This should not be called with the lock held.
+func (p *PeriodicDispatch) CatchUp(job *structs.Job, lastLaunch time.Time) (int, error) {
+	if !p.IsTracked(job.Namespace, job.ID) {
+		return 0, nil
+	}
+
+	now := time.Now().In(job.Periodic.GetLocation())
+	missed, err := job.Periodic.MissedLaunches(lastLaunch.In(job.Periodic.GetLocation()), now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to determine missed launches of job %s: %v", job.NamespacedID(), err)
+	}
+	if len(missed) == 0 {
+		return 0, nil
+	}
+	if job.Periodic.MissedRuns != structs.PeriodicMissedRunsRunAll {
+		missed = []time.Time{now}
+	}
+
+	p.logger.Debug("launching missed runs of periodic job", "job", job.NamespacedID(), "launches", len(missed))
+	return p.launch(job, missed)
+}
+
+// catchUpAdded launches a job which began to be tracked for the launches it
+// missed since its last launch.
+func (p *PeriodicDispatch) catchUpAdded(job *structs.Job) {
+	lastLaunch, err := p.dispatcher.LastLaunch(job)
+	if err != nil {
+		p.logger.Error("failed to get last launch of periodic job", "job", job.NamespacedID(), "error", err)
+		return
+	}
+	if lastLaunch.IsZero() {
+		return
+	}
+	if _, err := p.CatchUp(job, lastLaunch); err != nil {
+		p.logger.Error("failed to launch missed runs of periodic job", "job", job.NamespacedID(), "error", err)
+	}
+}
+
 // shouldRun returns whether the long lived run function should run.
 func (p *PeriodicDispatch) shouldRun() bool {
 	p.l.RLock()
@@ -347,26 +533,58 @@ func (p *PeriodicDispatch) dispatch(job *structs.Job, launchTime time.Time) {
 		p.logger.Error("failed to update next launch of periodic job", "job", job.NamespacedID(), "error", err)
 	}
 
-	// If the job prohibits overlapping and there are running children, we skip
-	// the launch.
-	if job.Periodic.ProhibitOverlap {
+	p.logger.Debug(" launching job", "job", job.NamespacedID(), "launch_time", launchTime)
+	p.l.Unlock()
+	p.launch(job, []time.Time{launchTime})
+}
+
+// launch creates an evaluation for the job at each of the launch times and
+// returns the number of launches. The concurrency policy of the job is applied
+// first: if the job prohibits overlapping and there are running children the
+// launches are skipped, and if the job replaces overlapping the running
+// children are stopped. Launches for several times would overlap each other,
+// so only the most recent one is launched for those jobs. Dead children beyond
+// the child history limit of the job are garbage collected afterwards. This
+// should not be called with the lock held, as stopping children removes them
+// from the dispatcher.
+func (p *PeriodicDispatch) launch(job *structs.Job, launches []time.Time) (int, error) {
+	if job.Periodic.ProhibitsOverlap() || job.Periodic.ReplacesOverlap() {
+		if len(launches) > 1 {
+			launches = launches[len(launches)-1:]
+		}
+
 		running, err := p.dispatcher.RunningChildren(job)
 		if err != nil {
 			p.logger.Error("failed to determine if periodic job has running children", "job", job.NamespacedID(), "error", err)
-			p.l.Unlock()
-			return
+			return 0, fmt.Errorf("failed to determine if periodic job %s has running children: %v", job.NamespacedID(), err)
 		}
 
-		if running {
+		if running && job.Periodic.ProhibitsOverlap() {
 			p.logger.Debug("skipping launch of periodic job because job prohibits overlap", "job", job.NamespacedID())
-			p.l.Unlock()
-			return
+			return 0, nil
+		}
+
+		if running {
+			p.logger.Debug("stopping running children of periodic job because job replaces overlap", "job", job.NamespacedID())
+			if err := p.dispatcher.StopChildren(job); err != nil {
+				p.logger.Error("failed to stop running children of periodic job", "job", job.NamespacedID(), "error", err)
+				return 0, fmt.Errorf("failed to stop running children of periodic job %s: %v", job.NamespacedID(), err)
+			}
 		}
 	}
 
-	p.logger.Debug(" launching job", "job", job.NamespacedID(), "launch_time", launchTime)
-	p.l.Unlock()
-	p.createEval(job, launchTime)
+	for i, launchTime := range launches {
+		if _, err := p.createEval(job, launchTime); err != nil {
+			return i, err
+		}
+	}
+
+	if limit := job.Periodic.ChildHistoryLimit; limit > 0 {
+		if err := p.dispatcher.PruneChildren(job, limit); err != nil {
+			p.logger.Error("failed to garbage collect children of periodic job", "job", job.NamespacedID(), "error", err)
+		}
+	}
+	return len(launches), nil
 }
 
 // nextLaunch returns the next job to launch and when it should be launched. If
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math/rand"
 	"reflect"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -20,17 +21,22 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 type MockJobEvalDispatcher struct {
-	Jobs map[structs.NamespacedID]*structs.Job
-	lock sync.Mutex
+	Jobs         map[structs.NamespacedID]*structs.Job
+	LastLaunches map[structs.NamespacedID]time.Time
+	lock         sync.Mutex
 }
 
 func NewMockJobEvalDispatcher() *MockJobEvalDispatcher {
-	return &MockJobEvalDispatcher{Jobs: make(map[structs.NamespacedID]*structs.Job)}
+	return &MockJobEvalDispatcher{
+		Jobs:         make(map[structs.NamespacedID]*structs.Job),
+		LastLaunches: make(map[structs.NamespacedID]time.Time),
+	}
 }
 
 func (m *MockJobEvalDispatcher) DispatchJob(job *structs.Job) (*structs.Evaluation, error) {
@@ -44,17 +50,67 @@ func (m *MockJobEvalDispatcher) DispatchJob(job *structs.Job) (*structs.Evaluati
 	return nil, nil
 }
 
+// RunningChildren returns whether the parent has any children that weren't
+// stopped.
 func (m *MockJobEvalDispatcher) RunningChildren(parent *structs.Job) (bool, error) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 	for _, job := range m.Jobs {
-		if job.ParentID == parent.ID && job.Namespace == parent.Namespace {
+		if job.ParentID == parent.ID && job.Namespace == parent.Namespace && !job.Stop {
 			return true, nil
 		}
 	}
 	return false, nil
 }
 
+// StopChildren marks the children of the parent as stopped.
+func (m *MockJobEvalDispatcher) StopChildren(parent *structs.Job) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, job := range m.Jobs {
+		if job.ParentID == parent.ID && job.Namespace == parent.Namespace {
+			job.Stop = true
+		}
+	}
+	return nil
+}
+
+// PruneChildren removes the stopped children of the parent launched before the
+// most recent ones up to the limit.
+func (m *MockJobEvalDispatcher) PruneChildren(parent *structs.Job, limit int) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var stopped []*structs.Job
+	for _, job := range m.Jobs {
+		if job.ParentID == parent.ID && job.Namespace == parent.Namespace && job.Stop {
+			stopped = append(stopped, job)
+		}
+	}
+	if len(stopped) <= limit {
+		return nil
+	}
+
+	sort.Slice(stopped, func(i, j int) bool { return stopped[i].ID > stopped[j].ID })
+	for _, job := range stopped[limit:] {
+		delete(m.Jobs, job.NamespacedID())
+	}
+	return nil
+}
+
+// LastLaunch returns the last launch time set for the job.
+func (m *MockJobEvalDispatcher) LastLaunch(job *structs.Job) (time.Time, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.LastLaunches[job.NamespacedID()], nil
+}
+
+// SetLastLaunch sets the last launch time of the job.
+func (m *MockJobEvalDispatcher) SetLastLaunch(job *structs.Job, launch time.Time) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.LastLaunches[job.NamespacedID()] = launch
+}
+
 // LaunchTimes returns the launch times of child jobs in sorted order.
 func (m *MockJobEvalDispatcher) LaunchTimes(p *PeriodicDispatch, namespace, parentID string) ([]time.Time, error) {
 	m.lock.Lock()
@@ -428,6 +484,191 @@ func TestPeriodicDispatch_Run_DisallowOverlaps(t *testing.T) {
 	}
 }
 
+func TestPeriodicDispatch_Run_ReplaceOverlaps(t *testing.T) {
+	ci.Parallel(t)
+	p, m := testPeriodicDispatcher(t)
+
+	// Create a job that will trigger two launches and replaces overlapping.
+	launch1 := time.Now().Round(1 * time.Second).Add(1 * time.Second)
+	launch2 := time.Now().Round(1 * time.Second).Add(2 * time.Second)
+	job := testPeriodicJob(launch1, launch2)
+	job.Periodic.ConcurrencyPolicy = structs.PeriodicConcurrencyReplace
+	must.NoError(t, p.Add(job))
+
+	time.Sleep(3 * time.Second)
+
+	// Check that both jobs were launched and the first one was stopped.
+	times, err := m.LaunchTimes(p, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Eq(t, []time.Time{launch1, launch2}, times)
+
+	for _, child := range m.dispatchedJobs(job) {
+		launch, err := p.LaunchTime(child.ID)
+		must.NoError(t, err)
+		must.Eq(t, launch.Equal(launch1), child.Stop, must.Sprintf("unexpected stop of child %s", child.ID))
+	}
+}
+
+func TestPeriodicDispatch_Run_ChildHistoryLimit(t *testing.T) {
+	ci.Parallel(t)
+	p, m := testPeriodicDispatcher(t)
+
+	// Create a job that will trigger three launches, replacing the previous
+	// launch each time and keeping a single stopped child.
+	launch1 := time.Now().Round(1 * time.Second).Add(1 * time.Second)
+	launch2 := time.Now().Round(1 * time.Second).Add(2 * time.Second)
+	launch3 := time.Now().Round(1 * time.Second).Add(3 * time.Second)
+	job := testPeriodicJob(launch1, launch2, launch3)
+	job.Periodic.ConcurrencyPolicy = structs.PeriodicConcurrencyReplace
+	job.Periodic.ChildHistoryLimit = 1
+	must.NoError(t, p.Add(job))
+
+	time.Sleep(4 * time.Second)
+
+	// Check that the oldest child was garbage collected.
+	times, err := m.LaunchTimes(p, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Eq(t, []time.Time{launch2, launch3}, times)
+}
+
+func TestPeriodicDispatch_CatchUp(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now().Round(1 * time.Second)
+	last := now.Add(-4 * time.Second)
+	past1 := now.Add(-3 * time.Second)
+	past2 := now.Add(-2 * time.Second)
+	future := now.Add(time.Hour)
+
+	cases := []struct {
+		name     string
+		modify   func(*structs.PeriodicConfig)
+		expected []time.Time
+		atNow    bool
+	}{
+		{
+			name:   "skip",
+			modify: func(p *structs.PeriodicConfig) { p.MissedRuns = structs.PeriodicMissedRunsSkip },
+		},
+		{
+			name:   "run-once",
+			modify: func(p *structs.PeriodicConfig) { p.MissedRuns = structs.PeriodicMissedRunsRunOnce },
+			atNow:  true,
+		},
+		{
+			name: "run-all",
+			modify: func(p *structs.PeriodicConfig) {
+				p.MissedRuns = structs.PeriodicMissedRunsRunAll
+				p.MaxMissedRuns = 5
+			},
+			expected: []time.Time{past1, past2},
+		},
+		{
+			name: "run-all limited",
+			modify: func(p *structs.PeriodicConfig) {
+				p.MissedRuns = structs.PeriodicMissedRunsRunAll
+				p.MaxMissedRuns = 1
+			},
+			expected: []time.Time{past2},
+		},
+		{
+			name: "run-all forbid",
+			modify: func(p *structs.PeriodicConfig) {
+				p.MissedRuns = structs.PeriodicMissedRunsRunAll
+				p.MaxMissedRuns = 5
+				p.ConcurrencyPolicy = structs.PeriodicConcurrencyForbid
+			},
+			expected: []time.Time{past2},
+		},
+		{
+			name: "run-all replace",
+			modify: func(p *structs.PeriodicConfig) {
+				p.MissedRuns = structs.PeriodicMissedRunsRunAll
+				p.MaxMissedRuns = 5
+				p.ConcurrencyPolicy = structs.PeriodicConcurrencyReplace
+			},
+			expected: []time.Time{past2},
+		},
+		{
+			name: "run-all past deadline",
+			modify: func(p *structs.PeriodicConfig) {
+				p.MissedRuns = structs.PeriodicMissedRunsRunAll
+				p.MaxMissedRuns = 5
+				p.StartingDeadline = time.Second
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p, m := testPeriodicDispatcher(t)
+
+			job := testPeriodicJob(past1, past2, future)
+			c.modify(job.Periodic)
+			must.NoError(t, p.Add(job))
+
+			launches, err := p.CatchUp(job, last)
+			must.NoError(t, err)
+
+			times, err := m.LaunchTimes(p, job.Namespace, job.ID)
+			must.NoError(t, err)
+			must.Len(t, launches, times)
+			if c.atNow {
+				must.Len(t, 1, times)
+				must.True(t, times[0].After(past2))
+			} else {
+				must.Eq(t, c.expected, times)
+			}
+		})
+	}
+}
+
+// TestPeriodicDispatch_Add_CatchUp asserts a job with a missed runs policy is
+// launched for its missed launches once it begins to be tracked, and only by
+// an enabled dispatcher.
+func TestPeriodicDispatch_Add_CatchUp(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now().Round(1 * time.Second)
+	past1 := now.Add(-3 * time.Second)
+	past2 := now.Add(-2 * time.Second)
+
+	job := testPeriodicJob(past1, past2, now.Add(time.Hour))
+	job.Periodic.MissedRuns = structs.PeriodicMissedRunsRunAll
+	job.Periodic.MaxMissedRuns = 5
+
+	// a disabled dispatcher, like on followers, doesn't launch the job
+	m := NewMockJobEvalDispatcher()
+	m.SetLastLaunch(job, now.Add(-4*time.Second))
+	disabled := NewPeriodicDispatch(testlog.HCLogger(t), m)
+	must.NoError(t, disabled.Add(job))
+	time.Sleep(100 * time.Millisecond)
+	must.SliceEmpty(t, m.dispatchedJobs(job))
+
+	p, m := testPeriodicDispatcher(t)
+	m.SetLastLaunch(job, now.Add(-4*time.Second))
+	must.NoError(t, p.Add(job))
+
+	must.Wait(t, wait.InitialSuccess(wait.ErrorFunc(func() error {
+		times, err := m.LaunchTimes(p, job.Namespace, job.ID)
+		if err != nil {
+			return err
+		}
+		if !slices.Equal(times, []time.Time{past1, past2}) {
+			return fmt.Errorf("expected launches at %v and %v, got %v", past1, past2, times)
+		}
+		return nil
+	}),
+		wait.Timeout(time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	// updating the tracked job doesn't launch it again
+	must.NoError(t, p.Add(job.Copy()))
+	time.Sleep(100 * time.Millisecond)
+	must.Len(t, 2, m.dispatchedJobs(job))
+}
+
 func TestPeriodicDispatch_Run_Multiple(t *testing.T) {
 	ci.Parallel(t)
 	p, m := testPeriodicDispatcher(t)
@@ -773,6 +1014,70 @@ func TestPeriodicDispatch_RunningChildren_ActiveAllocs(t *testing.T) {
 	}
 }
 
+func TestPeriodicDispatch_StopChildren(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Insert periodic job and a running child.
+	state := s1.fsm.State()
+	job := mock.PeriodicJob()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	childjob := deriveChildJob(job)
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1001, nil, childjob))
+
+	eval := mock.Eval()
+	eval.JobID = childjob.ID
+	eval.Status = structs.EvalStatusPending
+	must.NoError(t, state.UpsertEvals(structs.MsgTypeTestSetup, 1002, []*structs.Evaluation{eval}))
+
+	must.NoError(t, s1.StopChildren(job))
+
+	out, err := state.JobByID(nil, childjob.Namespace, childjob.ID)
+	must.NoError(t, err)
+	must.NotNil(t, out)
+	must.True(t, out.Stop)
+}
+
+func TestPeriodicDispatch_PruneChildren(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Insert periodic job, three dead children and a running child.
+	state := s1.fsm.State()
+	job := mock.PeriodicJob()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	var children []*structs.Job
+	for i := range 4 {
+		childjob := mock.Job()
+		childjob.ParentID = job.ID
+		childjob.ID = fmt.Sprintf("%s%s%d", job.ID, structs.PeriodicLaunchSuffix, i)
+		childjob.Stop = i < 3
+		must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, uint64(1001+i), nil, childjob))
+		children = append(children, childjob)
+	}
+
+	must.NoError(t, s1.PruneChildren(job, 1))
+
+	// Only the oldest dead children are purged.
+	for i, childjob := range children {
+		out, err := state.JobByID(nil, childjob.Namespace, childjob.ID)
+		must.NoError(t, err)
+		must.Eq(t, i >= 2, out != nil, must.Sprintf("unexpected child %d", i))
+	}
+}
+
 // TestPeriodicDispatch_JobEmptyStatus asserts that dispatched
 // job will always has an empty status
 func TestPeriodicDispatch_JobEmptyStatus(t *testing.T) {
@@ -49,6 +49,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/peers"
 	"github.com/hashicorp/nomad/nomad/reporting"
 	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/stream"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/nomad/structs/config"
 	"github.com/hashicorp/nomad/nomad/volumewatcher"
@@ -143,6 +144,10 @@ type Server struct {
 	// fsm is the state machine used with Raft
 	fsm *nomadFSM
 
+	// eventLog is the disk-backed log of events replayed to durable event
+	// consumers, it is nil if the server has no data directory
+	eventLog *stream.EventLog
+
 	// rpcListener is used to listen for incoming connections
 	rpcListener net.Listener
 	listenerCh  chan struct{}
@@ -482,6 +487,13 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI, consulConfigFunc
 		Encrypter:      s.encrypter,
 	})
 
+	// Open the event log before the FSM publishes any events
+	if err := s.setupEventLog(); err != nil {
+		s.Shutdown()
+		s.logger.Error("failed to open event log", "error", err)
+		return nil, fmt.Errorf("Failed to open event log: %v", err)
+	}
+
 	// Initialize the Raft server
 	if err := s.setupRaft(); err != nil {
 		s.Shutdown()
@@ -562,6 +574,9 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI, consulConfigFunc
 	// Emit raft and state store metrics
 	go s.EmitRaftStats(10*time.Second, s.shutdownCh)
 
+	// Prune the events acknowledged by durable event consumers
+	go s.pruneEventLog(s.shutdownCtx)
+
 	// Start enterprise background workers
 	s.startEnterpriseBackground()
 
@@ -789,6 +804,11 @@ func (s *Server) Shutdown() error {
 		s.fsm.Close()
 	}
 
+	// Close the event log once the fsm no longer publishes events
+	if s.eventLog != nil {
+		s.eventLog.Close()
+	}
+
 	// Stop being able to set Configuration Entries
 	s.consulConfigEntries.Stop()
 
@@ -1169,6 +1189,7 @@ func (s *Server) setupDeploymentWatcher() error {
 		NewJobEndpoints(s, nil),
 		s.config.DeploymentQueryRateLimit,
 		deploymentwatcher.CrossDeploymentUpdateBatchDuration,
+		s.config.CanaryAnalysisProviders,
 	)
 
 	return nil
@@ -1279,9 +1300,9 @@ func (s *Server) setupStreamingEndpoints(server *rpc.Server) {
 	agentEndpoint := NewAgentEndpoint(s)
 	agentEndpoint.register()
 
-	// Event is a streaming-only endpoint so we don't want to register it as a
-	// normal RPC
-	eventEndpoint := NewEventEndpoint(s)
+	// Event takes a RPC context but also has a streaming RPC that needs to be
+	// registered
+	eventEndpoint := NewEventEndpoint(s, nil)
 	eventEndpoint.register()
 
 	// Operator takes a RPC context but also has a streaming RPC that needs to
@@ -1306,6 +1327,7 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 	_ = server.Register(NewFileSystemEndpoint(s))
 	_ = server.Register(NewAgentEndpoint(s))
 	_ = server.Register(NewOperatorEndpoint(s, ctx))
+	_ = server.Register(NewEventEndpoint(s, ctx))
 
 	// All other endpoints include the connection context and don't need to be
 	// registered as streaming endpoints
@@ -1363,6 +1385,7 @@ func (s *Server) setupRaft() error {
 		Region:             s.Region(),
 		EnableEventBroker:  s.config.EnableEventBroker,
 		EventBufferSize:    s.config.EventBufferSize,
+		EventLog:           s.eventLog,
 		JobTrackedVersions: s.config.JobTrackedVersions,
 	}
 
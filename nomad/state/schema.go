@@ -31,6 +31,7 @@ const (
 	TableCSIVolumes               = "csi_volumes"
 	TableCSIPlugins               = "csi_plugins"
 	TableTaskGroupHostVolumeClaim = "task_volume"
+	TableEventConsumers           = "event_consumers"
 )
 
 const (
@@ -104,6 +105,7 @@ func init() {
 		bindingRulesTableSchema,
 		hostVolumeTableSchema,
 		taskGroupHostVolumeClaimSchema,
+		eventConsumerTableSchema,
 	}...)
 }
 
@@ -1696,3 +1698,23 @@ func taskGroupHostVolumeClaimSchema() *memdb.TableSchema {
 		},
 	}
 }
+
+// eventConsumerTableSchema returns the MemDB schema for the durable event
+// stream consumers table.
+func eventConsumerTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableEventConsumers,
+		Indexes: map[string]*memdb.IndexSchema{
+			// Name is the primary index used for lookup and is required to be
+			// unique.
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Name",
+				},
+			},
+		},
+	}
+}
@@ -87,6 +87,10 @@ type StateStoreConfig struct {
 	// EventBufferSize configures the amount of events to hold in memory
 	EventBufferSize int64
 
+	// EventLog is an optional disk-backed log of the published events used
+	// to replay them to durable event consumers.
+	EventLog *stream.EventLog
+
 	// JobTrackedVersions is the number of historic job versions that are kept.
 	JobTrackedVersions int
 }
@@ -147,6 +151,7 @@ func NewStateStore(config *StateStoreConfig) (*StateStore, error) {
 		broker, err := stream.NewEventBroker(ctx, stream.EventBrokerCfg{
 			EventBufferSize: config.EventBufferSize,
 			Logger:          config.Logger,
+			EventLog:        config.EventLog,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("creating state store event broker %w", err)
@@ -0,0 +1,159 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// EventConsumers returns an iterator over all durable event consumers.
+func (s *StateStore) EventConsumers(ws memdb.WatchSet, sort SortOption) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	var iter memdb.ResultIterator
+	var err error
+
+	switch sort {
+	case SortReverse:
+		iter, err = txn.GetReverse(TableEventConsumers, indexID)
+	default:
+		iter, err = txn.Get(TableEventConsumers, indexID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("event consumers lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// EventConsumerByName returns the event consumer that matches the given name
+// or nil if there is no match.
+func (s *StateStore) EventConsumerByName(ws memdb.WatchSet, name string) (*structs.EventConsumer, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableEventConsumers, indexID, name)
+	if err != nil {
+		return nil, fmt.Errorf("event consumer lookup failed: %w", err)
+	}
+	ws.Add(watchCh)
+
+	if existing == nil {
+		return nil, nil
+	}
+	return existing.(*structs.EventConsumer), nil
+}
+
+// MinEventConsumerAckIndex returns the lowest index acknowledged by the event
+// consumers, and whether any consumer exists.
+func (s *StateStore) MinEventConsumerAckIndex() (uint64, bool, error) {
+	iter, err := s.EventConsumers(nil, SortDefault)
+	if err != nil {
+		return 0, false, err
+	}
+
+	var minIndex uint64
+	found := false
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		consumer := raw.(*structs.EventConsumer)
+		if !found || consumer.AckIndex < minIndex {
+			minIndex = consumer.AckIndex
+		}
+		found = true
+	}
+	return minIndex, found, nil
+}
+
+// UpsertEventConsumer creates or updates an event consumer. New consumers
+// start streaming at the events after their creation, and updated consumers
+// keep their acknowledged index.
+func (s *StateStore) UpsertEventConsumer(msgType structs.MessageType, index uint64, consumer *structs.EventConsumer) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	existing, err := txn.First(TableEventConsumers, indexID, consumer.Name)
+	if err != nil {
+		return fmt.Errorf("event consumer lookup failed: %w", err)
+	}
+
+	consumer = consumer.Copy()
+	if existing != nil {
+		prev := existing.(*structs.EventConsumer)
+		consumer.CreateIndex = prev.CreateIndex
+		consumer.AckIndex = prev.AckIndex
+	} else {
+		consumer.CreateIndex = index
+		consumer.AckIndex = index
+	}
+	consumer.ModifyIndex = index
+
+	if err := txn.Insert(TableEventConsumers, consumer); err != nil {
+		return fmt.Errorf("event consumer insert failed: %w", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableEventConsumers, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+
+	return txn.Commit()
+}
+
+// AckEventConsumer sets the index acknowledged by an event consumer. The
+// acknowledged index never decreases, so acknowledging an earlier index is a
+// no-op.
+func (s *StateStore) AckEventConsumer(msgType structs.MessageType, index uint64, name string, ackIndex uint64) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	existing, err := txn.First(TableEventConsumers, indexID, name)
+	if err != nil {
+		return fmt.Errorf("event consumer lookup failed: %w", err)
+	}
+	if existing == nil {
+		return fmt.Errorf("event consumer %s not found", name)
+	}
+
+	consumer := existing.(*structs.EventConsumer)
+	if ackIndex <= consumer.AckIndex {
+		return nil
+	}
+
+	consumer = consumer.Copy()
+	consumer.AckIndex = ackIndex
+	consumer.ModifyIndex = index
+
+	if err := txn.Insert(TableEventConsumers, consumer); err != nil {
+		return fmt.Errorf("event consumer insert failed: %w", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableEventConsumers, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+
+	return txn.Commit()
+}
+
+// DeleteEventConsumer deletes an event consumer.
+func (s *StateStore) DeleteEventConsumer(msgType structs.MessageType, index uint64, name string) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	existing, err := txn.First(TableEventConsumers, indexID, name)
+	if err != nil {
+		return fmt.Errorf("event consumer lookup failed: %w", err)
+	}
+	if existing == nil {
+		return fmt.Errorf("event consumer %s not found", name)
+	}
+
+	if err := txn.Delete(TableEventConsumers, existing); err != nil {
+		return fmt.Errorf("event consumer deletion failed: %w", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableEventConsumers, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+
+	return txn.Commit()
+}
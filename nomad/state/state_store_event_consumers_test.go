@@ -0,0 +1,85 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"testing"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestStateStore_EventConsumers(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	consumer1 := mock.EventConsumer()
+	consumer2 := mock.EventConsumer()
+
+	_, found, err := state.MinEventConsumerAckIndex()
+	must.NoError(t, err)
+	must.False(t, found)
+
+	must.NoError(t, state.UpsertEventConsumer(structs.MsgTypeTestSetup, 1000, consumer1))
+	must.NoError(t, state.UpsertEventConsumer(structs.MsgTypeTestSetup, 1001, consumer2))
+
+	// new consumers start after their creation
+	got, err := state.EventConsumerByName(nil, consumer1.Name)
+	must.NoError(t, err)
+	must.Eq(t, 1000, got.AckIndex)
+	must.Eq(t, 1000, got.CreateIndex)
+	must.Eq(t, 1000, got.ModifyIndex)
+
+	ws := memdb.NewWatchSet()
+	_, err = state.EventConsumerByName(ws, consumer1.Name)
+	must.NoError(t, err)
+
+	// acknowledging moves the index forward only
+	must.NoError(t, state.AckEventConsumer(structs.MsgTypeTestSetup, 1002, consumer1.Name, 1500))
+	must.True(t, watchFired(ws))
+	must.NoError(t, state.AckEventConsumer(structs.MsgTypeTestSetup, 1003, consumer1.Name, 1200))
+	got, err = state.EventConsumerByName(nil, consumer1.Name)
+	must.NoError(t, err)
+	must.Eq(t, 1500, got.AckIndex)
+	must.Eq(t, 1002, got.ModifyIndex)
+
+	minIndex, found, err := state.MinEventConsumerAckIndex()
+	must.NoError(t, err)
+	must.True(t, found)
+	must.Eq(t, 1001, minIndex)
+
+	// updating a consumer keeps its acknowledged index
+	update := consumer1.Copy()
+	update.Filter = `Key == "example"`
+	must.NoError(t, state.UpsertEventConsumer(structs.MsgTypeTestSetup, 1004, update))
+	got, err = state.EventConsumerByName(nil, consumer1.Name)
+	must.NoError(t, err)
+	must.Eq(t, 1500, got.AckIndex)
+	must.Eq(t, 1000, got.CreateIndex)
+	must.Eq(t, `Key == "example"`, got.Filter)
+
+	iter, err := state.EventConsumers(nil, SortReverse)
+	must.NoError(t, err)
+	var names []string
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		names = append(names, raw.(*structs.EventConsumer).Name)
+	}
+	must.Len(t, 2, names)
+
+	must.NoError(t, state.DeleteEventConsumer(structs.MsgTypeTestSetup, 1005, consumer2.Name))
+	got, err = state.EventConsumerByName(nil, consumer2.Name)
+	must.NoError(t, err)
+	must.Nil(t, got)
+
+	index, err := state.Index(TableEventConsumers)
+	must.NoError(t, err)
+	must.Eq(t, 1005, index)
+
+	// missing consumers can't be acknowledged or deleted
+	must.ErrorContains(t, state.AckEventConsumer(structs.MsgTypeTestSetup, 1006, consumer2.Name, 2000), "not found")
+	must.ErrorContains(t, state.DeleteEventConsumer(structs.MsgTypeTestSetup, 1006, consumer2.Name), "not found")
+}
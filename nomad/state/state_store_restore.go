@@ -42,6 +42,14 @@ func (r *StateRestore) NodePoolRestore(pool *structs.NodePool) error {
 	return nil
 }
 
+// EventConsumerRestore is used to restore a durable event consumer
+func (r *StateRestore) EventConsumerRestore(consumer *structs.EventConsumer) error {
+	if err := r.txn.Insert(TableEventConsumers, consumer); err != nil {
+		return fmt.Errorf("event consumer insert failed: %v", err)
+	}
+	return nil
+}
+
 // JobRestore is used to restore a job
 func (r *StateRestore) JobRestore(job *structs.Job) error {
 
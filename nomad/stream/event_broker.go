@@ -24,6 +24,10 @@ const (
 type EventBrokerCfg struct {
 	EventBufferSize int64
 	Logger          hclog.Logger
+
+	// EventLog is an optional disk-backed log the published events are
+	// written to, so that they can be replayed to durable consumers.
+	EventLog *EventLog
 }
 
 type EventBroker struct {
@@ -34,6 +38,14 @@ type EventBroker struct {
 	// eventBuf stores a configurable amount of events in memory
 	eventBuf *eventBuffer
 
+	// eventLog stores events on disk to replay them, it may be nil
+	eventLog *EventLog
+
+	// publishLock is held while published events are written to the event
+	// log and buffer, so that replaying subscriptions get a consistent view
+	// of both
+	publishLock sync.Mutex
+
 	// publishCh is used to send messages from an active txn to a goroutine which
 	// publishes events, so that publishing can happen asynchronously from
 	// the Commit call in the FSM hot path.
@@ -62,6 +74,7 @@ func NewEventBroker(ctx context.Context, cfg EventBrokerCfg) (*EventBroker, erro
 	e := &EventBroker{
 		logger:    cfg.Logger.Named("event_broker"),
 		eventBuf:  buffer,
+		eventLog:  cfg.EventLog,
 		publishCh: make(chan *structs.Events, 64),
 		aclCh:     make(chan structs.Event, 10),
 		subscriptions: &subscriptions{
@@ -105,11 +118,29 @@ func (e *EventBroker) Publish(events *structs.Events) {
 // A Subscription will start at the requested index, or as close as possible to
 // the requested index if it is no longer in the buffer. If StartExactlyAtIndex is
 // set and the index is no longer in the buffer or not yet in the buffer an error
-// will be returned.
+// will be returned. An error wrapping ErrInvalidFilter is returned if the
+// Filter expression of the request can't be parsed.
+//
+// If Replay is set, the events from the requested index which are no longer in
+// the buffer are replayed from the event log, if any. An error wrapping
+// ErrCursorTruncated is returned if some of these events may be missing from
+// the log.
 //
 // When a caller is finished with the subscription it must call Subscription.Unsubscribe
 // to free ACL tracking resources.
 func (e *EventBroker) Subscribe(req *SubscribeRequest) (*Subscription, error) {
+	if req.Filter != "" {
+		filterFn, err := expressionFilterFn(req.Filter, req.FilterFn)
+		if err != nil {
+			return nil, err
+		}
+		req.FilterFn = filterFn
+	}
+
+	if req.Replay && e.eventLog != nil {
+		return e.subscribeReplay(req)
+	}
+
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
@@ -146,6 +177,69 @@ func (e *EventBroker) Subscribe(req *SubscribeRequest) (*Subscription, error) {
 	return sub, nil
 }
 
+// subscribeReplay returns a new Subscription for a request replaying the events
+// from the event log which are no longer in the buffer. The replayed events
+// are linked ahead of the first buffer item at or after the requested index,
+// and the subscription skips the events before the requested index.
+func (e *EventBroker) subscribeReplay(req *SubscribeRequest) (*Subscription, error) {
+	if req.Authenticate == nil {
+		req.Authenticate = func() error {
+			return nil
+		}
+	} else if err := req.Authenticate(); err != nil {
+		return nil, err
+	}
+
+	// Find the first item delivered from the buffer while no events are
+	// published, every event before it is either in the log or was never
+	// written to it. If no item is delivered yet, the subscription waits for
+	// the items published after the tail.
+	e.publishLock.Lock()
+	live := e.eventBuf.Tail()
+	cut := e.eventLog.LastIndex() + 1
+	for item := e.eventBuf.Head(); item != nil; item = item.NextNoBlock() {
+		if item.Events.Index != 0 && item.Events.Index >= req.Index {
+			live = item
+			cut = item.Events.Index
+			break
+		}
+	}
+	e.publishLock.Unlock()
+
+	// The events before the cut may still be queued to be written to the
+	// log, so wait for them before reading.
+	var replayed []*structs.Events
+	if req.Index < cut {
+		if err := e.eventLog.Sync(); err != nil {
+			return nil, fmt.Errorf("failed to write event log: %w", err)
+		}
+		var err error
+		replayed, err = e.eventLog.Read(req.Index, cut)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read event log: %w", err)
+		}
+	}
+
+	next := live
+	for i := len(replayed) - 1; i >= 0; i-- {
+		item := newBufferItem(replayed[i])
+		item.link.next.Store(next)
+		close(item.link.nextCh)
+		next = item
+	}
+
+	start := newBufferItem(&structs.Events{Index: req.Index})
+	start.link.next.Store(next)
+	close(start.link.nextCh)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	sub := newSubscription(req, start, e.subscriptions.unsubscribeFn(req))
+	e.subscriptions.add(req, sub)
+	return sub, nil
+}
+
 // CloseAll closes all subscriptions
 func (e *EventBroker) CloseAll() {
 	e.subscriptions.closeAll()
@@ -158,9 +252,23 @@ func (e *EventBroker) handleUpdates(ctx context.Context) {
 			e.subscriptions.closeAll()
 			return
 		case update := <-e.publishCh:
-			e.eventBuf.Append(update)
+			e.publish(update)
+		}
+	}
+}
+
+// publish writes the events to the event log, if any, and appends them to the
+// buffer.
+func (e *EventBroker) publish(update *structs.Events) {
+	e.publishLock.Lock()
+	defer e.publishLock.Unlock()
+
+	if e.eventLog != nil {
+		if err := e.eventLog.Append(update); err != nil {
+			e.logger.Error("failed to write events to the event log", "index", update.Index, "error", err)
 		}
 	}
+	e.eventBuf.Append(update)
 }
 
 func (e *EventBroker) handleACLUpdates(ctx context.Context) {
@@ -5,13 +5,16 @@ package stream
 
 import (
 	"context"
+	"path/filepath"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
 )
 
 func TestEventBroker_PublishChangesAndSubscribe(t *testing.T) {
@@ -67,6 +70,111 @@ func TestEventBroker_PublishChangesAndSubscribe(t *testing.T) {
 	must.Eq(t, expected, result.Events)
 }
 
+func TestEventBroker_Subscribe_Filter(t *testing.T) {
+	ci.Parallel(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	publisher, err := NewEventBroker(ctx, EventBrokerCfg{EventBufferSize: 100})
+	must.NoError(t, err)
+
+	_, err = publisher.Subscribe(&SubscribeRequest{
+		Topics: map[structs.Topic][]string{"Test": {"*"}},
+		Filter: `Key ==`,
+	})
+	must.ErrorIs(t, err, ErrInvalidFilter)
+
+	sub, err := publisher.Subscribe(&SubscribeRequest{
+		Topics: map[structs.Topic][]string{"Test": {"*"}},
+		Filter: `Key == "two"`,
+	})
+	must.NoError(t, err)
+	eventCh := consumeSubscription(ctx, sub)
+
+	publisher.Publish(&structs.Events{Index: 1, Events: []structs.Event{
+		{Index: 1, Topic: "Test", Key: "one"},
+		{Index: 1, Topic: "Test", Key: "two"},
+	}})
+
+	// Subscriber should only see the event matching the filter
+	result := nextResult(t, eventCh)
+	must.NoError(t, result.Err)
+	must.Eq(t, []structs.Event{{Index: 1, Topic: "Test", Key: "two"}}, result.Events)
+}
+
+func TestEventBroker_Subscribe_Replay(t *testing.T) {
+	ci.Parallel(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	eventLog, err := NewEventLog(testlog.HCLogger(t), filepath.Join(t.TempDir(), "events.db"))
+	must.NoError(t, err)
+	t.Cleanup(func() { eventLog.Close() })
+	eventLog.SetActive(true)
+
+	publisher, err := NewEventBroker(ctx, EventBrokerCfg{EventBufferSize: 2, EventLog: eventLog})
+	must.NoError(t, err)
+
+	for i := uint64(1); i <= 5; i++ {
+		publisher.Publish(testEventLogEvents(i))
+	}
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return eventLog.LastIndex() == 5 }),
+		wait.Timeout(time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	sub, err := publisher.Subscribe(&SubscribeRequest{
+		Topics: map[structs.Topic][]string{"Test": {"*"}},
+		Index:  2,
+		Replay: true,
+	})
+	must.NoError(t, err)
+	eventCh := consumeSubscription(ctx, sub)
+
+	// events evicted from the buffer are replayed from the log, followed by
+	// the events still in the buffer, each of them exactly once
+	for i := uint64(2); i <= 5; i++ {
+		result := nextResult(t, eventCh)
+		must.NoError(t, result.Err)
+		must.Eq(t, i, result.Events[0].Index)
+	}
+	assertNoResult(t, eventCh)
+
+	publisher.Publish(testEventLogEvents(6))
+	result := nextResult(t, eventCh)
+	must.NoError(t, result.Err)
+	must.Eq(t, 6, result.Events[0].Index)
+
+	// events before the requested index are never delivered, even when the
+	// requested index is not yet published
+	sub, err = publisher.Subscribe(&SubscribeRequest{
+		Topics: map[structs.Topic][]string{"Test": {"*"}},
+		Index:  8,
+		Replay: true,
+	})
+	must.NoError(t, err)
+	eventCh = consumeSubscription(ctx, sub)
+
+	publisher.Publish(testEventLogEvents(7))
+	publisher.Publish(testEventLogEvents(8))
+	result = nextResult(t, eventCh)
+	must.NoError(t, result.Err)
+	must.Eq(t, 8, result.Events[0].Index)
+
+	// events truncated from the log can't be replayed
+	_, err = eventLog.Truncate(3)
+	must.NoError(t, err)
+	_, err = publisher.Subscribe(&SubscribeRequest{
+		Topics: map[structs.Topic][]string{"Test": {"*"}},
+		Index:  3,
+		Replay: true,
+	})
+	must.ErrorIs(t, err, ErrCursorTruncated)
+}
+
 func TestEventBroker_ShutdownClosesSubscriptions(t *testing.T) {
 	ci.Parallel(t)
 
@@ -0,0 +1,460 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package stream
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"go.etcd.io/bbolt"
+)
+
+const (
+	// eventLogQueueSize is the number of appended events which may be waiting
+	// to be written to the log before Append drops them.
+	eventLogQueueSize = 1024
+
+	// eventLogMaxBatch is the maximum number of appended events written to
+	// the log in a single transaction.
+	eventLogMaxBatch = 256
+)
+
+var (
+	// eventLogBucket is the bucket of the events, keyed by their big endian
+	// encoded raft index.
+	eventLogBucket = []byte("events")
+
+	// eventLogMetaBucket is the bucket of the log metadata.
+	eventLogMetaBucket = []byte("meta")
+
+	// eventLogTruncatedKey is the key of the truncated index in the metadata
+	// bucket.
+	eventLogTruncatedKey = []byte("truncated")
+)
+
+// ErrCursorTruncated is returned when reading events from an index for which
+// events may no longer be, or may never have been, in the event log.
+var ErrCursorTruncated = errors.New("requested index was truncated from the event log")
+
+// errEventLogClosed is returned when appending events to a closed log.
+var errEventLogClosed = errors.New("event log closed")
+
+// errEventLogQueueFull is returned when appended events are dropped because
+// too many events are waiting to be written to the log.
+var errEventLogQueueFull = errors.New("event log write queue full")
+
+// EventLog is a disk-backed log of the events published to an EventBroker. It
+// is used to replay the events to durable consumers once they are no longer in
+// the in-memory event buffer.
+//
+// Events are only written while the log is active, which is the case while
+// durable consumers exist, and are kept until they are truncated once all
+// consumers acknowledged them. Appended events are queued and written by a
+// background goroutine, which commits all the queued events in a single
+// transaction so that publishing never waits on the disk.
+type EventLog struct {
+	db     *bbolt.DB
+	logger hclog.Logger
+
+	// active is whether events are written to the log
+	active atomic.Bool
+
+	// lastIndex is the index of the last events appended to the log
+	lastIndex atomic.Uint64
+
+	// truncated is the highest index for which events may be missing from
+	// the log, either because they were truncated, appended while the log
+	// was inactive or failed to be written
+	truncated atomic.Uint64
+
+	// l protects closed, and is held for reading while queuing writes
+	l      sync.RWMutex
+	closed bool
+
+	writeCh chan eventLogWrite
+	closeCh chan struct{}
+	doneCh  chan struct{}
+}
+
+// eventLogWrite is an entry in the write queue of the log, either events to
+// write or a marker to signal once the previous entries are written.
+type eventLogWrite struct {
+	events *structs.Events
+	done   chan error
+}
+
+// NewEventLog opens or creates the event log at path.
+func NewEventLog(logger hclog.Logger, path string) (*EventLog, error) {
+	db, err := bbolt.Open(path, 0600, &bbolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event log: %w", err)
+	}
+
+	l := &EventLog{
+		db:      db,
+		logger:  logger.Named("event_log"),
+		writeCh: make(chan eventLogWrite, eventLogQueueSize),
+		closeCh: make(chan struct{}),
+		doneCh:  make(chan struct{}),
+	}
+	err = db.Update(func(tx *bbolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(eventLogBucket)
+		if err != nil {
+			return err
+		}
+		if k, _ := b.Cursor().Last(); k != nil {
+			l.lastIndex.Store(binary.BigEndian.Uint64(k))
+		}
+
+		meta, err := tx.CreateBucketIfNotExists(eventLogMetaBucket)
+		if err != nil {
+			return err
+		}
+		if v := meta.Get(eventLogTruncatedKey); v != nil {
+			l.truncated.Store(binary.BigEndian.Uint64(v))
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize event log: %w", err)
+	}
+
+	go l.run()
+	return l, nil
+}
+
+// SetActive sets whether events are written to the log.
+func (l *EventLog) SetActive(active bool) {
+	l.active.Store(active)
+}
+
+// Active returns whether events are written to the log.
+func (l *EventLog) Active() bool {
+	return l.active.Load()
+}
+
+// LastIndex returns the index of the last events appended to the log.
+func (l *EventLog) LastIndex() uint64 {
+	return l.lastIndex.Load()
+}
+
+// Append queues the events to be written to the log if it is active, without
+// blocking. Events with an index already in the log, such as the ones
+// published again when raft logs are replayed on startup, overwrite the
+// existing ones. Call Sync to wait for the appended events to be written.
+func (l *EventLog) Append(events *structs.Events) error {
+	if events == nil || events.Index == 0 || len(events.Events) == 0 {
+		return nil
+	}
+	if !l.Active() {
+		l.setTruncated(events.Index)
+		return nil
+	}
+
+	l.l.RLock()
+	defer l.l.RUnlock()
+	if l.closed {
+		return errEventLogClosed
+	}
+
+	if events.Index > l.lastIndex.Load() {
+		l.lastIndex.Store(events.Index)
+	}
+
+	// Publishing must never wait on the disk, so the events are dropped if
+	// the queue is full and reading them fails with ErrCursorTruncated.
+	select {
+	case l.writeCh <- eventLogWrite{events: events}:
+		return nil
+	default:
+		l.setTruncated(events.Index)
+		return errEventLogQueueFull
+	}
+}
+
+// Sync waits for the events appended before the call to be written to the
+// log, and returns the error of the write if it failed.
+func (l *EventLog) Sync() error {
+	done := make(chan error, 1)
+
+	l.l.RLock()
+	if l.closed {
+		l.l.RUnlock()
+		return errEventLogClosed
+	}
+	l.writeCh <- eventLogWrite{done: done}
+	l.l.RUnlock()
+
+	return <-done
+}
+
+// run writes the queued events to the log until it is closed.
+func (l *EventLog) run() {
+	defer close(l.doneCh)
+
+	for {
+		select {
+		case w := <-l.writeCh:
+			l.writeBatch(w)
+		case <-l.closeCh:
+			// Appends are rejected once the log is closed, so the remaining
+			// writes are the last ones.
+			for {
+				select {
+				case w := <-l.writeCh:
+					l.writeBatch(w)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// writeBatch writes the first write along with the other queued ones in a
+// single transaction, and signals the markers once done.
+func (l *EventLog) writeBatch(first eventLogWrite) {
+	batch := []eventLogWrite{first}
+BATCH:
+	for len(batch) < eventLogMaxBatch {
+		select {
+		case w := <-l.writeCh:
+			batch = append(batch, w)
+		default:
+			break BATCH
+		}
+	}
+
+	var maxIndex uint64
+	err := l.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(eventLogBucket)
+		for _, w := range batch {
+			if w.events == nil {
+				continue
+			}
+			maxIndex = max(maxIndex, w.events.Index)
+
+			buf, err := json.Marshal(w.events)
+			if err != nil {
+				l.logger.Error("failed to encode events", "index", w.events.Index, "error", err)
+				l.setTruncated(w.events.Index)
+				continue
+			}
+			if err := b.Put(eventLogKey(w.events.Index), buf); err != nil {
+				return err
+			}
+		}
+		return l.putTruncated(tx)
+	})
+	if err != nil {
+		l.logger.Error("failed to write events", "index", maxIndex, "error", err)
+		l.setTruncated(maxIndex)
+	}
+
+	for _, w := range batch {
+		if w.done != nil {
+			w.done <- err
+		}
+	}
+}
+
+// Read returns the events in the log with an index greater than or equal to
+// from and less than to, in index order. ErrCursorTruncated is returned if
+// events after from may be missing from the log.
+func (l *EventLog) Read(from, to uint64) ([]*structs.Events, error) {
+	if from < to && from <= l.truncated.Load() {
+		return nil, fmt.Errorf("%w: index %d is not after truncated index %d",
+			ErrCursorTruncated, from, l.truncated.Load())
+	}
+
+	var result []*structs.Events
+	err := l.db.View(func(tx *bbolt.Tx) error {
+		c := tx.Bucket(eventLogBucket).Cursor()
+		for k, v := c.Seek(eventLogKey(from)); k != nil; k, v = c.Next() {
+			if binary.BigEndian.Uint64(k) >= to {
+				break
+			}
+			events, err := decodeEventLogEvents(v)
+			if err != nil {
+				return fmt.Errorf("failed to decode events: %w", err)
+			}
+			result = append(result, events)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Truncate deletes the events in the log with an index less than or equal to
+// index, and returns the number of deleted entries. The events appended before
+// the call are written first, so that they are truncated as well.
+func (l *EventLog) Truncate(index uint64) (int, error) {
+	if err := l.Sync(); err != nil {
+		return 0, err
+	}
+
+	l.setTruncated(index)
+	deleted := 0
+	err := l.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(eventLogBucket)
+
+		// keys are collected first, as deleting with the cursor while
+		// iterating skips entries
+		var keys [][]byte
+		c := b.Cursor()
+		for k, _ := c.First(); k != nil; k, _ = c.Next() {
+			if binary.BigEndian.Uint64(k) > index {
+				break
+			}
+			keys = append(keys, k)
+		}
+		for _, k := range keys {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+		deleted = len(keys)
+		return l.putTruncated(tx)
+	})
+	return deleted, err
+}
+
+// Close writes the queued events and closes the log.
+func (l *EventLog) Close() error {
+	l.l.Lock()
+	if l.closed {
+		l.l.Unlock()
+		return nil
+	}
+	l.closed = true
+	l.l.Unlock()
+
+	close(l.closeCh)
+	<-l.doneCh
+
+	err := l.db.Update(l.putTruncated)
+	if err != nil {
+		l.logger.Error("failed to write truncated index", "error", err)
+	}
+	return l.db.Close()
+}
+
+// setTruncated raises the index for which events may be missing from the log.
+func (l *EventLog) setTruncated(index uint64) {
+	for {
+		truncated := l.truncated.Load()
+		if index <= truncated || l.truncated.CompareAndSwap(truncated, index) {
+			return
+		}
+	}
+}
+
+// putTruncated stores the truncated index in the metadata bucket.
+func (l *EventLog) putTruncated(tx *bbolt.Tx) error {
+	return tx.Bucket(eventLogMetaBucket).Put(eventLogTruncatedKey, eventLogKey(l.truncated.Load()))
+}
+
+func eventLogKey(index uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, index)
+	return key
+}
+
+// eventLogEvents is the stored form of structs.Events, which keeps the event
+// payloads encoded until their type is known from the topic.
+type eventLogEvents struct {
+	Index  uint64
+	Events []eventLogEvent
+}
+
+type eventLogEvent struct {
+	structs.Event
+	Payload json.RawMessage
+}
+
+// decodeEventLogEvents decodes stored events, with the payloads of the known
+// topics decoded into the structs they are published with.
+func decodeEventLogEvents(buf []byte) (*structs.Events, error) {
+	var stored eventLogEvents
+	if err := json.Unmarshal(buf, &stored); err != nil {
+		return nil, err
+	}
+
+	events := &structs.Events{
+		Index:  stored.Index,
+		Events: make([]structs.Event, len(stored.Events)),
+	}
+	for i, e := range stored.Events {
+		event := e.Event
+		if len(e.Payload) > 0 && string(e.Payload) != "null" {
+			payload := newEventPayload(event.Topic)
+			if payload == nil {
+				var generic any
+				if err := json.Unmarshal(e.Payload, &generic); err != nil {
+					return nil, err
+				}
+				event.Payload = generic
+			} else {
+				if err := json.Unmarshal(e.Payload, payload); err != nil {
+					return nil, fmt.Errorf("failed to decode %s payload: %w", event.Topic, err)
+				}
+				event.Payload = payload
+			}
+		}
+		events.Events[i] = event
+	}
+	return events, nil
+}
+
+// newEventPayload returns a pointer to the payload struct of the events of the
+// topic, or nil if the topic is unknown.
+func newEventPayload(topic structs.Topic) any {
+	switch topic {
+	case structs.TopicDeployment:
+		return new(structs.DeploymentEvent)
+	case structs.TopicEvaluation:
+		return new(structs.EvaluationEvent)
+	case structs.TopicAllocation:
+		return new(structs.AllocationEvent)
+	case structs.TopicJob:
+		return new(structs.JobEvent)
+	case structs.TopicNode:
+		return new(structs.NodeStreamEvent)
+	case structs.TopicNodePool:
+		return new(structs.NodePoolEvent)
+	case structs.TopicACLPolicy:
+		return new(structs.ACLPolicyEvent)
+	case structs.TopicACLToken:
+		return new(structs.ACLTokenEvent)
+	case structs.TopicACLRole:
+		return new(structs.ACLRoleStreamEvent)
+	case structs.TopicACLAuthMethod:
+		return new(structs.ACLAuthMethodEvent)
+	case structs.TopicACLBindingRule:
+		return new(structs.ACLBindingRuleEvent)
+	case structs.TopicService:
+		return new(structs.ServiceRegistrationStreamEvent)
+	case structs.TopicHostVolume:
+		return new(structs.HostVolumeEvent)
+	case structs.TopicCSIVolume:
+		return new(structs.CSIVolumeEvent)
+	case structs.TopicCSIPlugin:
+		return new(structs.CSIPluginEvent)
+	case structs.TopicVariable:
+		return new(structs.VariableEvent)
+	default:
+		return nil
+	}
+}
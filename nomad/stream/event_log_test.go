@@ -0,0 +1,181 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package stream
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func testEventLogEvents(index uint64) *structs.Events {
+	return &structs.Events{Index: index, Events: []structs.Event{{
+		Index:   index,
+		Topic:   "Test",
+		Key:     "sub-key",
+		Payload: map[string]any{"Index": float64(index)},
+	}}}
+}
+
+func TestEventLog(t *testing.T) {
+	ci.Parallel(t)
+
+	path := filepath.Join(t.TempDir(), "events.db")
+	l, err := NewEventLog(testlog.HCLogger(t), path)
+	must.NoError(t, err)
+
+	// events are not written while the log is inactive
+	must.NoError(t, l.Append(testEventLogEvents(1)))
+	must.Eq(t, 0, l.LastIndex())
+
+	l.SetActive(true)
+	for i := uint64(2); i <= 5; i++ {
+		must.NoError(t, l.Append(testEventLogEvents(i)))
+	}
+	// empty events are never written
+	must.NoError(t, l.Append(&structs.Events{Index: 6}))
+	must.Eq(t, 5, l.LastIndex())
+	must.NoError(t, l.Sync())
+
+	events, err := l.Read(3, 5)
+	must.NoError(t, err)
+	must.Eq(t, []*structs.Events{testEventLogEvents(3), testEventLogEvents(4)}, events)
+
+	// the events appended while the log was inactive are missing
+	_, err = l.Read(1, 5)
+	must.ErrorIs(t, err, ErrCursorTruncated)
+
+	deleted, err := l.Truncate(3)
+	must.NoError(t, err)
+	must.Eq(t, 2, deleted)
+
+	events, err = l.Read(4, 10)
+	must.NoError(t, err)
+	must.Eq(t, []*structs.Events{testEventLogEvents(4), testEventLogEvents(5)}, events)
+
+	// reading from a truncated index fails
+	_, err = l.Read(3, 10)
+	must.ErrorIs(t, err, ErrCursorTruncated)
+
+	// the events, last index and truncated index are kept when the log is
+	// reopened
+	must.NoError(t, l.Close())
+	must.ErrorIs(t, l.Append(testEventLogEvents(6)), errEventLogClosed)
+
+	l, err = NewEventLog(testlog.HCLogger(t), path)
+	must.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+	must.False(t, l.Active())
+	must.Eq(t, 5, l.LastIndex())
+
+	events, err = l.Read(5, 10)
+	must.NoError(t, err)
+	must.Eq(t, []*structs.Events{testEventLogEvents(5)}, events)
+
+	_, err = l.Read(3, 10)
+	must.ErrorIs(t, err, ErrCursorTruncated)
+}
+
+func TestEventLog_Append_Concurrent(t *testing.T) {
+	ci.Parallel(t)
+
+	l, err := NewEventLog(testlog.HCLogger(t), filepath.Join(t.TempDir(), "events.db"))
+	must.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+	l.SetActive(true)
+
+	// events appended concurrently are all written, in batches
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Go(func() {
+			for j := range 200 {
+				must.NoError(t, l.Append(testEventLogEvents(uint64(i*200+j+1))))
+			}
+		})
+	}
+	wg.Wait()
+	must.NoError(t, l.Sync())
+	must.Eq(t, 800, l.LastIndex())
+
+	events, err := l.Read(1, 801)
+	must.NoError(t, err)
+	must.Len(t, 800, events)
+	for i, e := range events {
+		must.Eq(t, uint64(i+1), e.Index)
+	}
+}
+
+func TestEventLog_Read_TypedPayloads(t *testing.T) {
+	ci.Parallel(t)
+
+	l, err := NewEventLog(testlog.HCLogger(t), filepath.Join(t.TempDir(), "events.db"))
+	must.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+	l.SetActive(true)
+
+	job := &structs.Job{ID: "example", Namespace: "default", Priority: 50}
+	node := &structs.Node{ID: "node-1", Name: "node-1", Status: structs.NodeStatusReady}
+	must.NoError(t, l.Append(&structs.Events{Index: 1, Events: []structs.Event{
+		{Index: 1, Topic: structs.TopicJob, Key: job.ID, Payload: &structs.JobEvent{Job: job}},
+		{Index: 1, Topic: structs.TopicNode, Key: node.ID, Payload: &structs.NodeStreamEvent{Node: node}},
+		{Index: 1, Topic: structs.TopicEvaluation, Key: "eval"},
+	}}))
+	must.NoError(t, l.Sync())
+
+	// replayed events have the payload structs they were published with
+	events, err := l.Read(1, 2)
+	must.NoError(t, err)
+	must.Len(t, 1, events)
+	must.Len(t, 3, events[0].Events)
+
+	jobEvent, ok := events[0].Events[0].Payload.(*structs.JobEvent)
+	must.True(t, ok)
+	must.Eq(t, job, jobEvent.Job)
+
+	nodeEvent, ok := events[0].Events[1].Payload.(*structs.NodeStreamEvent)
+	must.True(t, ok)
+	must.Eq(t, node.ID, nodeEvent.Node.ID)
+	must.Eq(t, node.Status, nodeEvent.Node.Status)
+
+	must.Nil(t, events[0].Events[2].Payload)
+}
+
+func TestEventLog_Append_QueueFull(t *testing.T) {
+	ci.Parallel(t)
+
+	l, err := NewEventLog(testlog.HCLogger(t), filepath.Join(t.TempDir(), "events.db"))
+	must.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+	l.SetActive(true)
+
+	// hold the write transaction so that the queued events aren't written
+	tx, err := l.db.Begin(true)
+	must.NoError(t, err)
+
+	// appending never blocks, and events are dropped once the queue is full
+	var dropped uint64
+	for i := uint64(1); i <= 2*eventLogQueueSize && dropped == 0; i++ {
+		if err := l.Append(testEventLogEvents(i)); err != nil {
+			must.ErrorIs(t, err, errEventLogQueueFull)
+			dropped = i
+		}
+	}
+	must.Positive(t, dropped)
+	must.NoError(t, tx.Rollback())
+	must.NoError(t, l.Sync())
+
+	// reading from before the dropped events fails
+	_, err = l.Read(1, dropped+2)
+	must.ErrorIs(t, err, ErrCursorTruncated)
+	_, err = l.Read(dropped, dropped+2)
+	must.ErrorIs(t, err, ErrCursorTruncated)
+	events, err := l.Read(dropped+1, dropped+2)
+	must.NoError(t, err)
+	must.Len(t, 0, events)
+}
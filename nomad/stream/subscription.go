@@ -6,9 +6,11 @@ package stream
 import (
 	"context"
 	"errors"
+	"fmt"
 	"slices"
 	"sync/atomic"
 
+	"github.com/hashicorp/go-bexpr"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -27,6 +29,10 @@ const (
 // closed. The client should Unsubscribe, then re-Subscribe.
 var ErrSubscriptionClosed = errors.New("subscription closed by server, client should resubscribe")
 
+// ErrInvalidFilter is an error signalling the filter expression of a subscribe
+// request could not be parsed.
+var ErrInvalidFilter = errors.New("failed to read filter expression")
+
 type Subscription struct {
 	// state must be accessed atomically 0 means open, 1 means closed with reload
 	state uint32
@@ -70,6 +76,34 @@ type SubscribeRequest struct {
 	// topic/key/namespace matching. Returning false excludes the event
 	// from the subscription. It must be safe to call concurrently.
 	FilterFn func(event structs.Event) bool
+
+	// Replay, if true, replays the events from Index which are no longer in
+	// the event buffer from the event log of the broker, and excludes the
+	// events before Index.
+	Replay bool
+
+	// Filter, if non-empty, is a go-bexpr expression evaluated against each
+	// event that passes topic/key/namespace matching and FilterFn. Events the
+	// expression doesn't match, or can't be evaluated against because a
+	// selector doesn't exist in their payload, are excluded.
+	Filter string
+}
+
+// expressionFilterFn returns a FilterFn which excludes the events excluded by
+// filterFn, if non-nil, or not matched by the go-bexpr expression.
+func expressionFilterFn(expr string, filterFn func(structs.Event) bool) (func(structs.Event) bool, error) {
+	evaluator, err := bexpr.CreateEvaluator(expr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFilter, err)
+	}
+
+	return func(event structs.Event) bool {
+		if filterFn != nil && !filterFn(event) {
+			return false
+		}
+		match, err := evaluator.Evaluate(event)
+		return err == nil && match
+	}, nil
 }
 
 func newSubscription(req *SubscribeRequest, item *bufferItem, unsub func()) *Subscription {
@@ -96,6 +130,9 @@ func (s *Subscription) Next(ctx context.Context) (structs.Events, error) {
 		}
 		s.currentItem = next
 
+		if s.skip(next) {
+			continue
+		}
 		events := filter(s.req, next.Events.Events)
 		if len(events) == 0 {
 			continue
@@ -116,6 +153,9 @@ func (s *Subscription) NextNoBlock() ([]structs.Event, error) {
 		}
 		s.currentItem = next
 
+		if s.skip(next) {
+			continue
+		}
 		events := filter(s.req, next.Events.Events)
 		if len(events) == 0 {
 			continue
@@ -124,6 +164,12 @@ func (s *Subscription) NextNoBlock() ([]structs.Event, error) {
 	}
 }
 
+// skip returns whether the events of the item are before the requested index
+// of a replaying subscription.
+func (s *Subscription) skip(item *bufferItem) bool {
+	return s.req.Replay && item.Events.Index < s.req.Index
+}
+
 func (s *Subscription) Unsubscribe() {
 	s.unsub()
 }
@@ -205,3 +205,37 @@ func TestFilter_CustomFilter(t *testing.T) {
 	require.Equal(t, expected, actual)
 	require.Equal(t, 2, cap(actual))
 }
+
+func TestFilter_Expression(t *testing.T) {
+	ci.Parallel(t)
+
+	event1 := structs.Event{Topic: "Allocation", Key: "One", Namespace: "foo",
+		Payload: &structs.AllocationEvent{Allocation: &structs.Allocation{ClientStatus: "failed"}}}
+	event2 := structs.Event{Topic: "Allocation", Key: "Two", Namespace: "foo",
+		Payload: &structs.AllocationEvent{Allocation: &structs.Allocation{ClientStatus: "running"}}}
+	event3 := structs.Event{Topic: "Job", Key: "Three", Namespace: "foo",
+		Payload: &structs.JobEvent{Job: &structs.Job{ID: "Three"}}}
+	event4 := structs.Event{Topic: "Allocation", Key: "Four", Namespace: "bar",
+		Payload: &structs.AllocationEvent{Allocation: &structs.Allocation{ClientStatus: "failed"}}}
+	events := []structs.Event{event1, event2, event3, event4}
+
+	filterFn, err := expressionFilterFn(`Payload.Allocation.ClientStatus == "failed"`,
+		func(event structs.Event) bool { return event.Namespace == "foo" })
+	require.NoError(t, err)
+
+	req := &SubscribeRequest{
+		Topics: map[structs.Topic][]string{
+			"*": {"*"},
+		},
+		Namespaces: []string{"foo", "bar"},
+		FilterFn:   filterFn,
+	}
+	actual := filter(req, events)
+
+	// events of other payloads can't be evaluated and are excluded, as are
+	// events excluded by the custom filter
+	require.Equal(t, []structs.Event{event1}, actual)
+
+	_, err = expressionFilterFn(`Payload.Allocation.ClientStatus ==`, nil)
+	require.ErrorIs(t, err, ErrInvalidFilter)
+}
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"net/url"
 	"path"
 	"regexp"
 	"slices"
@@ -226,6 +227,10 @@ const (
 	// which uses the JWT type.
 	ACLAuthMethodTypeJWT = "JWT"
 
+	// ACLAuthMethodTypeLDAP the ACLAuthMethod.Type and represents an
+	// auth-method which authenticates users against an LDAP directory.
+	ACLAuthMethodTypeLDAP = "LDAP"
+
 	DefaultACLAuthMethodTokenNameFormat = "${auth_method_type}-${auth_method_name}"
 )
 
@@ -237,7 +242,7 @@ var (
 	ValidACLAuthMethod = regexp.MustCompile("^[a-zA-Z0-9-]{1,128}$")
 
 	// ValidACLAuthMethodTypes lists supported auth method types.
-	ValidACLAuthMethodTypes = []string{ACLAuthMethodTypeOIDC, ACLAuthMethodTypeJWT}
+	ValidACLAuthMethodTypes = []string{ACLAuthMethodTypeOIDC, ACLAuthMethodTypeJWT, ACLAuthMethodTypeLDAP}
 
 	// AnonymousACLToken is used when no SecretID is provided, and the request
 	// is made anonymously.
@@ -1269,6 +1274,24 @@ func (a *ACLAuthMethod) SetHash() []byte {
 		for _, key := range a.Config.JWTValidationPubKeys {
 			_, _ = hash.Write([]byte(key))
 		}
+		for _, u := range a.Config.LDAPURLs {
+			_, _ = hash.Write([]byte(u))
+		}
+		for _, pem := range a.Config.LDAPCACerts {
+			_, _ = hash.Write([]byte(pem))
+		}
+		_, _ = hash.Write([]byte(strconv.FormatBool(a.Config.LDAPStartTLS)))
+		_, _ = hash.Write([]byte(strconv.FormatBool(a.Config.LDAPInsecureTLS)))
+		_, _ = hash.Write([]byte(a.Config.LDAPClientTLSCert))
+		_, _ = hash.Write([]byte(a.Config.LDAPClientTLSKey))
+		_, _ = hash.Write([]byte(a.Config.LDAPBindDN))
+		_, _ = hash.Write([]byte(a.Config.LDAPBindPassword))
+		_, _ = hash.Write([]byte(a.Config.LDAPUserDN))
+		_, _ = hash.Write([]byte(a.Config.LDAPUserAttr))
+		_, _ = hash.Write([]byte(a.Config.LDAPUserFilter))
+		_, _ = hash.Write([]byte(a.Config.LDAPGroupDN))
+		_, _ = hash.Write([]byte(a.Config.LDAPGroupAttr))
+		_, _ = hash.Write([]byte(a.Config.LDAPGroupFilter))
 		for k, v := range a.Config.ClaimMappings {
 			_, _ = hash.Write([]byte(k))
 			_, _ = hash.Write([]byte(v))
@@ -1464,6 +1487,12 @@ func (a *ACLAuthMethod) Sanitize() *ACLAuthMethod {
 	if clean.Config.OIDCClientSecret != "" {
 		clean.Config.OIDCClientSecret = "redacted"
 	}
+	if clean.Config.LDAPBindPassword != "" {
+		clean.Config.LDAPBindPassword = "redacted"
+	}
+	if clean.Config.LDAPClientTLSKey != "" {
+		clean.Config.LDAPClientTLSKey = "redacted"
+	}
 	if clean.Config.OIDCClientAssertion != nil {
 		// this ClientSecret gets inherited by the above one
 		if clean.Config.OIDCClientAssertion.ClientSecret != "" {
@@ -1546,6 +1575,53 @@ type ACLAuthMethodConfig struct {
 	// clock skew.
 	ClockSkewLeeway time.Duration
 
+	// A list of LDAP server URLs, using the ldap:// or ldaps:// scheme, which
+	// are tried in order
+	LDAPURLs []string
+
+	// Issue a StartTLS command after connecting to ldap:// URLs
+	LDAPStartTLS bool
+
+	// Skip the verification of the LDAP server certificates
+	LDAPInsecureTLS bool
+
+	// PEM encoded CA certs for use by the TLS client used to talk with the
+	// LDAP servers
+	LDAPCACerts []string
+
+	// PEM encoded client certificate and key used for mutual TLS with the
+	// LDAP servers
+	LDAPClientTLSCert string
+	LDAPClientTLSKey  string
+
+	// The DN and password used to search for the user entry and the groups of
+	// the user. When unset, the DN of the user entry is built from
+	// LDAPUserAttr and LDAPUserDN instead of being searched for.
+	LDAPBindDN       string
+	LDAPBindPassword string
+
+	// The base DN under which to search for users
+	LDAPUserDN string
+
+	// The attribute of the user entries matched against the username,
+	// defaults to "cn"
+	LDAPUserAttr string
+
+	// An optional go template used to build the user search filter when
+	// LDAPBindDN is set, such as "({{.UserAttr}}={{.Username}})"
+	LDAPUserFilter string
+
+	// The base DN under which to search for groups
+	LDAPGroupDN string
+
+	// The attribute of the group entries used as the group names, defaults to
+	// "cn"
+	LDAPGroupAttr string
+
+	// An optional go template used to build the group membership search
+	// filter, such as "(member={{.UserDN}})"
+	LDAPGroupFilter string
+
 	// Mappings of claims (key) that will be copied to a metadata field
 	// (value).
 	ClaimMappings     map[string]string
@@ -1596,6 +1672,33 @@ func (a *ACLAuthMethodConfig) Validate(methodType string) error {
 				"JWT auth method requires either OIDCDiscoveryURL, or JWKS URL, or JWTValidationPubKeys set"),
 			)
 		}
+
+	case ACLAuthMethodTypeLDAP:
+		if len(a.LDAPURLs) == 0 {
+			mErr = multierror.Append(mErr, errors.New("missing LDAPURLs"))
+		}
+		for _, rawURL := range a.LDAPURLs {
+			u, err := url.Parse(rawURL)
+			if err != nil || (u.Scheme != "ldap" && u.Scheme != "ldaps") || u.Host == "" {
+				mErr = multierror.Append(mErr, fmt.Errorf("invalid LDAP URL %q", rawURL))
+				continue
+			}
+			if u.Scheme == "ldaps" && a.LDAPStartTLS {
+				mErr = multierror.Append(mErr, fmt.Errorf(
+					"LDAPStartTLS cannot be used with ldaps:// URL %q", rawURL))
+			}
+		}
+		if a.LDAPUserDN == "" {
+			mErr = multierror.Append(mErr, errors.New("missing LDAPUserDN"))
+		}
+		if (a.LDAPBindDN == "") != (a.LDAPBindPassword == "") {
+			mErr = multierror.Append(mErr, errors.New(
+				"LDAPBindDN and LDAPBindPassword must be set together"))
+		}
+		if (a.LDAPClientTLSCert == "") != (a.LDAPClientTLSKey == "") {
+			mErr = multierror.Append(mErr, errors.New(
+				"LDAPClientTLSCert and LDAPClientTLSKey must be set together"))
+		}
 	}
 
 	return helper.FlattenMultierror(mErr)
@@ -1616,6 +1719,8 @@ func (a *ACLAuthMethodConfig) Copy() *ACLAuthMethodConfig {
 	c.AllowedRedirectURIs = slices.Clone(a.AllowedRedirectURIs)
 	c.DiscoveryCaPem = slices.Clone(a.DiscoveryCaPem)
 	c.SigningAlgs = slices.Clone(a.SigningAlgs)
+	c.LDAPURLs = slices.Clone(a.LDAPURLs)
+	c.LDAPCACerts = slices.Clone(a.LDAPCACerts)
 	c.OIDCClientAssertion = a.OIDCClientAssertion.Copy()
 
 	return c
@@ -2407,9 +2512,15 @@ type ACLLoginRequest struct {
 	AuthMethodName string
 
 	// LoginToken is the 3rd party token that we use to exchange for Nomad ACL
-	// Token in order to authenticate. This is a required parameter.
+	// Token in order to authenticate. This is a required parameter, unless
+	// logging in with a username and password.
 	LoginToken string
 
+	// Username and Password are the credentials used to login to auth methods
+	// which authenticate users directly, such as LDAP.
+	Username string
+	Password string
+
 	WriteRequest
 }
 
@@ -2422,7 +2533,12 @@ func (a *ACLLoginRequest) Validate() error {
 	if a.AuthMethodName == "" {
 		mErr.Errors = append(mErr.Errors, errors.New("missing auth method name"))
 	}
-	if a.LoginToken == "" {
+	switch {
+	case a.Username != "":
+		if a.Password == "" {
+			mErr.Errors = append(mErr.Errors, errors.New("missing password"))
+		}
+	case a.LoginToken == "":
 		mErr.Errors = append(mErr.Errors, errors.New("missing login token"))
 	}
 	return mErr.ErrorOrNil()
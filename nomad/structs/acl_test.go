@@ -1386,6 +1386,17 @@ func TestACLAuthMethod_Sanitize(t *testing.T) {
 		must.Eq(t, "redacted", clean)
 	})
 
+	t.Run("ldap", func(t *testing.T) {
+		am := am.Copy()
+		am.Config.LDAPBindPassword = "very private password"
+		am.Config.LDAPClientTLSKey = "very private key"
+		clean := am.Sanitize().Config
+		must.Eq(t, "very private password", am.Config.LDAPBindPassword)
+		must.Eq(t, "very private key", am.Config.LDAPClientTLSKey)
+		must.Eq(t, "redacted", clean.LDAPBindPassword)
+		must.Eq(t, "redacted", clean.LDAPClientTLSKey)
+	})
+
 }
 
 func TestACLAuthMethod_Merge(t *testing.T) {
@@ -1471,6 +1482,34 @@ func TestACLAuthMethodConfig_Validate(t *testing.T) {
 	// valid JWT method config
 	validJWT := &ACLAuthMethodConfig{JWKSURL: "http://example.com"}
 	must.NoError(t, validJWT.Validate(ACLAuthMethodTypeJWT))
+
+	// invalid LDAP method configs
+	err = (&ACLAuthMethodConfig{}).Validate(ACLAuthMethodTypeLDAP)
+	must.ErrorContains(t, err, "missing LDAPURLs")
+	must.ErrorContains(t, err, "missing LDAPUserDN")
+
+	invalidLDAP := &ACLAuthMethodConfig{
+		LDAPURLs:          []string{"http://example.com", "ldaps://example.com"},
+		LDAPStartTLS:      true,
+		LDAPUserDN:        "ou=people,dc=example,dc=com",
+		LDAPBindDN:        "cn=admin,dc=example,dc=com",
+		LDAPClientTLSCert: "cert",
+	}
+	err = invalidLDAP.Validate(ACLAuthMethodTypeLDAP)
+	must.ErrorContains(t, err, `invalid LDAP URL "http://example.com"`)
+	must.ErrorContains(t, err, "LDAPStartTLS cannot be used with ldaps:// URL")
+	must.ErrorContains(t, err, "LDAPBindDN and LDAPBindPassword must be set together")
+	must.ErrorContains(t, err, "LDAPClientTLSCert and LDAPClientTLSKey must be set together")
+
+	// valid LDAP method config
+	validLDAP := &ACLAuthMethodConfig{
+		LDAPURLs:         []string{"ldap://example.com"},
+		LDAPStartTLS:     true,
+		LDAPUserDN:       "ou=people,dc=example,dc=com",
+		LDAPBindDN:       "cn=admin,dc=example,dc=com",
+		LDAPBindPassword: "password",
+	}
+	must.NoError(t, validLDAP.Validate(ACLAuthMethodTypeLDAP))
 }
 
 func TestACLAuthMethodConfig_Copy(t *testing.T) {
@@ -24,6 +24,14 @@ const (
 	// AllocTimeoutReasonMaxRunDuration is the reason used when an allocation is
 	// stopped because it exceeded its configured max_run_duration.
 	AllocTimeoutReasonMaxRunDuration = "allocation exceeded max_run_duration"
+
+	// AllocFailedReasonDiskLimit is the reason used when an allocation is
+	// stopped because it exceeded its ephemeral_disk size.
+	AllocFailedReasonDiskLimit = "allocation exceeded ephemeral_disk size"
+
+	// AllocFailedReasonMemoryPressure is the reason used when an allocation
+	// is evicted by the client because the node ran out of memory.
+	AllocFailedReasonMemoryPressure = "allocation evicted due to node memory pressure"
 )
 
 const (
@@ -436,6 +444,16 @@ func (a *Allocation) ClientTerminalStatus() bool {
 	return slices.Contains(terminalAllocationStatuses, a.ClientStatus)
 }
 
+// AvailableForDisruptionBudget returns whether the allocation is running,
+// healthy, and not already being migrated, and so counts as available toward
+// its task group's disruption budget.
+func (a *Allocation) AvailableForDisruptionBudget() bool {
+	return !a.TerminalStatus() &&
+		a.ClientStatus == AllocClientStatusRunning &&
+		a.DeploymentStatus.IsHealthy() &&
+		!a.DesiredTransition.ShouldMigrate()
+}
+
 // ShouldReschedule returns if the allocation is eligible to be rescheduled according
 // to its status and ReschedulePolicy given its failure time
 func (a *Allocation) ShouldReschedule(reschedulePolicy *ReschedulePolicy, failTime time.Time) bool {
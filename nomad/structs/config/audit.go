@@ -4,6 +4,7 @@
 package config
 
 import (
+	"maps"
 	"slices"
 	"time"
 )
@@ -32,7 +33,7 @@ type AuditSink struct {
 	// be met in order to successfully make requests
 	DeliveryGuarantee string `hcl:"delivery_guarantee"`
 
-	// Type is the sink type to configure. (file)
+	// Type is the sink type to configure. (file, http)
 	Type string `hcl:"type"`
 
 	// Format is the sink output format. (json)
@@ -54,6 +55,12 @@ type AuditSink struct {
 
 	// Mode is the octal formatted permissions for the audit log files.
 	Mode string `hcl:"mode"`
+
+	// Address is the URL audit events are posted to by http sinks.
+	Address string `hcl:"address"`
+
+	// Headers are added to the requests of http sinks.
+	Headers map[string]string `hcl:"headers"`
 }
 
 // AuditFilter is the configuration for a Audit Log Filter
@@ -128,6 +135,8 @@ func (a *AuditSink) Copy() *AuditSink {
 	nc := new(AuditSink)
 	*nc = *a
 
+	nc.Headers = maps.Clone(nc.Headers)
+
 	return nc
 }
 
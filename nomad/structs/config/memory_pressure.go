@@ -0,0 +1,55 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import "github.com/hashicorp/nomad/helper/pointer"
+
+// MemoryPressureConfig describes how a client evicts allocations when the
+// node runs out of memory because of memory oversubscription.
+type MemoryPressureConfig struct {
+	// Enabled enables evicting allocations under memory pressure.
+	Enabled *bool `hcl:"enabled"`
+
+	// Threshold is the percentage of time that all tasks were stalled
+	// waiting on memory above which an allocation is evicted.
+	Threshold *float64 `hcl:"threshold"`
+
+	// Window is the window the stall time is averaged over, one of the
+	// 10s, 60s or 5m windows of the kernel's pressure stall information.
+	Window *string `hcl:"window"`
+}
+
+func (m *MemoryPressureConfig) Copy() *MemoryPressureConfig {
+	if m == nil {
+		return nil
+	}
+
+	nm := new(MemoryPressureConfig)
+	*nm = *m
+	nm.Enabled = pointer.Copy(m.Enabled)
+	nm.Threshold = pointer.Copy(m.Threshold)
+	nm.Window = pointer.Copy(m.Window)
+	return nm
+}
+
+func (m *MemoryPressureConfig) Merge(o *MemoryPressureConfig) *MemoryPressureConfig {
+	switch {
+	case m == nil:
+		return o.Copy()
+	case o == nil:
+		return m.Copy()
+	default:
+		nm := m.Copy()
+		if o.Enabled != nil {
+			nm.Enabled = pointer.Copy(o.Enabled)
+		}
+		if o.Threshold != nil {
+			nm.Threshold = pointer.Copy(o.Threshold)
+		}
+		if o.Window != nil {
+			nm.Window = pointer.Copy(o.Window)
+		}
+		return nm
+	}
+}
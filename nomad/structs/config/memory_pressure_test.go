@@ -0,0 +1,81 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestMemoryPressureConfig_Copy(t *testing.T) {
+	ci.Parallel(t)
+
+	var nilConfig *MemoryPressureConfig
+	must.Nil(t, nilConfig.Copy())
+
+	c := &MemoryPressureConfig{
+		Enabled:   new(true),
+		Threshold: new(20.0),
+		Window:    new("60s"),
+	}
+	cc := c.Copy()
+	must.Eq(t, c, cc)
+
+	*cc.Enabled = false
+	*cc.Threshold = 30
+	must.True(t, *c.Enabled)
+	must.Eq(t, 20.0, *c.Threshold)
+}
+
+func TestMemoryPressureConfig_Merge(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name     string
+		input    *MemoryPressureConfig
+		merge    *MemoryPressureConfig
+		expected *MemoryPressureConfig
+	}{
+		{
+			name:     "nil",
+			input:    nil,
+			merge:    nil,
+			expected: nil,
+		},
+		{
+			name:  "nil input",
+			input: nil,
+			merge: &MemoryPressureConfig{
+				Enabled: new(true),
+			},
+			expected: &MemoryPressureConfig{
+				Enabled: new(true),
+			},
+		},
+		{
+			name: "partial",
+			input: &MemoryPressureConfig{
+				Enabled:   new(true),
+				Threshold: new(20.0),
+			},
+			merge: &MemoryPressureConfig{
+				Enabled: new(false),
+				Window:  new("5m"),
+			},
+			expected: &MemoryPressureConfig{
+				Enabled:   new(false),
+				Threshold: new(20.0),
+				Window:    new("5m"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.expected, tc.input.Merge(tc.merge))
+		})
+	}
+}
@@ -26,8 +26,65 @@ var (
 		"${device.",
 		"${meta.",
 	}
+
+	// validConstraintAllocExactTargets are the exact targets that resolve
+	// against the allocations placed on a node, rather than the node itself.
+	validConstraintAllocExactTargets = []string{
+		"${allocs.job_id}",
+		"${allocs.task_group}",
+	}
+
+	// validConstraintAllocPrefixTargets are the valid prefixes on constraint
+	// targets that resolve against the allocations placed on a node.
+	validConstraintAllocPrefixTargets = []string{
+		"${allocs.meta.",
+	}
+
+	// validAllocTargetOperands are the operands which can be used with a
+	// target that resolves against the allocations placed on a node. These
+	// targets resolve to the set of values found across all allocations, so
+	// only the set operators are meaningful.
+	validAllocTargetOperands = []string{
+		ConstraintSetContains,
+		ConstraintSetContainsAll,
+		ConstraintSetContainsAny,
+		ConstraintSetContainsNone,
+	}
 )
 
+// IsAllocTarget returns whether the constraint or affinity target resolves
+// against the allocations placed on a node, such as "${allocs.job_id}". These
+// targets depend on the proposed allocations for a node and therefore cannot
+// be evaluated using the node or its computed class alone.
+//
+// Only allocations in the same namespace as the job being scheduled are
+// considered, so a job cannot be placed relative to, or learn about, the
+// allocations of jobs in other namespaces.
+func IsAllocTarget(target string) bool {
+	if slices.Contains(validConstraintAllocExactTargets, target) {
+		return true
+	}
+	for _, prefix := range validConstraintAllocPrefixTargets {
+		if strings.HasPrefix(target, prefix) && strings.HasSuffix(target, "}") {
+			return true
+		}
+	}
+	return false
+}
+
+// validateAllocTargetOperand ensures a constraint or affinity that targets the
+// allocations placed on a node uses one of the supported set operators.
+func validateAllocTargetOperand(target, operand string) error {
+	if !IsAllocTarget(target) {
+		return nil
+	}
+	if !slices.Contains(validAllocTargetOperands, operand) {
+		return fmt.Errorf("attribute %q only supports the %s operators",
+			target, strings.Join(validAllocTargetOperands, ", "))
+	}
+	return nil
+}
+
 // validateConstraintAttribute ensures the constraint attribute is valid. It
 // does this by ensuring any interpolated field can be handled by the
 // resolveTarget function.
@@ -49,7 +106,7 @@ func validateConstraintAttribute(target string) error {
 
 	// Perform our exact target matching first. If the target does not hit this
 	// exact match, we will fall through to the prefix match check.
-	if slices.Contains(validConstraintExactTargets, target) {
+	if slices.Contains(validConstraintExactTargets, target) || IsAllocTarget(target) {
 		return nil
 	}
 
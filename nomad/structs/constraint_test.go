@@ -87,6 +87,21 @@ func TestValidateConstraintTarget(t *testing.T) {
 			inputTarget:      "${device.type}",
 			expectedErrorMsg: "",
 		},
+		{
+			name:             "valid allocs.job_id",
+			inputTarget:      "${allocs.job_id}",
+			expectedErrorMsg: "",
+		},
+		{
+			name:             "valid allocs.meta.team",
+			inputTarget:      "${allocs.meta.team}",
+			expectedErrorMsg: "",
+		},
+		{
+			name:             "invalid allocs attribute",
+			inputTarget:      "${allocs.node}",
+			expectedErrorMsg: `unsupported attribute "${allocs.node}"`,
+		},
 		{
 			name:             "missing closing brace",
 			inputTarget:      "${node.datacenter",
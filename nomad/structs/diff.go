@@ -299,6 +299,12 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 		diff.Objects = append(diff.Objects, migrateDiff)
 	}
 
+	// Disruption budget diff
+	budgetDiff := primitiveObjectDiff(tg.DisruptionBudget, other.DisruptionBudget, nil, "DisruptionBudget", contextual)
+	if budgetDiff != nil {
+		diff.Objects = append(diff.Objects, budgetDiff)
+	}
+
 	// Reschedule policy diff
 	reschedDiff := primitiveObjectDiff(tg.ReschedulePolicy, other.ReschedulePolicy, nil, "ReschedulePolicy", contextual)
 	if reschedDiff != nil {
@@ -317,8 +323,7 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 	}
 
 	// Update diff
-	// COMPAT: Remove "Stagger" in 0.7.0.
-	if uDiff := primitiveObjectDiff(tg.Update, other.Update, []string{"Stagger"}, "Update", contextual); uDiff != nil {
+	if uDiff := updateStrategyDiff(tg.Update, other.Update, contextual); uDiff != nil {
 		diff.Objects = append(diff.Objects, uDiff)
 	}
 
@@ -504,7 +509,7 @@ func (t *Task) Diff(other *Task, contextual bool) (*TaskDiff, error) {
 	}
 
 	// LogConfig diff
-	lDiff := primitiveObjectDiff(t.LogConfig, other.LogConfig, nil, "LogConfig", contextual)
+	lDiff := logConfigDiff(t.LogConfig, other.LogConfig, contextual)
 	if lDiff != nil {
 		diff.Objects = append(diff.Objects, lDiff)
 	}
@@ -604,6 +609,80 @@ func secretsDiff(old, new *Secret, contextual bool) *ObjectDiff {
 	return diff
 }
 
+// logConfigDiff returns the diff of a log config and its sinks.
+func logConfigDiff(old, new *LogConfig, contextual bool) *ObjectDiff {
+	diff := primitiveObjectDiff(old, new, nil, "LogConfig", contextual)
+
+	var oldSinks, newSinks []*LogSink
+	if old != nil {
+		oldSinks = old.Sinks
+	}
+	if new != nil {
+		newSinks = new.Sinks
+	}
+	sinkDiffs := logSinkDiffs(oldSinks, newSinks, contextual)
+	if len(sinkDiffs) == 0 {
+		return diff
+	}
+	if diff == nil {
+		diff = &ObjectDiff{Type: DiffTypeEdited, Name: "LogConfig"}
+	}
+	diff.Objects = append(diff.Objects, sinkDiffs...)
+	return diff
+}
+
+func logSinkDiff(old, new *LogSink, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Sink"}
+	if reflect.DeepEqual(old, new) {
+		return nil
+	} else if old == nil {
+		old = &LogSink{}
+		diff.Type = DiffTypeAdded
+	} else if new == nil {
+		new = &LogSink{}
+		diff.Type = DiffTypeDeleted
+	} else {
+		diff.Type = DiffTypeEdited
+	}
+
+	oldFlat := flatmap.Flatten(old, nil, false)
+	newFlat := flatmap.Flatten(new, nil, false)
+	diff.Fields = fieldDiffs(oldFlat, newFlat, contextual)
+	return diff
+}
+
+// logSinkDiffs diffs a set of log sinks. The comparator for whether a sink is
+// new/edited/deleted is the sink Type and Address fields.
+func logSinkDiffs(old, new []*LogSink, contextual bool) []*ObjectDiff {
+	var diffs []*ObjectDiff
+
+	key := func(s *LogSink) string { return s.Type + "|" + s.Address }
+	oldMap := map[string]*LogSink{}
+	newMap := map[string]*LogSink{}
+	for _, o := range old {
+		oldMap[key(o)] = o
+	}
+	for _, n := range new {
+		newMap[key(n)] = n
+	}
+
+	for k, v := range oldMap {
+		if diff := logSinkDiff(v, newMap[k], contextual); diff != nil {
+			diffs = append(diffs, diff)
+		}
+	}
+	for k, v := range newMap {
+		if _, ok := oldMap[k]; !ok {
+			if diff := logSinkDiff(nil, v, contextual); diff != nil {
+				diffs = append(diffs, diff)
+			}
+		}
+	}
+
+	sort.Sort(ObjectDiffs(diffs))
+	return diffs
+}
+
 // secretsDiffs diffs a set of secrets. The comparator for whether a secret
 // is new/edited/deleted is the secret Name field.
 func secretsDiffs(old, new []*Secret, contextual bool) []*ObjectDiff {
@@ -1769,7 +1848,7 @@ func sidecarTaskDiff(old, new *SidecarTask, contextual bool) *ObjectDiff {
 	}
 
 	// LogConfig diff
-	lDiff := primitiveObjectDiff(old.LogConfig, new.LogConfig, nil, "LogConfig", contextual)
+	lDiff := logConfigDiff(old.LogConfig, new.LogConfig, contextual)
 	if lDiff != nil {
 		diff.Objects = append(diff.Objects, lDiff)
 	}
@@ -2713,6 +2792,16 @@ func (n *NetworkResource) Diff(other *NetworkResource, contextual bool) *ObjectD
 		diff.Objects = append(diff.Objects, cniDiff)
 	}
 
+	// Upstream diffs
+	if upstreamDiffs := primitiveObjectSetDiff(
+		interfaceSlice(n.Upstreams),
+		interfaceSlice(other.Upstreams),
+		nil,
+		"Upstream",
+		contextual); upstreamDiffs != nil {
+		diff.Objects = append(diff.Objects, upstreamDiffs...)
+	}
+
 	return diff
 }
 
@@ -2795,6 +2884,65 @@ func disconectStrategyDiffs(old, new *DisconnectStrategy, contextual bool) *Obje
 	return diff
 }
 
+// updateStrategyDiff returns the diff of two update strategies, including
+// their update windows. If contextual diff is enabled, non-changed fields will
+// still be returned.
+func updateStrategyDiff(old, new *UpdateStrategy, contextual bool) *ObjectDiff {
+	// COMPAT: Remove "Stagger" in 0.7.0.
+	diff := primitiveObjectDiff(old, new, []string{"Stagger"}, "Update", contextual)
+
+	var oldWindows, newWindows []*UpdateWindow
+	var oldAnalysis, newAnalysis *UpdateAnalysis
+	if old != nil {
+		oldWindows = old.Windows
+		oldAnalysis = old.Analysis
+	}
+	if new != nil {
+		newWindows = new.Windows
+		newAnalysis = new.Analysis
+	}
+	objDiffs := primitiveObjectSetDiff(
+		interfaceSlice(oldWindows),
+		interfaceSlice(newWindows),
+		nil, "Window", contextual)
+	if aDiff := updateAnalysisDiff(oldAnalysis, newAnalysis, contextual); aDiff != nil {
+		objDiffs = append(objDiffs, aDiff)
+	}
+	if len(objDiffs) == 0 {
+		return diff
+	}
+
+	if diff == nil {
+		diff = &ObjectDiff{Type: DiffTypeEdited, Name: "Update"}
+	}
+	diff.Objects = append(diff.Objects, objDiffs...)
+	return diff
+}
+
+// updateAnalysisDiff returns the diff of two update analyses. If contextual
+// diff is enabled, non-changed fields will still be returned.
+func updateAnalysisDiff(old, new *UpdateAnalysis, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Analysis"}
+	var oldFlat, newFlat map[string]string
+
+	if reflect.DeepEqual(old, new) {
+		return nil
+	} else if old == nil {
+		diff.Type = DiffTypeAdded
+		newFlat = flatmap.Flatten(new, nil, false)
+	} else if new == nil {
+		diff.Type = DiffTypeDeleted
+		oldFlat = flatmap.Flatten(old, nil, false)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldFlat = flatmap.Flatten(old, nil, false)
+		newFlat = flatmap.Flatten(new, nil, false)
+	}
+
+	diff.Fields = fieldDiffs(oldFlat, newFlat, contextual)
+	return diff
+}
+
 // networkResourceDiffs diffs a set of NetworkResources. If contextual diff is enabled,
 // non-changed fields will still be returned.
 func networkResourceDiffs(old, new []*NetworkResource, contextual bool) []*ObjectDiff {
@@ -527,12 +527,24 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeAdded,
 						Name: "Periodic",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "ChildHistoryLimit",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Enabled",
 								Old:  "",
 								New:  "false",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "MaxMissedRuns",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "ProhibitOverlap",
@@ -551,6 +563,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  "",
 								New:  "foo",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "StartingDeadline",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "TimeZone",
@@ -581,12 +599,24 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeAdded,
 						Name: "Periodic",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "ChildHistoryLimit",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Enabled",
 								Old:  "",
 								New:  "false",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "MaxMissedRuns",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "ProhibitOverlap",
@@ -599,6 +629,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  "",
 								New:  "foo",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "StartingDeadline",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "TimeZone",
@@ -649,12 +685,24 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeDeleted,
 						Name: "Periodic",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeDeleted,
+								Name: "ChildHistoryLimit",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Enabled",
 								Old:  "false",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "MaxMissedRuns",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "ProhibitOverlap",
@@ -673,6 +721,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  "foo",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "StartingDeadline",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "TimeZone",
@@ -697,11 +751,16 @@ func TestJobDiff(t *testing.T) {
 			},
 			New: &Job{
 				Periodic: &PeriodicConfig{
-					Enabled:         true,
-					Spec:            "* * * * * *",
-					SpecType:        "cron",
-					ProhibitOverlap: true,
-					TimeZone:        "America/Los_Angeles",
+					Enabled:           true,
+					Spec:              "* * * * * *",
+					SpecType:          "cron",
+					ProhibitOverlap:   true,
+					TimeZone:          "America/Los_Angeles",
+					ConcurrencyPolicy: "forbid",
+					MissedRuns:        "run-all",
+					MaxMissedRuns:     2,
+					StartingDeadline:  time.Hour,
+					ChildHistoryLimit: 3,
 				},
 			},
 			Expected: &JobDiff{
@@ -711,12 +770,36 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "Periodic",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "ChildHistoryLimit",
+								Old:  "0",
+								New:  "3",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "ConcurrencyPolicy",
+								Old:  "",
+								New:  "forbid",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Enabled",
 								Old:  "false",
 								New:  "true",
 							},
+							{
+								Type: DiffTypeEdited,
+								Name: "MaxMissedRuns",
+								Old:  "0",
+								New:  "2",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "MissedRuns",
+								Old:  "",
+								New:  "run-all",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "ProhibitOverlap",
@@ -735,6 +818,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  "foo",
 								New:  "cron",
 							},
+							{
+								Type: DiffTypeEdited,
+								Name: "StartingDeadline",
+								Old:  "0",
+								New:  "3600000000000",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "TimeZone",
@@ -1026,12 +1115,36 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "Periodic",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeNone,
+								Name: "ChildHistoryLimit",
+								Old:  "0",
+								New:  "0",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "ConcurrencyPolicy",
+								Old:  "",
+								New:  "",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Enabled",
 								Old:  "false",
 								New:  "true",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "MaxMissedRuns",
+								Old:  "0",
+								New:  "0",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "MissedRuns",
+								Old:  "",
+								New:  "",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "ProhibitOverlap",
@@ -1050,6 +1163,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  "foo",
 								New:  "foo",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "StartingDeadline",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "TimeZone",
@@ -2227,6 +2346,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "",
 								New:  "1000000000",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "MaxDelay",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Mode",
@@ -2280,6 +2405,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "1000000000",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "MaxDelay",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Mode",
@@ -2398,12 +2529,24 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "1000000000",
 								New:  "1000000000",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "DelayFunction",
+								Old:  "",
+								New:  "",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Interval",
 								Old:  "1000000000",
 								New:  "2000000000",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "MaxDelay",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "Mode",
@@ -3193,6 +3336,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "",
 								New:  "true",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "PreCopy",
+								Old:  "",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "SizeMB",
@@ -3233,6 +3382,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "true",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "PreCopy",
+								Old:  "false",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "SizeMB",
@@ -3309,9 +3464,10 @@ func TestTaskGroupDiff(t *testing.T) {
 			},
 			New: &TaskGroup{
 				EphemeralDisk: &EphemeralDisk{
-					Migrate: true,
-					Sticky:  true,
-					SizeMB:  90,
+					Migrate:     true,
+					Sticky:      true,
+					SizeMB:      90,
+					Enforcement: EphemeralDiskEnforcementHard,
 				},
 			},
 			Expected: &TaskGroupDiff{
@@ -3321,12 +3477,24 @@ func TestTaskGroupDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "EphemeralDisk",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "Enforcement",
+								Old:  "",
+								New:  "hard",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Migrate",
 								Old:  "false",
 								New:  "true",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "PreCopy",
+								Old:  "false",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "SizeMB",
@@ -3711,6 +3879,12 @@ func TestTaskGroupDiff(t *testing.T) {
 										Old:  "",
 										New:  "",
 									},
+									{
+										Type: DiffTypeNone,
+										Name: "OnStart",
+										Old:  "false",
+										New:  "false",
+									},
 									{
 										Type: DiffTypeNone,
 										Name: "OnUpdate",
@@ -3735,6 +3909,12 @@ func TestTaskGroupDiff(t *testing.T) {
 										Old:  "http",
 										New:  "tcp",
 									},
+									{
+										Type: DiffTypeNone,
+										Name: "StartupTimeout",
+										Old:  "0",
+										New:  "0",
+									},
 									{
 										Type: DiffTypeEdited,
 										Name: "SuccessBeforePassing",
@@ -5083,6 +5263,60 @@ func TestTaskGroupDiff(t *testing.T) {
 				},
 			},
 		},
+		{TestCase: "TaskGroup upstream added",
+			Contextual: false,
+			Old: &TaskGroup{
+				Networks: Networks{},
+			},
+			New: &TaskGroup{
+				Networks: Networks{
+					{
+						Upstreams: NetworkUpstreams{
+							{
+								DestinationName:  "db",
+								LocalBindAddress: "127.0.0.1",
+								LocalBindPort:    5432,
+							},
+						},
+					},
+				},
+			},
+			Expected: &TaskGroupDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeAdded,
+						Name: "Network",
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "Upstream",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "DestinationName",
+										Old:  "",
+										New:  "db",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "LocalBindAddress",
+										Old:  "",
+										New:  "127.0.0.1",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "LocalBindPort",
+										Old:  "",
+										New:  "5432",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{TestCase: "TaskGroup CNI deleted",
 			Contextual: false,
 			Old: &TaskGroup{
@@ -5770,6 +6004,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "",
 								New:  "1",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "RotationInterval",
+								Old:  "",
+								New:  "0",
+							},
 						},
 					},
 				},
@@ -5810,6 +6050,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "1",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "RotationInterval",
+								Old:  "0",
+								New:  "",
+							},
 						},
 					},
 				},
@@ -5885,6 +6131,10 @@ func TestTaskDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "LogConfig",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeNone,
+								Name: "Compression",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Disabled",
@@ -5903,6 +6153,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "1",
 								New:  "1",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "RotationInterval",
+								Old:  "0",
+								New:  "0",
+							},
 						},
 					},
 				},
@@ -7575,6 +7831,12 @@ func TestTaskDiff(t *testing.T) {
 										Old:  "",
 										New:  "bam",
 									},
+									{
+										Type: DiffTypeAdded,
+										Name: "OnStart",
+										Old:  "",
+										New:  "false",
+									},
 									{
 										Type: DiffTypeAdded,
 										Name: "Path",
@@ -7587,6 +7849,12 @@ func TestTaskDiff(t *testing.T) {
 										Old:  "",
 										New:  "http",
 									},
+									{
+										Type: DiffTypeAdded,
+										Name: "StartupTimeout",
+										Old:  "",
+										New:  "0",
+									},
 									{
 										Type: DiffTypeAdded,
 										Name: "SuccessBeforePassing",
@@ -7659,6 +7927,12 @@ func TestTaskDiff(t *testing.T) {
 										Old:  "foo",
 										New:  "",
 									},
+									{
+										Type: DiffTypeDeleted,
+										Name: "OnStart",
+										Old:  "false",
+										New:  "",
+									},
 									{
 										Type: DiffTypeDeleted,
 										Name: "Path",
@@ -7671,6 +7945,12 @@ func TestTaskDiff(t *testing.T) {
 										Old:  "http",
 										New:  "",
 									},
+									{
+										Type: DiffTypeDeleted,
+										Name: "StartupTimeout",
+										Old:  "0",
+										New:  "",
+									},
 									{
 										Type: DiffTypeDeleted,
 										Name: "SuccessBeforePassing",
@@ -7927,6 +8207,12 @@ func TestTaskDiff(t *testing.T) {
 										Old:  "a note",
 										New:  "another note",
 									},
+									{
+										Type: DiffTypeNone,
+										Name: "OnStart",
+										Old:  "false",
+										New:  "false",
+									},
 									{
 										Type: DiffTypeEdited,
 										Name: "OnUpdate",
@@ -7951,6 +8237,12 @@ func TestTaskDiff(t *testing.T) {
 										Old:  "http",
 										New:  "http",
 									},
+									{
+										Type: DiffTypeNone,
+										Name: "StartupTimeout",
+										Old:  "0",
+										New:  "0",
+									},
 									{
 										Type: DiffTypeNone,
 										Name: "SuccessBeforePassing",
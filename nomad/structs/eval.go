@@ -29,6 +29,7 @@ const (
 	EvalTriggerMaxDisconnectTimeout = "max-disconnect-timeout"
 	EvalTriggerReconnect            = "reconnect"
 	EvalTriggerAllocReschedule      = "alloc-reschedule"
+	EvalTriggerRebalance            = "rebalance"
 
 	EvalStatusBlocked   = "blocked"
 	EvalStatusPending   = "pending"
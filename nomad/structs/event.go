@@ -8,6 +8,11 @@ type EventStreamRequest struct {
 	Topics map[Topic][]string
 	Index  int
 
+	// Consumer is the name of a durable event consumer to stream events to.
+	// The topics, namespace, filter and index of the consumer are used
+	// instead of the ones of the request.
+	Consumer string
+
 	QueryOptions
 }
 
@@ -0,0 +1,163 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/hashicorp/go-bexpr"
+	"github.com/hashicorp/go-multierror"
+)
+
+var (
+	// validEventConsumerName is the rule used to validate an event consumer
+	// name.
+	validEventConsumerName = regexp.MustCompile("^[a-zA-Z0-9-_]{1,128}$")
+)
+
+// EventConsumer is a named durable consumer of the event stream. The index of
+// the last event acknowledged by the consumer is stored in Raft, so that the
+// consumer can resume streaming after a disconnect or a leader change. Events
+// that are no longer in the event buffer of a server are replayed from its
+// event log.
+type EventConsumer struct {
+	// Name is the name of the consumer. It must be unique.
+	Name string
+
+	// Namespace is the namespace of the events streamed to the consumer, or
+	// the wildcard namespace to stream the events of all namespaces.
+	Namespace string
+
+	// Topics are the topics and keys of the events streamed to the consumer.
+	Topics map[Topic][]string
+
+	// Filter is an optional go-bexpr expression evaluated against each event
+	// streamed to the consumer.
+	Filter string
+
+	// AckIndex is the index of the last event acknowledged by the consumer.
+	// Streaming resumes at the first event after it.
+	AckIndex uint64
+
+	// Raft indexes.
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// GetID implements the IDGetter interface required for pagination.
+func (c *EventConsumer) GetID() string {
+	return c.Name
+}
+
+// Canonicalize sets the default values of the consumer.
+func (c *EventConsumer) Canonicalize() {
+	if c == nil {
+		return
+	}
+	if c.Namespace == "" {
+		c.Namespace = DefaultNamespace
+	}
+	if len(c.Topics) == 0 {
+		c.Topics = map[Topic][]string{TopicAll: {string(TopicAll)}}
+	}
+	for topic, keys := range c.Topics {
+		if len(keys) == 0 {
+			c.Topics[topic] = []string{string(TopicAll)}
+		}
+	}
+}
+
+// Validate returns an error if the consumer is invalid.
+func (c *EventConsumer) Validate() error {
+	var mErr *multierror.Error
+
+	if !validEventConsumerName.MatchString(c.Name) {
+		mErr = multierror.Append(mErr, fmt.Errorf("invalid name %q, must match regex %s", c.Name, validEventConsumerName))
+	}
+	if c.Namespace == "" {
+		mErr = multierror.Append(mErr, errors.New("missing namespace"))
+	}
+	for topic, keys := range c.Topics {
+		if topic == "" {
+			mErr = multierror.Append(mErr, errors.New("topic must not be empty"))
+		}
+		if slices.Contains(keys, "") {
+			mErr = multierror.Append(mErr, fmt.Errorf("topic %q has an empty key", topic))
+		}
+	}
+	if c.Filter != "" {
+		if _, err := bexpr.CreateEvaluator(c.Filter); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("invalid filter: %v", err))
+		}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Copy returns a deep copy of the consumer.
+func (c *EventConsumer) Copy() *EventConsumer {
+	if c == nil {
+		return nil
+	}
+
+	nc := new(EventConsumer)
+	*nc = *c
+	if c.Topics != nil {
+		nc.Topics = make(map[Topic][]string, len(c.Topics))
+		for topic, keys := range c.Topics {
+			nc.Topics[topic] = slices.Clone(keys)
+		}
+	}
+	return nc
+}
+
+// EventConsumerListRequest is used to list the event consumers.
+type EventConsumerListRequest struct {
+	QueryOptions
+}
+
+// EventConsumerListResponse is the response to an event consumers list
+// request.
+type EventConsumerListResponse struct {
+	Consumers []*EventConsumer
+	QueryMeta
+}
+
+// EventConsumerSpecificRequest is used to make a request for a specific event
+// consumer.
+type EventConsumerSpecificRequest struct {
+	Name string
+	QueryOptions
+}
+
+// SingleEventConsumerResponse is the response to a specific event consumer
+// request.
+type SingleEventConsumerResponse struct {
+	Consumer *EventConsumer
+	QueryMeta
+}
+
+// EventConsumerUpsertRequest is used to create or update an event consumer.
+// Updating a consumer keeps its acknowledged index.
+type EventConsumerUpsertRequest struct {
+	Consumer *EventConsumer
+	WriteRequest
+}
+
+// EventConsumerDeleteRequest is used to delete an event consumer.
+type EventConsumerDeleteRequest struct {
+	Name string
+	WriteRequest
+}
+
+// EventConsumerAckRequest is used to acknowledge the events streamed to a
+// consumer up to and including the index.
+type EventConsumerAckRequest struct {
+	Name  string
+	Index uint64
+	WriteRequest
+}
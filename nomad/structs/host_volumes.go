@@ -165,6 +165,10 @@ func (hv *HostVolume) Validate() error {
 				"invalid constraint %s: host volumes of the same name are always on distinct hosts", constraint.Operand))
 		default:
 		}
+		if IsAllocTarget(constraint.LTarget) {
+			mErr = multierror.Append(mErr, fmt.Errorf(
+				"invalid constraint %s: host volumes do not support allocation targets", constraint.LTarget))
+		}
 	}
 
 	return helper.FlattenMultierror(mErr.ErrorOrNil())
@@ -0,0 +1,127 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+const (
+	// UpstreamDefaultBindAddress is the address the local proxy of an
+	// upstream listens on when none is configured.
+	UpstreamDefaultBindAddress = "127.0.0.1"
+)
+
+// NetworkUpstream configures a local proxy in the network namespace of an
+// allocation which load balances connections across the healthy instances of
+// a service registered with the Nomad service provider.
+type NetworkUpstream struct {
+	// DestinationName is the name of the Nomad service to connect to.
+	DestinationName string
+
+	// DestinationNamespace is the namespace of the destination service. It
+	// defaults to the namespace of the job. The services are read with the
+	// workload identity of the group's first task, so a destination in
+	// another namespace requires an ACL policy granting read access to it to
+	// be attached to the job or group.
+	DestinationNamespace string
+
+	// LocalBindAddress is the address the proxy listens on in the network
+	// namespace of the allocation.
+	LocalBindAddress string
+
+	// LocalBindPort is the port the proxy listens on in the network namespace
+	// of the allocation.
+	LocalBindPort int
+}
+
+// Copy returns a copy of the upstream.
+func (u *NetworkUpstream) Copy() *NetworkUpstream {
+	if u == nil {
+		return nil
+	}
+	nu := new(NetworkUpstream)
+	*nu = *u
+	return nu
+}
+
+// Equal returns whether the two upstreams are the same.
+func (u *NetworkUpstream) Equal(o *NetworkUpstream) bool {
+	if u == nil || o == nil {
+		return u == o
+	}
+	return *u == *o
+}
+
+// Canonicalize sets the default bind address.
+func (u *NetworkUpstream) Canonicalize() {
+	if u.LocalBindAddress == "" {
+		u.LocalBindAddress = UpstreamDefaultBindAddress
+	}
+}
+
+// BindAddr returns the address the proxy of the upstream listens on.
+func (u *NetworkUpstream) BindAddr() string {
+	addr := u.LocalBindAddress
+	if addr == "" {
+		addr = UpstreamDefaultBindAddress
+	}
+	return net.JoinHostPort(addr, strconv.Itoa(u.LocalBindPort))
+}
+
+// DiffID returns the bind address of the upstream, which is unique within a
+// network block.
+func (u *NetworkUpstream) DiffID() string {
+	return u.BindAddr()
+}
+
+// Validate returns an error if the upstream is invalid.
+func (u *NetworkUpstream) Validate() error {
+	var mErr multierror.Error
+	if u.DestinationName == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("Upstream destination name must be set"))
+	}
+	if u.LocalBindAddress != "" && net.ParseIP(u.LocalBindAddress) == nil {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Upstream %q local bind address %q is not a valid IP address", u.DestinationName, u.LocalBindAddress))
+	}
+	if u.LocalBindPort <= 0 || u.LocalBindPort > math.MaxUint16 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Upstream %q local bind port %d must be between 1 and %d", u.DestinationName, u.LocalBindPort, math.MaxUint16))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// NetworkUpstreams is a list of upstreams of a network block.
+type NetworkUpstreams []*NetworkUpstream
+
+// Copy returns a deep copy of the upstreams.
+func (us NetworkUpstreams) Copy() NetworkUpstreams {
+	if us == nil {
+		return nil
+	}
+	out := make(NetworkUpstreams, len(us))
+	for i, u := range us {
+		out[i] = u.Copy()
+	}
+	return out
+}
+
+// Equal returns whether the two lists contain the same upstreams in the same
+// order.
+func (us NetworkUpstreams) Equal(o NetworkUpstreams) bool {
+	if len(us) != len(o) {
+		return false
+	}
+	for i := range us {
+		if !us[i].Equal(o[i]) {
+			return false
+		}
+	}
+	return true
+}
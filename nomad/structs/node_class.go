@@ -131,6 +131,8 @@ func constraintTargetEscapes(target string) bool {
 		return true
 	case strings.HasPrefix(target, "${meta.unique."):
 		return true
+	case IsAllocTarget(target):
+		return true
 	default:
 		return false
 	}
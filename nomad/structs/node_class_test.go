@@ -274,8 +274,13 @@ func TestNode_EscapedConstraints(t *testing.T) {
 		RTarget: "test",
 		Operand: "!=",
 	}
-	constraints := []*Constraint{ne1, ne2, ne3, e1, e2, e3}
-	expected := []*Constraint{e1, e2, e3}
+	e4 := &Constraint{
+		LTarget: "${allocs.job_id}",
+		RTarget: "api",
+		Operand: ConstraintSetContains,
+	}
+	constraints := []*Constraint{ne1, ne2, ne3, e1, e2, e3, e4}
+	expected := []*Constraint{e1, e2, e3, e4}
 	must.Eq(t, expected, EscapedConstraints(constraints),
 		must.Sprintf("expected unique fields to escape constraints"))
 }
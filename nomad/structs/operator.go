@@ -6,6 +6,7 @@ package structs
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"net/netip"
 	"time"
 
@@ -244,6 +245,12 @@ type SchedulerConfiguration struct {
 	// deterministic application of spread and/or affinity.
 	NodeLimitForFeasibilityChecks uint `hcl:"node_limit_for_feasibility_checks"`
 
+	// NamespaceWeights sets the relative share of evaluations each namespace
+	// has dequeued from the evaluation broker when several namespaces have
+	// evaluations ready. Namespaces without a weight have a weight of 1, so
+	// by default every namespace receives an equal share.
+	NamespaceWeights map[string]int `hcl:"namespace_weights"`
+
 	// CreateIndex/ModifyIndex store the create/modify indexes of this configuration.
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -255,6 +262,7 @@ func (s *SchedulerConfiguration) Copy() *SchedulerConfiguration {
 	}
 
 	ns := *s
+	ns.NamespaceWeights = maps.Clone(s.NamespaceWeights)
 	return &ns
 }
 
@@ -310,6 +318,12 @@ func (s *SchedulerConfiguration) Validate() error {
 		return fmt.Errorf("invalid scheduler algorithm: %v", s.SchedulerAlgorithm)
 	}
 
+	for namespace, weight := range s.NamespaceWeights {
+		if weight < 1 {
+			return fmt.Errorf("invalid weight for namespace %q: must be greater than zero", namespace)
+		}
+	}
+
 	return nil
 }
 
@@ -359,6 +373,131 @@ type SchedulerSetConfigRequest struct {
 	WriteRequest
 }
 
+// SchedulerSimulateRequest is used by the Operator endpoint to run the
+// schedulers against a copy of the cluster state, after applying a set of
+// hypothetical changes to it. The cluster state itself is never modified.
+type SchedulerSimulateRequest struct {
+	// Jobs are registered in the simulated state and evaluated in order.
+	Jobs []*Job
+
+	// RemoveNodes are the IDs, or ID prefixes, of nodes to remove from the
+	// simulated state. Jobs with allocations on these nodes are evaluated.
+	RemoveNodes []string
+
+	// NodePools maps the IDs, or ID prefixes, of nodes to the node pool they
+	// are moved to in the simulated state. Jobs with allocations on these nodes
+	// are evaluated.
+	NodePools map[string]string
+
+	// SchedulerConfig replaces the scheduler configuration used for the
+	// simulation, if set.
+	SchedulerConfig *SchedulerConfiguration
+
+	QueryOptions
+}
+
+// SchedulerSimulateResponse is the result of a scheduler simulation.
+type SchedulerSimulateResponse struct {
+	// Jobs are the results for each job evaluated by the simulation, in
+	// the order they were evaluated.
+	Jobs []*SimulatedJobResult
+
+	// Utilization is the resource utilization of the simulated cluster once
+	// all jobs have been evaluated.
+	Utilization *SimulatedUtilization
+
+	QueryMeta
+}
+
+// SimulatedJobResult is the outcome of evaluating a single job during a
+// scheduler simulation.
+type SimulatedJobResult struct {
+	Namespace string
+	JobID     string
+
+	// Placements are the new allocations placed for the job.
+	Placements []*SimulatedPlacement
+
+	// Stopped is the number of existing allocations of the job stopped.
+	Stopped int
+
+	// Preempted are the IDs of the allocations preempted to place the job.
+	Preempted []string
+
+	// FailedTGAllocs are the metrics of the task groups which failed to place.
+	FailedTGAllocs map[string]*AllocMetric
+}
+
+// SimulatedPlacement is an allocation placed during a scheduler simulation.
+type SimulatedPlacement struct {
+	AllocID   string
+	Name      string
+	TaskGroup string
+	NodeID    string
+	NodeName  string
+	NodePool  string
+}
+
+// SimulatedUtilization is the resource utilization of the ready nodes of the
+// simulated cluster.
+type SimulatedUtilization struct {
+	Nodes int
+
+	CPUCapacity  int64
+	CPUAllocated int64
+
+	MemoryCapacityMB  int64
+	MemoryAllocatedMB int64
+}
+
+// DefaultRebalanceMaxMoves is the number of allocations a rebalance moves
+// when the request does not set a limit.
+const DefaultRebalanceMaxMoves = 10
+
+// SchedulerRebalanceRequest is used by the Operator endpoint to move healthy
+// service allocations between nodes to improve the packing or spread of the
+// cluster, according to the scheduler algorithm.
+type SchedulerRebalanceRequest struct {
+	// NodePool limits the rebalance to the nodes of the node pool. All node
+	// pools are rebalanced if empty.
+	NodePool string
+
+	// MaxMoves is the maximum number of allocations moved by the request.
+	MaxMoves int
+
+	// DryRun computes the moves without migrating any allocations.
+	DryRun bool
+
+	WriteRequest
+}
+
+// SchedulerRebalanceResponse is the result of a rebalance request.
+type SchedulerRebalanceResponse struct {
+	// Moves are the allocation moves computed by the rebalance.
+	Moves []*RebalanceMove
+
+	// EvalIDs are the IDs of the evaluations created to migrate the
+	// allocations. It is empty for dry runs.
+	EvalIDs []string
+
+	WriteMeta
+}
+
+// RebalanceMove is a proposed move of an allocation from the node it runs on
+// to a better placed node. The replacement allocation is placed by the
+// scheduler, so it is not guaranteed to land on the target node.
+type RebalanceMove struct {
+	AllocID   string
+	AllocName string
+	Namespace string
+	JobID     string
+	TaskGroup string
+	NodePool  string
+
+	SourceNodeID string
+	TargetNodeID string
+}
+
 // SnapshotSaveRequest is used by the Operator endpoint to get a Raft snapshot
 type SnapshotSaveRequest struct {
 	QueryOptions
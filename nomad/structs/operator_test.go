@@ -139,3 +139,19 @@ func TestSchedulerConfiguration_WithNodePool(t *testing.T) {
 		})
 	}
 }
+
+func TestSchedulerConfiguration_Validate_NamespaceWeights(t *testing.T) {
+	ci.Parallel(t)
+
+	config := &SchedulerConfiguration{
+		SchedulerAlgorithm: SchedulerAlgorithmBinpack,
+		NamespaceWeights:   map[string]int{"default": 1, "prod": 4},
+	}
+	must.NoError(t, config.Validate())
+
+	config.NamespaceWeights["dev"] = 0
+	must.ErrorContains(t, config.Validate(), `invalid weight for namespace "dev"`)
+
+	config.NamespaceWeights["dev"] = -2
+	must.ErrorContains(t, config.Validate(), `invalid weight for namespace "dev"`)
+}
@@ -3,7 +3,10 @@
 
 package structs
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+)
 
 // Plan is used to submit a commit plan for task allocations. These
 // are submitted to the leader which verifies that resources have
@@ -237,6 +240,41 @@ func (p *Plan) PopUpdate(alloc *Allocation) {
 	}
 }
 
+// RemoveAlloc removes an allocation placement from the plan, along with any
+// preemptions made on its behalf.
+func (p *Plan) RemoveAlloc(alloc *Allocation) {
+	node := alloc.NodeID
+	p.NodeAllocation[node] = slices.DeleteFunc(p.NodeAllocation[node], func(a *Allocation) bool {
+		return a.ID == alloc.ID
+	})
+	if len(p.NodeAllocation[node]) == 0 {
+		delete(p.NodeAllocation, node)
+	}
+
+	for node, preempted := range p.NodePreemptions {
+		preempted = slices.DeleteFunc(preempted, func(a *Allocation) bool {
+			return a.PreemptedByAllocation == alloc.ID
+		})
+		if len(preempted) > 0 {
+			p.NodePreemptions[node] = preempted
+		} else {
+			delete(p.NodePreemptions, node)
+		}
+	}
+}
+
+// RemoveUpdate removes an allocation marked to be stopped from the plan. Unlike
+// PopUpdate, the allocation does not have to be the last one appended.
+func (p *Plan) RemoveUpdate(alloc *Allocation) {
+	node := alloc.NodeID
+	p.NodeUpdate[node] = slices.DeleteFunc(p.NodeUpdate[node], func(a *Allocation) bool {
+		return a.ID == alloc.ID
+	})
+	if len(p.NodeUpdate[node]) == 0 {
+		delete(p.NodeUpdate, node)
+	}
+}
+
 // AppendAlloc appends the alloc to the plan allocations.
 // Uses the passed job if explicitly passed, otherwise
 // it is assumed the alloc will use the plan Job version.
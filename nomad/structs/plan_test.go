@@ -98,3 +98,46 @@ func TestPlan_AppendPreemptedAllocAppendsAllocWithUpdatedAttrs(t *testing.T) {
 	}
 	must.Eq(t, expectedAlloc, appendedAlloc)
 }
+
+func TestPlan_RemoveAlloc(t *testing.T) {
+	ci.Parallel(t)
+	plan := &Plan{
+		NodeAllocation:  make(map[string][]*Allocation),
+		NodePreemptions: make(map[string][]*Allocation),
+	}
+	alloc := MockAlloc()
+	other := MockAlloc()
+	other.NodeID = alloc.NodeID
+	plan.AppendAlloc(alloc, nil)
+	plan.AppendAlloc(other, nil)
+
+	preempted := MockAlloc()
+	preempted.NodeID = alloc.NodeID
+	plan.AppendPreemptedAlloc(preempted, alloc.ID)
+
+	plan.RemoveAlloc(alloc)
+	must.Eq(t, []*Allocation{other}, plan.NodeAllocation[alloc.NodeID])
+	must.MapNotContainsKey(t, plan.NodePreemptions, alloc.NodeID)
+
+	plan.RemoveAlloc(other)
+	must.MapEmpty(t, plan.NodeAllocation)
+}
+
+func TestPlan_RemoveUpdate(t *testing.T) {
+	ci.Parallel(t)
+	plan := &Plan{
+		NodeUpdate: make(map[string][]*Allocation),
+	}
+	alloc := MockAlloc()
+	other := MockAlloc()
+	other.NodeID = alloc.NodeID
+	plan.AppendStoppedAlloc(alloc, "", "", "")
+	plan.AppendStoppedAlloc(other, "", "", "")
+
+	plan.RemoveUpdate(alloc)
+	must.Len(t, 1, plan.NodeUpdate[alloc.NodeID])
+	must.Eq(t, other.ID, plan.NodeUpdate[alloc.NodeID][0].ID)
+
+	plan.RemoveUpdate(other)
+	must.MapEmpty(t, plan.NodeUpdate)
+}
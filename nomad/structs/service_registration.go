@@ -91,6 +91,11 @@ type ServiceRegistration struct {
 	// is determined by a combination of factors on the client.
 	Port int
 
+	// CheckStatus is the aggregated status of the Nomad checks of this service
+	// registration, as observed by the client running the allocation. It is
+	// empty if the service has no checks.
+	CheckStatus CheckStatus
+
 	CreateIndex uint64
 	ModifyIndex uint64
 }
@@ -142,12 +147,21 @@ func (s *ServiceRegistration) Equal(o *ServiceRegistration) bool {
 	if s.Port != o.Port {
 		return false
 	}
+	if s.CheckStatus != o.CheckStatus {
+		return false
+	}
 	if !helper.SliceSetEq(s.Tags, o.Tags) {
 		return false
 	}
 	return true
 }
 
+// Healthy returns whether the service registration has no failing or pending
+// checks.
+func (s *ServiceRegistration) Healthy() bool {
+	return s.CheckStatus == "" || s.CheckStatus == CheckSuccess
+}
+
 // Validate ensures the upserted service registration contains valid
 // information and routing capabilities. Objects should never fail here as
 // Nomad controls the entire registration process; but it's possible
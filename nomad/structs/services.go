@@ -82,6 +82,25 @@ type ServiceCheck struct {
 	FailuresBeforeWarning  int                 // Number of consecutive failures required before showing warning
 	Body                   string              // Body to use in HTTP check
 	OnUpdate               string
+
+	// OnStart marks a startup check. The check_restart of the other checks of
+	// the workload is not enforced until all its startup checks have passed
+	// once, and checks of the Nomad provider are not run until then. Checks of
+	// the Consul provider are run by Consul from registration, so they may be
+	// reported as critical in Consul until the startup checks pass.
+	OnStart bool
+
+	// StartupTimeout is how long a startup check may take to pass once before
+	// the task is killed as failed, without being restarted. Zero means no
+	// timeout.
+	StartupTimeout time.Duration
+}
+
+// IsWatched returns whether the check must be watched by the check watcher,
+// either to restart its task when unhealthy or to gate the other checks of its
+// task until it passes.
+func (sc *ServiceCheck) IsWatched() bool {
+	return sc.TriggersRestarts() || sc.OnStart
 }
 
 // IsReadiness returns whether the configuration of the ServiceCheck is effectively
@@ -214,6 +233,14 @@ func (sc *ServiceCheck) Equal(o *ServiceCheck) bool {
 		return false
 	}
 
+	if sc.OnStart != o.OnStart {
+		return false
+	}
+
+	if sc.StartupTimeout != o.StartupTimeout {
+		return false
+	}
+
 	return true
 }
 
@@ -342,12 +369,23 @@ func (sc *ServiceCheck) validateCommon(allowableTypes []string) error {
 		return err
 	}
 
+	// validate on_start and startup_timeout
+	if sc.StartupTimeout < 0 {
+		return fmt.Errorf("startup_timeout must be non-negative")
+	}
+	if sc.StartupTimeout > 0 && !sc.OnStart {
+		return fmt.Errorf("startup_timeout may only be set on on_start checks")
+	}
+	if sc.OnStart && sc.CheckRestart != nil {
+		return fmt.Errorf("check_restart may not be set on on_start checks; use startup_timeout instead")
+	}
+
 	return nil
 }
 
 // validate a Service's ServiceCheck in the context of the Nomad provider.
 func (sc *ServiceCheck) validateNomad() error {
-	allowable := []string{ServiceCheckTCP, ServiceCheckHTTP}
+	allowable := []string{ServiceCheckTCP, ServiceCheckHTTP, ServiceCheckGRPC}
 	if err := sc.validateCommon(allowable); err != nil {
 		return err
 	}
@@ -398,9 +436,9 @@ func (sc *ServiceCheck) validateNomad() error {
 		return errors.New("failures_before_warning may only be set for Consul service checks")
 	}
 
-	// tls_server_name is consul only
-	if sc.TLSServerName != "" {
-		return errors.New("tls_server_name may only be set for Consul service checks")
+	// tls_server_name is consul only, except for nomad grpc checks
+	if sc.TLSServerName != "" && sc.Type != ServiceCheckGRPC {
+		return errors.New("tls_server_name may only be set for Consul service checks or Nomad grpc checks")
 	}
 
 	return nil
@@ -246,6 +246,64 @@ func TestServiceCheck_validate_OnUpdate_CheckRestart_Conflict(t *testing.T) {
 	})
 }
 
+func TestServiceCheck_validate_OnStart(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name  string
+		check *ServiceCheck
+		exp   string
+	}{
+		{
+			name: "valid",
+			check: &ServiceCheck{
+				OnStart:        true,
+				StartupTimeout: time.Minute,
+			},
+		},
+		{
+			name: "negative startup timeout",
+			check: &ServiceCheck{
+				OnStart:        true,
+				StartupTimeout: -time.Minute,
+			},
+			exp: `startup_timeout must be non-negative`,
+		},
+		{
+			name: "startup timeout without on_start",
+			check: &ServiceCheck{
+				StartupTimeout: time.Minute,
+			},
+			exp: `startup_timeout may only be set on on_start checks`,
+		},
+		{
+			name: "check_restart on startup check",
+			check: &ServiceCheck{
+				OnStart:      true,
+				CheckRestart: &CheckRestart{Limit: 3},
+			},
+			exp: `check_restart may not be set on on_start checks; use startup_timeout instead`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.check.Name = "check"
+			tc.check.Type = "http"
+			tc.check.Path = "/health"
+			tc.check.Interval = time.Second
+			tc.check.Timeout = time.Second
+
+			err := tc.check.validateConsul()
+			if tc.exp == "" {
+				must.NoError(t, err)
+			} else {
+				must.EqError(t, err, tc.exp)
+			}
+		})
+	}
+}
+
 func TestServiceCheck_validateNomad(t *testing.T) {
 	ci.Parallel(t)
 
@@ -254,8 +312,26 @@ func TestServiceCheck_validateNomad(t *testing.T) {
 		sc   *ServiceCheck
 		exp  string
 	}{
-		{name: "grpc", sc: &ServiceCheck{Type: ServiceCheckGRPC}, exp: `invalid check type ("grpc"), must be one of tcp, http`},
-		{name: "script", sc: &ServiceCheck{Type: ServiceCheckScript}, exp: `invalid check type ("script"), must be one of tcp, http`},
+		{name: "script", sc: &ServiceCheck{Type: ServiceCheckScript}, exp: `invalid check type ("script"), must be one of tcp, http, grpc`},
+		{
+			name: "grpc",
+			sc: &ServiceCheck{
+				Type:     ServiceCheckGRPC,
+				Interval: 3 * time.Second,
+				Timeout:  1 * time.Second,
+			},
+		},
+		{
+			name: "grpc with tls",
+			sc: &ServiceCheck{
+				Type:          ServiceCheckGRPC,
+				Interval:      3 * time.Second,
+				Timeout:       1 * time.Second,
+				GRPCService:   "foo.Bar",
+				GRPCUseTLS:    true,
+				TLSServerName: "foo",
+			},
+		},
 		{
 			name: "expose",
 			sc: &ServiceCheck{
@@ -391,7 +467,7 @@ func TestServiceCheck_validateNomad(t *testing.T) {
 				Path:          "/health",
 				TLSServerName: "foo",
 			},
-			exp: `tls_server_name may only be set for Consul service checks`,
+			exp: `tls_server_name may only be set for Consul service checks or Nomad grpc checks`,
 		},
 	}
 
@@ -2246,7 +2322,7 @@ func TestService_validateNomadService(t *testing.T) {
 			},
 			inputErr: &multierror.Error{},
 			expectedOutputErrors: []error{
-				errors.New(`invalid check type (""), must be one of tcp, http`),
+				errors.New(`invalid check type (""), must be one of tcp, http, grpc`),
 			},
 			name: "bad nomad check",
 		},
@@ -19,6 +19,7 @@ import (
 	"maps"
 	"math"
 	"net"
+	"net/url"
 	"os"
 	"reflect"
 	"regexp"
@@ -134,6 +135,9 @@ const (
 	HostVolumeRegisterRequestType             MessageType = 75
 	HostVolumeDeleteRequestType               MessageType = 76
 	TaskGroupHostVolumeClaimDeleteRequestType MessageType = 77
+	EventConsumerUpsertRequestType            MessageType = 78
+	EventConsumerDeleteRequestType            MessageType = 79
+	EventConsumerAckRequestType               MessageType = 80
 
 	// NOTE: MessageTypes are shared between CE and ENT. If you need to add a
 	// new type, check that ENT is not already using that value.
@@ -1142,6 +1146,10 @@ type AllocStopRequest struct {
 	NoShutdownDelay bool
 	Reschedule      bool
 
+	// IgnoreDisruptionBudget allows the allocation to be stopped even if
+	// doing so violates the disruption budget of its task group.
+	IgnoreDisruptionBudget bool
+
 	WriteRequest
 }
 
@@ -2885,6 +2893,10 @@ type NetworkResource struct {
 	ReservedPorts []Port     // Host Reserved ports
 	DynamicPorts  []Port     // Host Dynamically assigned ports
 	CNI           *CNIConfig // CNIConfig Configuration
+
+	// Upstreams are Nomad services proxied from a local address in the
+	// network namespace of the allocation
+	Upstreams NetworkUpstreams `json:",omitempty"`
 }
 
 func (n *NetworkResource) Hash() uint32 {
@@ -2926,6 +2938,12 @@ func (n *NetworkResource) Canonicalize() {
 			n.ReservedPorts[i].HostNetwork = "default"
 		}
 	}
+	if len(n.Upstreams) == 0 {
+		n.Upstreams = nil
+	}
+	for _, u := range n.Upstreams {
+		u.Canonicalize()
+	}
 }
 
 // Copy returns a deep copy of the network resource
@@ -2944,6 +2962,7 @@ func (n *NetworkResource) Copy() *NetworkResource {
 		newR.DynamicPorts = make([]Port, len(n.DynamicPorts))
 		copy(newR.DynamicPorts, n.DynamicPorts)
 	}
+	newR.Upstreams = n.Upstreams.Copy()
 	return newR
 }
 
@@ -5052,6 +5071,20 @@ func (j *Job) HasUpdateStrategy() bool {
 	return false
 }
 
+// HasGangs returns if any task group in the job is part of a gang
+func (j *Job) HasGangs() bool {
+	if j == nil {
+		return false
+	}
+	for _, tg := range j.TaskGroups {
+		if tg.IsGang() {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Stub is used to return a summary of the job
 func (j *Job) Stub(summary *JobSummary, fields *JobStubFields) *JobListStub {
 	jobStub := &JobListStub{
@@ -5229,6 +5262,15 @@ func (j *Job) RequiredSignals() map[string]map[string][]string {
 				taskSignals[t.ChangeSignal] = struct{}{}
 			}
 
+			// Check if any secret change mode uses signals
+			for _, s := range task.Secrets {
+				if s.ChangeMode != SecretChangeModeSignal {
+					continue
+				}
+
+				taskSignals[s.ChangeSignal] = struct{}{}
+			}
+
 			// Flatten and sort the signals
 			l := len(taskSignals)
 			if l == 0 {
@@ -5446,6 +5488,15 @@ type UpdateStrategy struct {
 	// Canary is the number of canaries to deploy when a change to the task
 	// group is detected.
 	Canary int
+
+	// Windows are the recurring windows of time during which a deployment
+	// may progress. If empty, a deployment may progress at any time.
+	Windows []*UpdateWindow
+
+	// Analysis is the metric analysis run against the canaries of a
+	// deployment, which promotes or fails the deployment depending on its
+	// results.
+	Analysis *UpdateAnalysis
 }
 
 func (u *UpdateStrategy) Copy() *UpdateStrategy {
@@ -5455,6 +5506,14 @@ func (u *UpdateStrategy) Copy() *UpdateStrategy {
 
 	c := new(UpdateStrategy)
 	*c = *u
+
+	if u.Windows != nil {
+		c.Windows = make([]*UpdateWindow, len(u.Windows))
+		for i, w := range u.Windows {
+			c.Windows[i] = w.Copy()
+		}
+	}
+	c.Analysis = u.Analysis.Copy()
 	return c
 }
 
@@ -5497,6 +5556,19 @@ func (u *UpdateStrategy) Validate() error {
 	if u.Stagger <= 0 {
 		_ = multierror.Append(&mErr, fmt.Errorf("Stagger must be greater than zero: %v", u.Stagger))
 	}
+	for i, w := range u.Windows {
+		if err := w.Validate(); err != nil {
+			_ = multierror.Append(&mErr, multierror.Prefix(err, fmt.Sprintf("Window %d:", i+1)))
+		}
+	}
+	if u.Analysis != nil {
+		if u.Canary == 0 {
+			_ = multierror.Append(&mErr, fmt.Errorf("Analysis requires a Canary count greater than zero"))
+		}
+		if err := u.Analysis.Validate(); err != nil {
+			_ = multierror.Append(&mErr, multierror.Prefix(err, "Analysis:"))
+		}
+	}
 
 	return mErr.ErrorOrNil()
 }
@@ -5521,6 +5593,218 @@ func (u *UpdateStrategy) Rolling() bool {
 	return u.Stagger > 0 && u.MaxParallel > 0
 }
 
+// WindowOpen returns whether a deployment may progress at the given time and
+// the time at which that next changes. A strategy without windows is always
+// open and the returned time is zero.
+func (u *UpdateStrategy) WindowOpen(now time.Time) (bool, time.Time) {
+	if u == nil || len(u.Windows) == 0 {
+		return true, time.Time{}
+	}
+
+	open := false
+	var change time.Time
+	for _, w := range u.Windows {
+		if end, ok := w.openUntil(now); ok {
+			// The strategy stays open until the last open window closes.
+			if !open || end.After(change) {
+				change = end
+			}
+			open = true
+			continue
+		}
+		if open {
+			continue
+		}
+		if next := w.nextOpen(now); !next.IsZero() && (change.IsZero() || next.Before(change)) {
+			change = next
+		}
+	}
+	return open, change
+}
+
+// UpdateAnalysis is a metric analysis run against the canaries of a
+// deployment once they are all healthy. The Prometheus-compatible HTTP API of
+// a provider configured on the servers is queried at each interval and the
+// result compared against the thresholds.
+// The deployment is promoted once enough measurements have passed, or failed
+// and potentially reverted once too many have failed.
+type UpdateAnalysis struct {
+	// Provider is the name of the Prometheus-compatible HTTP API to query. The
+	// providers and their addresses are configured on the servers, so that
+	// jobs can't make the leader send requests to arbitrary addresses.
+	Provider string
+
+	// Query is the instant query to evaluate. It must return a scalar or a
+	// vector, of which the first sample is used.
+	Query string
+
+	// Interval is the time between measurements.
+	Interval time.Duration
+
+	// Count is the number of measurements that must pass before the
+	// deployment is promoted.
+	Count int
+
+	// FailureLimit is the number of measurements that may fail before the
+	// deployment is failed. A measurement fails if the query result is
+	// outside of the thresholds or the query can't be made.
+	FailureLimit int
+
+	// Min and Max are the inclusive bounds the query result must be within
+	// for a measurement to pass. Either may be nil to leave it unbounded.
+	Min *float64
+	Max *float64
+}
+
+func (a *UpdateAnalysis) Copy() *UpdateAnalysis {
+	if a == nil {
+		return nil
+	}
+	na := new(UpdateAnalysis)
+	*na = *a
+	na.Min = pointer.Copy(a.Min)
+	na.Max = pointer.Copy(a.Max)
+	return na
+}
+
+func (a *UpdateAnalysis) Validate() error {
+	var mErr multierror.Error
+	if a.Provider == "" {
+		_ = multierror.Append(&mErr, errors.New("Must specify a provider"))
+	}
+	if a.Query == "" {
+		_ = multierror.Append(&mErr, errors.New("Must specify a query"))
+	}
+	if a.Interval <= 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Interval must be greater than zero: %v", a.Interval))
+	}
+	if a.Count < 1 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Count must be greater than zero: %d", a.Count))
+	}
+	if a.FailureLimit < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Failure limit can not be less than zero: %d", a.FailureLimit))
+	}
+	if a.Min == nil && a.Max == nil {
+		_ = multierror.Append(&mErr, errors.New("Must specify a min or max threshold"))
+	}
+	if a.Min != nil && a.Max != nil && *a.Min > *a.Max {
+		_ = multierror.Append(&mErr, fmt.Errorf("Min threshold must not be greater than max threshold: %v > %v", *a.Min, *a.Max))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// Passes returns whether a measurement is within the analysis thresholds.
+func (a *UpdateAnalysis) Passes(value float64) bool {
+	if math.IsNaN(value) {
+		return false
+	}
+	if a.Min != nil && value < *a.Min {
+		return false
+	}
+	if a.Max != nil && value > *a.Max {
+		return false
+	}
+	return true
+}
+
+// UpdateWindow is a recurring window of time during which a deployment may
+// progress. The window opens at each time matching its cron expression and
+// stays open for its duration.
+type UpdateWindow struct {
+	// Cron is the cron expression for the times at which the window opens.
+	Cron string
+
+	// Duration is how long the window stays open.
+	Duration time.Duration
+
+	// TimeZone is the IANA time zone the cron expression is evaluated in,
+	// such as "America/New_York". It defaults to UTC.
+	TimeZone string
+}
+
+func (w *UpdateWindow) Copy() *UpdateWindow {
+	if w == nil {
+		return nil
+	}
+	nw := new(UpdateWindow)
+	*nw = *w
+	return nw
+}
+
+func (w *UpdateWindow) Validate() error {
+	if w == nil {
+		return errors.New("Window must not be empty")
+	}
+
+	var mErr multierror.Error
+	if w.Cron == "" {
+		_ = multierror.Append(&mErr, errors.New("Must specify a cron expression"))
+	} else if _, err := cronexpr.Parse(w.Cron); err != nil {
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid cron expression %q: %v", w.Cron, err))
+	}
+	if w.Duration <= 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Duration must be greater than zero: %v", w.Duration))
+	}
+	if w.TimeZone != "" {
+		if _, err := time.LoadLocation(w.TimeZone); err != nil {
+			_ = multierror.Append(&mErr, fmt.Errorf("Invalid time zone %q: %v", w.TimeZone, err))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// location returns the time zone the window's cron expression is evaluated
+// in.
+func (w *UpdateWindow) location() *time.Location {
+	if w.TimeZone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(w.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// openUntil returns the time at which the window closes if it's open at the
+// given time.
+func (w *UpdateWindow) openUntil(now time.Time) (time.Time, bool) {
+	now = now.In(w.location())
+	start, err := CronParseNext(now.Add(-w.Duration), w.Cron)
+	if err != nil || start.IsZero() || start.After(now) {
+		return time.Time{}, false
+	}
+
+	// Find the latest time the window opened, which is when it closes last.
+	// Searching back from now first keeps this cheap for cron expressions
+	// that match often within the window's duration.
+	for back := time.Second; back < w.Duration; back *= 2 {
+		next, err := CronParseNext(now.Add(-back), w.Cron)
+		if err == nil && !next.IsZero() && !next.After(now) {
+			start = next
+			break
+		}
+	}
+	for {
+		next, err := CronParseNext(start, w.Cron)
+		if err != nil || next.IsZero() || next.After(now) {
+			break
+		}
+		start = next
+	}
+	return start.Add(w.Duration), true
+}
+
+// nextOpen returns the next time after the given time at which the window
+// opens, or the zero time if it never does.
+func (w *UpdateWindow) nextOpen(now time.Time) time.Time {
+	next, err := CronParseNext(now.In(w.location()), w.Cron)
+	if err != nil {
+		return time.Time{}
+	}
+	return next
+}
+
 type Multiregion struct {
 	Strategy *MultiregionStrategy
 	Regions  []*MultiregionRegion
@@ -5870,6 +6154,42 @@ const (
 	PeriodicSpecTest = "_internal_test"
 )
 
+const (
+	// PeriodicMissedRunsSkip skips the launches missed while the job was
+	// disabled or the cluster had no leader.
+	PeriodicMissedRunsSkip = "skip"
+
+	// PeriodicMissedRunsRunOnce launches the job once if any launch was
+	// missed. This is the default.
+	PeriodicMissedRunsRunOnce = "run-once"
+
+	// PeriodicMissedRunsRunAll launches the job for each missed launch, up
+	// to MaxMissedRuns.
+	PeriodicMissedRunsRunAll = "run-all"
+
+	// PeriodicMaxMissedRunsLimit is the largest allowed MaxMissedRuns.
+	PeriodicMaxMissedRunsLimit = 100
+
+	// periodicMissedLaunchesScanLimit caps the number of launch times iterated
+	// over when searching for missed launches, so that jobs launched
+	// frequently which missed launches for a long time are cheap to catch up.
+	periodicMissedLaunchesScanLimit = 10_000
+)
+
+const (
+	// PeriodicConcurrencyAllow launches the job even if previous launches are
+	// still running. This is the default.
+	PeriodicConcurrencyAllow = "allow"
+
+	// PeriodicConcurrencyForbid skips launches while a previous launch is
+	// still running. It is equivalent to ProhibitOverlap.
+	PeriodicConcurrencyForbid = "forbid"
+
+	// PeriodicConcurrencyReplace stops the previous launches which are still
+	// running before launching the job.
+	PeriodicConcurrencyReplace = "replace"
+)
+
 // Periodic defines the interval a job should be run at.
 type PeriodicConfig struct {
 	// Enabled determines if the job should be run periodically.
@@ -5889,6 +6209,28 @@ type PeriodicConfig struct {
 	// ProhibitOverlap enforces that spawned jobs do not run in parallel.
 	ProhibitOverlap bool
 
+	// ConcurrencyPolicy controls launches while a previous launch is still
+	// running. One of allow, forbid or replace.
+	ConcurrencyPolicy string
+
+	// MissedRuns controls the launches missed while the job was disabled or
+	// the cluster had no leader. One of skip, run-once or run-all. If unset,
+	// only a launch missed while the cluster had no leader is run once.
+	MissedRuns string
+
+	// MaxMissedRuns is the maximum number of missed launches that are run
+	// with the run-all missed runs policy, up to PeriodicMaxMissedRunsLimit.
+	MaxMissedRuns int
+
+	// StartingDeadline is how late a missed launch may still be run. Zero
+	// means missed launches are run regardless of how late they are.
+	StartingDeadline time.Duration
+
+	// ChildHistoryLimit is the number of dead child jobs to keep. Older dead
+	// child jobs are garbage collected when the job is launched. Zero means
+	// child jobs are only garbage collected by the job GC.
+	ChildHistoryLimit int
+
 	// TimeZone is the user specified string that determines the time zone to
 	// launch against. The time zones must be specified from IANA Time Zone
 	// database, such as "America/New_York".
@@ -5950,9 +6292,123 @@ func (p *PeriodicConfig) Validate() error {
 		_ = multierror.Append(&mErr, fmt.Errorf("Unknown periodic specification type %q", p.SpecType))
 	}
 
+	switch p.ConcurrencyPolicy {
+	case "", PeriodicConcurrencyForbid:
+	case PeriodicConcurrencyAllow, PeriodicConcurrencyReplace:
+		if p.ProhibitOverlap {
+			_ = multierror.Append(&mErr, fmt.Errorf("Prohibit overlap may not be set with concurrency policy %q", p.ConcurrencyPolicy))
+		}
+	default:
+		_ = multierror.Append(&mErr, fmt.Errorf("Unknown concurrency policy %q", p.ConcurrencyPolicy))
+	}
+
+	switch p.MissedRuns {
+	case "", PeriodicMissedRunsSkip, PeriodicMissedRunsRunOnce:
+		if p.MaxMissedRuns != 0 {
+			_ = multierror.Append(&mErr, fmt.Errorf("Max missed runs may only be set with missed runs %q", PeriodicMissedRunsRunAll))
+		}
+	case PeriodicMissedRunsRunAll:
+		if p.MaxMissedRuns <= 0 {
+			_ = multierror.Append(&mErr, fmt.Errorf("Max missed runs must be positive with missed runs %q", PeriodicMissedRunsRunAll))
+		} else if p.MaxMissedRuns > PeriodicMaxMissedRunsLimit {
+			_ = multierror.Append(&mErr, fmt.Errorf("Max missed runs must be at most %d", PeriodicMaxMissedRunsLimit))
+		}
+	default:
+		_ = multierror.Append(&mErr, fmt.Errorf("Unknown missed runs policy %q", p.MissedRuns))
+	}
+
+	if p.StartingDeadline < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Starting deadline must be non-negative"))
+	}
+	if p.ChildHistoryLimit < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Child history limit must be non-negative"))
+	}
+
 	return mErr.ErrorOrNil()
 }
 
+// ProhibitsOverlap returns whether launches are skipped while a previous
+// launch is still running.
+func (p *PeriodicConfig) ProhibitsOverlap() bool {
+	return p.ProhibitOverlap || p.ConcurrencyPolicy == PeriodicConcurrencyForbid
+}
+
+// ReplacesOverlap returns whether previous launches which are still running are
+// stopped when the job is launched.
+func (p *PeriodicConfig) ReplacesOverlap() bool {
+	return p.ConcurrencyPolicy == PeriodicConcurrencyReplace
+}
+
+// MissedLaunches returns the launch times missed between the last launch and
+// now which must be run according to the missed runs policy, oldest first.
+// With the run-once policy, only the most recent missed launch is returned.
+func (p *PeriodicConfig) MissedLaunches(lastLaunch, now time.Time) ([]time.Time, error) {
+	if p.MissedRuns == PeriodicMissedRunsSkip {
+		return nil, nil
+	}
+
+	from := lastLaunch
+	if p.StartingDeadline > 0 {
+		if deadline := now.Add(-p.StartingDeadline); from.Before(deadline) {
+			// launches are at least a second apart, so this can't skip a
+			// launch at the deadline
+			from = deadline.Add(-time.Nanosecond)
+		}
+	}
+
+	limit := 1
+	if p.MissedRuns == PeriodicMissedRunsRunAll {
+		limit = p.MaxMissedRuns
+	}
+
+	// Search windows ending now, doubling in length until they hold enough
+	// launches or start at the last launch, so that only the launches close
+	// to the most recent ones are iterated over rather than all the launches
+	// since the last launch.
+	window := time.Minute
+	for {
+		start := now.Add(-window)
+		complete := !start.After(from)
+		if complete {
+			start = from
+		}
+
+		missed, err := p.launchesBetween(start, now, limit)
+		if err != nil {
+			return nil, err
+		}
+		if complete || len(missed) == limit {
+			return missed, nil
+		}
+		window *= 2
+	}
+}
+
+// launchesBetween returns the most recent launch times after from and before
+// now, up to the limit, oldest first. It returns an error if there are too
+// many launch times to iterate over.
+func (p *PeriodicConfig) launchesBetween(from, now time.Time, limit int) ([]time.Time, error) {
+	var launches []time.Time
+	for i := 0; ; i++ {
+		if i == periodicMissedLaunchesScanLimit {
+			return nil, fmt.Errorf("more than %d launches to search for missed launches", periodicMissedLaunchesScanLimit)
+		}
+
+		next, err := p.Next(from)
+		if err != nil {
+			return nil, err
+		}
+		if next.IsZero() || !next.Before(now) {
+			return launches, nil
+		}
+		launches = append(launches, next)
+		if len(launches) > limit {
+			launches = launches[1:]
+		}
+		from = next
+	}
+}
+
 func (p *PeriodicConfig) Canonicalize() {
 	// Load the location
 	l, err := time.LoadLocation(p.TimeZone)
@@ -6550,6 +7006,15 @@ type RestartPolicy struct {
 	// Delay is the time between a failure and a restart.
 	Delay time.Duration
 
+	// DelayFunction determines how the delay progressively changes on
+	// subsequent restarts within an interval. Valid values are "constant",
+	// "exponential", and "fibonacci". An empty value is treated as "constant".
+	DelayFunction string
+
+	// MaxDelay is an upper bound on the delay when the delay function is not
+	// constant.
+	MaxDelay time.Duration
+
 	// Mode controls what happens when the task restarts more than attempt times
 	// in an interval.
 	Mode string
@@ -6587,6 +7052,16 @@ func (r *RestartPolicy) Validate() error {
 		_ = multierror.Append(&mErr,
 			fmt.Errorf("Nomad can't restart the TaskGroup %v times in an interval of %v with a delay of %v", r.Attempts, r.Interval, r.Delay))
 	}
+
+	switch r.DelayFunction {
+	case "", "constant":
+	case "exponential", "fibonacci":
+		if r.MaxDelay < r.Delay {
+			_ = multierror.Append(&mErr, fmt.Errorf("Max Delay cannot be less than Delay %v (got %v)", r.Delay, r.MaxDelay))
+		}
+	default:
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid delay function %q, must be one of %q", r.DelayFunction, RescheduleDelayFunctions))
+	}
 	return mErr.ErrorOrNil()
 }
 
@@ -6871,6 +7346,65 @@ func (m *MigrateStrategy) Validate() error {
 	return mErr.ErrorOrNil()
 }
 
+// DisruptionBudget limits the number of healthy allocations of a task group
+// which may be voluntarily disrupted at once. It is honored by node drains,
+// preemption, and operator initiated allocation stops. Exactly one of
+// MaxUnavailable or MinAvailable may be set.
+type DisruptionBudget struct {
+	// MaxUnavailable is the maximum number of allocations of the group which
+	// may be unavailable at once.
+	MaxUnavailable int
+
+	// MinAvailable is the minimum number of healthy allocations of the group
+	// which must remain available.
+	MinAvailable int
+}
+
+func (d *DisruptionBudget) Copy() *DisruptionBudget {
+	if d == nil {
+		return nil
+	}
+	nd := new(DisruptionBudget)
+	*nd = *d
+	return nd
+}
+
+func (d *DisruptionBudget) Validate() error {
+	var mErr multierror.Error
+
+	if d.MaxUnavailable < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("MaxUnavailable must be >= 0 but found %d", d.MaxUnavailable))
+	}
+	if d.MinAvailable < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("MinAvailable must be >= 0 but found %d", d.MinAvailable))
+	}
+
+	switch {
+	case d.MaxUnavailable > 0 && d.MinAvailable > 0:
+		_ = multierror.Append(&mErr, errors.New("Only one of MaxUnavailable or MinAvailable may be set"))
+	case d.MaxUnavailable == 0 && d.MinAvailable == 0:
+		_ = multierror.Append(&mErr, errors.New("One of MaxUnavailable or MinAvailable must be set"))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// MinHealthy returns the number of healthy allocations which must remain
+// available for a task group with the given count.
+func (d *DisruptionBudget) MinHealthy(count int) int {
+	if d.MinAvailable > 0 {
+		return d.MinAvailable
+	}
+	return count - d.MaxUnavailable
+}
+
+// Allowed returns the number of additional allocations which may be disrupted
+// for a task group with the given count, when the given number of its
+// allocations are currently healthy.
+func (d *DisruptionBudget) Allowed(count, healthy int) int {
+	return max(healthy-d.MinHealthy(count), 0)
+}
+
 // TaskGroup is an atomic unit of placement. Each task group belongs to
 // a job and may contain any number of tasks. A task group support running
 // in many replicas using the same configuration..
@@ -6882,12 +7416,21 @@ type TaskGroup struct {
 	// be scheduled.
 	Count int
 
+	// Gang is the name of the gang this task group belongs to. The
+	// allocations of every task group in the job sharing the same gang are
+	// placed atomically: either all of them are placed, or none are.
+	Gang string
+
 	// Update is used to control the update strategy for this task group
 	Update *UpdateStrategy
 
 	// Migrate is used to control the migration strategy for this task group
 	Migrate *MigrateStrategy
 
+	// DisruptionBudget limits the number of allocations of this task group
+	// which may be voluntarily disrupted at once
+	DisruptionBudget *DisruptionBudget
+
 	// Constraints can be specified at a task group level and apply to
 	// all the tasks contained.
 	Constraints []*Constraint
@@ -6970,6 +7513,7 @@ func (tg *TaskGroup) Copy() *TaskGroup {
 	ntg := new(TaskGroup)
 	*ntg = *tg
 	ntg.Update = ntg.Update.Copy()
+	ntg.DisruptionBudget = ntg.DisruptionBudget.Copy()
 	ntg.Constraints = CopySliceConstraints(ntg.Constraints)
 	ntg.RestartPolicy = ntg.RestartPolicy.Copy()
 	ntg.Disconnect = ntg.Disconnect.Copy()
@@ -7081,6 +7625,12 @@ func (tg *TaskGroup) Canonicalize(job *Job) {
 	}
 }
 
+// IsGang returns whether the task group is part of a gang and must therefore
+// be placed atomically along with the rest of the gang.
+func (tg *TaskGroup) IsGang() bool {
+	return tg != nil && tg.Gang != ""
+}
+
 // NomadServices returns a list of all group and task - level services in tg that
 // are making use of the nomad service provider.
 func (tg *TaskGroup) NomadServices() []*Service {
@@ -7137,6 +7687,18 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		}
 	}
 
+	if tg.Gang != "" {
+		switch j.Type {
+		case JobTypeService, JobTypeBatch:
+		default:
+			mErr = multierror.Append(mErr, fmt.Errorf("Job type %q does not allow gang", j.Type))
+		}
+
+		if tg.Update != nil && tg.Update.Canary > 0 {
+			mErr = multierror.Append(mErr, errors.New("Task groups in a gang may not use canary deployments"))
+		}
+	}
+
 	if tg.MaxRunDuration != nil {
 		if *tg.MaxRunDuration <= 0 {
 			mErr = multierror.Append(mErr, errors.New("MaxRunDuration must be greater than zero"))
@@ -7237,6 +7799,22 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		}
 	}
 
+	// Validate the disruption budget
+	if tg.DisruptionBudget != nil {
+		switch j.Type {
+		case JobTypeService:
+			if err := tg.DisruptionBudget.Validate(); err != nil {
+				mErr = multierror.Append(mErr, err)
+			}
+			if tg.DisruptionBudget.MinAvailable > tg.Count {
+				mErr = multierror.Append(mErr, fmt.Errorf(
+					"Disruption budget MinAvailable (%d) must not exceed count (%d)", tg.DisruptionBudget.MinAvailable, tg.Count))
+			}
+		default:
+			mErr = multierror.Append(mErr, fmt.Errorf("Job type %q does not allow disruption_budget block", j.Type))
+		}
+	}
+
 	// Check that there is only one leader task if any
 	tasks := make(map[string]int)
 	leaderTasks := 0
@@ -7311,6 +7889,8 @@ func (tg *TaskGroup) validateNetworks() error {
 	// host_network -> static port tracking
 	staticPortsIndex := make(map[string]map[int]string)
 	cniArgKeys := set.New[string](len(tg.Networks))
+	// bind address -> upstream destination tracking
+	upstreamAddrs := make(map[string]string)
 
 	for _, net := range tg.Networks {
 		for _, port := range append(net.ReservedPorts, net.DynamicPorts...) {
@@ -7392,6 +7972,24 @@ func (tg *TaskGroup) validateNetworks() error {
 				mErr.Errors = append(mErr.Errors, errors.New("Hostname is not a valid DNS name"))
 			}
 		}
+
+		// Upstreams are proxied from within the network namespace of the
+		// allocation, so they can't be used in host network mode where they
+		// would listen on the host.
+		if len(net.Upstreams) > 0 && net.Mode != "bridge" && !strings.HasPrefix(net.Mode, "cni/") {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Upstreams require a bridge or cni network mode, not %q", net.Mode))
+		}
+		for _, upstream := range net.Upstreams {
+			if err := upstream.Validate(); err != nil {
+				mErr.Errors = append(mErr.Errors, err)
+				continue
+			}
+			if other, ok := upstreamAddrs[upstream.BindAddr()]; ok {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("Upstream %q bind address %s already in use by upstream %q", upstream.DestinationName, upstream.BindAddr(), other))
+			} else {
+				upstreamAddrs[upstream.BindAddr()] = upstream.DestinationName
+			}
+		}
 	}
 
 	// Check for duplicate tasks or port labels, and no duplicated static ports
@@ -7812,8 +8410,35 @@ type LogConfig struct {
 	MaxFiles      int
 	MaxFileSizeMB int
 	Disabled      bool
+
+	// Sinks are where task logs are forwarded to in addition to the log
+	// files in the alloc dir.
+	Sinks []*LogSink
+
+	// RotationInterval is how long a log file is written to before it's
+	// rotated, even if it hasn't reached MaxFileSizeMB. Zero disables time
+	// based rotation.
+	RotationInterval time.Duration
+
+	// Compression is the algorithm rotated log files are compressed with, one
+	// of the LogCompression constants.
+	Compression string
 }
 
+const (
+	// LogCompressionNone leaves rotated log files uncompressed
+	LogCompressionNone = "none"
+
+	// LogCompressionGzip compresses rotated log files with gzip
+	LogCompressionGzip = "gzip"
+
+	// LogCompressionZstd compresses rotated log files with zstd
+	LogCompressionZstd = "zstd"
+
+	// minLogRotationInterval is the shortest allowed log rotation interval
+	minLogRotationInterval = time.Minute
+)
+
 func (l *LogConfig) Equal(o *LogConfig) bool {
 	if l == nil || o == nil {
 		return l == o
@@ -7831,7 +8456,15 @@ func (l *LogConfig) Equal(o *LogConfig) bool {
 		return false
 	}
 
-	return true
+	if l.RotationInterval != o.RotationInterval {
+		return false
+	}
+
+	if l.Compression != o.Compression {
+		return false
+	}
+
+	return slices.EqualFunc(l.Sinks, o.Sinks, (*LogSink).Equal)
 }
 
 func (l *LogConfig) Copy() *LogConfig {
@@ -7839,9 +8472,12 @@ func (l *LogConfig) Copy() *LogConfig {
 		return nil
 	}
 	return &LogConfig{
-		MaxFiles:      l.MaxFiles,
-		MaxFileSizeMB: l.MaxFileSizeMB,
-		Disabled:      l.Disabled,
+		MaxFiles:         l.MaxFiles,
+		MaxFileSizeMB:    l.MaxFileSizeMB,
+		Disabled:         l.Disabled,
+		Sinks:            helper.CopySlice(l.Sinks),
+		RotationInterval: l.RotationInterval,
+		Compression:      l.Compression,
 	}
 }
 
@@ -7873,6 +8509,117 @@ func (l *LogConfig) Validate(disk *EphemeralDisk) error {
 					logUsage, disk.SizeMB))
 		}
 	}
+	if l.Disabled && len(l.Sinks) > 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("log sinks can't be used when logging is disabled"))
+	}
+	if l.RotationInterval != 0 && l.RotationInterval < minLogRotationInterval {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("minimum rotation interval is %v; got %v",
+			minLogRotationInterval, l.RotationInterval))
+	}
+	switch l.Compression {
+	case "", LogCompressionNone, LogCompressionGzip, LogCompressionZstd:
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("compression must be one of %q, %q or %q; got %q",
+			LogCompressionNone, LogCompressionGzip, LogCompressionZstd, l.Compression))
+	}
+	for i, sink := range l.Sinks {
+		if err := sink.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, multierror.Prefix(err, fmt.Sprintf("Sink %d:", i+1)))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+const (
+	// LogSinkTypeSyslog forwards task logs as RFC5424 messages to a syslog
+	// server over a unix, TCP or UDP socket.
+	LogSinkTypeSyslog = "syslog"
+
+	// LogSinkTypeJournald forwards task logs to the local journald socket.
+	LogSinkTypeJournald = "journald"
+
+	// LogSinkTypeOTLP forwards task logs to an OTLP/HTTP logs endpoint.
+	LogSinkTypeOTLP = "otlp"
+)
+
+// LogSink configures a destination task logs are forwarded to.
+type LogSink struct {
+	// Type is one of the LogSinkType constants
+	Type string
+
+	// Address is where the logs are sent. For syslog it's a URL with a tcp,
+	// udp, unix or unixgram scheme, for journald it's the path of the
+	// journald socket, and for otlp it's the URL of the logs endpoint. If
+	// unset the sink's local default is used.
+	Address string
+
+	// Headers are added to the requests of otlp sinks
+	Headers map[string]string
+
+	// BufferSize is the number of log lines buffered for the sink before
+	// lines are dropped.
+	BufferSize int
+}
+
+func (s *LogSink) Copy() *LogSink {
+	if s == nil {
+		return nil
+	}
+	ns := *s
+	ns.Headers = maps.Clone(s.Headers)
+	return &ns
+}
+
+func (s *LogSink) Equal(o *LogSink) bool {
+	if s == nil || o == nil {
+		return s == o
+	}
+	switch {
+	case s.Type != o.Type:
+		return false
+	case s.Address != o.Address:
+		return false
+	case !maps.Equal(s.Headers, o.Headers):
+		return false
+	case s.BufferSize != o.BufferSize:
+		return false
+	}
+	return true
+}
+
+func (s *LogSink) Validate() error {
+	var mErr multierror.Error
+	switch s.Type {
+	case LogSinkTypeSyslog:
+		if s.Address != "" {
+			u, err := url.Parse(s.Address)
+			if err != nil {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid address: %v", err))
+			} else if !slices.Contains([]string{"tcp", "udp", "unix", "unixgram"}, u.Scheme) {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("syslog address scheme must be one of tcp, udp, unix or unixgram; got %q", u.Scheme))
+			}
+		}
+	case LogSinkTypeJournald:
+		if s.Address != "" && !strings.HasPrefix(s.Address, "/") {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("journald address must be an absolute path; got %q", s.Address))
+		}
+	case LogSinkTypeOTLP:
+		u, err := url.Parse(s.Address)
+		if err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid address: %v", err))
+		} else if u.Scheme != "http" && u.Scheme != "https" {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("otlp address must be an http or https URL; got %q", s.Address))
+		}
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("sink type must be one of %q, %q or %q; got %q",
+			LogSinkTypeSyslog, LogSinkTypeJournald, LogSinkTypeOTLP, s.Type))
+	}
+	if len(s.Headers) > 0 && s.Type != LogSinkTypeOTLP {
+		mErr.Errors = append(mErr.Errors, errors.New("headers can only be set for otlp sinks"))
+	}
+	if s.BufferSize < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("buffer_size must not be negative; got %d", s.BufferSize))
+	}
 	return mErr.ErrorOrNil()
 }
 
@@ -9154,6 +9901,11 @@ type TaskState struct {
 	// not be started again.
 	FinishedAt time.Time
 
+	// CrashLooping marks a task that keeps failing shortly after being
+	// started and is being restarted with its maximum restart delay. It is
+	// cleared once the task stays up for a full restart interval.
+	CrashLooping bool
+
 	// Series of task events that transition the state of the task.
 	Events []*TaskEvent
 
@@ -9224,6 +9976,9 @@ func (ts *TaskState) Equal(o *TaskState) bool {
 	if ts.FinishedAt != o.FinishedAt {
 		return false
 	}
+	if ts.CrashLooping != o.CrashLooping {
+		return false
+	}
 	if !slices.EqualFunc(ts.Events, o.Events, func(ts, o *TaskEvent) bool {
 		return ts.Equal(o)
 	}) {
@@ -9274,6 +10029,10 @@ const (
 	// restarted because it has exceeded its restart policy.
 	TaskNotRestarting = "Not Restarting"
 
+	// TaskCrashLooping indicates that the task keeps failing and is being
+	// restarted with its maximum restart delay.
+	TaskCrashLooping = "Crash Looping"
+
 	// TaskRestartSignal indicates that the task has been signaled to be
 	// restarted
 	TaskRestartSignal = "Restart Signaled"
@@ -9300,6 +10059,10 @@ const (
 	// exceeded the requested disk resources.
 	TaskDiskExceeded = "Disk Resources Exceeded"
 
+	// TaskMemoryPressureEvicted indicates that the allocation was evicted by
+	// the client because the node ran out of memory.
+	TaskMemoryPressureEvicted = "Evicted Memory Pressure"
+
 	// TaskSiblingFailed indicates that a sibling task in the task group has
 	// failed.
 	TaskSiblingFailed = "Sibling Task Failed"
@@ -9526,6 +10289,12 @@ func (e *TaskEvent) PopulateEventDisplayMessage() {
 		} else {
 			desc = "Task exceeded restart policy"
 		}
+	case TaskCrashLooping:
+		if e.RestartReason != "" {
+			desc = fmt.Sprintf("Task is crash looping - %s", e.RestartReason)
+		} else {
+			desc = "Task is crash looping"
+		}
 	case TaskSiblingFailed:
 		if e.FailedSibling != "" {
 			desc = fmt.Sprintf("Task's sibling %q failed", e.FailedSibling)
@@ -9972,6 +10741,7 @@ const (
 	ConstraintSetContains       = "set_contains"
 	ConstraintSetContainsAll    = "set_contains_all"
 	ConstraintSetContainsAny    = "set_contains_any"
+	ConstraintSetContainsNone   = "set_contains_none"
 	ConstraintAttributeIsSet    = "is_set"
 	ConstraintAttributeIsNotSet = "is_not_set"
 )
@@ -10020,7 +10790,7 @@ func (c *Constraint) Validate() error {
 	switch c.Operand {
 	case ConstraintDistinctHosts:
 		requireLtarget = false
-	case ConstraintSetContainsAll, ConstraintSetContainsAny, ConstraintSetContains:
+	case ConstraintSetContainsAll, ConstraintSetContainsAny, ConstraintSetContains, ConstraintSetContainsNone:
 		if c.RTarget == "" {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("Set contains constraint requires an RTarget"))
 		}
@@ -10067,6 +10837,9 @@ func (c *Constraint) Validate() error {
 			if err := validateConstraintAttribute(c.LTarget); err != nil {
 				mErr.Errors = append(mErr.Errors, err)
 			}
+			if err := validateAllocTargetOperand(c.LTarget, c.Operand); err != nil {
+				mErr.Errors = append(mErr.Errors, err)
+			}
 		}
 
 	}
@@ -10108,7 +10881,7 @@ SETEQUALS:
 type Affinity struct {
 	LTarget string // Left-hand target
 	RTarget string // Right-hand target
-	Operand string // Affinity operand (<=, <, =, !=, >, >=), set_contains_all, set_contains_any
+	Operand string // Affinity operand (<=, <, =, !=, >, >=), set_contains_all, set_contains_any, set_contains_none
 	Weight  int8   // Weight applied to nodes that match the affinity. Can be negative
 }
 
@@ -10154,7 +10927,7 @@ func (a *Affinity) Validate() error {
 
 	// Perform additional validation based on operand
 	switch a.Operand {
-	case ConstraintSetContainsAll, ConstraintSetContainsAny, ConstraintSetContains:
+	case ConstraintSetContainsAll, ConstraintSetContainsAny, ConstraintSetContains, ConstraintSetContainsNone:
 		if a.RTarget == "" {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("Set contains operators require an RTarget"))
 		}
@@ -10181,6 +10954,8 @@ func (a *Affinity) Validate() error {
 	// Ensure we have an LTarget
 	if a.LTarget == "" {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("No LTarget provided but is required"))
+	} else if err := validateAllocTargetOperand(a.LTarget, a.Operand); err != nil {
+		mErr.Errors = append(mErr.Errors, err)
 	}
 
 	// Ensure that weight is between -100 and 100, and not zero
@@ -10212,6 +10987,11 @@ type Spread struct {
 	// SpreadTarget is used to describe desired percentages for each attribute value
 	SpreadTarget []*SpreadTarget
 
+	// MaxSkew is the maximum allowed difference between the number of
+	// allocations on the most- and least-used attribute values. Nodes where
+	// a placement would exceed it are infeasible. Zero disables the limit.
+	MaxSkew int
+
 	// Memoized string representation
 	str string
 }
@@ -10225,6 +11005,8 @@ func (s *Spread) Equal(o *Spread) bool {
 		return false
 	case s.Weight != o.Weight:
 		return false
+	case s.MaxSkew != o.MaxSkew:
+		return false
 	case !slices.EqualFunc(s.SpreadTarget, o.SpreadTarget, func(a, b *SpreadTarget) bool { return a.Equal(b) }):
 		return false
 	}
@@ -10283,6 +11065,9 @@ func (s *Spread) Validate() error {
 	if s.Weight <= 0 || s.Weight > 100 {
 		mErr.Errors = append(mErr.Errors, errors.New("Spread block must have a positive weight from 0 to 100"))
 	}
+	if s.MaxSkew < 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("Spread max_skew must not be negative"))
+	}
 	seen := make(map[string]struct{})
 	sumPercent := uint32(0)
 
@@ -10359,8 +11144,31 @@ type EphemeralDisk struct {
 	// Migrate determines if Nomad client should migrate the allocation dir for
 	// sticky allocations
 	Migrate bool
+
+	// PreCopy determines if the Nomad client should start copying the
+	// allocation dir of a remote previous allocation while it is still
+	// running, so only the files changed since then are copied once it stops.
+	// Requires Migrate.
+	PreCopy bool
+
+	// Enforcement determines what the Nomad client does when the allocation
+	// dir grows larger than SizeMB. It's only enforced on Linux clients.
+	Enforcement string
 }
 
+const (
+	// EphemeralDiskEnforcementNone only uses the disk size for scheduling.
+	EphemeralDiskEnforcementNone = "none"
+
+	// EphemeralDiskEnforcementSoft emits a task event when the allocation
+	// exceeds its disk size.
+	EphemeralDiskEnforcementSoft = "soft"
+
+	// EphemeralDiskEnforcementHard fails the allocation when it exceeds its
+	// disk size.
+	EphemeralDiskEnforcementHard = "hard"
+)
+
 // DefaultEphemeralDisk returns a EphemeralDisk with default configurations
 func DefaultEphemeralDisk() *EphemeralDisk {
 	return &EphemeralDisk{
@@ -10379,6 +11187,10 @@ func (d *EphemeralDisk) Equal(o *EphemeralDisk) bool {
 		return false
 	case d.Migrate != o.Migrate:
 		return false
+	case d.PreCopy != o.PreCopy:
+		return false
+	case d.Enforcement != o.Enforcement:
+		return false
 	}
 	return true
 }
@@ -10388,9 +11200,33 @@ func (d *EphemeralDisk) Validate() error {
 	if d.SizeMB < 10 {
 		return fmt.Errorf("minimum DiskMB value is 10; got %d", d.SizeMB)
 	}
+	if d.PreCopy && !d.Migrate {
+		return fmt.Errorf("precopy requires migrate to be enabled")
+	}
+	switch d.Enforcement {
+	case "", EphemeralDiskEnforcementNone, EphemeralDiskEnforcementSoft, EphemeralDiskEnforcementHard:
+	default:
+		return fmt.Errorf("invalid enforcement %q; must be one of %q, %q or %q", d.Enforcement,
+			EphemeralDiskEnforcementNone, EphemeralDiskEnforcementSoft, EphemeralDiskEnforcementHard)
+	}
 	return nil
 }
 
+// Enforced returns whether the client should enforce the disk size, and
+// whether exceeding it fails the allocation.
+func (d *EphemeralDisk) Enforced() (enforced, hard bool) {
+	if d == nil {
+		return false, false
+	}
+	switch d.Enforcement {
+	case EphemeralDiskEnforcementSoft:
+		return true, false
+	case EphemeralDiskEnforcementHard:
+		return true, true
+	}
+	return false, false
+}
+
 // Copy copies the EphemeralDisk struct and returns a new one
 func (d *EphemeralDisk) Copy() *EphemeralDisk {
 	ld := new(EphemeralDisk)
@@ -10525,12 +11361,53 @@ func (v *Vault) Validate() error {
 	return mErr.ErrorOrNil()
 }
 
+const (
+	// SecretChangeModeNoop marks that no action should be taken if the
+	// secret changes
+	SecretChangeModeNoop = "noop"
+
+	// SecretChangeModeSignal marks that the task should be signaled if the
+	// secret changes
+	SecretChangeModeSignal = "signal"
+
+	// SecretChangeModeRestart marks that the task should be restarted if the
+	// secret changes
+	SecretChangeModeRestart = "restart"
+
+	// SecretChangeModeScript marks that the task should trigger a script if
+	// the secret changes
+	SecretChangeModeScript = "script"
+
+	// minSecretRefreshInterval is the shortest allowed secret refresh
+	// interval
+	minSecretRefreshInterval = 10 * time.Second
+)
+
 type Secret struct {
 	Name     string
 	Provider string
 	Path     string
 	Config   map[string]any
 	Env      map[string]string
+
+	// RefreshInterval is how often the secret is fetched again after the
+	// task has started. Zero disables refreshing, so the secret is only
+	// fetched before the task starts, unless its plugin returns a TTL.
+	RefreshInterval time.Duration
+
+	// ChangeMode is the action taken on the task when a refreshed secret
+	// has changed, one of the SecretChangeMode constants. An empty change
+	// mode restarts the task, like a template's default change mode. The
+	// environment of a task that isn't restarted doesn't change, so
+	// refreshed secrets are also rendered to secret.<name>.env in the
+	// task's secrets dir.
+	ChangeMode string
+
+	// ChangeSignal is the signal sent to the task when ChangeMode is signal
+	ChangeSignal string
+
+	// ChangeScript is the script run in the task when ChangeMode is script
+	ChangeScript *ChangeScript
 }
 
 func (s *Secret) Equal(o *Secret) bool {
@@ -10549,6 +11426,14 @@ func (s *Secret) Equal(o *Secret) bool {
 		return false
 	case !maps.Equal(s.Env, o.Env):
 		return false
+	case s.RefreshInterval != o.RefreshInterval:
+		return false
+	case s.ChangeMode != o.ChangeMode:
+		return false
+	case s.ChangeSignal != o.ChangeSignal:
+		return false
+	case !s.ChangeScript.Equal(o.ChangeScript):
+		return false
 	}
 
 	return true
@@ -10567,11 +11452,15 @@ func (s *Secret) Copy() *Secret {
 	}
 
 	return &Secret{
-		Name:     s.Name,
-		Provider: s.Provider,
-		Path:     s.Path,
-		Config:   confCopy.(map[string]any),
-		Env:      maps.Clone(s.Env),
+		Name:            s.Name,
+		Provider:        s.Provider,
+		Path:            s.Path,
+		Config:          confCopy.(map[string]any),
+		Env:             maps.Clone(s.Env),
+		RefreshInterval: s.RefreshInterval,
+		ChangeMode:      s.ChangeMode,
+		ChangeSignal:    s.ChangeSignal,
+		ChangeScript:    s.ChangeScript.Copy(),
 	}
 }
 
@@ -10608,6 +11497,30 @@ func (s *Secret) Validate() error {
 		}
 	}
 
+	if s.RefreshInterval < 0 {
+		_ = multierror.Append(&mErr, errors.New("refresh_interval cannot be negative"))
+	} else if s.RefreshInterval > 0 && s.RefreshInterval < minSecretRefreshInterval {
+		_ = multierror.Append(&mErr, fmt.Errorf("minimum refresh_interval is %v; got %v",
+			minSecretRefreshInterval, s.RefreshInterval))
+	}
+
+	switch s.ChangeMode {
+	case "", SecretChangeModeNoop, SecretChangeModeRestart:
+	case SecretChangeModeSignal:
+		if s.ChangeSignal == "" {
+			_ = multierror.Append(&mErr, errors.New("must specify signal value when change mode is signal"))
+		}
+	case SecretChangeModeScript:
+		if s.ChangeScript == nil {
+			_ = multierror.Append(&mErr, errors.New("must specify change script configuration value when change mode is script"))
+		} else if err := s.ChangeScript.Validate(); err != nil {
+			_ = multierror.Append(&mErr, err)
+		}
+	default:
+		_ = multierror.Append(&mErr, fmt.Errorf("change mode must be one of %q, %q, %q or %q; got %q",
+			SecretChangeModeNoop, SecretChangeModeSignal, SecretChangeModeRestart, SecretChangeModeScript, s.ChangeMode))
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -1962,6 +1962,54 @@ func TestTaskGroup_Validate(t *testing.T) {
 			},
 			jobType: JobTypeService,
 		},
+		{
+			name: "disruption budget not allowed for batch jobs",
+			tg: &TaskGroup{
+				Name:             "group-a",
+				DisruptionBudget: &DisruptionBudget{MaxUnavailable: 1},
+			},
+			expErr: []string{
+				`Job type "batch" does not allow disruption_budget block`,
+			},
+			jobType: JobTypeBatch,
+		},
+		{
+			name: "disruption budget min available exceeds count",
+			tg: &TaskGroup{
+				Name:             "group-a",
+				Count:            2,
+				DisruptionBudget: &DisruptionBudget{MinAvailable: 3},
+			},
+			expErr: []string{
+				"Disruption budget MinAvailable (3) must not exceed count (2)",
+			},
+			jobType: JobTypeService,
+		},
+		{
+			name: "gang not allowed for system jobs",
+			tg: &TaskGroup{
+				Name: "group-a",
+				Gang: "training",
+			},
+			expErr: []string{
+				`Job type "system" does not allow gang`,
+			},
+			jobType: JobTypeSystem,
+		},
+		{
+			name: "gang may not use canaries",
+			tg: &TaskGroup{
+				Name: "group-a",
+				Gang: "training",
+				Update: &UpdateStrategy{
+					Canary: 1,
+				},
+			},
+			expErr: []string{
+				"Task groups in a gang may not use canary deployments",
+			},
+			jobType: JobTypeService,
+		},
 	}
 
 	for _, tc := range tests {
@@ -1979,6 +2027,37 @@ func TestTaskGroup_Validate(t *testing.T) {
 	}
 }
 
+func TestDisruptionBudget(t *testing.T) {
+	ci.Parallel(t)
+
+	t.Run("validate", func(t *testing.T) {
+		must.NoError(t, (&DisruptionBudget{MaxUnavailable: 1}).Validate())
+		must.NoError(t, (&DisruptionBudget{MinAvailable: 1}).Validate())
+
+		err := (&DisruptionBudget{}).Validate()
+		must.ErrorContains(t, err, "One of MaxUnavailable or MinAvailable must be set")
+
+		err = (&DisruptionBudget{MaxUnavailable: 1, MinAvailable: 1}).Validate()
+		must.ErrorContains(t, err, "Only one of MaxUnavailable or MinAvailable may be set")
+
+		err = (&DisruptionBudget{MaxUnavailable: -1}).Validate()
+		must.ErrorContains(t, err, "MaxUnavailable must be >= 0")
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		maxUnavailable := &DisruptionBudget{MaxUnavailable: 2}
+		must.Eq(t, 3, maxUnavailable.MinHealthy(5))
+		must.Eq(t, 2, maxUnavailable.Allowed(5, 5))
+		must.Eq(t, 1, maxUnavailable.Allowed(5, 4))
+		must.Eq(t, 0, maxUnavailable.Allowed(5, 2))
+
+		minAvailable := &DisruptionBudget{MinAvailable: 4}
+		must.Eq(t, 4, minAvailable.MinHealthy(5))
+		must.Eq(t, 1, minAvailable.Allowed(5, 5))
+		must.Eq(t, 0, minAvailable.Allowed(5, 4))
+	})
+}
+
 func TestTaskGroupNetwork_Validate(t *testing.T) {
 	ci.Parallel(t)
 
@@ -2301,6 +2380,51 @@ func TestTaskGroupNetwork_Validate(t *testing.T) {
 			},
 			ErrContains: "collision may not be ignored on non-host network mode",
 		},
+		{
+			TG: &TaskGroup{
+				Name: "testing-upstreams-ok",
+				Networks: []*NetworkResource{{
+					Mode: "bridge",
+					Upstreams: NetworkUpstreams{
+						{DestinationName: "db", LocalBindPort: 5432},
+						{DestinationName: "cache", LocalBindAddress: "127.0.0.2", LocalBindPort: 5432},
+					},
+				}},
+			},
+		},
+		{
+			TG: &TaskGroup{
+				Name: "testing-upstreams-host-network-mode",
+				Networks: []*NetworkResource{{
+					Mode:      "host",
+					Upstreams: NetworkUpstreams{{DestinationName: "db", LocalBindPort: 5432}},
+				}},
+			},
+			ErrContains: "Upstreams require a bridge or cni network mode",
+		},
+		{
+			TG: &TaskGroup{
+				Name: "testing-upstreams-invalid-port",
+				Networks: []*NetworkResource{{
+					Mode:      "bridge",
+					Upstreams: NetworkUpstreams{{DestinationName: "db"}},
+				}},
+			},
+			ErrContains: "local bind port 0 must be between 1 and 65535",
+		},
+		{
+			TG: &TaskGroup{
+				Name: "testing-upstreams-duplicate-bind-address",
+				Networks: []*NetworkResource{{
+					Mode: "cni/mynet",
+					Upstreams: NetworkUpstreams{
+						{DestinationName: "db", LocalBindPort: 5432},
+						{DestinationName: "cache", LocalBindAddress: "127.0.0.1", LocalBindPort: 5432},
+					},
+				}},
+			},
+			ErrContains: "bind address 127.0.0.1:5432 already in use by upstream \"db\"",
+		},
 	}
 
 	for i := range cases {
@@ -3404,6 +3528,115 @@ func TestLogConfig_Equals(t *testing.T) {
 		b := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200}
 		require.True(t, a.Equal(b))
 	})
+
+	t.Run("sinks", func(t *testing.T) {
+		a := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200, Sinks: []*LogSink{{Type: LogSinkTypeJournald}}}
+		b := a.Copy()
+		must.True(t, a.Equal(b))
+		b.Sinks[0].Address = "/run/journal.sock"
+		must.False(t, a.Equal(b))
+	})
+
+	t.Run("rotation", func(t *testing.T) {
+		a := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200, RotationInterval: time.Hour}
+		b := a.Copy()
+		must.True(t, a.Equal(b))
+		b.Compression = LogCompressionGzip
+		must.False(t, a.Equal(b))
+	})
+}
+
+func TestLogConfig_Validate_Sinks(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name   string
+		sinks  []*LogSink
+		expErr string
+	}{
+		{
+			name: "valid",
+			sinks: []*LogSink{
+				{Type: LogSinkTypeSyslog},
+				{Type: LogSinkTypeSyslog, Address: "udp://10.0.0.1:514"},
+				{Type: LogSinkTypeJournald, Address: "/run/systemd/journal/socket"},
+				{
+					Type:    LogSinkTypeOTLP,
+					Address: "https://otel.example.com/v1/logs",
+					Headers: map[string]string{"Authorization": "Bearer token"},
+				},
+			},
+		},
+		{
+			name:   "unknown type",
+			sinks:  []*LogSink{{Type: "kafka"}},
+			expErr: `sink type must be one of "syslog", "journald" or "otlp"; got "kafka"`,
+		},
+		{
+			name:   "syslog scheme",
+			sinks:  []*LogSink{{Type: LogSinkTypeSyslog, Address: "http://10.0.0.1:514"}},
+			expErr: "syslog address scheme must be one of tcp, udp, unix or unixgram",
+		},
+		{
+			name:   "journald path",
+			sinks:  []*LogSink{{Type: LogSinkTypeJournald, Address: "journal.sock"}},
+			expErr: "journald address must be an absolute path",
+		},
+		{
+			name:   "otlp address",
+			sinks:  []*LogSink{{Type: LogSinkTypeOTLP}},
+			expErr: "otlp address must be an http or https URL",
+		},
+		{
+			name: "headers",
+			sinks: []*LogSink{{
+				Type:    LogSinkTypeSyslog,
+				Headers: map[string]string{"X-Token": "secret"},
+			}},
+			expErr: "headers can only be set for otlp sinks",
+		},
+		{
+			name:   "buffer size",
+			sinks:  []*LogSink{{Type: LogSinkTypeJournald, BufferSize: -1}},
+			expErr: "Sink 1: buffer_size must not be negative",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			l := DefaultLogConfig()
+			l.Sinks = tc.sinks
+			err := l.Validate(nil)
+			if tc.expErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.expErr)
+			}
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		l := DefaultLogConfig()
+		l.Disabled = true
+		l.Sinks = []*LogSink{{Type: LogSinkTypeJournald}}
+		must.ErrorContains(t, l.Validate(nil), "log sinks can't be used when logging is disabled")
+	})
+}
+
+func TestLogConfig_Validate_Rotation(t *testing.T) {
+	ci.Parallel(t)
+
+	l := DefaultLogConfig()
+	l.RotationInterval = time.Hour
+	l.Compression = LogCompressionZstd
+	must.NoError(t, l.Validate(nil))
+
+	l.RotationInterval = time.Second
+	must.ErrorContains(t, l.Validate(nil), "minimum rotation interval is 1m0s; got 1s")
+
+	l.RotationInterval = 0
+	l.Compression = "bzip2"
+	must.ErrorContains(t, l.Validate(nil), `compression must be one of "none", "gzip" or "zstd"; got "bzip2"`)
 }
 
 func TestTask_Validate_CSIPluginConfig(t *testing.T) {
@@ -3972,6 +4205,33 @@ func TestConstraint_Validate(t *testing.T) {
 			},
 			expectedErrorMsg: "unsupported attribute",
 		},
+		{
+			name: "invalid set contains none",
+			inputConstraint: &Constraint{
+				LTarget: "${allocs.job_id}",
+				RTarget: "",
+				Operand: ConstraintSetContainsNone,
+			},
+			expectedErrorMsg: "requires an RTarget",
+		},
+		{
+			name: "valid alloc target",
+			inputConstraint: &Constraint{
+				LTarget: "${allocs.job_id}",
+				RTarget: "noisy-batch",
+				Operand: ConstraintSetContainsNone,
+			},
+			expectedErrorMsg: "",
+		},
+		{
+			name: "invalid alloc target operand",
+			inputConstraint: &Constraint{
+				LTarget: "${allocs.meta.team}",
+				RTarget: "web",
+				Operand: "=",
+			},
+			expectedErrorMsg: `attribute "${allocs.meta.team}" only supports the set_contains, set_contains_all, set_contains_any, set_contains_none operators`,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -4059,6 +4319,23 @@ func TestAffinity_Validate(t *testing.T) {
 			},
 			err: fmt.Errorf("Regular expression failed to compile"),
 		},
+		{
+			affinity: &Affinity{
+				Operand: ConstraintSetContains,
+				LTarget: "${allocs.job_id}",
+				RTarget: "api",
+				Weight:  50,
+			},
+		},
+		{
+			affinity: &Affinity{
+				Operand: ConstraintRegex,
+				LTarget: "${allocs.task_group}",
+				RTarget: "api",
+				Weight:  50,
+			},
+			err: fmt.Errorf(`attribute "${allocs.task_group}" only supports the`),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -4100,6 +4377,101 @@ func TestUpdateStrategy_Validate(t *testing.T) {
 	)
 }
 
+func TestUpdateStrategy_Validate_Windows(t *testing.T) {
+	ci.Parallel(t)
+
+	u := DefaultUpdateStrategy.Copy()
+	u.Windows = []*UpdateWindow{
+		{Cron: "0 22 * * *", Duration: 4 * time.Hour, TimeZone: "Europe/London"},
+		{Cron: "not a cron", Duration: 0, TimeZone: "Nowhere/Special"},
+	}
+
+	err := u.Validate()
+	requireErrors(t, err,
+		"Window 2: Invalid cron expression",
+		"Window 2: Duration must be greater than zero",
+		"Window 2: Invalid time zone",
+	)
+}
+
+func TestUpdateStrategy_Validate_Analysis(t *testing.T) {
+	ci.Parallel(t)
+
+	u := DefaultUpdateStrategy.Copy()
+	u.Canary = 1
+	u.Analysis = &UpdateAnalysis{
+		Provider: "prometheus",
+		Query:    "sum(rate(errors[1m]))",
+		Interval: time.Minute,
+		Count:    3,
+		Max:      new(0.01),
+	}
+	must.NoError(t, u.Validate())
+
+	u.Canary = 0
+	u.Analysis = &UpdateAnalysis{
+		FailureLimit: -1,
+		Min:          new(2.0),
+		Max:          new(1.0),
+	}
+	requireErrors(t, u.Validate(),
+		"Analysis requires a Canary count greater than zero",
+		"Analysis: Must specify a provider",
+		"Analysis: Must specify a query",
+		"Analysis: Interval must be greater than zero",
+		"Analysis: Count must be greater than zero",
+		"Analysis: Failure limit can not be less than zero",
+		"Analysis: Min threshold must not be greater than max threshold",
+	)
+
+	u.Analysis.Min, u.Analysis.Max = new(1.0), new(2.0)
+	must.True(t, u.Analysis.Passes(1.5))
+	must.True(t, u.Analysis.Passes(2.0))
+	must.False(t, u.Analysis.Passes(0.5))
+	must.False(t, u.Analysis.Passes(2.5))
+}
+
+func TestUpdateStrategy_WindowOpen(t *testing.T) {
+	ci.Parallel(t)
+
+	// No windows means deployments may always progress
+	open, change := DefaultUpdateStrategy.WindowOpen(time.Now())
+	must.True(t, open)
+	must.True(t, change.IsZero())
+
+	u := DefaultUpdateStrategy.Copy()
+	u.Windows = []*UpdateWindow{
+		// Open every day from 22:00 to 02:00
+		{Cron: "0 22 * * *", Duration: 4 * time.Hour},
+		// Open every day from 12:00 to 12:30
+		{Cron: "0 12 * * *", Duration: 30 * time.Minute},
+	}
+
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		now    time.Time
+		open   bool
+		change time.Time
+	}{
+		{now: day.Add(1 * time.Hour), open: true, change: day.Add(2 * time.Hour)},
+		{now: day.Add(2 * time.Hour), open: false, change: day.Add(12 * time.Hour)},
+		{now: day.Add(12*time.Hour + 10*time.Minute), open: true, change: day.Add(12*time.Hour + 30*time.Minute)},
+		{now: day.Add(13 * time.Hour), open: false, change: day.Add(22 * time.Hour)},
+		{now: day.Add(22 * time.Hour), open: true, change: day.Add(26 * time.Hour)},
+	}
+	for _, tc := range cases {
+		open, change := u.WindowOpen(tc.now)
+		must.Eq(t, tc.open, open, must.Sprintf("now=%v", tc.now))
+		must.Eq(t, tc.change, change.UTC(), must.Sprintf("now=%v", tc.now))
+	}
+
+	// Windows are evaluated in their time zone
+	u.Windows = []*UpdateWindow{{Cron: "0 9 * * *", Duration: time.Hour, TimeZone: "America/New_York"}}
+	open, change = u.WindowOpen(time.Date(2024, 3, 1, 14, 30, 0, 0, time.UTC))
+	must.True(t, open)
+	must.Eq(t, time.Date(2024, 3, 1, 15, 0, 0, 0, time.UTC), change.UTC())
+}
+
 func TestResource_NetIndex(t *testing.T) {
 	ci.Parallel(t)
 
@@ -4851,6 +5223,197 @@ func TestPeriodicConfig_NextCron(t *testing.T) {
 	}
 }
 
+func TestPeriodicConfig_Validate_Policies(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name     string
+		modify   func(*PeriodicConfig)
+		errorMsg string
+	}{
+		{
+			name:   "defaults",
+			modify: func(*PeriodicConfig) {},
+		},
+		{
+			name: "forbid with prohibit overlap",
+			modify: func(p *PeriodicConfig) {
+				p.ProhibitOverlap = true
+				p.ConcurrencyPolicy = PeriodicConcurrencyForbid
+			},
+		},
+		{
+			name: "replace with prohibit overlap",
+			modify: func(p *PeriodicConfig) {
+				p.ProhibitOverlap = true
+				p.ConcurrencyPolicy = PeriodicConcurrencyReplace
+			},
+			errorMsg: `Prohibit overlap may not be set with concurrency policy "replace"`,
+		},
+		{
+			name:     "unknown concurrency policy",
+			modify:   func(p *PeriodicConfig) { p.ConcurrencyPolicy = "queue" },
+			errorMsg: `Unknown concurrency policy "queue"`,
+		},
+		{
+			name: "run-all",
+			modify: func(p *PeriodicConfig) {
+				p.MissedRuns = PeriodicMissedRunsRunAll
+				p.MaxMissedRuns = 3
+				p.StartingDeadline = time.Hour
+			},
+		},
+		{
+			name:     "run-all without max",
+			modify:   func(p *PeriodicConfig) { p.MissedRuns = PeriodicMissedRunsRunAll },
+			errorMsg: `Max missed runs must be positive with missed runs "run-all"`,
+		},
+		{
+			name: "run-all max too large",
+			modify: func(p *PeriodicConfig) {
+				p.MissedRuns = PeriodicMissedRunsRunAll
+				p.MaxMissedRuns = PeriodicMaxMissedRunsLimit + 1
+			},
+			errorMsg: `Max missed runs must be at most 100`,
+		},
+		{
+			name: "max without run-all",
+			modify: func(p *PeriodicConfig) {
+				p.MissedRuns = PeriodicMissedRunsRunOnce
+				p.MaxMissedRuns = 3
+			},
+			errorMsg: `Max missed runs may only be set with missed runs "run-all"`,
+		},
+		{
+			name:     "unknown missed runs policy",
+			modify:   func(p *PeriodicConfig) { p.MissedRuns = "run-twice" },
+			errorMsg: `Unknown missed runs policy "run-twice"`,
+		},
+		{
+			name:     "negative starting deadline",
+			modify:   func(p *PeriodicConfig) { p.StartingDeadline = -time.Second },
+			errorMsg: "Starting deadline must be non-negative",
+		},
+		{
+			name:     "negative child history limit",
+			modify:   func(p *PeriodicConfig) { p.ChildHistoryLimit = -1 },
+			errorMsg: "Child history limit must be non-negative",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p := &PeriodicConfig{Enabled: true, SpecType: PeriodicSpecCron, Spec: "@hourly"}
+			c.modify(p)
+			p.Canonicalize()
+
+			err := p.Validate()
+			if c.errorMsg == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, c.errorMsg)
+			}
+		})
+	}
+}
+
+func TestPeriodicConfig_MissedLaunches(t *testing.T) {
+	ci.Parallel(t)
+
+	last := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	now := last.Add(50 * time.Minute)
+	at := func(minutes ...int) []time.Time {
+		var launches []time.Time
+		for _, m := range minutes {
+			launches = append(launches, last.Add(time.Duration(m)*time.Minute))
+		}
+		return launches
+	}
+
+	cases := []struct {
+		name     string
+		modify   func(*PeriodicConfig)
+		expected []time.Time
+	}{
+		{
+			name:     "default",
+			modify:   func(*PeriodicConfig) {},
+			expected: at(45),
+		},
+		{
+			name:   "skip",
+			modify: func(p *PeriodicConfig) { p.MissedRuns = PeriodicMissedRunsSkip },
+		},
+		{
+			name:     "run-once",
+			modify:   func(p *PeriodicConfig) { p.MissedRuns = PeriodicMissedRunsRunOnce },
+			expected: at(45),
+		},
+		{
+			name: "run-all",
+			modify: func(p *PeriodicConfig) {
+				p.MissedRuns = PeriodicMissedRunsRunAll
+				p.MaxMissedRuns = 20
+			},
+			expected: at(15, 30, 45),
+		},
+		{
+			name: "run-all limited",
+			modify: func(p *PeriodicConfig) {
+				p.MissedRuns = PeriodicMissedRunsRunAll
+				p.MaxMissedRuns = 2
+			},
+			expected: at(30, 45),
+		},
+		{
+			name: "run-all with deadline",
+			modify: func(p *PeriodicConfig) {
+				p.MissedRuns = PeriodicMissedRunsRunAll
+				p.MaxMissedRuns = 20
+				p.StartingDeadline = 20 * time.Minute
+			},
+			expected: at(30, 45),
+		},
+		{
+			name:   "run-once past deadline",
+			modify: func(p *PeriodicConfig) { p.StartingDeadline = 2 * time.Minute },
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p := &PeriodicConfig{Enabled: true, SpecType: PeriodicSpecCron, Spec: "*/15 * * * *"}
+			c.modify(p)
+			p.Canonicalize()
+
+			missed, err := p.MissedLaunches(last, now)
+			must.NoError(t, err)
+			must.Eq(t, c.expected, missed)
+		})
+	}
+
+	t.Run("frequent launches", func(t *testing.T) {
+		// a job launched every second which missed a year of launches only
+		// has its most recent launches iterated over
+		p := &PeriodicConfig{
+			Enabled:       true,
+			SpecType:      PeriodicSpecCron,
+			Spec:          "* * * * * * *",
+			MissedRuns:    PeriodicMissedRunsRunAll,
+			MaxMissedRuns: 3,
+		}
+		p.Canonicalize()
+
+		missed, err := p.MissedLaunches(now.AddDate(-1, 0, 0), now)
+		must.NoError(t, err)
+		must.Eq(t, []time.Time{
+			now.Add(-3 * time.Second),
+			now.Add(-2 * time.Second),
+			now.Add(-time.Second),
+		}, missed)
+	})
+}
+
 func TestPeriodicConfig_ValidTimeZone(t *testing.T) {
 	ci.Parallel(t)
 
@@ -4997,6 +5560,31 @@ func TestRestartPolicy_Validate(t *testing.T) {
 	if err := p.Validate(); err == nil || !strings.Contains(err.Error(), "Interval can not be less than") {
 		t.Fatalf("expect interval too small error, got: %v", err)
 	}
+
+	// Policy with exponential backoff passes
+	p = &RestartPolicy{
+		Mode:          RestartPolicyModeDelay,
+		Attempts:      3,
+		Delay:         5 * time.Second,
+		DelayFunction: "exponential",
+		MaxDelay:      time.Minute,
+		Interval:      5 * time.Minute,
+	}
+	if err := p.Validate(); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	// Fails when max delay is less than delay
+	p.MaxDelay = time.Second
+	if err := p.Validate(); err == nil || !strings.Contains(err.Error(), "Max Delay cannot be less than Delay") {
+		t.Fatalf("expect max delay error, got: %v", err)
+	}
+
+	// Bad delay function fails
+	p.DelayFunction = "linear"
+	if err := p.Validate(); err == nil || !strings.Contains(err.Error(), "Invalid delay function") {
+		t.Fatalf("expect delay function error, got: %v", err)
+	}
 }
 
 func TestReschedulePolicy_Validate(t *testing.T) {
@@ -5738,6 +6326,58 @@ func TestSecrets_Validate(t *testing.T) {
 			},
 			expectErr: errors.New("custom plugin provider test cannot use the config block"),
 		},
+		{
+			name: "valid refresh",
+			secret: &Secret{
+				Name:            "testsecret",
+				Provider:        "test",
+				Path:            "test",
+				RefreshInterval: time.Minute,
+				ChangeMode:      SecretChangeModeSignal,
+				ChangeSignal:    "SIGHUP",
+			},
+			expectErr: nil,
+		},
+		{
+			name: "refresh interval too short",
+			secret: &Secret{
+				Name:            "testsecret",
+				Provider:        "test",
+				Path:            "test",
+				RefreshInterval: time.Second,
+			},
+			expectErr: errors.New("minimum refresh_interval is 10s; got 1s"),
+		},
+		{
+			name: "signal change mode without signal",
+			secret: &Secret{
+				Name:       "testsecret",
+				Provider:   "test",
+				Path:       "test",
+				ChangeMode: SecretChangeModeSignal,
+			},
+			expectErr: errors.New("must specify signal value when change mode is signal"),
+		},
+		{
+			name: "script change mode without script",
+			secret: &Secret{
+				Name:       "testsecret",
+				Provider:   "test",
+				Path:       "test",
+				ChangeMode: SecretChangeModeScript,
+			},
+			expectErr: errors.New("must specify change script configuration value when change mode is script"),
+		},
+		{
+			name: "unknown change mode",
+			secret: &Secret{
+				Name:       "testsecret",
+				Provider:   "test",
+				Path:       "test",
+				ChangeMode: "reload",
+			},
+			expectErr: errors.New(`change mode must be one of "noop", "signal", "restart" or "script"; got "reload"`),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -6459,6 +7099,15 @@ func TestSpread_Validate(t *testing.T) {
 			err:  fmt.Errorf("Spread block must have a positive weight from 0 to 100"),
 			name: "Invalid weight",
 		},
+		{
+			spread: &Spread{
+				Attribute: "${node.datacenter}",
+				Weight:    50,
+				MaxSkew:   -1,
+			},
+			err:  fmt.Errorf("Spread max_skew must not be negative"),
+			name: "Invalid max skew",
+		},
 		{
 			spread: &Spread{
 				Attribute: "${node.datacenter}",
@@ -7288,9 +7937,11 @@ func TestEphemeralDisk_Equal(t *testing.T) {
 	must.NotEqual[*EphemeralDisk](t, nil, new(EphemeralDisk))
 
 	must.StructEqual(t, &EphemeralDisk{
-		Sticky:  true,
-		SizeMB:  42,
-		Migrate: true,
+		Sticky:      true,
+		SizeMB:      42,
+		Migrate:     true,
+		PreCopy:     true,
+		Enforcement: EphemeralDiskEnforcementSoft,
 	}, []must.Tweak[*EphemeralDisk]{{
 		Field: "Sticky",
 		Apply: func(e *EphemeralDisk) { e.Sticky = false },
@@ -7300,9 +7951,49 @@ func TestEphemeralDisk_Equal(t *testing.T) {
 	}, {
 		Field: "Migrate",
 		Apply: func(e *EphemeralDisk) { e.Migrate = false },
+	}, {
+		Field: "PreCopy",
+		Apply: func(e *EphemeralDisk) { e.PreCopy = false },
+	}, {
+		Field: "Enforcement",
+		Apply: func(e *EphemeralDisk) { e.Enforcement = EphemeralDiskEnforcementHard },
 	}})
 }
 
+func TestEphemeralDisk_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	d := DefaultEphemeralDisk()
+	must.NoError(t, d.Validate())
+	enforced, hard := d.Enforced()
+	must.False(t, enforced)
+	must.False(t, hard)
+
+	d.Enforcement = EphemeralDiskEnforcementSoft
+	must.NoError(t, d.Validate())
+	enforced, hard = d.Enforced()
+	must.True(t, enforced)
+	must.False(t, hard)
+
+	d.Enforcement = EphemeralDiskEnforcementHard
+	must.NoError(t, d.Validate())
+	enforced, hard = d.Enforced()
+	must.True(t, enforced)
+	must.True(t, hard)
+
+	d.Enforcement = "quota"
+	must.ErrorContains(t, d.Validate(), `invalid enforcement "quota"`)
+
+	d = &EphemeralDisk{SizeMB: 5}
+	must.ErrorContains(t, d.Validate(), "minimum DiskMB value is 10")
+
+	d = &EphemeralDisk{SizeMB: 10, PreCopy: true}
+	must.ErrorContains(t, d.Validate(), "precopy requires migrate")
+
+	d.Migrate = true
+	must.NoError(t, d.Validate())
+}
+
 func TestDNSConfig_Equal(t *testing.T) {
 	ci.Parallel(t)
 
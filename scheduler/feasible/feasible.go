@@ -15,6 +15,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/go-set/v3"
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/nomad/helper/constraints/semver"
 	"github.com/hashicorp/nomad/nomad/state"
@@ -1017,6 +1018,85 @@ func (iter *DistinctPropertyIterator) Reset() {
 	}
 }
 
+// AllocConstraintIterator is a FeasibleIterator which returns nodes that pass
+// constraints targeting the allocations placed on a node, such as
+// "${allocs.job_id}". These constraints depend on the proposed allocations for
+// a node rather than the node alone, so unlike other constraints they cannot be
+// checked by the ConstraintChecker using computed node classes. Only the
+// allocations in the namespace of the job are considered.
+type AllocConstraintIterator struct {
+	ctx       Context
+	source    FeasibleIterator
+	namespace string
+
+	jobConstraints []*structs.Constraint
+	constraints    []*structs.Constraint
+}
+
+// NewAllocConstraintIterator creates an AllocConstraintIterator from a source.
+func NewAllocConstraintIterator(ctx Context, source FeasibleIterator) *AllocConstraintIterator {
+	return &AllocConstraintIterator{
+		ctx:    ctx,
+		source: source,
+	}
+}
+
+func (iter *AllocConstraintIterator) SetJob(job *structs.Job) {
+	iter.namespace = job.Namespace
+	iter.jobConstraints = allocConstraints(job.Constraints)
+}
+
+func (iter *AllocConstraintIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	tgConstr := TaskGroupConstraints(tg)
+	iter.constraints = append(slices.Clone(iter.jobConstraints), allocConstraints(tgConstr.Constraints)...)
+}
+
+// allocConstraints returns the subset of constraints which target the
+// allocations placed on a node.
+func allocConstraints(constraints []*structs.Constraint) []*structs.Constraint {
+	var result []*structs.Constraint
+	for _, c := range constraints {
+		if structs.IsAllocTarget(c.LTarget) {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+func (iter *AllocConstraintIterator) Next() *structs.Node {
+OUTER:
+	for {
+		// Get the next option from the source
+		option := iter.source.Next()
+
+		// Hot path if there is nothing to check
+		if option == nil || len(iter.constraints) == 0 {
+			return option
+		}
+
+		proposed, err := iter.ctx.ProposedAllocs(option.ID)
+		if err != nil {
+			iter.ctx.Logger().Named("alloc_constraint").Error("failed to get proposed allocations", "error", err)
+			continue
+		}
+
+		for _, constraint := range iter.constraints {
+			lVal, lOk := resolveAllocTarget(constraint.LTarget, iter.namespace, proposed)
+			rVal, rOk := resolveTarget(constraint.RTarget, option)
+			if !checkConstraint(iter.ctx, constraint.Operand, lVal, rVal, lOk, rOk) {
+				iter.ctx.Metrics().FilterNode(option, constraint.String())
+				continue OUTER
+			}
+		}
+
+		return option
+	}
+}
+
+func (iter *AllocConstraintIterator) Reset() {
+	iter.source.Reset()
+}
+
 // ConstraintChecker is a FeasibilityChecker which returns nodes that match a
 // given set of constraints. This is used to filter on job, task group, and task
 // constraints.
@@ -1049,6 +1129,12 @@ func (c *ConstraintChecker) Feasible(option *structs.Node) bool {
 }
 
 func (c *ConstraintChecker) meetsConstraint(constraint *structs.Constraint, option *structs.Node) bool {
+	// Constraints targeting allocations are handled by the
+	// AllocConstraintIterator, as they cannot be evaluated from the node alone.
+	if structs.IsAllocTarget(constraint.LTarget) {
+		return true
+	}
+
 	// Resolve the targets. Targets that are not present are treated as `nil`.
 	// This is to allow for matching constraints where a target is not present.
 	lVal, lOk := resolveTarget(constraint.LTarget, option)
@@ -1097,6 +1183,55 @@ func resolveTarget(target string, node *structs.Node) (string, bool) {
 	}
 }
 
+// resolveAllocTarget is used to resolve a target referring to the allocations
+// placed on a node, such as "${allocs.job_id}". Only non-terminal allocations
+// in the given namespace are considered. The result is the comma separated set
+// of distinct values found across those allocations, so that it can be used
+// with the set operators. The target is not found if no allocation provides a
+// value.
+func resolveAllocTarget(target, namespace string, allocs []*structs.Allocation) (string, bool) {
+	values := set.New[string](len(allocs))
+	for _, alloc := range allocs {
+		if alloc.Namespace != namespace || alloc.TerminalStatus() {
+			continue
+		}
+
+		switch {
+		case "${allocs.job_id}" == target:
+			values.Insert(alloc.JobID)
+
+		case "${allocs.task_group}" == target:
+			values.Insert(alloc.TaskGroup)
+
+		case strings.HasPrefix(target, "${allocs.meta."):
+			key := strings.TrimSuffix(strings.TrimPrefix(target, "${allocs.meta."), "}")
+			if val, ok := allocMeta(alloc, key); ok {
+				values.Insert(val)
+			}
+		}
+	}
+
+	if values.Empty() {
+		return "", false
+	}
+	return strings.Join(slices.Sorted(values.Items()), ","), true
+}
+
+// allocMeta looks up the meta key for an allocation, with the task group meta
+// taking precedence over the job meta.
+func allocMeta(alloc *structs.Allocation, key string) (string, bool) {
+	if alloc.Job == nil {
+		return "", false
+	}
+	if tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup); tg != nil {
+		if val, ok := tg.Meta[key]; ok {
+			return val, true
+		}
+	}
+	val, ok := alloc.Job.Meta[key]
+	return val, ok
+}
+
 // checkConstraint checks if a constraint is satisfied. The lVal and rVal
 // interfaces may be nil.
 func checkConstraint(ctx ConstraintContext, operand string, lVal, rVal interface{}, lFound, rFound bool) bool {
@@ -1131,6 +1266,8 @@ func checkConstraint(ctx ConstraintContext, operand string, lVal, rVal interface
 		return lFound && rFound && checkSetContainsAll(lVal, rVal)
 	case structs.ConstraintSetContainsAny:
 		return lFound && rFound && checkSetContainsAny(lVal, rVal)
+	case structs.ConstraintSetContainsNone:
+		return rFound && (!lFound || !checkSetContainsAny(lVal, rVal))
 	default:
 		return false
 	}
@@ -1506,6 +1643,21 @@ OUTER:
 	}
 }
 
+// FeasibleNodes returns the nodes that pass the feasibility checks of the job
+// and task group, without advancing the source. The checks record their
+// results in the metrics of the context like Next does.
+func (w *FeasibilityWrapper) FeasibleNodes(nodes []*structs.Node) []*structs.Node {
+	source := w.source
+	w.source = NewStaticIterator(w.ctx, nodes)
+	defer func() { w.source = source }()
+
+	var feasible []*structs.Node
+	for option := w.Next(); option != nil; option = w.Next() {
+		feasible = append(feasible, option)
+	}
+	return feasible
+}
+
 // available checks transient feasibility checkers which depend on changing conditions,
 // e.g. the health status of a plugin or driver, or that are not considered in node
 // computed class, e.g. host volumes.
@@ -1789,6 +1941,21 @@ func checkAttributeConstraint(ctx ConstraintContext, operand string, lVal, rVal
 		}
 
 		return checkSetContainsAny(ls, rs)
+	case structs.ConstraintSetContainsNone:
+		if !rFound {
+			return false
+		}
+		if !lFound {
+			return true
+		}
+
+		ls, ok := lVal.GetString()
+		rs, ok2 := rVal.GetString()
+		if !ok || !ok2 {
+			return false
+		}
+
+		return !checkSetContainsAny(ls, rs)
 	case structs.ConstraintAttributeIsSet:
 		return lFound
 	case structs.ConstraintAttributeIsNotSet:
@@ -1766,6 +1766,21 @@ func TestCheckConstraint(t *testing.T) {
 			lVal: "foo,bar,baz", rVal: "foo,bam",
 			result: false,
 		},
+		{
+			op:   structs.ConstraintSetContainsNone,
+			lVal: "foo,bar,baz", rVal: "bam, qux",
+			result: true,
+		},
+		{
+			op:   structs.ConstraintSetContainsNone,
+			lVal: "foo,bar,baz", rVal: "bam,bar",
+			result: false,
+		},
+		{
+			op:   structs.ConstraintSetContainsNone,
+			lVal: nil, rVal: "bar",
+			result: true,
+		},
 		{
 			op:     structs.ConstraintAttributeIsSet,
 			lVal:   "foo",
@@ -2335,6 +2350,169 @@ func TestDistinctHostsIterator_TaskGroupDistinctHosts(t *testing.T) {
 	}
 }
 
+func TestResolveAllocTarget(t *testing.T) {
+	ci.Parallel(t)
+
+	job := mock.Job()
+	job.Meta = map[string]string{"team": "web", "tier": "frontend"}
+	job.TaskGroups[0].Meta = map[string]string{"tier": "cache"}
+
+	allocs := []*structs.Allocation{
+		{ID: uuid.Generate(), Namespace: job.Namespace, JobID: job.ID, Job: job, TaskGroup: "web"},
+		{ID: uuid.Generate(), Namespace: job.Namespace, JobID: "api", TaskGroup: "server"},
+		{ID: uuid.Generate(), Namespace: job.Namespace, JobID: "api", TaskGroup: "server"},
+
+		// Should be ignored as it is terminal
+		{ID: uuid.Generate(), Namespace: job.Namespace, JobID: "stopped", TaskGroup: "old",
+			DesiredStatus: structs.AllocDesiredStatusStop},
+
+		// Should be ignored as it is in a different namespace
+		{ID: uuid.Generate(), Namespace: "other", JobID: "elsewhere", TaskGroup: "other"},
+	}
+
+	cases := []struct {
+		target   string
+		expVal   string
+		expFound bool
+	}{
+		{target: "${allocs.job_id}", expVal: "api," + job.ID, expFound: true},
+		{target: "${allocs.task_group}", expVal: "server,web", expFound: true},
+		{target: "${allocs.meta.team}", expVal: "web", expFound: true},
+		{target: "${allocs.meta.tier}", expVal: "cache", expFound: true},
+		{target: "${allocs.meta.missing}", expVal: "", expFound: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.target, func(t *testing.T) {
+			val, found := resolveAllocTarget(tc.target, job.Namespace, allocs)
+			must.Eq(t, tc.expVal, val)
+			must.Eq(t, tc.expFound, found)
+		})
+	}
+
+	val, found := resolveAllocTarget("${allocs.job_id}", job.Namespace, nil)
+	must.Eq(t, "", val)
+	must.False(t, found)
+}
+
+func TestAllocConstraintIterator(t *testing.T) {
+	ci.Parallel(t)
+
+	_, ctx := MockContext(t)
+	nodes := []*structs.Node{
+		mock.Node(),
+		mock.Node(),
+		mock.Node(),
+	}
+
+	// Place the "api" job on node1 and the "noisy" job on node2, leaving node3
+	// empty.
+	plan := ctx.Plan()
+	plan.NodeAllocation[nodes[0].ID] = []*structs.Allocation{{
+		ID:        uuid.Generate(),
+		Namespace: structs.DefaultNamespace,
+		JobID:     "api",
+		TaskGroup: "api",
+	}}
+	plan.NodeAllocation[nodes[1].ID] = []*structs.Allocation{{
+		ID:        uuid.Generate(),
+		Namespace: structs.DefaultNamespace,
+		JobID:     "noisy",
+		TaskGroup: "batch",
+	}}
+
+	cases := []struct {
+		name        string
+		jobConstr   []*structs.Constraint
+		tgConstr    []*structs.Constraint
+		expNodes    []*structs.Node
+		expFiltered int
+	}{
+		{
+			name:     "no constraints",
+			expNodes: nodes,
+		},
+		{
+			name: "job colocate",
+			jobConstr: []*structs.Constraint{{
+				LTarget: "${allocs.job_id}",
+				RTarget: "api",
+				Operand: structs.ConstraintSetContains,
+			}},
+			expNodes:    []*structs.Node{nodes[0]},
+			expFiltered: 2,
+		},
+		{
+			name: "group anti-affinity",
+			tgConstr: []*structs.Constraint{{
+				LTarget: "${allocs.job_id}",
+				RTarget: "noisy",
+				Operand: structs.ConstraintSetContainsNone,
+			}},
+			expNodes:    []*structs.Node{nodes[0], nodes[2]},
+			expFiltered: 1,
+		},
+		{
+			name: "job and group",
+			jobConstr: []*structs.Constraint{{
+				LTarget: "${allocs.task_group}",
+				RTarget: "api,batch",
+				Operand: structs.ConstraintSetContainsAny,
+			}},
+			tgConstr: []*structs.Constraint{{
+				LTarget: "${allocs.job_id}",
+				RTarget: "noisy",
+				Operand: structs.ConstraintSetContainsNone,
+			}},
+			expNodes:    []*structs.Node{nodes[0]},
+			expFiltered: 2,
+		},
+		{
+			name: "ignores node constraints",
+			jobConstr: []*structs.Constraint{{
+				LTarget: "${node.class}",
+				RTarget: "does-not-exist",
+				Operand: "=",
+			}},
+			expNodes: nodes,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx.Reset()
+			tg := &structs.TaskGroup{Name: "cache", Constraints: tc.tgConstr}
+			job := &structs.Job{
+				ID:          "cache",
+				Namespace:   structs.DefaultNamespace,
+				Constraints: tc.jobConstr,
+				TaskGroups:  []*structs.TaskGroup{tg},
+			}
+
+			iter := NewAllocConstraintIterator(ctx, NewStaticIterator(ctx, nodes))
+			iter.SetJob(job)
+			iter.SetTaskGroup(tg)
+
+			out := collectFeasible(iter)
+			must.SliceContainsAll(t, tc.expNodes, out)
+			must.Eq(t, tc.expFiltered, ctx.Metrics().NodesFiltered)
+		})
+	}
+}
+
+func TestConstraintChecker_SkipsAllocTargets(t *testing.T) {
+	ci.Parallel(t)
+
+	_, ctx := MockContext(t)
+	checker := NewConstraintChecker(ctx, []*structs.Constraint{{
+		LTarget: "${allocs.job_id}",
+		RTarget: "api",
+		Operand: structs.ConstraintSetContains,
+	}})
+
+	must.True(t, checker.Feasible(mock.Node()))
+}
+
 // This test puts creates allocations across task groups that use a property
 // value to detect if the constraint at the job level properly considers all
 // task groups.
@@ -175,6 +175,7 @@ func (p *Preemptor) SetNode(node *structs.Node) {
 func (p *Preemptor) SetCandidates(allocs []*structs.Allocation) {
 	// Reset candidate set
 	p.currentAllocs = []*structs.Allocation{}
+	disruptions := make(map[disruptionKey]int)
 	for _, alloc := range allocs {
 		// Ignore any allocations of the job being placed
 		// This filters out any previous allocs of the job, and any new allocs in the plan
@@ -187,11 +188,58 @@ func (p *Preemptor) SetCandidates(allocs []*structs.Allocation) {
 		if tg != nil && tg.Migrate != nil {
 			maxParallel = tg.Migrate.MaxParallel
 		}
+
+		// Ignore any allocations whose task group can't tolerate any further
+		// disruption
+		if tg != nil && tg.DisruptionBudget != nil {
+			key := disruptionKey{structs.NewNamespacedID(alloc.JobID, alloc.Namespace), alloc.TaskGroup}
+			allowed, ok := disruptions[key]
+			if !ok {
+				allowed = p.allowedDisruptions(alloc, tg)
+			}
+			if allowed <= 0 {
+				disruptions[key] = 0
+				continue
+			}
+			disruptions[key] = allowed - 1
+		}
+
 		p.allocDetails[alloc.ID] = &allocInfo{maxParallel: maxParallel, resources: alloc.AllocatedResources.Comparable()}
 		p.currentAllocs = append(p.currentAllocs, alloc)
 	}
 }
 
+// disruptionKey identifies the task group of a job whose disruption budget is
+// being tracked
+type disruptionKey struct {
+	job       structs.NamespacedID
+	taskGroup string
+}
+
+// allowedDisruptions returns the number of allocations of the alloc's task
+// group which may still be preempted without violating the group's disruption
+// budget, accounting for the preemptions already made in the plan.
+func (p *Preemptor) allowedDisruptions(alloc *structs.Allocation, tg *structs.TaskGroup) int {
+	allocs, err := p.ctx.State().AllocsByJob(nil, alloc.Namespace, alloc.JobID, false)
+	if err != nil {
+		p.ctx.Logger().Error("failed to get allocations for disruption budget",
+			"job_id", alloc.JobID, "namespace", alloc.Namespace, "error", err)
+		return 0
+	}
+
+	available := 0
+	for _, a := range allocs {
+		if a.TaskGroup == tg.Name && a.AvailableForDisruptionBudget() {
+			available++
+		}
+	}
+
+	// Allocations preempted earlier in the plan are still running in the
+	// state store, so remove them from the available allocations
+	available -= p.getNumPreemptions(alloc)
+	return tg.DisruptionBudget.Allowed(tg.Count, available)
+}
+
 // SetPreemptions initializes a map tracking existing counts of preempted allocations
 // per job/task group. This is used while scoring preemption options
 func (p *Preemptor) SetPreemptions(allocs []*structs.Allocation) {
@@ -1367,3 +1367,57 @@ func TestPreemption_Normal(t *testing.T) {
 		})
 	}
 }
+
+func TestPreemptor_SetCandidates_DisruptionBudget(t *testing.T) {
+	ci.Parallel(t)
+
+	state, ctx := MockContext(t)
+
+	job := mock.Job()
+	job.Priority = 30
+	job.TaskGroups[0].Count = 4
+	job.TaskGroups[0].DisruptionBudget = &structs.DisruptionBudget{MaxUnavailable: 1}
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 999, nil, job))
+
+	// Create four healthy allocations of the job, one of which is on another
+	// node
+	var allocs []*structs.Allocation
+	for range 4 {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		alloc.DeploymentStatus = &structs.AllocDeploymentStatus{Healthy: new(true)}
+		allocs = append(allocs, alloc)
+	}
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, allocs))
+
+	// An allocation without a budget is always a candidate
+	other := mock.Alloc()
+	other.Job.Priority = 30
+
+	jobID := structs.NewNamespacedID("preempting", structs.DefaultNamespace)
+	preemptor := NewPreemptor(100, ctx, &jobID)
+
+	// Only one allocation of the budgeted group may be preempted
+	preemptor.SetCandidates(append(allocs[:3:3], other))
+	must.Len(t, 2, preemptor.currentAllocs)
+	must.Eq(t, other.ID, preemptor.currentAllocs[1].ID)
+
+	// With a preemption already in the plan, the budget is exhausted
+	preemptor.SetPreemptions([]*structs.Allocation{allocs[3]})
+	preemptor.SetCandidates(append(allocs[:3:3], other))
+	must.Len(t, 1, preemptor.currentAllocs)
+	must.Eq(t, other.ID, preemptor.currentAllocs[0].ID)
+
+	// Unhealthy allocations don't count toward the budget
+	preemptor.SetPreemptions(nil)
+	allocs[0] = allocs[0].Copy()
+	allocs[0].DeploymentStatus.Healthy = new(false)
+	allocs[1] = allocs[1].Copy()
+	allocs[1].DeploymentStatus.Healthy = new(false)
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1001, allocs[:2]))
+	preemptor.SetCandidates(append(allocs[:3:3], other))
+	must.Len(t, 1, preemptor.currentAllocs)
+	must.Eq(t, other.ID, preemptor.currentAllocs[0].ID)
+}
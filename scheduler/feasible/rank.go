@@ -919,6 +919,7 @@ func (iter *NodeReschedulingPenaltyIterator) Reset() {
 type NodeAffinityIterator struct {
 	ctx           Context
 	source        RankIterator
+	namespace     string
 	jobAffinities []*structs.Affinity
 	affinities    []*structs.Affinity
 }
@@ -934,6 +935,7 @@ func NewNodeAffinityIterator(ctx Context, source RankIterator) *NodeAffinityIter
 }
 
 func (iter *NodeAffinityIterator) SetJob(job *structs.Job) {
+	iter.namespace = job.Namespace
 	iter.jobAffinities = job.Affinities
 }
 
@@ -979,9 +981,28 @@ func (iter *NodeAffinityIterator) Next() *RankedNode {
 		sumWeight += math.Abs(float64(affinity.Weight))
 	}
 
+	// Affinities targeting allocations need the proposed allocations for the
+	// node, which are only looked up once and only if required.
+	var proposed []*structs.Allocation
+	var proposedLoaded bool
+
 	totalAffinityScore := 0.0
 	for _, affinity := range iter.affinities {
-		if matchesAffinity(iter.ctx, affinity, option.Node) {
+		var matches bool
+		if structs.IsAllocTarget(affinity.LTarget) {
+			if !proposedLoaded {
+				var err error
+				proposed, err = iter.ctx.ProposedAllocs(option.Node.ID)
+				if err != nil {
+					iter.ctx.Logger().Named("node_affinity").Error("failed to get proposed allocations", "error", err)
+				}
+				proposedLoaded = true
+			}
+			matches = matchesAllocAffinity(iter.ctx, affinity, option.Node, iter.namespace, proposed)
+		} else {
+			matches = matchesAffinity(iter.ctx, affinity, option.Node)
+		}
+		if matches {
 			totalAffinityScore += float64(affinity.Weight)
 		}
 	}
@@ -1001,6 +1022,16 @@ func matchesAffinity(ctx Context, affinity *structs.Affinity, option *structs.No
 	return checkAffinity(ctx, affinity.Operand, lVal, rVal, lOk, rOk)
 }
 
+// matchesAllocAffinity checks an affinity which targets the allocations placed
+// on a node, such as "${allocs.job_id}", against the proposed allocations in
+// the namespace of the job.
+func matchesAllocAffinity(ctx Context, affinity *structs.Affinity, option *structs.Node, namespace string, proposed []*structs.Allocation) bool {
+	lVal, lOk := resolveAllocTarget(affinity.LTarget, namespace, proposed)
+	rVal, rOk := resolveTarget(affinity.RTarget, option)
+
+	return checkAffinity(ctx, affinity.Operand, lVal, rVal, lOk, rOk)
+}
+
 // ScoreNormalizationIterator is used to combine scores from various prior
 // iterators and combine them into one final score. The current implementation
 // averages the scores together.
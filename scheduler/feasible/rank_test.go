@@ -2684,3 +2684,65 @@ func TestNodeAffinityIterator(t *testing.T) {
 		test.Less(t, out[3].FinalScore, out[4].FinalScore)
 	})
 }
+
+func TestNodeAffinityIterator_AllocTargets(t *testing.T) {
+	ci.Parallel(t)
+	_, ctx := MockContext(t)
+
+	nodes := []*RankedNode{
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+	}
+
+	// Place the "api" job on node0 and the "noisy" job on node1, leaving node2
+	// empty.
+	plan := ctx.Plan()
+	plan.NodeAllocation[nodes[0].Node.ID] = []*structs.Allocation{{
+		ID:        uuid.Generate(),
+		Namespace: structs.DefaultNamespace,
+		JobID:     "api",
+		TaskGroup: "api",
+	}}
+	plan.NodeAllocation[nodes[1].Node.ID] = []*structs.Allocation{{
+		ID:        uuid.Generate(),
+		Namespace: structs.DefaultNamespace,
+		JobID:     "noisy",
+		TaskGroup: "batch",
+	}}
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+	tg.Affinities = []*structs.Affinity{
+		{
+			Operand: structs.ConstraintSetContains,
+			LTarget: "${allocs.job_id}",
+			RTarget: "api",
+			Weight:  100,
+		},
+		{
+			Operand: structs.ConstraintSetContains,
+			LTarget: "${allocs.job_id}",
+			RTarget: "noisy",
+			Weight:  -50,
+		},
+	}
+
+	nodeAffinity := NewNodeAffinityIterator(ctx, NewStaticRankIterator(ctx, nodes))
+	nodeAffinity.SetJob(job)
+	nodeAffinity.SetTaskGroup(tg)
+
+	scoreNorm := NewScoreNormalizationIterator(ctx, nodeAffinity)
+	out := collectRanked(scoreNorm)
+	must.Len(t, 3, out)
+
+	// Total weight = 150
+	// Node 0 is co-located with the "api" job, weight = 100
+	test.Eq(t, 100.0/150.0, out[0].FinalScore)
+
+	// Node 1 is co-located with the "noisy" job, weight = -50
+	test.Eq(t, -50.0/150.0, out[1].FinalScore)
+
+	// Node 2 has no allocations
+	test.Eq(t, 0, out[2].FinalScore)
+}
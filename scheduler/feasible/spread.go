@@ -4,6 +4,10 @@
 package feasible
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-set/v3"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
@@ -45,12 +49,25 @@ type SpreadIterator struct {
 	// existing allocs are computed once, and allocs from the plan are updated
 	// when Reset is called
 	groupPropertySets map[string][]*propertySet
+
+	// nodes is the set of base nodes being considered for placement
+	nodes []*structs.Node
+
+	// skewNodes is a map from task group to the base nodes that passed its
+	// feasibility checks, used to find the attribute values spread over for
+	// max_skew
+	skewNodes map[string][]*structs.Node
+
+	// skewDomains is a memoized map from task group and attribute to the
+	// attribute values of the skew nodes
+	skewDomains map[string]*set.Set[string]
 }
 
 type spreadAttributeMap map[string]*spreadInfo
 
 type spreadInfo struct {
 	weight        int8
+	maxSkew       int
 	desiredCounts map[string]float64
 }
 
@@ -60,11 +77,52 @@ func NewSpreadIterator(ctx Context, source RankIterator) *SpreadIterator {
 		source:            source,
 		groupPropertySets: make(map[string][]*propertySet),
 		tgSpreadInfo:      make(map[string]spreadAttributeMap),
+		skewNodes:         make(map[string][]*structs.Node),
+		skewDomains:       make(map[string]*set.Set[string]),
 		lowestSpreadBoost: -1.0,
 	}
 	return iter
 }
 
+// SetNodes sets the base nodes being considered for placement. Spreads with a
+// max_skew measure skew across the attribute values of these nodes, or of the
+// ones set with SetSkewNodes for the task group.
+func (iter *SpreadIterator) SetNodes(nodes []*structs.Node) {
+	iter.nodes = nodes
+	iter.skewNodes = make(map[string][]*structs.Node)
+	iter.skewDomains = make(map[string]*set.Set[string])
+}
+
+// NeedsSkewNodes returns whether the task group has spreads with a max_skew
+// whose nodes haven't been set with SetSkewNodes.
+func (iter *SpreadIterator) NeedsSkewNodes() bool {
+	if !iter.hasSpread {
+		return false
+	}
+	if _, ok := iter.skewNodes[iter.tg.Name]; ok {
+		return false
+	}
+	for _, info := range iter.tgSpreadInfo[iter.tg.Name] {
+		if info.maxSkew > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// SetSkewNodes sets the base nodes which passed the feasibility checks of the
+// task group. Spreads with a max_skew measure skew across the attribute values
+// of these nodes, so that values only found on nodes the task group can't be
+// placed on don't block placements.
+func (iter *SpreadIterator) SetSkewNodes(nodes []*structs.Node) {
+	iter.skewNodes[iter.tg.Name] = nodes
+	for key := range iter.skewDomains {
+		if strings.HasPrefix(key, iter.tg.Name+"\x00") {
+			delete(iter.skewDomains, key)
+		}
+	}
+}
+
 func (iter *SpreadIterator) Reset() {
 	iter.source.Reset()
 	for _, sets := range iter.groupPropertySets {
@@ -85,6 +143,8 @@ func (iter *SpreadIterator) SetJob(job *structs.Job) {
 	// versions of spread/properties to the new job version
 	iter.tgSpreadInfo = make(map[string]spreadAttributeMap)
 	iter.groupPropertySets = make(map[string][]*propertySet)
+	iter.skewNodes = make(map[string][]*structs.Node)
+	iter.skewDomains = make(map[string]*set.Set[string])
 }
 
 func (iter *SpreadIterator) SetTaskGroup(tg *structs.TaskGroup) {
@@ -139,19 +199,26 @@ func (iter *SpreadIterator) Next() *RankedNode {
 		propertySets := iter.groupPropertySets[tgName]
 		// Iterate over each spread attribute's property set and add a weighted score
 		totalSpreadScore := 0.0
+		feasible := true
 		for _, pset := range propertySets {
 			nValue, errorMsg, usedCount := pset.UsedCount(option.Node, tgName)
+			spreadAttributeMap := iter.tgSpreadInfo[tgName]
+			spreadDetails := spreadAttributeMap[pset.targetAttribute]
 
 			// Add one to include placement on this node in the scoring calculation
 			usedCount += 1
 			// Set score to -1 if there were errors in building this attribute
 			if errorMsg != "" {
+				if spreadDetails != nil && spreadDetails.maxSkew > 0 {
+					// The skew can't be bounded for nodes without the attribute
+					iter.ctx.Metrics().FilterNode(option.Node, fmt.Sprintf("spread: %s", errorMsg))
+					feasible = false
+					break
+				}
 				iter.ctx.Logger().Named("spread").Debug("error building spread attributes for task group", "task_group", tgName, "error", errorMsg)
 				totalSpreadScore -= 1.0
 				continue
 			}
-			spreadAttributeMap := iter.tgSpreadInfo[tgName]
-			spreadDetails := spreadAttributeMap[pset.targetAttribute]
 
 			if spreadDetails == nil {
 				iter.ctx.Logger().Named("spread").Error(
@@ -162,6 +229,13 @@ func (iter *SpreadIterator) Next() *RankedNode {
 				continue
 			}
 
+			if spreadDetails.maxSkew > 0 && iter.exceedsMaxSkew(pset, nValue, usedCount, spreadDetails.maxSkew) {
+				iter.ctx.Metrics().FilterNode(option.Node, fmt.Sprintf(
+					"spread: %s=%s would exceed max_skew of %d", pset.targetAttribute, nValue, spreadDetails.maxSkew))
+				feasible = false
+				break
+			}
+
 			if len(spreadDetails.desiredCounts) == 0 {
 				// When desired counts map is empty the user didn't specify any targets
 				// Use even spreading scoring algorithm for this scenario
@@ -201,6 +275,10 @@ func (iter *SpreadIterator) Next() *RankedNode {
 			}
 		}
 
+		if !feasible {
+			continue
+		}
+
 		if totalSpreadScore != 0.0 {
 			option.Scores = append(option.Scores, totalSpreadScore)
 			iter.ctx.Metrics().ScoreNode(option.Node, "allocation-spread", totalSpreadScore)
@@ -209,6 +287,51 @@ func (iter *SpreadIterator) Next() *RankedNode {
 	}
 }
 
+// exceedsMaxSkew returns whether placing an allocation on a node with the given
+// attribute value, which would then be used by usedCount allocations, leaves
+// the difference between the most- and least-used values of the attribute
+// greater than maxSkew.
+func (iter *SpreadIterator) exceedsMaxSkew(pset *propertySet, value string, usedCount uint64, maxSkew int) bool {
+	combinedUseMap := pset.GetCombinedUseMap()
+	domain := iter.skewDomain(pset)
+
+	minCount := usedCount - 1
+	for domainValue := range domain.Items() {
+		minCount = min(minCount, combinedUseMap[domainValue])
+	}
+	if domain.Empty() {
+		// Without base nodes only the values already in use are known
+		for _, count := range combinedUseMap {
+			minCount = min(minCount, count)
+		}
+	}
+
+	return usedCount-minCount > uint64(maxSkew)
+}
+
+// skewDomain returns the set of attribute values of the nodes that the
+// property set spreads over: the skew nodes of the task group if set, or else
+// the base nodes.
+func (iter *SpreadIterator) skewDomain(pset *propertySet) *set.Set[string] {
+	key := pset.taskGroup + "\x00" + pset.targetAttribute
+	if domain, ok := iter.skewDomains[key]; ok {
+		return domain
+	}
+
+	nodes, ok := iter.skewNodes[pset.taskGroup]
+	if !ok {
+		nodes = iter.nodes
+	}
+	domain := set.New[string](0)
+	for _, node := range nodes {
+		if value, ok := getProperty(node, pset.targetAttribute); ok {
+			domain.Insert(pset.targetedPropertyValue(value))
+		}
+	}
+	iter.skewDomains[key] = domain
+	return domain
+}
+
 // evenSpreadScoreBoost is a scoring helper that calculates the score
 // for the option when even spread is desired (all attribute values get equal preference)
 func evenSpreadScoreBoost(pset *propertySet, option *structs.Node) float64 {
@@ -274,7 +397,7 @@ func (iter *SpreadIterator) computeSpreadInfo(tg *structs.TaskGroup) {
 	combinedSpreads = append(combinedSpreads, tg.Spreads...)
 	combinedSpreads = append(combinedSpreads, iter.jobSpreads...)
 	for _, spread := range combinedSpreads {
-		si := &spreadInfo{weight: spread.Weight, desiredCounts: make(map[string]float64)}
+		si := &spreadInfo{weight: spread.Weight, maxSkew: spread.MaxSkew, desiredCounts: make(map[string]float64)}
 		sumDesiredCounts := 0.0
 		for _, st := range spread.SpreadTarget {
 			desiredCount := (float64(st.Percent) / float64(100)) * float64(totalCount)
@@ -180,6 +180,86 @@ func TestSpreadIterator_SingleAttribute(t *testing.T) {
 	}
 }
 
+func TestSpreadIterator_MaxSkew(t *testing.T) {
+	ci.Parallel(t)
+
+	state, ctx := MockContext(t)
+	dcs := []string{"dc1", "dc1", "dc2", "dc3"}
+	var nodes []*RankedNode
+	var baseNodes []*structs.Node
+
+	for i, dc := range dcs {
+		node := mock.Node()
+		node.Datacenter = dc
+		must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, uint64(100+i), node))
+		nodes = append(nodes, &RankedNode{Node: node})
+		baseNodes = append(baseNodes, node)
+	}
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+	tg.Count = 4
+	tg.Spreads = []*structs.Spread{{
+		Weight:    100,
+		Attribute: "${node.datacenter}",
+		MaxSkew:   1,
+	}}
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 999, nil, job))
+
+	// Place one alloc in dc1 and one in dc2, leaving dc3 empty
+	upserting := []*structs.Allocation{}
+	for _, i := range []int{0, 2} {
+		upserting = append(upserting, &structs.Allocation{
+			Namespace: structs.DefaultNamespace,
+			TaskGroup: tg.Name,
+			JobID:     job.ID,
+			Job:       job,
+			ID:        uuid.Generate(),
+			EvalID:    uuid.Generate(),
+			NodeID:    nodes[i].Node.ID,
+		})
+	}
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, upserting))
+
+	static := NewStaticRankIterator(ctx, nodes)
+	spreadIter := NewSpreadIterator(ctx, static)
+	spreadIter.SetNodes(baseNodes)
+	spreadIter.SetJob(job)
+	spreadIter.SetTaskGroup(tg)
+
+	// Only dc3 can be placed on without exceeding the skew
+	out := collectRanked(spreadIter)
+	must.Len(t, 1, out)
+	must.Eq(t, "dc3", out[0].Node.Datacenter)
+
+	metrics := ctx.Metrics()
+	must.Eq(t, 3, metrics.NodesFiltered)
+	must.Eq(t, 2, metrics.ConstraintFiltered["spread: ${node.datacenter}=dc1 would exceed max_skew of 1"])
+	must.Eq(t, 1, metrics.ConstraintFiltered["spread: ${node.datacenter}=dc2 would exceed max_skew of 1"])
+
+	// Once dc3 has an alloc every datacenter is feasible again
+	ctx.plan.NodeAllocation[nodes[3].Node.ID] = []*structs.Allocation{{
+		Namespace: structs.DefaultNamespace,
+		TaskGroup: tg.Name,
+		JobID:     job.ID,
+		Job:       job,
+		ID:        uuid.Generate(),
+		NodeID:    nodes[3].Node.ID,
+	}}
+	static.Reset()
+	spreadIter.Reset()
+	out = collectRanked(spreadIter)
+	must.Len(t, 4, out)
+
+	// Nodes missing the attribute are infeasible
+	tg.Spreads[0].Attribute = "${meta.missing}"
+	spreadIter.SetJob(job)
+	spreadIter.SetTaskGroup(tg)
+	static.Reset()
+	out = collectRanked(spreadIter)
+	must.Len(t, 0, out)
+}
+
 func TestSpreadIterator_MultipleAttributes(t *testing.T) {
 	ci.Parallel(t)
 
@@ -448,6 +448,58 @@ func TestServiceStack_Select_ConstraintFilter(t *testing.T) {
 	must.One(t, met.ConstraintFiltered["${attr.kernel.name} = freebsd"])
 }
 
+// TestServiceStack_Select_SpreadMaxSkew asserts that the skew of a spread is
+// only measured across the nodes the task group is feasible on.
+func TestServiceStack_Select_SpreadMaxSkew(t *testing.T) {
+	ci.Parallel(t)
+
+	state, ctx := MockContext(t)
+	var nodes []*structs.Node
+	for i, dc := range []string{"dc1", "dc2", "dc3"} {
+		node := mock.Node()
+		node.Datacenter = dc
+		must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, uint64(100+i), node))
+		nodes = append(nodes, node)
+	}
+
+	// the only node of dc3 is filtered out by the job constraint
+	nodes[2].Attributes["kernel.name"] = "freebsd"
+	must.NoError(t, nodes[2].ComputeClass())
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+	tg.Spreads = []*structs.Spread{{
+		Weight:    100,
+		Attribute: "${node.datacenter}",
+		MaxSkew:   1,
+	}}
+
+	// dc1 and dc2 already have an alloc each
+	for _, node := range nodes[:2] {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.TaskGroup = tg.Name
+		alloc.NodeID = node.ID
+		ctx.plan.NodeAllocation[node.ID] = []*structs.Allocation{alloc}
+	}
+
+	stack := NewGenericStack(false, ctx)
+	stack.SetNodes(nodes)
+	stack.SetJob(job)
+
+	// placing a third alloc doesn't exceed the skew, as dc3 is not part of
+	// the nodes the task group can be placed on
+	node := stack.Select(tg, &SelectOptions{})
+	must.NotNil(t, node, must.Sprintf("missing node %#v", ctx.Metrics()))
+	must.NotEq(t, "dc3", node.Node.Datacenter)
+
+	// finding the feasible nodes isn't part of the placement metrics
+	met := ctx.Metrics()
+	must.Eq(t, 3, met.NodesEvaluated)
+	must.One(t, met.NodesFiltered)
+}
+
 func TestServiceStack_Select_BinPack_Overflow(t *testing.T) {
 	ci.Parallel(t)
 
@@ -125,7 +125,8 @@ func (s *GenericScheduler) Process(eval *structs.Evaluation) (err error) {
 		structs.EvalTriggerPeriodicJob, structs.EvalTriggerMaxPlans,
 		structs.EvalTriggerDeploymentWatcher, structs.EvalTriggerRetryFailedAlloc,
 		structs.EvalTriggerFailedFollowUp, structs.EvalTriggerPreemption,
-		structs.EvalTriggerScaling, structs.EvalTriggerMaxDisconnectTimeout, structs.EvalTriggerReconnect:
+		structs.EvalTriggerScaling, structs.EvalTriggerMaxDisconnectTimeout, structs.EvalTriggerReconnect,
+		structs.EvalTriggerRebalance:
 	default:
 		desc := fmt.Sprintf("scheduler cannot handle '%s' evaluation reason",
 			eval.TriggeredBy)
@@ -220,6 +221,12 @@ func (s *GenericScheduler) process() (bool, error) {
 	// Create a plan
 	s.plan = s.eval.MakePlan(s.job)
 
+	// Gangs must be placed atomically, so the plan applier must commit the
+	// plan in its entirety or not at all.
+	if s.job.HasGangs() {
+		s.plan.AllAtOnce = true
+	}
+
 	if !s.batch {
 		// Get any existing deployment
 		s.deployment, err = s.state.LatestDeploymentByJobID(ws, s.eval.Namespace, s.eval.JobID)
@@ -497,6 +504,10 @@ func (s *GenericScheduler) computePlacements(
 	// Capture current time to use as the start time for any rescheduled allocations
 	now := time.Now()
 
+	// Track the placements made for task groups in a gang, so they can be
+	// rolled back if any member of the gang fails to place.
+	gangPlacements := make(map[string][]gangPlacement)
+
 	// Have to handle destructive changes first as we need to discount their
 	// resources. To understand this imagine the resources were reduced and the
 	// count was scaled up.
@@ -540,6 +551,12 @@ func (s *GenericScheduler) computePlacements(
 				continue
 			}
 
+			// Skip placing the members of a gang which has already failed, as
+			// their placements would be rolled back anyway.
+			if tg.IsGang() && s.gangFailed(tg.Gang) {
+				continue
+			}
+
 			// Use downgraded job in scheduling stack to honor old job
 			// resources, constraints, and node pool scheduler configuration.
 			if downgradedJob != nil {
@@ -681,6 +698,14 @@ func (s *GenericScheduler) computePlacements(
 				// Track the placement
 				s.plan.AppendAlloc(alloc, downgradedJob)
 
+				if tg.IsGang() {
+					gangPlacements[tg.Gang] = append(gangPlacements[tg.Gang], gangPlacement{
+						alloc:         alloc,
+						missing:       missing,
+						stopPrevAlloc: stopPrevAlloc,
+					})
+				}
+
 			} else {
 				// Lazy initialize the failed map
 				if s.failedTGAllocs == nil {
@@ -714,9 +739,76 @@ func (s *GenericScheduler) computePlacements(
 		}
 	}
 
+	s.rollbackFailedGangs(gangPlacements)
 	return nil
 }
 
+// gangPlacement is a placement made for a task group which is part of a gang.
+type gangPlacement struct {
+	alloc         *structs.Allocation
+	missing       reconciler.PlacementResult
+	stopPrevAlloc bool
+}
+
+// gangFailed returns whether any task group of the named gang has failed to
+// place in this evaluation.
+func (s *GenericScheduler) gangFailed(gang string) bool {
+	for tgName := range s.failedTGAllocs {
+		if tg := s.job.LookupTaskGroup(tgName); tg != nil && tg.Gang == gang {
+			return true
+		}
+	}
+	return false
+}
+
+// rollbackFailedGangs removes the placements made for every gang in which at
+// least one task group failed to place, so that a gang is placed either in its
+// entirety or not at all. The removed placements do not hold any capacity and
+// are retried along with the failed task group by the blocked evaluation.
+func (s *GenericScheduler) rollbackFailedGangs(placements map[string][]gangPlacement) {
+	for gang, placed := range placements {
+		if !s.gangFailed(gang) {
+			continue
+		}
+
+		for _, p := range placed {
+			s.plan.RemoveAlloc(p.alloc)
+			s.removePreemptionAnnotations(p.alloc, p.missing)
+
+			// Back out the fact that we asked to stop the previous allocation,
+			// as happens when a placement fails.
+			prevAllocation := p.missing.PreviousAllocation()
+			if p.stopPrevAlloc {
+				s.plan.RemoveUpdate(prevAllocation)
+			}
+			if prevAllocation != nil && p.missing.IsRescheduling() {
+				markFailedToReschedule(s.plan, prevAllocation, s.job)
+			}
+		}
+
+		s.logger.Debug("failed to place all members of gang, placements rolled back",
+			"gang", gang, "rolled_back", len(placed))
+	}
+}
+
+// removePreemptionAnnotations removes the plan annotations for any allocations
+// preempted on behalf of a placement which has been removed from the plan.
+func (s *GenericScheduler) removePreemptionAnnotations(alloc *structs.Allocation, missing reconciler.PlacementResult) {
+	if s.planAnnotations == nil || len(alloc.PreemptedAllocations) == 0 {
+		return
+	}
+
+	s.planAnnotations.PreemptedAllocs = slices.DeleteFunc(s.planAnnotations.PreemptedAllocs,
+		func(stub *structs.AllocListStub) bool {
+			return slices.Contains(alloc.PreemptedAllocations, stub.ID)
+		})
+	if s.planAnnotations.DesiredTGUpdates != nil {
+		if desired := s.planAnnotations.DesiredTGUpdates[missing.TaskGroup().Name]; desired != nil {
+			desired.Preemptions -= uint64(len(alloc.PreemptedAllocations))
+		}
+	}
+}
+
 // markFailedToReschedule takes a "previous" allocation that we were unable to
 // reschedule and updates the plan to annotate its reschedule tracker and to
 // move it out of the stop list and into the update list so that we don't drop
@@ -809,6 +901,12 @@ func getSelectOptions(prevAllocation *structs.Allocation, preferredNode *structs
 				penaltyNodes[reschedEvent.PrevNodeID] = struct{}{}
 			}
 		}
+
+		// If alloc is migrating off a node that remains eligible, such as
+		// when rebalancing, penalize the node so the alloc actually moves.
+		if prevAllocation.DesiredTransition.ShouldMigrate() {
+			penaltyNodes[prevAllocation.NodeID] = struct{}{}
+		}
 		selectOptions.PenaltyNodeIDs = penaltyNodes
 	}
 	if preferredNode != nil {
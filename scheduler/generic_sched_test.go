@@ -1628,6 +1628,75 @@ func TestServiceSched_JobRegister_AllocFail(t *testing.T) {
 	h.AssertEvalStatus(t, structs.EvalStatusComplete)
 }
 
+func TestServiceSched_JobRegister_Gang(t *testing.T) {
+	ci.Parallel(t)
+
+	setup := func(t *testing.T, workerCPU int) (*tests.Harness, *structs.Job) {
+		h := tests.NewHarness(t)
+		for i := 0; i < 4; i++ {
+			node := mock.Node()
+			must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+		}
+
+		// Create a job with two groups which form a gang
+		job := mock.Job()
+		job.TaskGroups[0].Count = 2
+		job.TaskGroups[0].Gang = "training"
+		worker := job.TaskGroups[0].Copy()
+		worker.Name = "worker"
+		worker.Tasks[0].Resources.CPU = workerCPU
+		job.TaskGroups = append(job.TaskGroups, worker)
+		must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+		eval := &structs.Evaluation{
+			Namespace:   structs.DefaultNamespace,
+			ID:          uuid.Generate(),
+			Priority:    job.Priority,
+			TriggeredBy: structs.EvalTriggerJobRegister,
+			JobID:       job.ID,
+			Status:      structs.EvalStatusPending,
+		}
+		must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+		must.NoError(t, h.Process(NewServiceScheduler, eval))
+		return h, job
+	}
+
+	t.Run("all members placed", func(t *testing.T) {
+		h, _ := setup(t, 500)
+
+		must.Len(t, 1, h.Plans)
+		plan := h.Plans[0]
+		must.True(t, plan.AllAtOnce)
+
+		var planned []*structs.Allocation
+		for _, allocList := range plan.NodeAllocation {
+			planned = append(planned, allocList...)
+		}
+		must.Len(t, 4, planned)
+		must.Len(t, 0, h.CreateEvals)
+		h.AssertEvalStatus(t, structs.EvalStatusComplete)
+	})
+
+	t.Run("member fails to place", func(t *testing.T) {
+		// The worker group can't fit on any node, so none of the gang should
+		// be placed
+		h, job := setup(t, 100_000)
+
+		must.Len(t, 0, h.Plans)
+
+		must.Len(t, 1, h.CreateEvals)
+		must.Eq(t, structs.EvalStatusBlocked, h.CreateEvals[0].Status)
+
+		must.Len(t, 1, h.Evals)
+		outEval := h.Evals[0]
+		must.MapLen(t, 1, outEval.FailedTGAllocs)
+		must.MapContainsKey(t, outEval.FailedTGAllocs, "worker")
+		must.Eq(t, 2, outEval.QueuedAllocations[job.TaskGroups[0].Name])
+		must.Eq(t, 2, outEval.QueuedAllocations["worker"])
+		h.AssertEvalStatus(t, structs.EvalStatusComplete)
+	})
+}
+
 func TestServiceSched_JobRegister_CreateBlockedEval(t *testing.T) {
 	ci.Parallel(t)
 
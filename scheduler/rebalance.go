@@ -0,0 +1,484 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"slices"
+	"strings"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler/feasible"
+)
+
+// Rebalance computes moves of healthy service allocations between the ready
+// nodes of each node pool. When the scheduler algorithm of the pool is binpack
+// the least utilized nodes are evacuated onto fuller nodes, and when it is
+// spread allocations are moved from the most to the least utilized nodes.
+//
+// Allocations are only moved if their job has no active deployment, and no
+// task group has more allocations moved than its migrate block's max_parallel
+// and its disruption budget allow. The store is not modified.
+func Rebalance(logger log.Logger, store *state.StateStore, req *structs.SchedulerRebalanceRequest) ([]*structs.RebalanceMove, error) {
+	maxMoves := req.MaxMoves
+	if maxMoves <= 0 {
+		maxMoves = structs.DefaultRebalanceMaxMoves
+	}
+
+	_, schedConfig, err := store.SchedulerConfig()
+	if err != nil {
+		return nil, err
+	}
+	if schedConfig == nil {
+		schedConfig = &structs.SchedulerConfiguration{}
+	}
+
+	r := &rebalancer{
+		store:    store,
+		ctx:      feasible.NewEvalContext(nil, store, &structs.Plan{}, logger),
+		maxMoves: maxMoves,
+		jobs:     make(map[structs.NamespacedID]*structs.Job),
+		limits:   make(map[rebalanceGroup]int),
+		moved:    make(map[string]struct{}),
+	}
+
+	nodes, err := r.readyNodes(req.NodePool)
+	if err != nil {
+		return nil, err
+	}
+
+	pools := make([]string, 0, len(nodes))
+	for pool := range nodes {
+		pools = append(pools, pool)
+	}
+	slices.Sort(pools)
+
+	for _, poolName := range pools {
+		pool, err := store.NodePoolByName(nil, poolName)
+		if err != nil {
+			return nil, err
+		}
+
+		switch schedConfig.WithNodePool(pool).EffectiveSchedulerAlgorithm() {
+		case structs.SchedulerAlgorithmSpread:
+			err = r.spread(nodes[poolName])
+		default:
+			err = r.binpack(nodes[poolName])
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return r.moves, nil
+}
+
+// rebalanceGroup identifies a task group of a job.
+type rebalanceGroup struct {
+	job       structs.NamespacedID
+	taskGroup string
+}
+
+// rebalanceNode tracks the allocations proposed to run on a node.
+type rebalanceNode struct {
+	node     *structs.Node
+	allocs   []*structs.Allocation
+	capacity *structs.ComparableResources
+	used     *structs.ComparableResources
+}
+
+// utilization returns the fraction of the node's most utilized resource
+// allocated to the proposed allocations.
+func (n *rebalanceNode) utilization() float64 {
+	return utilization(n.capacity, n.used)
+}
+
+// fits returns whether the allocation fits on the node in addition to the
+// proposed allocations, and the utilization of the node if it was placed.
+func (n *rebalanceNode) fits(alloc *structs.Allocation) (bool, float64, error) {
+	allocs := append(slices.Clone(n.allocs), alloc)
+	fit, _, used, err := structs.AllocsFit(n.node, allocs, nil, true)
+	if err != nil || !fit {
+		return false, 0, err
+	}
+	return true, utilization(n.capacity, used), nil
+}
+
+// refresh recomputes the resources used by the proposed allocations.
+func (n *rebalanceNode) refresh() error {
+	_, _, used, err := structs.AllocsFit(n.node, n.allocs, nil, false)
+	if err != nil {
+		return err
+	}
+	n.used = used
+	return nil
+}
+
+// hasGroup returns whether any of the proposed allocations belong to the task
+// group.
+func (n *rebalanceNode) hasGroup(alloc *structs.Allocation) bool {
+	return slices.ContainsFunc(n.allocs, func(a *structs.Allocation) bool {
+		return a.Namespace == alloc.Namespace && a.JobID == alloc.JobID && a.TaskGroup == alloc.TaskGroup
+	})
+}
+
+func utilization(capacity, used *structs.ComparableResources) float64 {
+	var cpu, mem float64
+	if c := capacity.Flattened.Cpu.CpuShares; c > 0 {
+		cpu = float64(used.Flattened.Cpu.CpuShares) / float64(c)
+	}
+	if m := capacity.Flattened.Memory.MemoryMB; m > 0 {
+		mem = float64(used.Flattened.Memory.MemoryMB) / float64(m)
+	}
+	return max(cpu, mem)
+}
+
+type rebalancer struct {
+	store    *state.StateStore
+	ctx      *feasible.EvalContext
+	maxMoves int
+	moves    []*structs.RebalanceMove
+
+	// jobs caches the jobs of the allocations considered
+	jobs map[structs.NamespacedID]*structs.Job
+
+	// limits is the number of allocations of each task group which may still
+	// be moved
+	limits map[rebalanceGroup]int
+
+	// moved is the set of allocation IDs already moved
+	moved map[string]struct{}
+}
+
+// readyNodes returns the ready nodes by node pool.
+func (r *rebalancer) readyNodes(nodePool string) (map[string][]*rebalanceNode, error) {
+	iter, err := r.store.Nodes(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[string][]*rebalanceNode)
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		node := raw.(*structs.Node)
+		if !node.Ready() || (nodePool != "" && node.NodePool != nodePool) {
+			continue
+		}
+
+		capacity := node.NodeResources.Comparable()
+		if capacity == nil {
+			continue
+		}
+		capacity.Subtract(node.ReservedResources.Comparable())
+
+		allocs, err := r.store.AllocsByNodeTerminal(nil, node.ID, false)
+		if err != nil {
+			return nil, err
+		}
+		slices.SortFunc(allocs, func(a, b *structs.Allocation) int {
+			return strings.Compare(a.ID, b.ID)
+		})
+
+		_, _, used, err := structs.AllocsFit(node, allocs, nil, false)
+		if err != nil {
+			// Nodes which are already overcommitted are left alone
+			continue
+		}
+
+		nodes[node.NodePool] = append(nodes[node.NodePool], &rebalanceNode{
+			node:     node,
+			allocs:   allocs,
+			capacity: capacity,
+			used:     used,
+		})
+	}
+	return nodes, nil
+}
+
+// binpack evacuates the least utilized nodes onto more utilized nodes. A node
+// is only evacuated if all of its allocations can be moved.
+func (r *rebalancer) binpack(nodes []*rebalanceNode) error {
+	sortByUtilization(nodes)
+
+	evacuated := make(map[string]struct{})
+	for _, source := range nodes {
+		if len(r.moves) >= r.maxMoves {
+			return nil
+		}
+		if len(source.allocs) == 0 || len(r.moves)+len(source.allocs) > r.maxMoves {
+			continue
+		}
+
+		sourceUtil := source.utilization()
+		limits := make(map[rebalanceGroup]int)
+		var moves []*structs.RebalanceMove
+		var targets []*rebalanceNode
+
+		for _, alloc := range source.allocs {
+			group, ok, err := r.movable(alloc)
+			if err != nil {
+				return err
+			}
+			if !ok || r.limits[group]-limits[group] <= 0 {
+				break
+			}
+
+			// Find the most utilized node the allocation fits on
+			var best *rebalanceNode
+			var bestUtil float64
+			for _, target := range nodes {
+				if _, ok := evacuated[target.node.ID]; ok || target == source ||
+					target.utilization() <= sourceUtil {
+					continue
+				}
+				fit, util, err := r.canHost(target, alloc)
+				if err != nil {
+					return err
+				}
+				if fit && (best == nil || util > bestUtil) {
+					best, bestUtil = target, util
+				}
+			}
+			if best == nil {
+				break
+			}
+
+			best.allocs = append(best.allocs, alloc)
+			targets = append(targets, best)
+			limits[group]++
+			moves = append(moves, newRebalanceMove(alloc, source, best))
+		}
+
+		if len(moves) != len(source.allocs) {
+			// Roll back the allocations proposed for the targets
+			for _, target := range targets {
+				target.allocs = target.allocs[:len(target.allocs)-1]
+			}
+			continue
+		}
+
+		for _, target := range targets {
+			if err := target.refresh(); err != nil {
+				return err
+			}
+		}
+		for group, n := range limits {
+			r.limits[group] -= n
+		}
+		for _, move := range moves {
+			r.moved[move.AllocID] = struct{}{}
+		}
+		r.moves = append(r.moves, moves...)
+		evacuated[source.node.ID] = struct{}{}
+		source.allocs = nil
+		source.used = &structs.ComparableResources{}
+	}
+	return nil
+}
+
+// spread moves allocations from the most utilized nodes to the least utilized
+// nodes, for as long as each move narrows the gap between them.
+func (r *rebalancer) spread(nodes []*rebalanceNode) error {
+	for len(r.moves) < r.maxMoves {
+		moved, err := r.spreadOnce(nodes)
+		if err != nil || !moved {
+			return err
+		}
+	}
+	return nil
+}
+
+// spreadOnce makes the first move found from the most utilized nodes which
+// leaves the target node less utilized than the source node was.
+func (r *rebalancer) spreadOnce(nodes []*rebalanceNode) (bool, error) {
+	sortByUtilization(nodes)
+
+	for i := len(nodes) - 1; i > 0; i-- {
+		source := nodes[i]
+		sourceUtil := source.utilization()
+
+		for _, alloc := range source.allocs {
+			if _, ok := r.moved[alloc.ID]; ok {
+				continue
+			}
+			group, ok, err := r.movable(alloc)
+			if err != nil {
+				return false, err
+			}
+			if !ok || r.limits[group] <= 0 {
+				continue
+			}
+
+			for _, target := range nodes[:i] {
+				fit, util, err := r.canHost(target, alloc)
+				if err != nil {
+					return false, err
+				}
+				if !fit || util >= sourceUtil {
+					continue
+				}
+
+				source.allocs = slices.DeleteFunc(source.allocs, func(a *structs.Allocation) bool {
+					return a.ID == alloc.ID
+				})
+				target.allocs = append(target.allocs, alloc)
+				if err := source.refresh(); err != nil {
+					return false, err
+				}
+				if err := target.refresh(); err != nil {
+					return false, err
+				}
+
+				r.limits[group]--
+				r.moved[alloc.ID] = struct{}{}
+				r.moves = append(r.moves, newRebalanceMove(alloc, source, target))
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// movable returns whether the allocation may be moved, along with its task
+// group. The number of moves allowed for the task group is computed the first
+// time one of its allocations is considered.
+func (r *rebalancer) movable(alloc *structs.Allocation) (rebalanceGroup, bool, error) {
+	id := structs.NewNamespacedID(alloc.JobID, alloc.Namespace)
+	group := rebalanceGroup{job: id, taskGroup: alloc.TaskGroup}
+
+	if !alloc.AvailableForDisruptionBudget() {
+		return group, false, nil
+	}
+
+	job, ok := r.jobs[id]
+	if !ok {
+		var err error
+		job, err = r.store.JobByID(nil, id.Namespace, id.ID)
+		if err != nil {
+			return group, false, err
+		}
+		if job != nil {
+			deployment, err := r.store.LatestDeploymentByJobID(nil, id.Namespace, id.ID)
+			if err != nil {
+				return group, false, err
+			}
+			if deployment != nil && deployment.Active() {
+				// Leave jobs which are being deployed alone
+				job = nil
+			}
+		}
+		r.jobs[id] = job
+	}
+	if job == nil || job.Stopped() || job.Type != structs.JobTypeService ||
+		alloc.Job == nil || alloc.Job.Version != job.Version {
+		return group, false, nil
+	}
+
+	tg := job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil || len(tg.Volumes) > 0 || (tg.EphemeralDisk != nil && tg.EphemeralDisk.Sticky) {
+		return group, false, nil
+	}
+
+	if _, ok := r.limits[group]; !ok {
+		limit, err := r.groupLimit(job, tg)
+		if err != nil {
+			return group, false, err
+		}
+		r.limits[group] = limit
+	}
+	return group, true, nil
+}
+
+// groupLimit returns the number of allocations of the task group which may be
+// moved, given its migrate block, disruption budget, and the allocations of
+// the group already migrating.
+func (r *rebalancer) groupLimit(job *structs.Job, tg *structs.TaskGroup) (int, error) {
+	migrate := tg.Migrate
+	if migrate == nil {
+		migrate = structs.DefaultMigrateStrategy()
+	}
+
+	allocs, err := r.store.AllocsByJob(nil, job.Namespace, job.ID, false)
+	if err != nil {
+		return 0, err
+	}
+
+	var migrating, healthy int
+	for _, alloc := range allocs {
+		if alloc.TaskGroup != tg.Name || alloc.TerminalStatus() {
+			continue
+		}
+		if alloc.DesiredTransition.ShouldMigrate() {
+			migrating++
+		}
+		if alloc.AvailableForDisruptionBudget() {
+			healthy++
+		}
+	}
+
+	limit := migrate.MaxParallel - migrating
+	if tg.DisruptionBudget != nil {
+		limit = min(limit, tg.DisruptionBudget.Allowed(tg.Count, healthy))
+	}
+	return max(limit, 0), nil
+}
+
+// canHost returns whether the allocation may be placed on the target node, and
+// the utilization of the node if it was.
+func (r *rebalancer) canHost(target *rebalanceNode, alloc *structs.Allocation) (bool, float64, error) {
+	// Never co-locate allocations of the same group, so distinct_hosts and
+	// the spread of the group are preserved
+	if target.node.ID == alloc.NodeID || target.hasGroup(alloc) {
+		return false, 0, nil
+	}
+
+	job := r.jobs[structs.NewNamespacedID(alloc.JobID, alloc.Namespace)]
+	tg := job.LookupTaskGroup(alloc.TaskGroup)
+	if !target.node.IsInAnyDC(job.Datacenters) || target.node.NodePool != job.NodePool {
+		return false, 0, nil
+	}
+
+	tgConstr := feasible.TaskGroupConstraints(tg)
+	constraints := append(slices.Clone(job.Constraints), tgConstr.Constraints...)
+	if !feasible.NewConstraintChecker(r.ctx, constraints).Feasible(target.node) ||
+		!feasible.NewDriverChecker(r.ctx, tgConstr.Drivers).Feasible(target.node) {
+		return false, 0, nil
+	}
+
+	devices := feasible.NewDeviceChecker(r.ctx)
+	devices.SetTaskGroup(tg)
+	if !devices.Feasible(target.node) {
+		return false, 0, nil
+	}
+
+	return target.fits(alloc)
+}
+
+// sortByUtilization sorts the nodes from least to most utilized.
+func sortByUtilization(nodes []*rebalanceNode) {
+	slices.SortStableFunc(nodes, func(a, b *rebalanceNode) int {
+		ua, ub := a.utilization(), b.utilization()
+		switch {
+		case ua < ub:
+			return -1
+		case ua > ub:
+			return 1
+		default:
+			return strings.Compare(a.node.ID, b.node.ID)
+		}
+	})
+}
+
+func newRebalanceMove(alloc *structs.Allocation, source, target *rebalanceNode) *structs.RebalanceMove {
+	return &structs.RebalanceMove{
+		AllocID:      alloc.ID,
+		AllocName:    alloc.Name,
+		Namespace:    alloc.Namespace,
+		JobID:        alloc.JobID,
+		TaskGroup:    alloc.TaskGroup,
+		NodePool:     source.node.NodePool,
+		SourceNodeID: source.node.ID,
+		TargetNodeID: target.node.ID,
+	}
+}
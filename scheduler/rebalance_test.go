@@ -0,0 +1,131 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestRebalance(t *testing.T) {
+	ci.Parallel(t)
+
+	setup := func(t *testing.T, algorithm structs.SchedulerAlgorithm) (*state.StateStore, []*structs.Node) {
+		store := state.TestStateStore(t)
+		must.NoError(t, store.SchedulerSetConfig(10, &structs.SchedulerConfiguration{
+			SchedulerAlgorithm: algorithm,
+		}))
+
+		var nodes []*structs.Node
+		for i := 0; i < 3; i++ {
+			node := mock.Node()
+			must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 100+uint64(i), node))
+			nodes = append(nodes, node)
+		}
+		return store, nodes
+	}
+
+	// runJob registers a service job with a healthy alloc of the given CPU
+	// on each of the nodes
+	runJob := func(t *testing.T, store *state.StateStore, maxParallel, cpu int, nodes ...*structs.Node) []*structs.Allocation {
+		job := mock.Job()
+		job.TaskGroups[0].Count = len(nodes)
+		job.TaskGroups[0].Migrate.MaxParallel = maxParallel
+		index, _ := store.LatestIndex()
+		must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, index+1, nil, job))
+
+		var allocs []*structs.Allocation
+		for i, node := range nodes {
+			alloc := mock.Alloc()
+			alloc.Job = job
+			alloc.JobID = job.ID
+			alloc.NodeID = node.ID
+			alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+			alloc.ClientStatus = structs.AllocClientStatusRunning
+			alloc.DeploymentStatus = &structs.AllocDeploymentStatus{Healthy: new(true)}
+			alloc.AllocatedResources.Shared.Networks = nil
+			alloc.AllocatedResources.Tasks["web"].Networks = nil
+			alloc.AllocatedResources.Tasks["web"].Cpu.CpuShares = int64(cpu)
+			allocs = append(allocs, alloc)
+		}
+		must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, index+2, allocs))
+		return allocs
+	}
+
+	t.Run("binpack evacuates least utilized node", func(t *testing.T) {
+		store, nodes := setup(t, structs.SchedulerAlgorithmBinpack)
+		runJob(t, store, 1, 2000, nodes[1], nodes[2])
+		small := runJob(t, store, 1, 500, nodes[0])
+
+		moves, err := Rebalance(testlog.HCLogger(t), store, &structs.SchedulerRebalanceRequest{})
+		must.NoError(t, err)
+		must.Len(t, 1, moves)
+		must.Eq(t, small[0].ID, moves[0].AllocID)
+		must.Eq(t, nodes[0].ID, moves[0].SourceNodeID)
+		must.NotEq(t, nodes[0].ID, moves[0].TargetNodeID)
+	})
+
+	t.Run("binpack skips unhealthy allocs", func(t *testing.T) {
+		store, nodes := setup(t, structs.SchedulerAlgorithmBinpack)
+		runJob(t, store, 1, 2000, nodes[1], nodes[2])
+		small := runJob(t, store, 1, 500, nodes[0])
+
+		alloc := small[0].Copy()
+		alloc.DeploymentStatus = nil
+		must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{alloc}))
+
+		moves, err := Rebalance(testlog.HCLogger(t), store, &structs.SchedulerRebalanceRequest{})
+		must.NoError(t, err)
+		must.Len(t, 0, moves)
+	})
+
+	t.Run("spread respects max_parallel", func(t *testing.T) {
+		store, nodes := setup(t, structs.SchedulerAlgorithmSpread)
+		runJob(t, store, 1, 500, nodes[0], nodes[0], nodes[0])
+
+		moves, err := Rebalance(testlog.HCLogger(t), store, &structs.SchedulerRebalanceRequest{})
+		must.NoError(t, err)
+		must.Len(t, 1, moves)
+		must.Eq(t, nodes[0].ID, moves[0].SourceNodeID)
+	})
+
+	t.Run("spread evens out nodes", func(t *testing.T) {
+		store, nodes := setup(t, structs.SchedulerAlgorithmSpread)
+		runJob(t, store, 3, 500, nodes[0], nodes[0], nodes[0])
+
+		moves, err := Rebalance(testlog.HCLogger(t), store, &structs.SchedulerRebalanceRequest{})
+		must.NoError(t, err)
+		must.Len(t, 2, moves)
+		must.NotEq(t, moves[0].TargetNodeID, moves[1].TargetNodeID)
+	})
+
+	t.Run("max moves", func(t *testing.T) {
+		store, nodes := setup(t, structs.SchedulerAlgorithmSpread)
+		runJob(t, store, 3, 500, nodes[0], nodes[0], nodes[0])
+
+		moves, err := Rebalance(testlog.HCLogger(t), store, &structs.SchedulerRebalanceRequest{
+			MaxMoves: 1,
+		})
+		must.NoError(t, err)
+		must.Len(t, 1, moves)
+	})
+
+	t.Run("node pool", func(t *testing.T) {
+		store, nodes := setup(t, structs.SchedulerAlgorithmSpread)
+		runJob(t, store, 3, 500, nodes[0], nodes[0], nodes[0])
+
+		moves, err := Rebalance(testlog.HCLogger(t), store, &structs.SchedulerRebalanceRequest{
+			NodePool: "other-" + uuid.Short(),
+		})
+		must.NoError(t, err)
+		must.Len(t, 0, moves)
+	})
+}
@@ -535,7 +535,9 @@ func (a *AllocReconciler) computeGroup(group string, all allocSet) (*ReconcileRe
 	result.Stop = append(result.Stop, replacementsAllocsToStop...)
 	result.Place = append(result.Place, replacements...)
 
-	if deploymentPlaceReady {
+	// Destructive updates are only made while the group's update windows are
+	// open, so that rollouts don't progress outside of them.
+	if windowOpen, _ := tg.Update.WindowOpen(a.clusterState.Now); deploymentPlaceReady && windowOpen {
 		result.DestructiveUpdate = a.computeDestructiveUpdates(destructive, underProvisionedBy, result.DesiredTGUpdates[group], tg)
 	} else {
 		result.DesiredTGUpdates[group].Ignore += uint64(len(destructive))
@@ -708,7 +710,8 @@ func (a *AllocReconciler) computeCanaries(
 
 	placementResult := []AllocPlaceResult{}
 
-	if !a.jobState.DeploymentPaused && !a.jobState.DeploymentFailed {
+	windowOpen, _ := tg.Update.WindowOpen(a.clusterState.Now)
+	if !a.jobState.DeploymentPaused && !a.jobState.DeploymentFailed && windowOpen {
 		result.DesiredTGUpdates[group].Canary += uint64(tg.Update.Canary - len(canaries))
 		total := uint(result.DesiredTGUpdates[group].Canary)
 
@@ -795,6 +798,15 @@ func (a *AllocReconciler) computeUnderProvisionedBy(group *structs.TaskGroup, un
 		return group.Count
 	}
 
+	// Members of a gang must be replaced together, so ignore MaxParallel and
+	// allow the whole group to be placed unless the deployment is halted.
+	if group.IsGang() {
+		if a.jobState.DeploymentPaused || a.jobState.DeploymentFailed {
+			return 0
+		}
+		return group.Count
+	}
+
 	// If the deployment is nil, allow MaxParallel placements
 	if a.jobState.DeploymentCurrent == nil {
 		return group.Update.MaxParallel
@@ -0,0 +1,303 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	sstructs "github.com/hashicorp/nomad/scheduler/structs"
+)
+
+// Simulate runs the schedulers against the given state store after applying
+// the hypothetical changes described by the request. Each plan created by the
+// schedulers is applied to the store, so later jobs observe the placements
+// of earlier ones. The store is modified by the simulation and so must be a
+// copy of the cluster state, such as a snapshot or a restored archive.
+func Simulate(logger log.Logger, store *state.StateStore, req *structs.SchedulerSimulateRequest) (*structs.SchedulerSimulateResponse, error) {
+	index, err := store.LatestIndex()
+	if err != nil {
+		return nil, err
+	}
+	nextIndex := func() uint64 {
+		index++
+		return index
+	}
+
+	if req.SchedulerConfig != nil {
+		config := req.SchedulerConfig.Copy()
+		config.Canonicalize()
+		if err := config.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid scheduler configuration: %w", err)
+		}
+		if err := store.SchedulerSetConfig(nextIndex(), config); err != nil {
+			return nil, err
+		}
+	}
+
+	// Jobs with allocations on nodes that are removed or moved to another
+	// node pool must be evaluated, as their allocations may need to move.
+	affected := make(map[structs.NamespacedID]struct{})
+	addAffected := func(nodeID string) error {
+		allocs, err := store.AllocsByNode(nil, nodeID)
+		if err != nil {
+			return err
+		}
+		for _, alloc := range allocs {
+			if !alloc.TerminalStatus() {
+				affected[structs.NewNamespacedID(alloc.JobID, alloc.Namespace)] = struct{}{}
+			}
+		}
+		return nil
+	}
+
+	for prefix, pool := range req.NodePools {
+		node, err := simulationNode(store, prefix)
+		if err != nil {
+			return nil, err
+		}
+		if err := addAffected(node.ID); err != nil {
+			return nil, err
+		}
+
+		node = node.Copy()
+		node.NodePool = pool
+		if err := node.ComputeClass(); err != nil {
+			return nil, err
+		}
+		if err := store.UpsertNode(structs.IgnoreUnknownTypeFlag, nextIndex(), node, state.NodeUpsertWithNodePool); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, prefix := range req.RemoveNodes {
+		node, err := simulationNode(store, prefix)
+		if err != nil {
+			return nil, err
+		}
+		if err := addAffected(node.ID); err != nil {
+			return nil, err
+		}
+		if err := store.DeleteNode(structs.IgnoreUnknownTypeFlag, nextIndex(), []string{node.ID}); err != nil {
+			return nil, err
+		}
+	}
+
+	// Evaluate the affected jobs in a stable order, followed by the jobs of
+	// the request in the order they were given.
+	var evalJobs []structs.NamespacedID
+	for id := range affected {
+		evalJobs = append(evalJobs, id)
+	}
+	sort.Slice(evalJobs, func(i, j int) bool {
+		if evalJobs[i].Namespace != evalJobs[j].Namespace {
+			return evalJobs[i].Namespace < evalJobs[j].Namespace
+		}
+		return evalJobs[i].ID < evalJobs[j].ID
+	})
+
+	for _, job := range req.Jobs {
+		job = job.Copy()
+		job.Canonicalize()
+		if err := job.Validate(); err != nil {
+			return nil, fmt.Errorf("job %q is invalid: %w", job.ID, err)
+		}
+		if err := store.UpsertJob(structs.IgnoreUnknownTypeFlag, nextIndex(), nil, job); err != nil {
+			return nil, err
+		}
+
+		id := structs.NewNamespacedID(job.ID, job.Namespace)
+		if _, ok := affected[id]; !ok {
+			evalJobs = append(evalJobs, id)
+		}
+	}
+
+	resp := &structs.SchedulerSimulateResponse{}
+	for _, id := range evalJobs {
+		result, lastIndex, err := simulateJob(logger, store, id, nextIndex())
+		if err != nil {
+			return nil, err
+		}
+		index = lastIndex
+		if result != nil {
+			resp.Jobs = append(resp.Jobs, result)
+		}
+	}
+
+	resp.Utilization, err = simulationUtilization(store)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Index = index
+	return resp, nil
+}
+
+// simulationNode returns the single node matching the ID prefix.
+func simulationNode(store *state.StateStore, prefix string) (*structs.Node, error) {
+	iter, err := store.NodesByIDPrefix(nil, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []*structs.Node
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		nodes = append(nodes, raw.(*structs.Node))
+	}
+
+	switch len(nodes) {
+	case 0:
+		return nil, fmt.Errorf("no node with prefix or ID %q found", prefix)
+	case 1:
+		return nodes[0], nil
+	default:
+		return nil, fmt.Errorf("prefix %q matched multiple nodes", prefix)
+	}
+}
+
+// simulateJob runs the scheduler for the job, applying the resulting plans to
+// the store. It returns an index greater than any written to the store.
+func simulateJob(logger log.Logger, store *state.StateStore, id structs.NamespacedID, index uint64) (*structs.SimulatedJobResult, uint64, error) {
+	job, err := store.JobByID(nil, id.Namespace, id.ID)
+	if err != nil {
+		return nil, index, err
+	}
+	if job == nil {
+		return nil, index, nil
+	}
+
+	now := time.Now().UnixNano()
+	eval := &structs.Evaluation{
+		ID:             uuid.Generate(),
+		Namespace:      job.Namespace,
+		Priority:       job.Priority,
+		Type:           job.Type,
+		TriggeredBy:    structs.EvalTriggerJobRegister,
+		JobID:          job.ID,
+		JobModifyIndex: job.JobModifyIndex,
+		Status:         structs.EvalStatusPending,
+		CreateTime:     now,
+		ModifyTime:     now,
+	}
+	if err := store.UpsertEvals(structs.IgnoreUnknownTypeFlag, index, []*structs.Evaluation{eval}); err != nil {
+		return nil, index, err
+	}
+
+	snap, err := store.Snapshot()
+	if err != nil {
+		return nil, index, err
+	}
+
+	// Create an in-memory planner which applies the plans to the store
+	planner := sstructs.NewPlanWithStateAndIndex(store, index+1, true)
+	sched, err := NewScheduler(eval.Type, logger, nil, snap, planner)
+	if err != nil {
+		return nil, index, err
+	}
+	if err := sched.Process(eval); err != nil {
+		return nil, index, fmt.Errorf("failed to evaluate job %q: %w", job.ID, err)
+	}
+
+	result := &structs.SimulatedJobResult{
+		Namespace: job.Namespace,
+		JobID:     job.ID,
+	}
+	for _, plan := range planner.Plans {
+		for nodeID, allocs := range plan.NodeAllocation {
+			node, err := snap.NodeByID(nil, nodeID)
+			if err != nil {
+				return nil, index, err
+			}
+			for _, alloc := range allocs {
+				// Skip in-place updates of existing allocations
+				existing, err := snap.AllocByID(nil, alloc.ID)
+				if err != nil {
+					return nil, index, err
+				}
+				if existing != nil {
+					continue
+				}
+
+				placement := &structs.SimulatedPlacement{
+					AllocID:   alloc.ID,
+					Name:      alloc.Name,
+					TaskGroup: alloc.TaskGroup,
+					NodeID:    nodeID,
+				}
+				if node != nil {
+					placement.NodeName = node.Name
+					placement.NodePool = node.NodePool
+				}
+				result.Placements = append(result.Placements, placement)
+			}
+		}
+		for _, allocs := range plan.NodeUpdate {
+			result.Stopped += len(allocs)
+		}
+		for _, allocs := range plan.NodePreemptions {
+			for _, alloc := range allocs {
+				result.Preempted = append(result.Preempted, alloc.ID)
+			}
+		}
+	}
+
+	sort.Slice(result.Placements, func(i, j int) bool {
+		return result.Placements[i].Name < result.Placements[j].Name
+	})
+	sort.Strings(result.Preempted)
+
+	if n := len(planner.Evals); n > 0 {
+		result.FailedTGAllocs = planner.Evals[n-1].FailedTGAllocs
+	}
+	// The planner allocates indexes for each plan it applies, so skip past
+	// the last of them
+	return result, planner.NextIndex(), nil
+}
+
+// simulationUtilization returns the resources allocated on the ready nodes of
+// the store.
+func simulationUtilization(store *state.StateStore) (*structs.SimulatedUtilization, error) {
+	iter, err := store.Nodes(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	util := &structs.SimulatedUtilization{}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		node := raw.(*structs.Node)
+		if !node.Ready() {
+			continue
+		}
+
+		capacity := node.NodeResources.Comparable()
+		if capacity == nil {
+			continue
+		}
+		capacity.Subtract(node.ReservedResources.Comparable())
+
+		util.Nodes++
+		util.CPUCapacity += capacity.Flattened.Cpu.CpuShares
+		util.MemoryCapacityMB += capacity.Flattened.Memory.MemoryMB
+
+		allocs, err := store.AllocsByNode(nil, node.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, alloc := range allocs {
+			if alloc.TerminalStatus() || alloc.AllocatedResources == nil {
+				continue
+			}
+			used := alloc.AllocatedResources.Comparable()
+			util.CPUAllocated += used.Flattened.Cpu.CpuShares
+			util.MemoryAllocatedMB += used.Flattened.Memory.MemoryMB
+		}
+	}
+
+	return util, nil
+}
@@ -0,0 +1,124 @@
+// Copyright IBM Corp. 2015, 2026
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestSimulate(t *testing.T) {
+	ci.Parallel(t)
+
+	setup := func(t *testing.T) (*state.StateStore, []*structs.Node, *structs.Job) {
+		store := state.TestStateStore(t)
+
+		var nodes []*structs.Node
+		for i := 0; i < 3; i++ {
+			node := mock.Node()
+			must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 100+uint64(i), node))
+			nodes = append(nodes, node)
+		}
+
+		// Run an existing job with a single alloc on the first node
+		job := mock.Job()
+		job.TaskGroups[0].Count = 1
+		must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 200, nil, job))
+
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = nodes[0].ID
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, 0)
+		must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 201, []*structs.Allocation{alloc}))
+
+		return store, nodes, job
+	}
+
+	t.Run("register job", func(t *testing.T) {
+		store, _, _ := setup(t)
+
+		job := mock.Job()
+		job.TaskGroups[0].Count = 2
+
+		resp, err := Simulate(testlog.HCLogger(t), store, &structs.SchedulerSimulateRequest{
+			Jobs: []*structs.Job{job},
+		})
+		must.NoError(t, err)
+		must.Len(t, 1, resp.Jobs)
+		must.Eq(t, job.ID, resp.Jobs[0].JobID)
+		must.Len(t, 2, resp.Jobs[0].Placements)
+		must.MapEmpty(t, resp.Jobs[0].FailedTGAllocs)
+
+		must.Eq(t, 3, resp.Utilization.Nodes)
+		must.Eq(t, 3*int64(job.TaskGroups[0].Tasks[0].Resources.CPU), resp.Utilization.CPUAllocated)
+	})
+
+	t.Run("remove node", func(t *testing.T) {
+		store, nodes, existing := setup(t)
+
+		resp, err := Simulate(testlog.HCLogger(t), store, &structs.SchedulerSimulateRequest{
+			RemoveNodes: []string{nodes[0].ID[:8]},
+		})
+		must.NoError(t, err)
+		must.Len(t, 1, resp.Jobs)
+		must.Eq(t, existing.ID, resp.Jobs[0].JobID)
+		must.Len(t, 1, resp.Jobs[0].Placements)
+		must.NotEq(t, nodes[0].ID, resp.Jobs[0].Placements[0].NodeID)
+		must.Eq(t, 2, resp.Utilization.Nodes)
+	})
+
+	t.Run("move node pool", func(t *testing.T) {
+		store, nodes, _ := setup(t)
+
+		// Move every node out of the default pool, so the job can't place
+		job := mock.Job()
+		job.TaskGroups[0].Count = 1
+		resp, err := Simulate(testlog.HCLogger(t), store, &structs.SchedulerSimulateRequest{
+			Jobs: []*structs.Job{job},
+			NodePools: map[string]string{
+				nodes[0].ID: "other",
+				nodes[1].ID: "other",
+				nodes[2].ID: "other",
+			},
+		})
+		must.NoError(t, err)
+		must.Len(t, 2, resp.Jobs)
+		for _, result := range resp.Jobs {
+			must.Len(t, 0, result.Placements)
+		}
+		must.MapContainsKey(t, resp.Jobs[1].FailedTGAllocs, job.TaskGroups[0].Name)
+	})
+
+	t.Run("scheduler config", func(t *testing.T) {
+		store, _, _ := setup(t)
+
+		resp, err := Simulate(testlog.HCLogger(t), store, &structs.SchedulerSimulateRequest{
+			SchedulerConfig: &structs.SchedulerConfiguration{
+				SchedulerAlgorithm: structs.SchedulerAlgorithmSpread,
+			},
+		})
+		must.NoError(t, err)
+		must.Len(t, 0, resp.Jobs)
+
+		_, config, err := store.SchedulerConfig()
+		must.NoError(t, err)
+		must.Eq(t, structs.SchedulerAlgorithmSpread, config.SchedulerAlgorithm)
+	})
+
+	t.Run("unknown node", func(t *testing.T) {
+		store, _, _ := setup(t)
+
+		_, err := Simulate(testlog.HCLogger(t), store, &structs.SchedulerSimulateRequest{
+			RemoveNodes: []string{"ffffffff"},
+		})
+		must.ErrorContains(t, err, `no node with prefix or ID "ffffffff" found`)
+	})
+}